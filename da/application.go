@@ -1,9 +1,11 @@
 package da
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"reflect"
 
 	"github.com/michaelquigley/df/dd"
 )
@@ -88,6 +90,8 @@ type Application[C any] struct {
 	Cfg       C            // configuration object
 	C         *Container   // container
 	Factories []Factory[C] // factories for creating and registering objects
+
+	shutdown ShutdownHooks
 }
 
 // NewApplication creates a new application with the given configuration.
@@ -212,15 +216,33 @@ func (a *Application[C]) Configure(path string, opts ...*dd.Options) error {
 
 // Build executes all registered factories to create and register objects in the container.
 // Factories are responsible for calling SetAs[T]() to register their created objects.
+// When the configuration or the container holds a Tracer, the phase and each factory are traced.
 //
 // Deprecated: Use concrete container pattern with Wireable[C] instead.
 // See da/examples/da_02_concrete_container for migration guidance.
 func (a *Application[C]) Build() error {
+	trace := startPhase(a.tracer(), "build")
 	for _, f := range a.Factories {
-		if err := f.Build(a); err != nil {
+		comp := component{value: reflect.ValueOf(f), name: fmt.Sprintf("%T", f)}
+		if err := trace.component(comp, func() error { return f.Build(a) }); err != nil {
+			trace.end(err)
 			return err
 		}
 	}
+	trace.end(nil)
+	return nil
+}
+
+// tracer returns the Tracer held by the configuration, or else the first one registered in the container.
+func (a *Application[C]) tracer() Tracer {
+	if t := findTracer(reflect.ValueOf(&a.Cfg).Elem()); t != nil {
+		return t
+	}
+	if a.C != nil {
+		if tracers := AsType[Tracer](a.C); len(tracers) > 0 {
+			return tracers[0]
+		}
+	}
 	return nil
 }
 
@@ -233,7 +255,7 @@ func (a *Application[C]) Build() error {
 func (a *Application[C]) Link() error {
 	return a.C.Visit(func(object any) error {
 		if l, ok := object.(Linkable); ok {
-			return l.Link(a.C)
+			return safeCall("link", fmt.Sprintf("%T", object), func() error { return l.Link(a.C) })
 		}
 		return nil
 	})
@@ -247,13 +269,19 @@ func (a *Application[C]) Link() error {
 func (a *Application[C]) Start() error {
 	return a.C.Visit(func(object any) error {
 		if startable, ok := object.(Startable); ok {
-			return startable.Start()
+			return safeCall("start", fmt.Sprintf("%T", object), startable.Start)
 		}
 		return nil
 	})
 }
 
-// Stop shuts down all Stoppable objects for graceful cleanup.
+// OnShutdown registers fn to run during Stop, after all Stoppable objects have stopped.
+// Hooks run in reverse registration order.
+func (a *Application[C]) OnShutdown(fn func(ctx context.Context) error) {
+	a.shutdown.OnShutdown(fn)
+}
+
+// Stop shuts down all Stoppable objects for graceful cleanup, then runs any hooks registered with OnShutdown.
 // Returns the first error encountered, but continues attempting to stop remaining objects.
 //
 // Deprecated: Use da.Stop with concrete container instead.
@@ -263,13 +291,17 @@ func (a *Application[C]) Stop() error {
 
 	err := a.C.Visit(func(object any) error {
 		if stoppable, ok := object.(Stoppable); ok {
-			if err := stoppable.Stop(); err != nil && firstError == nil {
+			if err := safeCall("stop", fmt.Sprintf("%T", object), stoppable.Stop); err != nil && firstError == nil {
 				firstError = err
 			}
 		}
 		return nil
 	})
 
+	if err := a.shutdown.Run(context.Background()); err != nil && firstError == nil {
+		firstError = err
+	}
+
 	if err != nil {
 		return err
 	}
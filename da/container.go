@@ -17,6 +17,7 @@ type Container struct {
 	singletons    map[reflect.Type]any
 	namedObjects  map[namedKey]any
 	taggedObjects map[string][]any
+	namespaces    map[string]*Container
 }
 
 // NewContainer creates and returns a new empty container.
@@ -28,10 +29,11 @@ func NewContainer() *Container {
 		singletons:    make(map[reflect.Type]any),
 		namedObjects:  make(map[namedKey]any),
 		taggedObjects: make(map[string][]any),
+		namespaces:    make(map[string]*Container),
 	}
 }
 
-// Visit calls the provided function for each object in the container.
+// Visit calls the provided function for each object in the container, including objects in its namespaces.
 // Objects that appear in multiple locations (e.g., both as singleton and tagged) are only visited once.
 //
 // Deprecated: Use concrete container pattern with Wireable[C] instead.
@@ -39,8 +41,12 @@ func NewContainer() *Container {
 func (c *Container) Visit(f func(object any) error) error {
 	// Track visited objects using pointer addresses for deduplication
 	// This works for pointer types; value types are tracked if comparable
-	visited := make(map[uintptr]bool)
+	return c.visit(f, make(map[uintptr]bool), false)
+}
 
+// visit walks the container; namespaces are walked with skipVisited set, so objects shared with an enclosing
+// container are not visited twice.
+func (c *Container) visit(f func(object any) error, visited map[uintptr]bool, skipVisited bool) error {
 	markVisited := func(obj any) bool {
 		v := reflect.ValueOf(obj)
 		// For pointer types, use the pointer address
@@ -58,7 +64,9 @@ func (c *Container) Visit(f func(object any) error) error {
 
 	// Visit singletons
 	for _, object := range c.singletons {
-		markVisited(object)
+		if markVisited(object) && skipVisited {
+			continue
+		}
 		if err := f(object); err != nil {
 			return err
 		}
@@ -66,7 +74,9 @@ func (c *Container) Visit(f func(object any) error) error {
 
 	// Visit named objects
 	for _, object := range c.namedObjects {
-		markVisited(object)
+		if markVisited(object) && skipVisited {
+			continue
+		}
 		if err := f(object); err != nil {
 			return err
 		}
@@ -83,6 +93,13 @@ func (c *Container) Visit(f func(object any) error) error {
 		}
 	}
 
+	// Visit namespaces
+	for _, name := range c.Namespaces() {
+		if err := c.namespaces[name].visit(f, visited, true); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -353,7 +370,7 @@ func ClearTagged(c *Container, tag string) int {
 	return count
 }
 
-// Clear removes all objects from the container.
+// Clear removes all objects and namespaces from the container.
 //
 // Deprecated: Use concrete container pattern with Wireable[C] instead.
 // See da/examples/da_02_concrete_container for migration guidance.
@@ -361,6 +378,7 @@ func (c *Container) Clear() {
 	c.singletons = make(map[reflect.Type]any)
 	c.namedObjects = make(map[namedKey]any)
 	c.taggedObjects = make(map[string][]any)
+	c.namespaces = make(map[string]*Container)
 }
 
 // Tags returns a slice of all tags in the container.
@@ -510,15 +528,17 @@ type InspectSummary struct {
 	Singletons int `json:"singletons" yaml:"singletons"`
 	Named      int `json:"named" yaml:"named"`
 	Tagged     int `json:"tagged" yaml:"tagged"`
+	Namespaced int `json:"namespaced" yaml:"namespaced"`
 }
 
 // InspectObject represents a single object in the container for inspection.
 type InspectObject struct {
-	Type    string  `json:"type" yaml:"type"`
-	Storage string  `json:"storage" yaml:"storage"`
-	Name    *string `json:"name,omitempty" yaml:"name,omitempty"`
-	Tag     *string `json:"tag,omitempty" yaml:"tag,omitempty"`
-	Value   string  `json:"value" yaml:"value"`
+	Type      string  `json:"type" yaml:"type"`
+	Storage   string  `json:"storage" yaml:"storage"`
+	Namespace *string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      *string `json:"name,omitempty" yaml:"name,omitempty"`
+	Tag       *string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Value     string  `json:"value" yaml:"value"`
 }
 
 // Inspect returns a formatted representation of the container contents.
@@ -590,6 +610,21 @@ func (c *Container) gatherInspectData() InspectData {
 		Tagged:     taggedCount,
 	}
 
+	// collect namespaced objects, qualifying nested namespaces as "outer/inner"
+	for _, name := range c.Namespaces() {
+		nested := c.namespaces[name].gatherInspectData()
+		for _, obj := range nested.Objects {
+			namespace := name
+			if obj.Namespace != nil {
+				namespace = name + "/" + *obj.Namespace
+			}
+			obj.Namespace = &namespace
+			objects = append(objects, obj)
+		}
+		summary.Total += nested.Summary.Total
+		summary.Namespaced += nested.Summary.Total
+	}
+
 	return InspectData{
 		Summary: summary,
 		Objects: objects,
@@ -0,0 +1,49 @@
+package da
+
+import (
+	"reflect"
+
+	"github.com/michaelquigley/df/dl"
+)
+
+// LoggerAware is implemented by components that want a channel-scoped logger. Wire calls SetLogger on each such
+// component before wiring, passing dl.ChannelLog for the component's channel.
+//
+// the channel is named after the component's field path in the container, lowercased (e.g. `database` for a
+// Database field, `services.auth` for Services.Auth); override it with a `da:"channel=name"` tag on the container
+// field. elements of slices and maps share their field's channel.
+type LoggerAware interface {
+	SetLogger(log *dl.Builder)
+}
+
+var builderType = reflect.TypeOf((*dl.Builder)(nil))
+
+// injectLoggers gives each component a logger for its channel, through LoggerAware or through exported
+// *dl.Builder fields tagged `da:"logger"`. tagged fields that are already set are left alone.
+func injectLoggers(components []component) {
+	for _, comp := range components {
+		if comp.channel == "" {
+			continue
+		}
+		if aware, ok := comp.value.Interface().(LoggerAware); ok {
+			aware.SetLogger(dl.ChannelLog(comp.channel))
+		}
+
+		v := comp.value
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Type != builderType || !hasTagOption(field.Tag.Get("da"), "logger") {
+				continue
+			}
+			if fv := v.Field(i); fv.IsNil() && fv.CanSet() {
+				fv.Set(reflect.ValueOf(dl.ChannelLog(comp.channel)))
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package da
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/michaelquigley/df/dl"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLoggerAware struct {
+	log *dl.Builder
+}
+
+func (c *testLoggerAware) SetLogger(log *dl.Builder) { c.log = log }
+
+type testLoggerTagged struct {
+	Log      *dl.Builder `da:"logger"`
+	Untagged *dl.Builder
+}
+
+type testLoggerApp struct {
+	Database *testLoggerAware
+	API      *testLoggerTagged `da:"channel=api"`
+	Preset   *testLoggerTagged
+	Workers  []*testLoggerAware
+}
+
+func TestWireInjectsLoggers(t *testing.T) {
+	preset := dl.ChannelLog("preset")
+	app := &testLoggerApp{
+		Database: &testLoggerAware{},
+		API:      &testLoggerTagged{},
+		Preset:   &testLoggerTagged{Log: preset},
+		Workers:  []*testLoggerAware{{}, {}},
+	}
+	assert.NoError(t, Wire(app))
+
+	assert.NotNil(t, app.Database.log)
+	assert.NotNil(t, app.API.Log)
+	assert.Nil(t, app.API.Untagged)
+	assert.Same(t, preset, app.Preset.Log)
+	assert.NotNil(t, app.Workers[0].log)
+	assert.NotNil(t, app.Workers[1].log)
+}
+
+func TestComponentChannels(t *testing.T) {
+	app := &testLoggerApp{
+		Database: &testLoggerAware{},
+		API:      &testLoggerTagged{},
+		Workers:  []*testLoggerAware{{}},
+	}
+	channels := make(map[string]string)
+	for _, comp := range traverse(reflect.ValueOf(app)) {
+		channels[comp.name] = comp.channel
+	}
+	assert.Equal(t, map[string]string{
+		"Database":   "database",
+		"API":        "api",
+		"Workers[0]": "workers",
+	}, channels)
+}
@@ -0,0 +1,105 @@
+package da
+
+import "sort"
+
+// Namespace returns the namespace-scoped view of the container with the given name, creating it on first use. a
+// namespace is itself a Container, so every registration and lookup function works on it; objects registered in
+// one namespace never collide with objects of the same type or name in another namespace or in the parent. this lets
+// multi-tenant or multi-instance applications host parallel object sets in one container.
+//
+// objects in namespaces are included by Visit (and therefore by the Application lifecycle), but not by lookups on
+// the parent such as Get or OfType.
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func (c *Container) Namespace(name string) *Container {
+	if c.namespaces == nil {
+		c.namespaces = make(map[string]*Container)
+	}
+	ns, exists := c.namespaces[name]
+	if !exists {
+		ns = NewContainer()
+		c.namespaces[name] = ns
+	}
+	return ns
+}
+
+// Namespaces returns the names of the container's namespaces, sorted.
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func (c *Container) Namespaces() []string {
+	names := make([]string, 0, len(c.namespaces))
+	for name := range c.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasNamespace checks if a namespace with the given name exists in the container.
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func (c *Container) HasNamespace(name string) bool {
+	_, exists := c.namespaces[name]
+	return exists
+}
+
+// RemoveNamespace removes a namespace and all of its objects from the container.
+// Returns true if the namespace was found and removed, false if it didn't exist.
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func (c *Container) RemoveNamespace(name string) bool {
+	if _, exists := c.namespaces[name]; !exists {
+		return false
+	}
+	delete(c.namespaces, name)
+	return true
+}
+
+// SetIn registers a singleton object by its type in the named namespace. it is shorthand for
+// Set(c.Namespace(namespace), object).
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func SetIn(c *Container, namespace string, object any) {
+	Set(c.Namespace(namespace), object)
+}
+
+// SetNamedIn registers a named object by its type and name in the named namespace.
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func SetNamedIn(c *Container, namespace, name string, object any) {
+	SetNamed(c.Namespace(namespace), name, object)
+}
+
+// GetIn retrieves an object of type T from the named namespace.
+// Returns the object and true if found, or zero value and false if not found.
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func GetIn[T any](c *Container, namespace string) (T, bool) {
+	ns, exists := c.namespaces[namespace]
+	if !exists {
+		var zero T
+		return zero, false
+	}
+	return Get[T](ns)
+}
+
+// GetNamedIn retrieves a named object of type T from the named namespace.
+// Returns the object and true if found, or zero value and false if not found.
+//
+// Deprecated: Use concrete container pattern with Wireable[C] instead.
+// See da/examples/da_02_concrete_container for migration guidance.
+func GetNamedIn[T any](c *Container, namespace, name string) (T, bool) {
+	ns, exists := c.namespaces[namespace]
+	if !exists {
+		var zero T
+		return zero, false
+	}
+	return GetNamed[T](ns, name)
+}
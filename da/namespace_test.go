@@ -0,0 +1,74 @@
+package da
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_Namespaces(t *testing.T) {
+	container := NewContainer()
+
+	root := &containerTestService{name: "root"}
+	tenantA := &containerTestService{name: "tenantA"}
+	tenantB := &containerTestService{name: "tenantB"}
+	Set(container, root)
+	SetIn(container, "tenantA", tenantA)
+	SetIn(container, "tenantB", tenantB)
+	SetNamedIn(container, "tenantA", "primary", &containerTestRepository{database: "a"})
+
+	retrieved, found := GetIn[*containerTestService](container, "tenantA")
+	assert.True(t, found)
+	assert.Equal(t, tenantA, retrieved)
+
+	retrieved, found = Get[*containerTestService](container.Namespace("tenantB"))
+	assert.True(t, found)
+	assert.Equal(t, tenantB, retrieved)
+
+	// the parent is unaffected by namespaced registrations
+	retrieved, found = Get[*containerTestService](container)
+	assert.True(t, found)
+	assert.Equal(t, root, retrieved)
+
+	repo, found := GetNamedIn[*containerTestRepository](container, "tenantA", "primary")
+	assert.True(t, found)
+	assert.Equal(t, "a", repo.database)
+	_, found = GetNamedIn[*containerTestRepository](container, "tenantB", "primary")
+	assert.False(t, found)
+	_, found = GetIn[*containerTestService](container, "missing")
+	assert.False(t, found)
+	assert.False(t, container.HasNamespace("missing"))
+
+	assert.Equal(t, []string{"tenantA", "tenantB"}, container.Namespaces())
+}
+
+func TestContainer_NamespacesVisit(t *testing.T) {
+	container := NewContainer()
+	shared := &containerTestService{name: "shared"}
+	Set(container, shared)
+	SetIn(container, "tenantA", shared)
+	SetIn(container, "tenantA", &containerTestRepository{database: "a"})
+	SetIn(container.Namespace("tenantA"), "replica", &containerTestRepository{database: "a-replica"})
+
+	var visited []any
+	assert.NoError(t, container.Visit(func(object any) error {
+		visited = append(visited, object)
+		return nil
+	}))
+	assert.Len(t, visited, 3)
+
+	data := container.gatherInspectData()
+	assert.Equal(t, 4, data.Summary.Total)
+	assert.Equal(t, 3, data.Summary.Namespaced)
+	var namespaces []string
+	for _, obj := range data.Objects {
+		if obj.Namespace != nil {
+			namespaces = append(namespaces, *obj.Namespace)
+		}
+	}
+	assert.ElementsMatch(t, []string{"tenantA", "tenantA", "tenantA/replica"}, namespaces)
+
+	assert.True(t, container.RemoveNamespace("tenantA"))
+	assert.False(t, container.RemoveNamespace("tenantA"))
+	assert.Empty(t, container.Namespaces())
+}
@@ -0,0 +1,38 @@
+package da
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError reports a panic recovered from a component during a lifecycle phase. Wire, Start, and Stop (and the
+// deprecated Application phases) convert panics into PanicError so that one misbehaving component produces an
+// error, and an orderly shutdown, rather than crashing the process.
+type PanicError struct {
+	Phase     string // lifecycle phase: "build", "link", "wire", "start", "stop", or "shutdown"
+	Component string // field path (e.g. "Services.Auth") or type of the offending component
+	Value     any    // the value passed to panic
+	Stack     []byte // stack trace captured at the point of recovery
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %s of %s: %v", e.Phase, e.Component, e.Value)
+}
+
+// Unwrap returns the panic value when it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// safeCall runs fn, converting a panic into a *PanicError attributed to component.
+func safeCall(phase, component string, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Phase: phase, Component: component, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
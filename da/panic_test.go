@@ -0,0 +1,107 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPanicApp struct {
+	Good  *testPanicComponent `da:"order=1"`
+	Bad   *testPanicComponent `da:"order=2"`
+	Hooks ShutdownHooks       `da:"-"`
+}
+
+type testPanicComponent struct {
+	panicOn string
+	stopped bool
+}
+
+func (c *testPanicComponent) Wire(app *testPanicApp) error {
+	if c.panicOn == "wire" {
+		panic("wire exploded")
+	}
+	return nil
+}
+
+func (c *testPanicComponent) Start() error {
+	if c.panicOn == "start" {
+		panic(errors.New("start exploded"))
+	}
+	return nil
+}
+
+func (c *testPanicComponent) Stop() error {
+	if c.panicOn == "stop" {
+		var m map[string]int
+		m["boom"] = 1
+	}
+	c.stopped = true
+	return nil
+}
+
+func TestWireRecoversPanic(t *testing.T) {
+	app := &testPanicApp{Good: &testPanicComponent{}, Bad: &testPanicComponent{panicOn: "wire"}}
+	err := Wire(app)
+
+	var pe *PanicError
+	if assert.True(t, errors.As(err, &pe)) {
+		assert.Equal(t, "wire", pe.Phase)
+		assert.Equal(t, "Bad", pe.Component)
+		assert.Equal(t, "wire exploded", pe.Value)
+		assert.Contains(t, string(pe.Stack), "testPanicComponent")
+	}
+	assert.EqualError(t, err, "panic in wire of Bad: wire exploded")
+}
+
+func TestStartPanicUnwrapsError(t *testing.T) {
+	app := &testPanicApp{Good: &testPanicComponent{}, Bad: &testPanicComponent{panicOn: "start"}}
+	err := Start(app)
+	assert.EqualError(t, errors.Unwrap(err), "start exploded")
+}
+
+func TestStopContinuesAfterPanic(t *testing.T) {
+	app := &testPanicApp{Good: &testPanicComponent{}, Bad: &testPanicComponent{panicOn: "stop"}}
+	hookRan := false
+	app.Hooks.OnShutdown(func(ctx context.Context) error {
+		hookRan = true
+		return nil
+	})
+
+	err := Stop(app)
+	var pe *PanicError
+	if assert.True(t, errors.As(err, &pe)) {
+		assert.Equal(t, "stop", pe.Phase)
+		assert.Equal(t, "Bad", pe.Component)
+	}
+	assert.True(t, app.Good.stopped)
+	assert.True(t, hookRan)
+}
+
+func TestShutdownHookPanic(t *testing.T) {
+	var hooks ShutdownHooks
+	ran := false
+	hooks.OnShutdown(func(ctx context.Context) error { ran = true; return nil })
+	hooks.OnShutdown(func(ctx context.Context) error { panic("hook exploded") })
+
+	err := hooks.Run(context.Background())
+	var pe *PanicError
+	if assert.True(t, errors.As(err, &pe)) {
+		assert.Equal(t, "shutdown", pe.Phase)
+	}
+	assert.True(t, ran)
+}
+
+func TestApplicationBuildRecoversPanic(t *testing.T) {
+	app := NewApplication(struct{}{})
+	WithFactoryFunc(app, func(a *Application[struct{}]) error { panic("factory exploded") })
+
+	err := app.Build()
+	var pe *PanicError
+	if assert.True(t, errors.As(err, &pe)) {
+		assert.Equal(t, "build", pe.Phase)
+		assert.Equal(t, "factory exploded", pe.Value)
+	}
+}
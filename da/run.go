@@ -1,6 +1,7 @@
 package da
 
 import (
+	"errors"
 	"os"
 	"os/signal"
 	"reflect"
@@ -9,60 +10,92 @@ import (
 
 // Wire calls Wire(c) on all Wireable[C] components in the container.
 // Components are processed in order specified by `da:"order=N"` tags.
+// Before wiring, each component is given a channel-scoped logger; see LoggerAware.
+// When the container holds a Tracer, the phase and each component are traced.
 func Wire[C any](c *C) error {
 	v := reflect.ValueOf(c)
 	components := traverse(v)
+	injectLoggers(components)
+	trace := startPhase(findTracer(v), "wire")
 
 	for _, comp := range components {
 		obj := comp.value.Interface()
 		if wirer, ok := obj.(Wireable[C]); ok {
-			if err := wirer.Wire(c); err != nil {
+			if err := trace.component(comp, func() error { return wirer.Wire(c) }); err != nil {
+				trace.end(err)
 				return err
 			}
 		}
 	}
+	trace.end(nil)
 	return nil
 }
 
 // Start calls Start() on all Startable components in the container.
 // Components are processed in order specified by `da:"order=N"` tags.
+// When the container holds a Supervisor, Runnable and FailureNotifier components are then supervised.
+// When the container holds a Tracer, the phase and each component are traced.
 func Start[C any](c *C) error {
 	v := reflect.ValueOf(c)
 	components := traverse(v)
+	trace := startPhase(findTracer(v), "start")
 
 	for _, comp := range components {
 		obj := comp.value.Interface()
 		if starter, ok := obj.(Startable); ok {
-			if err := starter.Start(); err != nil {
+			if err := trace.component(comp, starter.Start); err != nil {
+				trace.end(err)
 				return err
 			}
 		}
 	}
+	if supervisor := findSupervisor(v); supervisor != nil {
+		supervisor.supervise(components)
+	}
+	trace.end(nil)
 	return nil
 }
 
 // Stop calls Stop() on all Stoppable components in the container.
 // Components are processed in reverse order of `da:"order=N"` tags.
+// When the container holds a Supervisor, supervision is halted first.
+// When the container holds ShutdownHooks, they run after all components have stopped.
 // Continues on error and returns the first error encountered.
+// When the container holds a Tracer, the phase and each component are traced.
 func Stop[C any](c *C) error {
 	v := reflect.ValueOf(c)
 	components := traverse(v)
+	trace := startPhase(findTracer(v), "stop")
+
+	// supervised components are halted before anything is stopped
+	if supervisor := findSupervisor(v); supervisor != nil {
+		supervisor.halt()
+	}
 
 	// reverse order for shutdown
 	var firstErr error
 	for i := len(components) - 1; i >= 0; i-- {
 		obj := components[i].value.Interface()
 		if stopper, ok := obj.(Stoppable); ok {
-			if err := stopper.Stop(); err != nil && firstErr == nil {
+			if err := trace.component(components[i], stopper.Stop); err != nil && firstErr == nil {
 				firstErr = err
 			}
 		}
 	}
+
+	// shutdown hooks run after every component has stopped
+	if hooks := findShutdownHooks(v); hooks != nil {
+		if err := hooks.Run(trace.ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	trace.end(firstErr)
 	return firstErr
 }
 
 // Run is a convenience function that: Wire -> Start -> wait for signal -> Stop.
-// Blocks until SIGINT or SIGTERM is received.
+// Blocks until SIGINT or SIGTERM is received, or until the container's Supervisor escalates a failure, in which case
+// the application is stopped and the *SupervisorError is returned.
 func Run[C any](c *C) error {
 	if err := Wire(c); err != nil {
 		return err
@@ -71,7 +104,21 @@ func Run[C any](c *C) error {
 		_ = Stop(c)
 		return err
 	}
-	WaitForSignal()
+	var failed <-chan error
+	if supervisor := findSupervisor(reflect.ValueOf(c)); supervisor != nil {
+		failed = supervisor.Failed()
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(ch)
+	select {
+	case <-ch:
+	case err := <-failed:
+		if stopErr := Stop(c); stopErr != nil {
+			return errors.Join(err, stopErr)
+		}
+		return err
+	}
 	return Stop(c)
 }
 
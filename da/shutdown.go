@@ -0,0 +1,89 @@
+package da
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ShutdownHooks collects ad-hoc cleanup callbacks (flushing logs, closing tracing, deleting temporary files) that
+// should run at shutdown without being wrapped in a component. When a container holds a ShutdownHooks field (by value
+// or pointer), Stop runs the registered hooks after every component has stopped, in reverse registration order.
+//
+// The zero value is ready to use and safe for concurrent registration:
+//
+//	type App struct {
+//	    Hooks da.ShutdownHooks `da:"-"`
+//	    DB    *Database
+//	}
+//
+//	func (d *Database) Wire(app *App) error {
+//	    app.Hooks.OnShutdown(func(ctx context.Context) error { return os.RemoveAll(d.tmpDir) })
+//	    return nil
+//	}
+type ShutdownHooks struct {
+	mu    sync.Mutex
+	hooks []func(ctx context.Context) error
+}
+
+// OnShutdown registers fn to run at shutdown.
+func (s *ShutdownHooks) OnShutdown(fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, fn)
+}
+
+// Run executes the registered hooks in reverse registration order, continuing on error (or panic) and returning the
+// first error encountered. Hooks are consumed, so calling Run again only runs hooks registered since.
+func (s *ShutdownHooks) Run(ctx context.Context) error {
+	s.mu.Lock()
+	hooks := s.hooks
+	s.hooks = nil
+	s.mu.Unlock()
+
+	var firstErr error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		err := safeCall("shutdown", fmt.Sprintf("shutdown hook %d", i), func() error { return hook(ctx) })
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+var shutdownHooksType = reflect.TypeOf(ShutdownHooks{})
+
+// findShutdownHooks locates the first ShutdownHooks held by the container, searching fields of the container and of
+// nested structs within it, like findTracer.
+func findShutdownHooks(v reflect.Value) *ShutdownHooks {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		field := v.Field(i)
+		switch {
+		case field.Type() == shutdownHooksType && field.CanAddr():
+			return field.Addr().Interface().(*ShutdownHooks)
+		case field.Type() == reflect.PointerTo(shutdownHooksType):
+			if !field.IsNil() {
+				return field.Interface().(*ShutdownHooks)
+			}
+		case field.Kind() == reflect.Struct:
+			if hooks := findShutdownHooks(field); hooks != nil {
+				return hooks
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testShutdownApp struct {
+	Hooks  ShutdownHooks `da:"-"`
+	Worker *testShutdownWorker
+	events *[]string
+}
+
+type testShutdownWorker struct {
+	events *[]string
+}
+
+func (w *testShutdownWorker) Wire(app *testShutdownApp) error {
+	app.Hooks.OnShutdown(func(ctx context.Context) error {
+		*w.events = append(*w.events, "hook:worker")
+		return nil
+	})
+	return nil
+}
+
+func (w *testShutdownWorker) Stop() error {
+	*w.events = append(*w.events, "stop:worker")
+	return nil
+}
+
+func TestStopRunsShutdownHooksInReverse(t *testing.T) {
+	var events []string
+	app := &testShutdownApp{Worker: &testShutdownWorker{events: &events}}
+	app.Hooks.OnShutdown(func(ctx context.Context) error {
+		events = append(events, "hook:first")
+		return nil
+	})
+
+	assert.NoError(t, Wire(app))
+	app.Hooks.OnShutdown(func(ctx context.Context) error {
+		events = append(events, "hook:last")
+		return nil
+	})
+	assert.NoError(t, Stop(app))
+
+	assert.Equal(t, []string{"stop:worker", "hook:last", "hook:worker", "hook:first"}, events)
+}
+
+func TestShutdownHooksContinueOnError(t *testing.T) {
+	var hooks ShutdownHooks
+	var ran []int
+	hooks.OnShutdown(func(ctx context.Context) error { ran = append(ran, 1); return errors.New("first") })
+	hooks.OnShutdown(func(ctx context.Context) error { ran = append(ran, 2); return errors.New("second") })
+
+	err := hooks.Run(context.Background())
+	assert.EqualError(t, err, "second")
+	assert.Equal(t, []int{2, 1}, ran)
+
+	// hooks are consumed
+	assert.NoError(t, hooks.Run(context.Background()))
+	assert.Equal(t, []int{2, 1}, ran)
+}
+
+func TestStopShutdownHooksPointerField(t *testing.T) {
+	app := &struct {
+		Hooks *ShutdownHooks `da:"-"`
+	}{Hooks: &ShutdownHooks{}}
+	app.Hooks.OnShutdown(func(ctx context.Context) error { return errors.New("cleanup failed") })
+
+	assert.EqualError(t, Stop(app), "cleanup failed")
+}
+
+func TestApplicationOnShutdown(t *testing.T) {
+	app := NewApplication(struct{}{})
+	var ran []string
+	app.OnShutdown(func(ctx context.Context) error { ran = append(ran, "a"); return nil })
+	app.OnShutdown(func(ctx context.Context) error { ran = append(ran, "b"); return nil })
+
+	assert.NoError(t, app.Stop())
+	assert.Equal(t, []string{"b", "a"}, ran)
+}
@@ -0,0 +1,296 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Runnable defines components that do their work in a long-running loop. When the container holds a Supervisor,
+// Start launches Run for every Runnable component in its own goroutine, and restarts it if it fails. Run should
+// return when ctx is cancelled; any other return (including a nil error) is treated as a failure.
+type Runnable interface {
+	Run(ctx context.Context) error
+}
+
+// FailureNotifier defines components that run in the background after Start and report failures asynchronously.
+// When the container holds a Supervisor, a failure received from Failures restarts the component by calling Stop
+// (if Stoppable) and then Start (if Startable).
+type FailureNotifier interface {
+	Failures() <-chan error
+}
+
+// RestartPolicy controls how a Supervisor restarts failed components.
+type RestartPolicy struct {
+	InitialBackoff time.Duration // delay before the first restart, defaults to 100ms
+	MaxBackoff     time.Duration // upper bound on the restart delay, defaults to 30s
+	Multiplier     float64       // growth factor for successive delays, defaults to 2
+	MaxRestarts    int           // restarts allowed within Window before escalating, defaults to 5
+	Window         time.Duration // period over which restarts are counted, defaults to 1m
+}
+
+// SupervisorError reports a component that failed more often than its RestartPolicy allows.
+type SupervisorError struct {
+	Component string // field path of the failing component
+	Failures  int    // failures counted within the policy window
+	Cause     error  // the most recent failure
+}
+
+func (e *SupervisorError) Error() string {
+	return fmt.Sprintf("component %s failed %d times; giving up: %v", e.Component, e.Failures, e.Cause)
+}
+
+func (e *SupervisorError) Unwrap() error {
+	return e.Cause
+}
+
+// Supervisor monitors Runnable and FailureNotifier components and restarts them with exponential backoff. When a
+// component exceeds the policy's restart budget, the supervisor escalates: the failure is delivered on Failed and Run
+// shuts the whole application down.
+//
+// Place a Supervisor in the container to enable supervision; Start begins supervising after all components have
+// started, and Stop halts supervision before any component is stopped:
+//
+//	type App struct {
+//	    Supervisor *da.Supervisor `da:"-"`
+//	    Consumer   *QueueConsumer // implements Run(ctx) error
+//	}
+//
+//	app := &App{Supervisor: da.NewSupervisor(da.RestartPolicy{MaxRestarts: 3})}
+type Supervisor struct {
+	// OnRestart, when set, is called before each restart with the failing component, the failure, the attempt
+	// number within the window, and the delay before restarting.
+	OnRestart func(component string, err error, attempt int, delay time.Duration)
+
+	policy RestartPolicy
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	failed chan error
+	once   sync.Once
+	setup  sync.Once
+}
+
+// NewSupervisor creates a Supervisor using policy; zero fields take their defaults. A zero Supervisor is also ready
+// to use, with the default policy.
+func NewSupervisor(policy RestartPolicy) *Supervisor {
+	s := &Supervisor{policy: policy}
+	s.init()
+	return s
+}
+
+// init fills in the policy defaults and creates the Failed channel, on first use.
+func (s *Supervisor) init() {
+	s.setup.Do(func() {
+		s.policy = withDefaults(s.policy)
+		s.failed = make(chan error, 1)
+	})
+}
+
+// withDefaults returns policy with its zero fields set to their defaults.
+func withDefaults(policy RestartPolicy) RestartPolicy {
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = 100 * time.Millisecond
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 30 * time.Second
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = 2
+	}
+	if policy.MaxRestarts <= 0 {
+		policy.MaxRestarts = 5
+	}
+	if policy.Window <= 0 {
+		policy.Window = time.Minute
+	}
+	return policy
+}
+
+// Failed returns a channel that receives a *SupervisorError when a component exhausts its restart budget.
+func (s *Supervisor) Failed() <-chan error {
+	s.init()
+	return s.failed
+}
+
+// supervise begins monitoring the Runnable and FailureNotifier components.
+func (s *Supervisor) supervise(components []component) {
+	s.init()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, comp := range components {
+		obj := comp.value.Interface()
+		if runner, ok := obj.(Runnable); ok {
+			s.wg.Add(1)
+			go s.runLoop(ctx, comp.name, runner)
+		} else if notifier, ok := obj.(FailureNotifier); ok {
+			s.wg.Add(1)
+			go s.watchLoop(ctx, comp.name, obj, notifier)
+		}
+	}
+}
+
+// halt cancels supervision and waits for every supervised Run to return.
+func (s *Supervisor) halt() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		s.wg.Wait()
+	}
+}
+
+func (s *Supervisor) runLoop(ctx context.Context, name string, runner Runnable) {
+	defer s.wg.Done()
+	budget := &restartBudget{policy: s.policy}
+	for {
+		err := safeCall("run", name, func() error { return runner.Run(ctx) })
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("exited unexpectedly")
+		}
+		if !s.backoff(ctx, name, err, budget) {
+			return
+		}
+	}
+}
+
+func (s *Supervisor) watchLoop(ctx context.Context, name string, obj any, notifier FailureNotifier) {
+	defer s.wg.Done()
+	budget := &restartBudget{policy: s.policy}
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case err = <-notifier.Failures():
+		}
+		if err == nil {
+			err = errors.New("reported failure")
+		}
+		for {
+			if !s.backoff(ctx, name, err, budget) {
+				return
+			}
+			if err = restart(name, obj); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// restart stops and starts a component in place.
+func restart(name string, obj any) error {
+	if stopper, ok := obj.(Stoppable); ok {
+		_ = safeCall("stop", name, stopper.Stop)
+	}
+	if starter, ok := obj.(Startable); ok {
+		return safeCall("start", name, starter.Start)
+	}
+	return nil
+}
+
+// backoff records a failure and waits before the next restart. returns false when supervision should end, either
+// because ctx was cancelled or because the failure was escalated.
+func (s *Supervisor) backoff(ctx context.Context, name string, err error, budget *restartBudget) bool {
+	attempt, delay, ok := budget.fail(time.Now())
+	if !ok {
+		s.escalate(&SupervisorError{Component: name, Failures: attempt, Cause: err})
+		return false
+	}
+	if s.OnRestart != nil {
+		s.OnRestart(name, err, attempt, delay)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *Supervisor) escalate(err error) {
+	s.once.Do(func() {
+		s.failed <- err
+	})
+}
+
+// restartBudget tracks recent failures of one component against a RestartPolicy.
+type restartBudget struct {
+	policy   RestartPolicy
+	failures []time.Time
+}
+
+// fail records a failure at now, returning the attempt number within the window, the delay before restarting, and
+// whether a restart is still allowed.
+func (b *restartBudget) fail(now time.Time) (int, time.Duration, bool) {
+	recent := b.failures[:0]
+	for _, t := range b.failures {
+		if now.Sub(t) < b.policy.Window {
+			recent = append(recent, t)
+		}
+	}
+	b.failures = append(recent, now)
+	attempt := len(b.failures)
+	if attempt > b.policy.MaxRestarts {
+		return attempt, 0, false
+	}
+	delay := float64(b.policy.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= b.policy.Multiplier
+		if delay >= float64(b.policy.MaxBackoff) {
+			break
+		}
+	}
+	if delay > float64(b.policy.MaxBackoff) {
+		delay = float64(b.policy.MaxBackoff)
+	}
+	return attempt, time.Duration(delay), true
+}
+
+var supervisorType = reflect.TypeOf((*Supervisor)(nil))
+
+// findSupervisor locates the first Supervisor held by the container, like findTracer.
+func findSupervisor(v reflect.Value) *Supervisor {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		field := v.Field(i)
+		switch {
+		case field.Type() == supervisorType:
+			if !field.IsNil() {
+				return field.Interface().(*Supervisor)
+			}
+		case field.Kind() == reflect.Struct:
+			if s := findSupervisor(field); s != nil {
+				return s
+			}
+		}
+	}
+	return nil
+}
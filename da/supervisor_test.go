@@ -0,0 +1,160 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSupervisedApp struct {
+	Supervisor *Supervisor `da:"-"`
+	Worker     *testFlakyWorker
+	Service    *testNotifyingService
+}
+
+// testFlakyWorker fails its first `failures` runs, then runs until cancelled
+type testFlakyWorker struct {
+	failures int32
+	runs     atomic.Int32
+	running  chan struct{}
+	once     sync.Once
+}
+
+func (w *testFlakyWorker) Run(ctx context.Context) error {
+	if w.runs.Add(1) <= w.failures {
+		return errors.New("flaked")
+	}
+	w.once.Do(func() { close(w.running) })
+	<-ctx.Done()
+	return nil
+}
+
+type testNotifyingService struct {
+	failures chan error
+	starts   atomic.Int32
+	stops    atomic.Int32
+}
+
+func (s *testNotifyingService) Start() error           { s.starts.Add(1); return nil }
+func (s *testNotifyingService) Stop() error            { s.stops.Add(1); return nil }
+func (s *testNotifyingService) Failures() <-chan error { return s.failures }
+
+func fastPolicy() RestartPolicy {
+	return RestartPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, MaxRestarts: 3, Window: time.Minute}
+}
+
+func TestSupervisorRestartsRunnable(t *testing.T) {
+	worker := &testFlakyWorker{failures: 2, running: make(chan struct{})}
+	app := &testSupervisedApp{Supervisor: NewSupervisor(fastPolicy()), Worker: worker}
+
+	var restarts atomic.Int32
+	app.Supervisor.OnRestart = func(component string, err error, attempt int, delay time.Duration) {
+		assert.Equal(t, "Worker", component)
+		restarts.Add(1)
+	}
+
+	assert.NoError(t, Start(app))
+	select {
+	case <-worker.running:
+	case <-time.After(2 * time.Second):
+		t.Fatal("worker never recovered")
+	}
+	assert.NoError(t, Stop(app))
+
+	assert.Equal(t, int32(3), worker.runs.Load())
+	assert.Equal(t, int32(2), restarts.Load())
+}
+
+func TestSupervisorEscalates(t *testing.T) {
+	worker := &testFlakyWorker{failures: 100, running: make(chan struct{})}
+	app := &testSupervisedApp{Supervisor: NewSupervisor(fastPolicy()), Worker: worker}
+
+	assert.NoError(t, Start(app))
+	select {
+	case err := <-app.Supervisor.Failed():
+		var se *SupervisorError
+		if assert.True(t, errors.As(err, &se)) {
+			assert.Equal(t, "Worker", se.Component)
+			assert.Equal(t, 4, se.Failures)
+			assert.EqualError(t, se.Cause, "flaked")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("supervisor never escalated")
+	}
+	assert.NoError(t, Stop(app))
+}
+
+func TestSupervisorRestartsFailureNotifier(t *testing.T) {
+	service := &testNotifyingService{failures: make(chan error)}
+	app := &testSupervisedApp{Supervisor: NewSupervisor(fastPolicy()), Service: service}
+
+	assert.NoError(t, Start(app))
+	service.failures <- errors.New("connection lost")
+	assert.Eventually(t, func() bool { return service.starts.Load() == 2 }, 2*time.Second, time.Millisecond)
+	assert.NoError(t, Stop(app))
+
+	assert.Equal(t, int32(2), service.stops.Load())
+}
+
+func TestZeroSupervisor(t *testing.T) {
+	worker := &testFlakyWorker{failures: 100, running: make(chan struct{})}
+	app := &testSupervisedApp{Supervisor: &Supervisor{}, Worker: worker}
+
+	delays := make(chan time.Duration, 10)
+	app.Supervisor.OnRestart = func(component string, err error, attempt int, delay time.Duration) {
+		delays <- delay
+	}
+	assert.NotNil(t, app.Supervisor.Failed())
+
+	assert.NoError(t, Start(app))
+	select {
+	case delay := <-delays:
+		assert.Equal(t, 100*time.Millisecond, delay)
+	case <-time.After(2 * time.Second):
+		t.Fatal("worker never restarted")
+	}
+
+	stopped := make(chan error)
+	go func() { stopped <- Stop(app) }()
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop never returned")
+	}
+}
+
+func TestRestartBudgetBackoff(t *testing.T) {
+	b := &restartBudget{policy: RestartPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2, MaxRestarts: 4, Window: time.Minute}}
+	now := time.Now()
+
+	var delays []time.Duration
+	for i := 0; i < 4; i++ {
+		_, delay, ok := b.fail(now)
+		assert.True(t, ok)
+		delays = append(delays, delay)
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}, delays)
+
+	_, _, ok := b.fail(now)
+	assert.False(t, ok)
+
+	// failures outside the window no longer count
+	attempt, delay, ok := b.fail(now.Add(2 * time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, 1, attempt)
+	assert.Equal(t, 100*time.Millisecond, delay)
+}
+
+func TestStartWithoutSupervisorIgnoresRunnable(t *testing.T) {
+	worker := &testFlakyWorker{running: make(chan struct{})}
+	app := &testSupervisedApp{Worker: worker}
+	assert.NoError(t, Start(app))
+	assert.NoError(t, Stop(app))
+	assert.Equal(t, int32(0), worker.runs.Load())
+}
@@ -0,0 +1,113 @@
+package da
+
+import (
+	"context"
+	"reflect"
+)
+
+// Tracer creates spans around lifecycle phases. When a container holds a field whose value implements Tracer, Wire,
+// Start, and Stop emit one span per phase, with a child span for each component processed in that phase. Likewise,
+// Application.Build emits a span with a child span per factory when its configuration or container holds a Tracer.
+//
+// Tracer is intentionally minimal so that it can be adapted to OpenTelemetry (or any other tracing system) without
+// da depending on it:
+//
+//	type otelTracer struct{ t trace.Tracer }
+//
+//	func (o *otelTracer) StartSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, da.Span) {
+//	    ctx, span := o.t.Start(ctx, name)
+//	    for k, v := range attrs {
+//	        span.SetAttributes(attribute.String(k, v))
+//	    }
+//	    return ctx, &otelSpan{span}
+//	}
+type Tracer interface {
+	StartSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, Span)
+}
+
+// Span is a single timed operation created by a Tracer.
+type Span interface {
+	// End completes the span, recording err when non-nil.
+	End(err error)
+}
+
+// Span attribute keys emitted for component spans.
+const (
+	SpanAttrComponent = "da.component"
+	SpanAttrType      = "da.type"
+	SpanAttrPhase     = "da.phase"
+)
+
+// findTracer locates the first Tracer held by the container. fields of the container (and of nested structs within
+// it) are checked; components are not searched. `da:"-"` fields are included, since a tracer is typically not a
+// lifecycle component itself.
+func findTracer(v reflect.Value) Tracer {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if field.IsNil() {
+				continue
+			}
+			if t, ok := field.Interface().(Tracer); ok {
+				return t
+			}
+		case reflect.Struct:
+			if t := findTracer(field); t != nil {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// phaseTrace tracks the span for a single lifecycle phase.
+type phaseTrace struct {
+	tracer Tracer
+	ctx    context.Context
+	span   Span
+	phase  string
+}
+
+// startPhase opens a span for a lifecycle phase; a nil tracer yields a no-op trace.
+func startPhase(tracer Tracer, phase string) *phaseTrace {
+	pt := &phaseTrace{tracer: tracer, ctx: context.Background(), phase: phase}
+	if tracer != nil {
+		pt.ctx, pt.span = tracer.StartSpan(pt.ctx, "da."+phase, map[string]string{SpanAttrPhase: phase})
+	}
+	return pt
+}
+
+// component runs fn inside a child span describing comp. a panic in fn is recovered and returned as a *PanicError.
+func (pt *phaseTrace) component(comp component, fn func() error) error {
+	if pt.tracer == nil {
+		return safeCall(pt.phase, comp.name, fn)
+	}
+	_, span := pt.tracer.StartSpan(pt.ctx, "da."+pt.phase+" "+comp.name, map[string]string{
+		SpanAttrComponent: comp.name,
+		SpanAttrType:      comp.value.Type().String(),
+		SpanAttrPhase:     pt.phase,
+	})
+	err := safeCall(pt.phase, comp.name, fn)
+	span.End(err)
+	return err
+}
+
+// end completes the phase span.
+func (pt *phaseTrace) end(err error) {
+	if pt.span != nil {
+		pt.span.End(err)
+	}
+}
@@ -0,0 +1,146 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedSpan struct {
+	name   string
+	attrs  map[string]string
+	parent string
+	ended  bool
+	err    error
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type spanKey struct{}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parent, _ := ctx.Value(spanKey{}).(string)
+	s := &recordedSpan{name: name, attrs: attrs, parent: parent}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, spanKey{}, name), s
+}
+
+func (s *recordedSpan) End(err error) {
+	s.ended = true
+	s.err = err
+}
+
+type tracedComponent struct {
+	startErr error
+}
+
+func (c *tracedComponent) Wire(app *tracedApp) error { return nil }
+func (c *tracedComponent) Start() error              { return c.startErr }
+func (c *tracedComponent) Stop() error               { return nil }
+
+type tracedApp struct {
+	Tracer   *recordingTracer `da:"-"`
+	Database *tracedComponent `da:"order=1"`
+	Services struct {
+		API *tracedComponent `da:"order=2"`
+	}
+}
+
+func TestTracingSpansPerComponent(t *testing.T) {
+	tracer := &recordingTracer{}
+	app := &tracedApp{Tracer: tracer, Database: &tracedComponent{}}
+	app.Services.API = &tracedComponent{}
+
+	assert.Nil(t, Wire(app))
+
+	assert.Len(t, tracer.spans, 3)
+	assert.Equal(t, "da.wire", tracer.spans[0].name)
+	assert.Equal(t, "da.wire Database", tracer.spans[1].name)
+	assert.Equal(t, "da.wire", tracer.spans[1].parent)
+	assert.Equal(t, "Database", tracer.spans[1].attrs[SpanAttrComponent])
+	assert.Equal(t, "*da.tracedComponent", tracer.spans[1].attrs[SpanAttrType])
+	assert.Equal(t, "da.wire Services.API", tracer.spans[2].name)
+	for _, s := range tracer.spans {
+		assert.True(t, s.ended)
+	}
+}
+
+func TestTracingRecordsErrors(t *testing.T) {
+	tracer := &recordingTracer{}
+	failure := errors.New("boom")
+	app := &tracedApp{Tracer: tracer, Database: &tracedComponent{startErr: failure}}
+
+	err := Start(app)
+	assert.Equal(t, failure, err)
+	assert.Len(t, tracer.spans, 2)
+	assert.Equal(t, failure, tracer.spans[0].err)
+	assert.Equal(t, failure, tracer.spans[1].err)
+}
+
+func TestTracingStopReverseOrder(t *testing.T) {
+	tracer := &recordingTracer{}
+	app := &tracedApp{Tracer: tracer, Database: &tracedComponent{}}
+	app.Services.API = &tracedComponent{}
+
+	assert.Nil(t, Stop(app))
+	assert.Equal(t, "da.stop", tracer.spans[0].name)
+	assert.Equal(t, "da.stop Services.API", tracer.spans[1].name)
+	assert.Equal(t, "da.stop Database", tracer.spans[2].name)
+}
+
+func TestTracingWithoutTracer(t *testing.T) {
+	app := &tracedApp{Database: &tracedComponent{}}
+	assert.Nil(t, Wire(app))
+	assert.Nil(t, Start(app))
+	assert.Nil(t, Stop(app))
+}
+
+type tracedFactory struct {
+	err error
+}
+
+func (f *tracedFactory) Build(a *Application[tracedConfig]) error { return f.err }
+
+type tracedConfig struct {
+	Tracer *recordingTracer
+}
+
+func TestTracingBuild(t *testing.T) {
+	tracer := &recordingTracer{}
+	a := NewApplication(tracedConfig{Tracer: tracer})
+	WithFactory[tracedConfig](a, &tracedFactory{})
+	WithFactoryFunc(a, func(a *Application[tracedConfig]) error { return nil })
+
+	assert.Nil(t, a.Build())
+	assert.Len(t, tracer.spans, 3)
+	assert.Equal(t, "da.build", tracer.spans[0].name)
+	assert.Equal(t, "build", tracer.spans[0].attrs[SpanAttrPhase])
+	assert.Equal(t, "da.build *da.tracedFactory", tracer.spans[1].name)
+	assert.Equal(t, "da.build", tracer.spans[1].parent)
+	assert.Equal(t, "da.build da.FactoryFunc[github.com/michaelquigley/df/da.tracedConfig]", tracer.spans[2].name)
+	for _, s := range tracer.spans {
+		assert.True(t, s.ended)
+	}
+}
+
+func TestTracingBuildFromContainer(t *testing.T) {
+	tracer := &recordingTracer{}
+	failure := errors.New("boom")
+	a := NewApplication(tracedConfig{})
+	SetAs[Tracer](a.C, tracer)
+	WithFactory[tracedConfig](a, &tracedFactory{err: failure})
+
+	assert.Equal(t, failure, a.Build())
+	assert.Len(t, tracer.spans, 2)
+	assert.Equal(t, "da.build", tracer.spans[0].name)
+	assert.Equal(t, failure, tracer.spans[0].err)
+	assert.Equal(t, failure, tracer.spans[1].err)
+}
@@ -1,6 +1,7 @@
 package da
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"strconv"
@@ -9,8 +10,10 @@ import (
 
 // component represents a discovered component with its order for processing.
 type component struct {
-	value reflect.Value
-	order int
+	value   reflect.Value
+	order   int
+	name    string // field path within the container, e.g. "Services.Auth" or "Workers[0]"
+	channel string // dl channel for injected loggers; `da:"channel=name"` or the lowercased field path
 }
 
 // traverse finds all pointer fields in a struct recursively,
@@ -18,8 +21,8 @@ type component struct {
 // Fields with `da:"-"` are skipped.
 func traverse(v reflect.Value) []component {
 	var components []component
-	traverseRecursive(v, &components)
-	sort.Slice(components, func(i, j int) bool {
+	traverseRecursive(v, "", &components)
+	sort.SliceStable(components, func(i, j int) bool {
 		return components[i].order < components[j].order
 	})
 	return components
@@ -46,7 +49,7 @@ func addComponent(v reflect.Value) (reflect.Value, bool) {
 	return reflect.Value{}, false
 }
 
-func traverseRecursive(v reflect.Value, components *[]component) {
+func traverseRecursive(v reflect.Value, prefix string, components *[]component) {
 	if v.Kind() == reflect.Ptr {
 		if v.IsNil() {
 			return
@@ -59,7 +62,7 @@ func traverseRecursive(v reflect.Value, components *[]component) {
 		// handle slice at top level - iterate through elements
 		for i := 0; i < v.Len(); i++ {
 			if val, ok := addComponent(v.Index(i)); ok {
-				*components = append(*components, component{value: val, order: 0})
+				*components = append(*components, component{value: val, order: 0, name: fmt.Sprintf("%s[%d]", prefix, i), channel: strings.ToLower(prefix)})
 			}
 		}
 		return
@@ -68,7 +71,7 @@ func traverseRecursive(v reflect.Value, components *[]component) {
 		iter := v.MapRange()
 		for iter.Next() {
 			if val, ok := addComponent(iter.Value()); ok {
-				*components = append(*components, component{value: val, order: 0})
+				*components = append(*components, component{value: val, order: 0, name: fmt.Sprintf("%s[%v]", prefix, iter.Key().Interface()), channel: strings.ToLower(prefix)})
 			}
 		}
 		return
@@ -94,37 +97,54 @@ func traverseRecursive(v reflect.Value, components *[]component) {
 			continue
 		}
 		order := parseOrder(tag)
+		name := structField.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		channel := parseChannel(tag)
+		if channel == "" {
+			channel = strings.ToLower(name)
+		}
 
 		// handle different field types
 		switch field.Kind() {
 		case reflect.Ptr:
 			if !field.IsNil() {
-				*components = append(*components, component{value: field, order: order})
+				*components = append(*components, component{value: field, order: order, name: name, channel: channel})
 			}
 		case reflect.Interface:
 			if val, ok := addComponent(field); ok {
-				*components = append(*components, component{value: val, order: order})
+				*components = append(*components, component{value: val, order: order, name: name, channel: channel})
 			}
 		case reflect.Struct:
 			// recurse into embedded/nested structs
-			traverseRecursive(field, components)
+			traverseRecursive(field, name, components)
 		case reflect.Slice:
 			for j := 0; j < field.Len(); j++ {
 				if val, ok := addComponent(field.Index(j)); ok {
-					*components = append(*components, component{value: val, order: order})
+					*components = append(*components, component{value: val, order: order, name: fmt.Sprintf("%s[%d]", name, j), channel: channel})
 				}
 			}
 		case reflect.Map:
 			iter := field.MapRange()
 			for iter.Next() {
 				if val, ok := addComponent(iter.Value()); ok {
-					*components = append(*components, component{value: val, order: order})
+					*components = append(*components, component{value: val, order: order, name: fmt.Sprintf("%s[%v]", name, iter.Key().Interface()), channel: channel})
 				}
 			}
 		}
 	}
 }
 
+func parseChannel(tag string) string {
+	for _, part := range strings.Split(tag, ",") {
+		if strings.HasPrefix(part, "channel=") {
+			return strings.TrimPrefix(part, "channel=")
+		}
+	}
+	return ""
+}
+
 func parseOrder(tag string) int {
 	for _, part := range strings.Split(tag, ",") {
 		if strings.HasPrefix(part, "order=") {
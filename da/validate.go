@@ -0,0 +1,113 @@
+package da
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WiringError reports every missing wiring found by Validate.
+type WiringError struct {
+	Missing []string // field paths that are nil, e.g. "Cache" or "Services.API.db"
+}
+
+func (e *WiringError) Error() string {
+	return fmt.Sprintf("incomplete wiring; missing: %s", strings.Join(e.Missing, ", "))
+}
+
+// Validate checks that a wired container is complete, reporting all problems at once as a *WiringError:
+//   - every pointer or interface field of the container (including nested structs) must be non-nil, unless tagged
+//     `da:"optional"` or `da:"-"`.
+//   - every field of a component tagged `da:"required"` (exported or not) must be non-nil. components use this to
+//     declare the dependencies they expect Wire to fill in.
+//
+// call Validate after Wire to turn nil-pointer panics at runtime into errors at startup.
+func Validate[C any](c *C) error {
+	v := reflect.ValueOf(c)
+	var missing []string
+	collectMissingFields(v, "", &missing)
+	for _, comp := range traverse(v) {
+		collectMissingDependencies(comp.value, comp.name, &missing)
+	}
+	if len(missing) > 0 {
+		return &WiringError{Missing: missing}
+	}
+	return nil
+}
+
+// collectMissingFields records nil, non-optional pointer and interface fields of the container.
+func collectMissingFields(v reflect.Value, prefix string, missing *[]string) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		tag := structField.Tag.Get("da")
+		if tag == "-" || hasTagOption(tag, "optional") {
+			continue
+		}
+		name := structField.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if field.IsNil() {
+				*missing = append(*missing, name)
+			}
+		case reflect.Struct:
+			collectMissingFields(field, name, missing)
+		}
+	}
+}
+
+// collectMissingDependencies records nil fields tagged `da:"required"` on a component.
+func collectMissingDependencies(v reflect.Value, name string, missing *[]string) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		structField := t.Field(i)
+		if !hasTagOption(structField.Tag.Get("da"), "required") {
+			continue
+		}
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			if field.IsNil() {
+				*missing = append(*missing, name+"."+structField.Name)
+			}
+		}
+	}
+}
+
+// hasTagOption reports whether a comma-separated `da` tag contains option.
+func hasTagOption(tag, option string) bool {
+	for _, part := range strings.Split(tag, ",") {
+		if strings.TrimSpace(part) == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package da
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validateDatabase struct{}
+
+type validateService struct {
+	db    *validateDatabase `da:"required"`
+	cache map[string]string `da:"required"`
+	extra *validateDatabase
+}
+
+func (s *validateService) Wire(app *validateApp) error {
+	s.db = app.Database
+	return nil
+}
+
+type validateApp struct {
+	Config   *struct{}         `da:"-"`
+	Database *validateDatabase `da:"order=1"`
+	Metrics  *validateDatabase `da:"optional"`
+	Services struct {
+		API    *validateService
+		Worker *validateService
+	}
+}
+
+func TestValidateComplete(t *testing.T) {
+	app := &validateApp{Database: &validateDatabase{}}
+	app.Services.API = &validateService{cache: map[string]string{}}
+	app.Services.Worker = &validateService{cache: map[string]string{}}
+
+	assert.Nil(t, Wire(app))
+	assert.Nil(t, Validate(app))
+}
+
+func TestValidateReportsAllMissing(t *testing.T) {
+	app := &validateApp{}
+	app.Services.API = &validateService{}
+
+	assert.Nil(t, Wire(app))
+	err := Validate(app)
+	assert.NotNil(t, err)
+
+	wiringErr, ok := err.(*WiringError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Database", "Services.Worker", "Services.API.db", "Services.API.cache"}, wiringErr.Missing)
+	assert.Contains(t, err.Error(), "Services.API.db")
+}
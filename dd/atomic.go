@@ -0,0 +1,64 @@
+package dd
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// writeFile writes data to path atomically: data is written to a temporary file in the same directory, which is then
+// renamed over path, so that readers (and a crash mid-write) never see a partially written file. a symlink at path is
+// followed, and its target replaced. the file's permissions and durability follow Options.FileMode and
+// Options.SyncWrites. operation names the write in a returned FileError (e.g. "write JSON").
+func writeFile(path string, data []byte, operation string, opts ...*Options) error {
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	mode := os.FileMode(0644)
+	if opt != nil && opt.FileMode != 0 {
+		mode = opt.FileMode
+	} else if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	sync := opt != nil && opt.SyncWrites
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return &FileError{Path: path, Operation: operation, Cause: err}
+	}
+	fail := func(err error) error {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return &FileError{Path: path, Operation: operation, Cause: err}
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return fail(err)
+	}
+	if sync {
+		if err := tmp.Sync(); err != nil {
+			return fail(err)
+		}
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fail(err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return &FileError{Path: path, Operation: operation, Cause: err}
+	}
+	if sync {
+		// persist the rename; directories cannot be synced on every platform, so failures are ignored
+		if d, err := os.Open(dir); err == nil {
+			_ = d.Sync()
+			_ = d.Close()
+		}
+	}
+	return nil
+}
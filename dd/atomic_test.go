@@ -0,0 +1,74 @@
+package dd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type atomicConfig struct {
+	Name string
+}
+
+func TestAtomicWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	assert.NoError(t, UnbindJSONFile(&atomicConfig{Name: "a"}, path, &Options{SyncWrites: true}))
+	cfg, err := NewJSONFile[atomicConfig](path)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", cfg.Name)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+		// an explicit mode applies, and is then kept by later writes
+		assert.NoError(t, UnbindYAMLFile(&atomicConfig{Name: "b"}, path, &Options{FileMode: 0600}))
+		info, err = os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		assert.NoError(t, UnbindJSONFile(&atomicConfig{Name: "c"}, path))
+		info, err = os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		// a symlink is preserved, and its target replaced
+		link := filepath.Join(dir, "current.json")
+		assert.NoError(t, os.Symlink(path, link))
+		assert.NoError(t, UnbindJSONFile(&atomicConfig{Name: "d"}, link))
+		target, err := os.Readlink(link)
+		assert.NoError(t, err)
+		assert.Equal(t, path, target)
+		cfg, err = NewJSONFile[atomicConfig](path)
+		assert.NoError(t, err)
+		assert.Equal(t, "d", cfg.Name)
+	}
+}
+
+func TestAtomicWriteFailure(t *testing.T) {
+	dir := t.TempDir()
+	// a non-empty directory cannot be replaced; the temporary file is cleaned up
+	path := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.MkdirAll(filepath.Join(path, "inner"), 0755))
+	err := UnbindJSONFile(&atomicConfig{Name: "a"}, path)
+	var fileErr *FileError
+	if assert.ErrorAs(t, err, &fileErr) {
+		assert.Equal(t, "write JSON", fileErr.Operation)
+	}
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	err = UnbindMsgpackFile(&atomicConfig{Name: "a"}, filepath.Join(dir, "missing", "config.msgpack"))
+	assert.ErrorAs(t, err, &fileErr)
+}
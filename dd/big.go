@@ -0,0 +1,278 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+var bigIntType = reflect.TypeOf(big.Int{})
+var bigFloatType = reflect.TypeOf(big.Float{})
+var bigRatType = reflect.TypeOf(big.Rat{})
+
+// bindBig sets a big.Int, big.Float, or big.Rat destination from a raw string or number. strings and json.Number
+// values are parsed exactly; other numbers are accepted as-is, but note that JSON numbers are decoded as float64 and
+// may have lost precision unless Options.PreciseNumbers is set. returns false if dst is not a supported big type.
+func bindBig(dst reflect.Value, raw interface{}, path string) (bool, error) {
+	switch dst.Type() {
+	case bigIntType:
+		i, err := toBigInt(raw)
+		if err != nil {
+			return true, &ConversionError{Path: path, Value: fmt.Sprintf("%v", raw), Type: "big.Int", Cause: err}
+		}
+		setBig(dst, func(z *big.Int) { z.Set(i) })
+		return true, nil
+
+	case bigFloatType:
+		f, err := toBigFloat(raw)
+		if err != nil {
+			return true, &ConversionError{Path: path, Value: fmt.Sprintf("%v", raw), Type: "big.Float", Cause: err}
+		}
+		// Copy rather than Set, which would round to the precision of an existing destination
+		setBig(dst, func(z *big.Float) { z.Copy(f) })
+		return true, nil
+
+	case bigRatType:
+		r, err := toBigRat(raw)
+		if err != nil {
+			return true, &ConversionError{Path: path, Value: fmt.Sprintf("%v", raw), Type: "big.Rat", Cause: err}
+		}
+		setBig(dst, func(z *big.Rat) { z.Set(r) })
+		return true, nil
+	}
+	return false, nil
+}
+
+// setBig sets the big value held in dst through set, in place when dst is addressable.
+func setBig[T any](dst reflect.Value, set func(*T)) {
+	if dst.CanAddr() {
+		set(dst.Addr().Interface().(*T))
+		return
+	}
+	z := new(T)
+	set(z)
+	dst.Set(reflect.ValueOf(z).Elem())
+}
+
+// unbindBig formats big.Int, big.Float, and big.Rat values as strings, preserving their full precision. a big.Rat is
+// written as a decimal when it has a finite decimal expansion, and as a fraction ("1/3") otherwise.
+// returns false if v is not a supported big type.
+func unbindBig(v reflect.Value) (interface{}, bool) {
+	switch v.Type() {
+	case bigIntType:
+		i := bigValue[big.Int](v)
+		return i.String(), true
+	case bigFloatType:
+		f := bigValue[big.Float](v)
+		return f.Text('g', -1), true
+	case bigRatType:
+		return ratString(bigValue[big.Rat](v)), true
+	}
+	return nil, false
+}
+
+// bigValue returns a pointer to the big value held in v without copying it when v is addressable.
+func bigValue[T any](v reflect.Value) *T {
+	if v.CanAddr() {
+		return v.Addr().Interface().(*T)
+	}
+	x := v.Interface().(T)
+	return &x
+}
+
+func toBigInt(raw interface{}) (*big.Int, error) {
+	if n, ok := raw.(json.Number); ok {
+		raw = string(n)
+	}
+	switch v := raw.(type) {
+	case string:
+		i, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("cannot parse %q as integer", v)
+		}
+		return i, nil
+	case float32:
+		return floatToBigInt(float64(v))
+	case float64:
+		return floatToBigInt(v)
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	}
+	if i, ok := coerceToInt64(raw); ok {
+		if u, isUint := raw.(uint64); isUint {
+			return new(big.Int).SetUint64(u), nil
+		}
+		return big.NewInt(i), nil
+	}
+	return nil, fmt.Errorf("expected integer string or number, got %T", raw)
+}
+
+func floatToBigInt(f float64) (*big.Int, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return nil, fmt.Errorf("%v is not an integer", f)
+	}
+	i, _ := big.NewFloat(f).Int(nil)
+	return i, nil
+}
+
+func toBigFloat(raw interface{}) (*big.Float, error) {
+	if n, ok := raw.(json.Number); ok {
+		raw = string(n)
+	}
+	switch v := raw.(type) {
+	case string:
+		f, _, err := big.ParseFloat(v, 10, decimalPrec(v), big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	case *big.Float:
+		return new(big.Float).Copy(v), nil
+	case big.Float:
+		return new(big.Float).Copy(&v), nil
+	case *big.Int:
+		return new(big.Float).SetInt(v), nil
+	}
+	if f, ok := coerceToFloat64(raw); ok {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%v is not a finite number", f)
+		}
+		return big.NewFloat(f), nil
+	}
+	return nil, fmt.Errorf("expected numeric string or number, got %T", raw)
+}
+
+// decimalPrec returns the precision, in bits, needed to hold the significant digits of the decimal number s, and at
+// least that of a float64, so that it formats back to the same digits.
+func decimalPrec(s string) uint {
+	digits := 0
+	for _, c := range s {
+		if c == 'e' || c == 'E' {
+			break
+		}
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	if prec := uint(math.Ceil(float64(digits)*math.Log2(10))) + 1; prec > 53 {
+		return prec
+	}
+	return 53
+}
+
+func toBigRat(raw interface{}) (*big.Rat, error) {
+	if n, ok := raw.(json.Number); ok {
+		raw = string(n)
+	}
+	switch v := raw.(type) {
+	case string:
+		r, ok := new(big.Rat).SetString(v)
+		if !ok {
+			return nil, fmt.Errorf("cannot parse %q as rational", v)
+		}
+		return r, nil
+	case *big.Rat:
+		return new(big.Rat).Set(v), nil
+	case big.Rat:
+		return new(big.Rat).Set(&v), nil
+	case *big.Int:
+		return new(big.Rat).SetInt(v), nil
+	case float32, float64:
+		f, _ := coerceToFloat64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%v is not a finite number", f)
+		}
+		// go through the shortest decimal form, so 0.1 binds as 1/10 rather than the binary approximation of 0.1
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+		return r, nil
+	}
+	if u, isUint := raw.(uint64); isUint {
+		return new(big.Rat).SetUint64(u), nil
+	}
+	if i, ok := coerceToInt64(raw); ok {
+		return new(big.Rat).SetInt64(i), nil
+	}
+	return nil, fmt.Errorf("expected rational string or number, got %T", raw)
+}
+
+// ratString formats r as an exact decimal when it has a finite decimal expansion, and as a fraction otherwise.
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	// a reduced fraction has a finite decimal expansion iff its denominator has no prime factors other than 2 and 5
+	d := new(big.Int).Set(r.Denom())
+	digits := 0
+	for _, p := range []int64{2, 5} {
+		n := 0
+		for q, m := new(big.Int), new(big.Int); ; n++ {
+			q.QuoRem(d, big.NewInt(p), m)
+			if m.Sign() != 0 {
+				break
+			}
+			d.Set(q)
+		}
+		if n > digits {
+			digits = n
+		}
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return r.RatString()
+	}
+	return r.FloatString(digits)
+}
+
+// DecimalConverter adapts an arbitrary-precision decimal type to the Converter interface, given a parse function
+// and a format function. this is the integration point for third-party decimal libraries, for example:
+//
+//	opts := &dd.Options{Converters: map[reflect.Type]dd.Converter{
+//	    reflect.TypeOf(decimal.Decimal{}): dd.DecimalConverter(decimal.NewFromString, decimal.Decimal.String),
+//	}}
+//
+// raw strings and json.Number values (see Options.PreciseNumbers) are parsed directly; other raw numbers are
+// formatted with the shortest exact representation before parsing.
+// on unbind, values are emitted as strings so that no precision is lost through float64.
+func DecimalConverter[T any](parse func(string) (T, error), format func(T) string) Converter {
+	return &decimalConverter[T]{parse: parse, format: format}
+}
+
+type decimalConverter[T any] struct {
+	parse  func(string) (T, error)
+	format func(T) string
+}
+
+func (c *decimalConverter[T]) FromRaw(raw interface{}) (interface{}, error) {
+	var s string
+	switch v := raw.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = string(v)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case T:
+		return v, nil
+	default:
+		if i, ok := coerceToInt64(raw); ok {
+			s = strconv.FormatInt(i, 10)
+		} else {
+			return nil, fmt.Errorf("expected decimal string or number, got %T", raw)
+		}
+	}
+	return c.parse(s)
+}
+
+func (c *decimalConverter[T]) ToRaw(value interface{}) (interface{}, error) {
+	v, ok := value.(T)
+	if !ok {
+		return nil, fmt.Errorf("expected %T, got %T", *new(T), value)
+	}
+	return c.format(v), nil
+}
@@ -0,0 +1,197 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bigConfig struct {
+	Supply  big.Int
+	Price   *big.Float
+	Amounts []*big.Int
+}
+
+func TestBindBigInt(t *testing.T) {
+	data := map[string]any{
+		"supply":  "123456789012345678901234567890",
+		"price":   "0.1",
+		"amounts": []any{"1", 2, float64(3)},
+	}
+
+	cfg, err := New[bigConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012345678901234567890", cfg.Supply.String())
+	assert.Equal(t, "0.1", cfg.Price.Text('g', -1))
+	assert.Len(t, cfg.Amounts, 3)
+	assert.Equal(t, int64(1), cfg.Amounts[0].Int64())
+	assert.Equal(t, int64(2), cfg.Amounts[1].Int64())
+	assert.Equal(t, int64(3), cfg.Amounts[2].Int64())
+}
+
+func TestBindBigIntRejectsFractions(t *testing.T) {
+	_, err := New[bigConfig](map[string]any{"supply": 1.5})
+	assert.Error(t, err)
+
+	_, err = New[bigConfig](map[string]any{"supply": "not a number"})
+	assert.Error(t, err)
+	var convErr *ConversionError
+	assert.ErrorAs(t, err, &convErr)
+}
+
+func TestUnbindBigRoundTrip(t *testing.T) {
+	supply, _ := new(big.Int).SetString("98765432109876543210", 10)
+	cfg := &bigConfig{Supply: *supply, Price: big.NewFloat(2.5), Amounts: []*big.Int{big.NewInt(7)}}
+
+	data, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "98765432109876543210", data["supply"])
+	assert.Equal(t, "2.5", data["price"])
+	assert.Equal(t, []any{"7"}, data["amounts"])
+
+	out, err := New[bigConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, out.Supply.Cmp(supply))
+	assert.Equal(t, 0, out.Price.Cmp(big.NewFloat(2.5)))
+}
+
+func TestBigFloatPrecision(t *testing.T) {
+	cfg, err := New[bigConfig](map[string]any{"price": "123456789012345678901234567890.125"})
+	assert.NoError(t, err)
+	data, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.23456789012345678901234567890125e+29", data["price"])
+
+	out, err := New[bigConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, out.Price.Cmp(cfg.Price))
+
+	// merging into an existing value keeps the precision of the input
+	cfg.Price.SetPrec(64)
+	assert.NoError(t, Merge(cfg, map[string]any{"price": "0.1000000000000000000000000001"}))
+	data, err = Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1000000000000000000000000001", data["price"])
+}
+
+// testDecimal is a minimal fixed-point decimal used to exercise DecimalConverter.
+type testDecimal struct {
+	units int64
+	scale int
+}
+
+func parseTestDecimal(s string) (testDecimal, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	var units int64
+	if _, err := fmt.Sscan(whole+frac, &units); err != nil {
+		return testDecimal{}, err
+	}
+	return testDecimal{units: units, scale: len(frac)}, nil
+}
+
+func (d testDecimal) String() string {
+	s := fmt.Sprintf("%d", d.units)
+	if d.scale == 0 {
+		return s
+	}
+	for len(s) <= d.scale {
+		s = "0" + s
+	}
+	return s[:len(s)-d.scale] + "." + s[len(s)-d.scale:]
+}
+
+func TestDecimalConverter(t *testing.T) {
+	type account struct {
+		Balance testDecimal
+	}
+	opts := &Options{Converters: map[reflect.Type]Converter{
+		reflect.TypeOf(testDecimal{}): DecimalConverter(parseTestDecimal, testDecimal.String),
+	}}
+
+	acct, err := New[account](map[string]any{"balance": "10.25"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, testDecimal{units: 1025, scale: 2}, acct.Balance)
+
+	acct, err = New[account](map[string]any{"balance": 0.5}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", acct.Balance.String())
+
+	data, err := Unbind(acct, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", data["balance"])
+}
+
+func TestBigRat(t *testing.T) {
+	type ledger struct {
+		Rate  big.Rat
+		Share *big.Rat
+		Fees  []big.Rat
+	}
+	data := map[string]any{
+		"rate":  "0.0125",
+		"share": "1/3",
+		"fees":  []any{0.1, 2, "5e-3"},
+	}
+	l, err := New[ledger](data)
+	assert.NoError(t, err)
+	assert.Equal(t, "1/80", l.Rate.String())
+	assert.Equal(t, "1/3", l.Share.String())
+	assert.Equal(t, "1/10", l.Fees[0].String())
+	assert.Equal(t, "2/1", l.Fees[1].String())
+	assert.Equal(t, "1/200", l.Fees[2].String())
+
+	out, err := Unbind(l)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0125", out["rate"])
+	assert.Equal(t, "1/3", out["share"])
+	assert.Equal(t, []any{"0.1", "2", "0.005"}, out["fees"])
+
+	_, err = New[ledger](map[string]any{"rate": "1/0"})
+	var convErr *ConversionError
+	assert.ErrorAs(t, err, &convErr)
+
+	schema, err := Schema[ledger]()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"type": []any{"string", "number"}}, schema["properties"].(map[string]any)["rate"])
+}
+
+func TestPreciseNumbers(t *testing.T) {
+	type payment struct {
+		Amount testDecimal
+		Total  big.Int
+		Ratio  big.Rat
+		Count  int64
+		Fee    float64
+		Extra  map[string]any `dd:",+extra"`
+	}
+	opts := &Options{
+		PreciseNumbers: true,
+		Converters: map[reflect.Type]Converter{
+			reflect.TypeOf(testDecimal{}): DecimalConverter(parseTestDecimal, testDecimal.String),
+		},
+	}
+	data := []byte(`{"amount": 12345678901.23, "total": 123456789012345678901234567890, "ratio": 0.3,
+		"count": 9007199254740993, "fee": 0.25, "memo": 1.10}`)
+
+	p, err := NewJSON[payment](data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901.23", p.Amount.String())
+	assert.Equal(t, "123456789012345678901234567890", p.Total.String())
+	assert.Equal(t, "3/10", p.Ratio.String())
+	assert.Equal(t, int64(9007199254740993), p.Count)
+	assert.Equal(t, 0.25, p.Fee)
+	assert.Equal(t, map[string]any{"memo": json.Number("1.10")}, p.Extra)
+
+	// without the option the same document loses precision through float64
+	p, err = NewJSON[payment](data, &Options{Converters: opts.Converters})
+	assert.NoError(t, err)
+	assert.NotEqual(t, "123456789012345678901234567890", p.Total.String())
+
+	_, err = NewJSON[payment]([]byte(`{"count": 1} {}`), opts)
+	assert.Error(t, err)
+}
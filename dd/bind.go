@@ -1,7 +1,9 @@
 package dd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 	"time"
@@ -15,7 +17,10 @@ type Options struct {
 
 	// FieldDynamicBinders allows specifying binder sets per field path. The key is the structured path of the field as
 	// used internally by Bind, e.g.: "Root.Items" for a slice field, "Root.Nested.Field" for nested fields.
-	// any array indices in the path are ignored for matching purposes.
+	// any array indices in the path are ignored for matching purposes, so "Root.Stages[].Actions" also works.
+	// keys may use wildcard segments: "*" matches one segment ("*.Steps") and "**" any number ("**.Steps"). an exact
+	// key is consulted first, then matching patterns from most to least specific; the first set with a binder for the
+	// discriminator wins.
 	// when present for a field, this map takes precedence over DynamicBinders.
 	FieldDynamicBinders map[string]map[string]func(map[string]any) (Dynamic, error)
 
@@ -23,25 +28,157 @@ type Options struct {
 	// the key is the reflect.Type of the target field, and the value is a Converter
 	// that handles bidirectional conversion between raw data and the target type.
 	Converters map[reflect.Type]Converter
+
+	// Source names the origin of the data being bound (a file path, "env", "defaults", etc.). when set, Bind and Merge
+	// record which source supplied each field's final value in Provenance.
+	Source string
+
+	// Provenance, when non-nil, receives the Source of each field's final value during Bind and Merge. see Provenance.
+	Provenance Provenance
+
+	// ExplicitNulls, when non-nil, receives the pointer fields cleared by an explicit null during Bind and Merge, and
+	// makes Unbind emit those fields as null instead of omitting them. see ExplicitNulls.
+	ExplicitNulls ExplicitNulls
+
+	// SecretPolicy controls how Unbind emits `+secret` fields: in the clear (the default), replaced by
+	// SecretPlaceholder, or omitted. use it when serializing structs for support bundles, API responses, or logs.
+	SecretPolicy SecretPolicy
+
+	// SecretPlaceholder replaces secret values under MaskSecrets; defaults to "<redacted>".
+	SecretPlaceholder string
+
+	// SecretMasker, when set, computes the value emitted for each secret under MaskSecrets from its unbound value,
+	// instead of SecretPlaceholder (e.g. to keep the last few characters of a token for identification).
+	SecretMasker func(value any) any
+
+	// NamingStrategy derives external names for fields without an explicit name in their dd tag; defaults to
+	// SnakeCase. it applies symmetrically to Bind, Merge, and Unbind, so use the same strategy in both directions.
+	NamingStrategy NamingStrategy
+
+	// Coercion controls how strictly primitive values are converted to bool, integer, and float fields. the zero value
+	// keeps the default behavior.
+	Coercion Coercion
+
+	// SliceMergeStrategy controls how Merge combines slices in the data with those already in the target: replace
+	// them (the default), append to them, or append only new elements. a `+merge=append` (or replace, union) tag
+	// overrides it per field. Bind always replaces.
+	SliceMergeStrategy SliceMergeStrategy
+
+	// NullDeletes makes an explicit null in Merge data reset the field to its zero value, so override files can unset
+	// defaults. maps are then merged key by key instead of replaced, and a null entry removes its key. Bind is
+	// unaffected.
+	NullDeletes bool
+
+	// PreserveOrder makes UnbindJSON and UnbindYAML (and their variants) emit object keys in struct field declaration
+	// order rather than sorted, as UnbindOrdered does.
+	PreserveOrder bool
+
+	// YAMLAnchors makes UnbindYAML (and its variants) emit each object or list that appears more than once in the
+	// output with an anchor at its first occurrence and an alias everywhere else, keeping large repetitive
+	// configurations compact. the output binds back to the same value.
+	YAMLAnchors bool
+
+	// JSONIndent is the indentation used by UnbindJSON (and its variants) for each level of nesting; the default is
+	// two spaces.
+	JSONIndent string
+
+	// JSONDisableHTMLEscape makes UnbindJSON (and its variants) emit "<", ">", and "&" in strings as is, rather than
+	// as \u003c, \u003e, and \u0026.
+	JSONDisableHTMLEscape bool
+
+	// YAMLIndent is the number of spaces used by UnbindYAML (and its variants) for each level of nesting; the
+	// default is 4.
+	YAMLIndent int
+
+	// YAMLFlowLists makes UnbindYAML (and its variants) emit lists of at most this many scalars in flow style, on one
+	// line (e.g. "ports: [80, 443]"), rather than one item per line. zero leaves every list in block style.
+	YAMLFlowLists int
+
+	// PointerResolver makes Bind, New, and Merge attach it to every Pointer[T] they bind, so that each reference is
+	// resolved on first access through Resolve or Lookup, with no Link pass.
+	PointerResolver Resolver
+
+	// RefKey is the key holding a Pointer's reference in bound and unbound data, for documents that use "ref",
+	// "@id", or similar in place of the default "$ref".
+	RefKey string
+
+	// PlainRefs makes Unbind emit each Pointer's reference as a plain string ("author": "alice") rather than an object
+	// under RefKey, as does `+plainref` for a single field. Bind accepts either form regardless.
+	PlainRefs bool
+
+	// FileMode sets the permissions of files written by UnbindJSONFile, UnbindYAMLFile, and the other format file
+	// variants. when zero, an existing file keeps its permissions, and a new file is created with 0644.
+	FileMode os.FileMode
+
+	// SyncWrites makes the file variants of Unbind flush the file (and its directory) to stable storage before
+	// returning, so that a new configuration survives a power loss as well as a crash.
+	SyncWrites bool
+
+	// SecretKeys, when set, makes Bind and Merge decrypt sealed `+secret` values ("enc:v1:...") before binding, and
+	// Unbind encrypt `+secret` values, as Open and Seal do. with it, BindYAMLFile, UnbindYAMLFile, and the other format
+	// helpers read and write encrypted secrets transparently, so they can be committed the way sops allows. plain
+	// secret values are still bound as-is. Unbind only encrypts under PlainSecrets; masked or omitted secrets are left
+	// alone.
+	SecretKeys KeyProvider
+
+	// ExpandEnv, when set, makes Bind and Merge expand ${VAR} and ${VAR:-default} references in string values from
+	// the environment before binding. see EnvExpansion.
+	ExpandEnv *EnvExpansion
+
+	// JSONMarshalers makes types implementing json.Marshaler and json.Unmarshaler round-trip through their JSON
+	// representation when no Converter is registered for them, easing migration of types already wired for
+	// encoding/json. types dd handles natively (time.Time, math/big, text marshalers, etc.) are unaffected.
+	JSONMarshalers bool
+
+	// InterfaceBinders extends Dynamic-style binding to arbitrary interface types. it maps an interface type (e.g.
+	// reflect.TypeOf((*Sink)(nil)).Elem()) to factories keyed by the "type" discriminator found in the data; each
+	// factory returns a new implementation, either a struct or a pointer to one (e.g. func() any { return &FileSink{} }),
+	// into which the remaining keys are bound. Unbind emits the discriminator for values of registered types.
+	InterfaceBinders map[reflect.Type]map[string]func() any
+
+	// PreciseNumbers makes BindJSON, NewJSON, and MergeJSON (and their variants, BindStream, and JSON layers of
+	// LoadLayered) decode numbers as json.Number rather than float64. integer fields then receive large values such as
+	// int64 IDs exactly, and big.Int, big.Float, big.Rat, and DecimalConverter fields the exact digits from the
+	// document. untyped destinations (any, map[string]any, +extra) receive the json.Number itself.
+	PreciseNumbers bool
+
+	// deferRequired suppresses `+required` checks while LoadLayered applies individual layers; it checks them once
+	// across all layers instead.
+	deferRequired bool
+
+	// replaceSlices makes Merge replace slices whatever SliceMergeStrategy and `+merge` tags say, for ApplyOverrides
+	// and Patch, whose data holds whole slices.
+	replaceSlices bool
+
+	// rawNodes indexes the source document when BindYAML or BindJSON bind a type with `+raw` fields.
+	rawNodes *rawNodeIndex
+
+	// ctx is the context given to BindCtx and its siblings.
+	ctx context.Context
 }
 
 // Bind populates the exported fields of target (a pointer to a struct) from the given data map. Keys are matched using
 // either a struct tag `dd:"name,+required"` (where name overrides the key and the optional "+required" flag enforces
 // presence), `dd:"-"` to skip a field, or, when no tag is provided, a best-effort snake_case conversion of the
-// field name.
+// field name (or Options.NamingStrategy, when set).
 //
 // Use Bind when you need to control how the prototype object is allocated. Use New when you just want to allocate a new
 // object to bind off the heap.
 //
 // supported kinds:
-// - primitives: string, bool, all int/uint sizes, float32/64, time.Duration, time.Time (from RFC3339 strings)
-// - pointers to the above
-// - structs and pointers to structs (recursively bound from map[string]any)
-// - slices of the above (slice items are bound from []interface{})
-// - maps with comparable key types and any supported value type (map keys from JSON/YAML are coerced from strings)
+//   - primitives: string, bool, all int/uint sizes, float32/64, time.Duration, time.Time (from RFC3339 strings)
+//   - pointers to the above; an explicit null sets the pointer to nil, while an absent key leaves it unchanged
+//   - structs and pointers to structs (recursively bound from map[string]any)
+//   - slices of the above (slice items are bound from []interface{})
+//   - maps with comparable key types and any supported value type (map keys from JSON/YAML are coerced from strings),
+//     including Dynamic and []Dynamic values
+//
+// structs implementing BeforeBinder, AfterBinder, or Validator are called around the binding of their fields, in that
+// order.
 //
 // interface types are not supported and will return an error if encountered,
-// except for fields of type Dynamic which are resolved using Options.DynamicBinders.
+// except for fields of type Dynamic which are resolved using Options.DynamicBinders, and interfaces with
+// implementations registered in Options.InterfaceBinders.
 //
 // opts are optional; pass nil or omit to use defaults.
 func Bind(target interface{}, data map[string]any, opts ...*Options) error {
@@ -53,7 +190,15 @@ func Bind(target interface{}, data map[string]any, opts ...*Options) error {
 	if err != nil {
 		return err
 	}
-	return bindStruct(elem, data, elem.Type().Name(), opt, false, nil)
+	if data, err = prepareData(elem, data, opt); err != nil {
+		return err
+	}
+	if err := bindStruct(elem, data, elem.Type().Name(), opt, false, nil); err != nil {
+		return err
+	}
+	recordProvenance(elem.Type(), data, opt, true)
+	recordNulls(elem.Type(), data, opt, true)
+	return nil
 }
 
 // New creates and populates a new instance of type T from the given data map.
@@ -111,11 +256,43 @@ func Merge(target interface{}, data map[string]any, opts ...*Options) error {
 	if err != nil {
 		return err
 	}
-	return bindStruct(elem, data, elem.Type().Name(), opt, true, nil)
+	if data, err = prepareData(elem, data, opt); err != nil {
+		return err
+	}
+	if err := bindStruct(elem, data, elem.Type().Name(), opt, true, nil); err != nil {
+		return err
+	}
+	recordProvenance(elem.Type(), data, opt, false)
+	recordNulls(elem.Type(), data, opt, false)
+	return nil
+}
+
+// prepareData applies the Options transformations that rewrite data before it is bound into elem: environment
+// expansion, then decryption of sealed secrets. data itself is not modified.
+func prepareData(elem reflect.Value, data map[string]any, opt *Options) (map[string]any, error) {
+	if opt == nil {
+		return data, nil
+	}
+	var err error
+	if opt.ExpandEnv != nil {
+		if data, err = expandEnvData(data, opt.ExpandEnv); err != nil {
+			return nil, err
+		}
+	}
+	if opt.SecretKeys != nil {
+		if data, err = openSecrets(elem.Type(), data, opt.SecretKeys, opt.naming()); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
 }
 
 func bindStruct(structValue reflect.Value, data map[string]any, path string, opt *Options, preserveExisting bool, consumedKeys map[string]bool) error {
 	structType := structValue.Type()
+	if isPointerType(structType) {
+		bindPointerResolver(structValue, opt)
+		data = withDefaultRefKey(data, opt)
+	}
 
 	type deferredUnmarshal struct {
 		fieldVal reflect.Value
@@ -126,23 +303,42 @@ func bindStruct(structValue reflect.Value, data map[string]any, path string, opt
 	var deferred []deferredUnmarshal
 
 	// initialize consumed keys tracking if not provided (entry point call)
-	if consumedKeys == nil {
+	entryPoint := consumedKeys == nil
+	if entryPoint {
+		if err := checkContext(opt); err != nil {
+			return err
+		}
+		var err error
+		if data, err = beforeBind(structValue, data, path); err != nil {
+			return err
+		}
+
+		// code generated by ddgen implements the default behavior without reflection
+		if opt == nil && !preserveExisting && structValue.CanAddr() {
+			if codec, ok := lookupGenerated(structType); ok {
+				if err := codec.bind(structValue.Addr().Interface(), data); err != nil {
+					return err
+				}
+				return afterBind(structValue, path)
+			}
+		}
 		consumedKeys = make(map[string]bool)
+		opt.descendRaw(data)
 	}
 
 	// track extra field for capturing unmatched keys
 	var extraFieldVal reflect.Value
 
-	for i := 0; i < structValue.NumField(); i++ {
-		field := structType.Field(i)
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
 		if field.PkgPath != "" { // unexported
 			continue
 		}
 
-		fieldVal := structValue.Field(i)
+		fieldVal := structValue.Field(meta.index)
 
 		// handle embedded structs by recursively binding their fields
-		if field.Anonymous {
+		if isInlined(field, meta.tag) {
 			if field.Type.Kind() == reflect.Ptr {
 				// for pointer embedded structs, only allocate if there are fields for it in data
 				if fieldVal.IsNil() {
@@ -150,20 +346,14 @@ func bindStruct(structValue reflect.Value, data map[string]any, path string, opt
 					hasEmbeddedFields := false
 					embeddedType := field.Type.Elem()
 					if embeddedType.Kind() == reflect.Struct {
-						for j := 0; j < embeddedType.NumField(); j++ {
-							embeddedField := embeddedType.Field(j)
-							if embeddedField.PkgPath != "" { // unexported
+						for _, embedded := range cachedFields(embeddedType) {
+							if embedded.field.PkgPath != "" { // unexported
 								continue
 							}
-							embeddedTag := parseDdTag(embeddedField)
-							if embeddedTag.Skip {
+							if embedded.tag.Skip {
 								continue
 							}
-							embeddedName := embeddedTag.Name
-							if embeddedName == "" {
-								embeddedName = toSnakeCase(embeddedField.Name)
-							}
-							if _, exists := data[embeddedName]; exists {
+							if _, exists := data[embedded.externalName(opt.naming())]; exists {
 								hasEmbeddedFields = true
 								break
 							}
@@ -196,10 +386,22 @@ func bindStruct(structValue reflect.Value, data map[string]any, path string, opt
 					}
 				}
 			}
+			// unlike embedded structs, whose methods are promoted, +inline fields are finished and validated on their own
+			if !field.Anonymous {
+				inlineVal := fieldVal
+				if inlineVal.Kind() == reflect.Ptr {
+					inlineVal = inlineVal.Elem()
+				}
+				if inlineVal.IsValid() {
+					if err := afterBind(inlineVal, path+"."+field.Name); err != nil {
+						return err
+					}
+				}
+			}
 			continue
 		}
 
-		tag := parseDdTag(field)
+		tag := meta.tag
 		if tag.Skip {
 			continue
 		}
@@ -221,23 +423,32 @@ func bindStruct(structValue reflect.Value, data map[string]any, path string, opt
 			extraFieldVal = fieldVal
 			continue
 		}
-
-		name := tag.Name
-		if name == "" {
-			name = toSnakeCase(field.Name)
+		if tag.Raw && !isRawNodeType(field.Type) {
+			return &TypeMismatchError{
+				Path:     path,
+				Expected: "yaml.Node, *yaml.Node, or json.RawMessage for +raw field",
+				Actual:   field.Type.String(),
+			}
 		}
 
+		name := meta.externalName(opt.naming())
+
 		raw, ok := data[name]
 		if ok {
 			consumedKeys[name] = true
 		}
 		if !ok {
-			if tag.Required {
+			if tag.Required && (opt == nil || !opt.deferRequired) {
 				return &RequiredFieldError{Path: path, Field: field.Name}
 			}
 			continue
 		}
 
+		if raw == nil && preserveExisting && opt != nil && opt.NullDeletes {
+			fieldVal.Set(reflect.Zero(field.Type))
+			continue
+		}
+
 		// validate match constraint if specified
 		if tag.HasMatch {
 			actualStr := fmt.Sprintf("%v", raw)
@@ -251,6 +462,14 @@ func bindStruct(structValue reflect.Value, data map[string]any, path string, opt
 			}
 		}
 
+		// +raw fields capture the subtree without interpreting it
+		if tag.Raw {
+			if err := bindRawNode(fieldVal, raw, opt.rawSource(data, name), path+"."+field.Name); err != nil {
+				return &BindingError{Path: path, Field: field.Name, Key: name, Cause: err}
+			}
+			continue
+		}
+
 		// defer custom unmarshalers to run after all other fields are bound.
 		if (fieldVal.CanAddr() && fieldVal.Addr().Type().Implements(unmarshalerInterfaceType)) || fieldVal.Type().Implements(unmarshalerInterfaceType) {
 			deferred = append(deferred, deferredUnmarshal{
@@ -262,9 +481,55 @@ func bindStruct(structValue reflect.Value, data map[string]any, path string, opt
 			continue
 		}
 
+		// +format fields parse their wire format here; the resulting time.Time binds like any other
+		if tag.Format != "" && raw != nil && isTimeField(field.Type) {
+			t, err := parseTimeFormat(raw, tag.Format)
+			if err != nil {
+				return &BindingError{Path: path, Field: field.Name, Key: name, Cause: fmt.Errorf("%s.%s: %w", path, field.Name, err)}
+			}
+			raw = t
+		}
+
+		// +keyed fields move each map key under the type discriminator, then bind like any other dynamic value
+		if tag.Keyed && raw != nil {
+			keyed, err := keyedToDiscriminated(raw, field.Type)
+			if err != nil {
+				return &BindingError{Path: path, Field: field.Name, Key: name, Cause: fmt.Errorf("%s.%s: %w", path, field.Name, err)}
+			}
+			raw = keyed
+		}
+
+		var existing reflect.Value
+		strategy := SliceReplace
+		if preserveExisting && field.Type.Kind() == reflect.Slice && raw != nil {
+			var err error
+			if strategy, err = sliceMergeStrategy(tag, opt); err != nil {
+				return &BindingError{Path: path, Field: field.Name, Key: name, Cause: err}
+			}
+			if strategy != SliceReplace {
+				existing = reflect.AppendSlice(reflect.MakeSlice(field.Type, 0, fieldVal.Len()), fieldVal)
+			}
+		}
+
 		if err := setField(fieldVal, raw, path+"."+field.Name, opt, preserveExisting); err != nil {
 			return &BindingError{Path: path, Field: field.Name, Key: name, Cause: err}
 		}
+		if existing.IsValid() {
+			fieldVal.Set(mergeSlices(existing, fieldVal, strategy))
+		}
+		if err := checkConstraints(fieldVal, tag, path, name); err != nil {
+			return err
+		}
+	}
+
+	present := func(name string) bool { return data[name] != nil }
+	if opt == nil || !opt.deferRequired {
+		if err := checkFieldGroups(structType, present, path, opt.naming()); err != nil {
+			return err
+		}
+	}
+	if err := checkFieldComparisons(structValue, present, path, opt.naming()); err != nil {
+		return err
 	}
 
 	// run deferred unmarshalers now that all other fields are populated.
@@ -301,6 +566,10 @@ func bindStruct(structValue reflect.Value, data map[string]any, path string, opt
 		}
 	}
 
+	// embedded structs are finished and validated as part of their parent
+	if entryPoint {
+		return afterBind(structValue, path)
+	}
 	return nil
 }
 
@@ -339,10 +608,20 @@ func setField(fieldVal reflect.Value, raw interface{}, path string, opt *Options
 
 	// handle pointers by allocating as needed then setting the element
 	if fieldType.Kind() == reflect.Ptr {
+		// an explicit null clears the pointer; an absent key never reaches here and leaves it unchanged
+		if raw == nil {
+			fieldVal.Set(reflect.Zero(fieldType))
+			return nil
+		}
+		// converters registered for the pointer type itself (e.g. *regexp.Regexp) produce the pointer
+		if hasConverter(fieldType, opt) {
+			return setNonPtrValue(fieldVal, raw, path, opt, preserveExisting)
+		}
 		elemType := fieldType.Elem()
 
-		// special-case *time.Time before checking for struct pointer
-		if elemType == reflect.TypeOf(time.Time{}) {
+		// special-case scalar structs (*time.Time, *big.Int, ...), converted types, optionals, and json fallbacks
+		// before checking for struct pointer
+		if bindsAsValue(elemType, opt) || isOptionalType(elemType) {
 			newPtr := reflect.New(elemType)
 			if err := setNonPtrValue(newPtr.Elem(), raw, path, opt, preserveExisting); err != nil {
 				return err
@@ -352,7 +631,7 @@ func setField(fieldVal reflect.Value, raw interface{}, path string, opt *Options
 		}
 
 		if elemType.Kind() == reflect.Struct {
-			subMap, ok := raw.(map[string]any)
+			subMap, ok := structData(elemType, raw, opt)
 			if !ok {
 				return &TypeMismatchError{Path: path, Expected: "object for struct pointer", Actual: fmt.Sprintf("%T", raw)}
 			}
@@ -383,6 +662,12 @@ func setField(fieldVal reflect.Value, raw interface{}, path string, opt *Options
 	return setNonPtrValue(fieldVal, raw, path, opt, preserveExisting)
 }
 
+// bindsAsValue reports whether struct type t is bound from a single raw value rather than field by field: scalar
+// structs, types with a registered converter, and json fallbacks.
+func bindsAsValue(t reflect.Type, opt *Options) bool {
+	return isScalarStruct(t) || hasConverter(t, opt) || jsonFallback(t, opt)
+}
+
 func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *Options, preserveExisting bool) error {
 	// check for custom converter first
 	if converted, wasConverted, err := tryCustomConverter(fieldVal.Type(), raw, opt, true); err != nil {
@@ -414,10 +699,25 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 			return fmt.Errorf("%s: expected time (RFC3339 string or time.Time), got %T", path, raw)
 		}
 	}
+	if isScalarStruct(fieldVal.Type()) {
+		return convertAndSet(fieldVal, raw, path, opt)
+	}
+	if isOptionalType(fieldVal.Type()) {
+		return bindOptional(fieldVal, raw, path, opt, preserveExisting)
+	}
+	if handled, err := bindRaw(fieldVal, raw, path); handled {
+		return err
+	}
+	if handled, err := bindText(fieldVal, raw, path); handled {
+		return err
+	}
+	if handled, err := bindJSON(fieldVal, raw, path, opt); handled {
+		return err
+	}
 
 	switch fieldVal.Kind() {
 	case reflect.Struct:
-		subMap, ok := raw.(map[string]any)
+		subMap, ok := structData(fieldVal.Type(), raw, opt)
 		if !ok {
 			return fmt.Errorf("%s: expected object for struct, got %T", path, raw)
 		}
@@ -428,6 +728,7 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 		if rawVal.Kind() != reflect.Slice {
 			return fmt.Errorf("%s: expected array for slice, got %T", path, raw)
 		}
+		opt.descendRaw(raw)
 		elemType := fieldVal.Type().Elem()
 		out := reflect.MakeSlice(fieldVal.Type(), 0, rawVal.Len())
 		// handle slices of Dynamic interface specially
@@ -451,10 +752,14 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 		for idx := 0; idx < rawVal.Len(); idx++ {
 			item := rawVal.Index(idx).Interface()
 			itemPath := fmt.Sprintf("%s[%d]", path, idx)
-			if elemType.Kind() == reflect.Ptr {
+			if elemType.Kind() == reflect.Ptr && !hasConverter(elemType, opt) {
+				if item == nil {
+					out = reflect.Append(out, reflect.Zero(elemType))
+					continue
+				}
 				elemPtr := reflect.New(elemType.Elem())
-				if elemType.Elem().Kind() == reflect.Struct {
-					subMap, ok := item.(map[string]any)
+				if elemType.Elem().Kind() == reflect.Struct && !bindsAsValue(elemType.Elem(), opt) {
+					subMap, ok := structData(elemType.Elem(), item, opt)
 					if !ok {
 						return fmt.Errorf("%s: expected object for struct slice element, got %T", itemPath, item)
 					}
@@ -474,8 +779,8 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 
 			// non-pointer element
 			elemVal := reflect.New(elemType).Elem()
-			if elemType.Kind() == reflect.Struct {
-				subMap, ok := item.(map[string]any)
+			if elemType.Kind() == reflect.Struct && !bindsAsValue(elemType, opt) {
+				subMap, ok := structData(elemType, item, opt)
 				if !ok {
 					return fmt.Errorf("%s: expected object for struct slice element, got %T", itemPath, item)
 				}
@@ -498,21 +803,33 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 		if !ok {
 			return fmt.Errorf("%s: expected object for map field, got %T", path, raw)
 		}
+		opt.descendRaw(rawMap)
 
 		keyType := fieldVal.Type().Key()
 		elemType := fieldVal.Type().Elem()
 
-		// create new map
+		// create new map; under NullDeletes, Merge updates a copy of the existing map instead
 		newMap := reflect.MakeMap(fieldVal.Type())
+		mergeKeys := preserveExisting && opt != nil && opt.NullDeletes
+		if mergeKeys {
+			iter := fieldVal.MapRange()
+			for iter.Next() {
+				newMap.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
 
 		// populate map with converted keys and values
 		for keyStr, value := range rawMap {
 			itemPath := fmt.Sprintf("%s[%q]", path, keyStr)
 
 			// convert string key to target key type
-			keyVal, err := stringToKey(keyStr, keyType)
+			keyVal, err := stringToKey(keyStr, keyType, itemPath, opt)
 			if err != nil {
-				return fmt.Errorf("%s: %w", path, err)
+				return keyError(path, err)
+			}
+			if mergeKeys && value == nil {
+				newMap.SetMapIndex(keyVal, reflect.Value{})
+				continue
 			}
 
 			// handle different value types similar to slice element handling
@@ -530,12 +847,12 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 				continue
 			}
 
-			if elemType.Kind() == reflect.Ptr {
+			if elemType.Kind() == reflect.Ptr && !hasConverter(elemType, opt) {
 				// pointer to value
 				elemPtr := reflect.New(elemType.Elem())
-				if elemType.Elem().Kind() == reflect.Struct {
+				if elemType.Elem().Kind() == reflect.Struct && !bindsAsValue(elemType.Elem(), opt) {
 					// pointer to struct
-					subMap, ok := value.(map[string]any)
+					subMap, ok := structData(elemType.Elem(), value, opt)
 					if !ok {
 						return fmt.Errorf("%s: expected object for struct map value, got %T", itemPath, value)
 					}
@@ -555,9 +872,9 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 
 			// non-pointer value
 			elemVal := reflect.New(elemType).Elem()
-			if elemType.Kind() == reflect.Struct {
+			if elemType.Kind() == reflect.Struct && !bindsAsValue(elemType, opt) {
 				// struct value
-				subMap, ok := value.(map[string]any)
+				subMap, ok := structData(elemType, value, opt)
 				if !ok {
 					return fmt.Errorf("%s: expected object for struct map value, got %T", itemPath, value)
 				}
@@ -584,7 +901,7 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 				continue
 			}
 			// primitive or interface value
-			if elemType.Kind() == reflect.Interface {
+			if elemType.Kind() == reflect.Interface && interfaceBinders(elemType, opt) == nil {
 				// interface{} or any type - store raw value
 				newMap.SetMapIndex(keyVal, reflect.ValueOf(value))
 				continue
@@ -612,26 +929,28 @@ func setNonPtrValue(fieldVal reflect.Value, raw interface{}, path string, opt *O
 			fieldVal.Set(reflect.ValueOf(dynVal))
 			return nil
 		}
+		// other interfaces via registered implementations
+		if handled, err := bindInterface(fieldVal, raw, path, opt); handled {
+			return err
+		}
 		return fmt.Errorf("%s: interface fields are not supported", path)
 
 	default:
 		// check if this is a Pointer[T] type before falling back to convertAndSet
 		if isPointerType(fieldVal.Type()) {
-			subMap, ok := raw.(map[string]any)
+			subMap, ok := structData(fieldVal.Type(), raw, opt)
 			if !ok {
 				return fmt.Errorf("%s: expected object for Pointer, got %T", path, raw)
 			}
-			return bindPointer(fieldVal, subMap, path)
+			return bindPointer(fieldVal, subMap, path, opt)
 		}
 		return convertAndSet(fieldVal, raw, path, opt)
 	}
 }
 
-// bindDynamic resolves a Dynamic implementation from a map using the Options registry.
+// bindDynamic resolves a Dynamic implementation from a map using the Options registry, falling back to types
+// registered with RegisterDynamic.
 func bindDynamic(m map[string]any, path string, opt *Options) (Dynamic, error) {
-	if opt == nil {
-		return nil, fmt.Errorf("%s: no options provided to resolve Dynamic field", path)
-	}
 	tVal, ok := m[TypeKey]
 	if !ok {
 		return nil, fmt.Errorf("%s: missing '%v' discriminator for Dynamic field", path, TypeKey)
@@ -642,15 +961,21 @@ func bindDynamic(m map[string]any, path string, opt *Options) (Dynamic, error) {
 	}
 	// prefer field-specific binder set if provided
 	var binder func(map[string]any) (Dynamic, error)
-	if opt.FieldDynamicBinders != nil {
-		if perField, ok := opt.FieldDynamicBinders[stripIndices(path)]; ok && perField != nil {
-			binder = perField[typeStr]
+	if opt != nil {
+		for _, perField := range matchFieldPath(opt.FieldDynamicBinders, path) {
+			if binder = perField[typeStr]; binder != nil {
+				break
+			}
 		}
 	}
 	// fall back to global binders
-	if binder == nil && opt.DynamicBinders != nil {
+	if binder == nil && opt != nil && opt.DynamicBinders != nil {
 		binder = opt.DynamicBinders[typeStr]
 	}
+	// and finally to the package-level registry
+	if binder == nil {
+		binder = registeredDynamic(typeStr)
+	}
 	if binder == nil {
 		return nil, fmt.Errorf("%s: unknown Dynamic type %q", path, typeStr)
 	}
@@ -703,3 +1028,20 @@ func stripIndices(path string) string {
 	}
 	return b.String()
 }
+
+// validateStruct calls Validate on a bound struct implementing Validator.
+func validateStruct(structValue reflect.Value, path string) error {
+	var validator Validator
+	if structValue.CanAddr() && structValue.Addr().Type().Implements(validatorInterfaceType) {
+		validator = structValue.Addr().Interface().(Validator)
+	} else if structValue.Type().Implements(validatorInterfaceType) {
+		validator = structValue.Interface().(Validator)
+	}
+	if validator == nil {
+		return nil
+	}
+	if err := validator.Validate(); err != nil {
+		return &ValidationError{Field: path, Message: err.Error(), Cause: err}
+	}
+	return nil
+}
@@ -696,3 +696,49 @@ func TestBindUnbindCustomPrimitiveTypesRoundTrip(t *testing.T) {
 	assert.Equal(t, CustomBool(false), target.MyBool)
 	assert.Equal(t, CustomFloat(2.718), target.MyFloat)
 }
+
+func TestBindDynamicMapValues(t *testing.T) {
+	type plugins struct {
+		Plugins map[string]Dynamic
+		Groups  map[string][]Dynamic
+	}
+	opts := &Options{
+		FieldDynamicBinders: map[string]map[string]func(map[string]any) (Dynamic, error){
+			"plugins.Plugins": {
+				"a": func(m map[string]any) (Dynamic, error) {
+					name, _ := m["name"].(string)
+					return &dynA{Name: name}, nil
+				},
+			},
+		},
+		DynamicBinders: map[string]func(map[string]any) (Dynamic, error){
+			"b": func(m map[string]any) (Dynamic, error) {
+				count, _ := m["count"].(int)
+				return &dynB{Count: count}, nil
+			},
+		},
+	}
+	data := map[string]any{
+		"plugins": map[string]any{
+			"auth":  map[string]any{"type": "a", "name": "oidc"},
+			"cache": map[string]any{"type": "b", "count": 2},
+		},
+		"groups": map[string]any{
+			"ingest": []any{map[string]any{"type": "b", "count": 1}, map[string]any{"type": "b", "count": 3}},
+		},
+	}
+
+	p, err := New[plugins](data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Dynamic{"auth": &dynA{Name: "oidc"}, "cache": &dynB{Count: 2}}, p.Plugins)
+	assert.Equal(t, map[string][]Dynamic{"ingest": {&dynB{Count: 1}, &dynB{Count: 3}}}, p.Groups)
+
+	out, err := Unbind(p)
+	assert.NoError(t, err)
+	assert.Equal(t, data, out)
+
+	// errors name the offending key
+	_, err = New[plugins](map[string]any{"plugins": map[string]any{"auth": map[string]any{"type": "zz"}}}, opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `plugins.Plugins["auth"]: unknown Dynamic type "zz"`)
+}
@@ -0,0 +1,146 @@
+package dd
+
+import (
+	"maps"
+	"reflect"
+)
+
+// OptionsBuilder assembles Options fluently, without spelling out reflect.TypeOf keys or nested binder maps:
+//
+//	opts := dd.NewOptions().
+//	    With(dd.ConverterFor[time.Time](&TimeConverter{}), dd.DynamicFor[*EmailAction]("email")).
+//	    WithDynamic("slack", bindSlack).
+//	    Strict().
+//	    Build()
+//
+// Go methods cannot take type parameters, so the generic settings are Option values passed to With.
+type OptionsBuilder struct {
+	opts Options
+}
+
+// Option applies one setting to the Options being built; see ConverterFor, DynamicFor, and InterfaceFor.
+type Option func(*Options)
+
+// NewOptions starts an OptionsBuilder from the zero Options.
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// With applies the given options in order.
+func (b *OptionsBuilder) With(options ...Option) *OptionsBuilder {
+	for _, option := range options {
+		option(&b.opts)
+	}
+	return b
+}
+
+// WithConverter registers c for fields of type t; ConverterFor is the generic form.
+func (b *OptionsBuilder) WithConverter(t reflect.Type, c Converter) *OptionsBuilder {
+	if b.opts.Converters == nil {
+		b.opts.Converters = make(map[reflect.Type]Converter)
+	}
+	b.opts.Converters[t] = c
+	return b
+}
+
+// WithDynamic registers binder for the Dynamic discriminator name in DynamicBinders; DynamicFor binds into a type
+// without a hand-written binder.
+func (b *OptionsBuilder) WithDynamic(name string, binder func(map[string]any) (Dynamic, error)) *OptionsBuilder {
+	if b.opts.DynamicBinders == nil {
+		b.opts.DynamicBinders = make(map[string]func(map[string]any) (Dynamic, error))
+	}
+	b.opts.DynamicBinders[name] = binder
+	return b
+}
+
+// WithFieldDynamic registers binder for the discriminator name at the field path (or pattern) in FieldDynamicBinders.
+func (b *OptionsBuilder) WithFieldDynamic(path, name string, binder func(map[string]any) (Dynamic, error)) *OptionsBuilder {
+	if b.opts.FieldDynamicBinders == nil {
+		b.opts.FieldDynamicBinders = make(map[string]map[string]func(map[string]any) (Dynamic, error))
+	}
+	if b.opts.FieldDynamicBinders[path] == nil {
+		b.opts.FieldDynamicBinders[path] = make(map[string]func(map[string]any) (Dynamic, error))
+	}
+	b.opts.FieldDynamicBinders[path][name] = binder
+	return b
+}
+
+// WithNaming sets the NamingStrategy.
+func (b *OptionsBuilder) WithNaming(naming NamingStrategy) *OptionsBuilder {
+	b.opts.NamingStrategy = naming
+	return b
+}
+
+// WithCoercion sets the Coercion rules.
+func (b *OptionsBuilder) WithCoercion(coercion Coercion) *OptionsBuilder {
+	b.opts.Coercion = coercion
+	return b
+}
+
+// Strict rejects lossy numeric conversions; see StrictCoercion.
+func (b *OptionsBuilder) Strict() *OptionsBuilder {
+	return b.WithCoercion(StrictCoercion)
+}
+
+// WithSource sets the Source recorded for provenance.
+func (b *OptionsBuilder) WithSource(source string) *OptionsBuilder {
+	b.opts.Source = source
+	return b
+}
+
+// Build returns the assembled Options. each call returns a copy with its own maps, so the builder may be extended and
+// built again without affecting Options already built.
+func (b *OptionsBuilder) Build() *Options {
+	opts := b.opts
+	opts.Converters = maps.Clone(b.opts.Converters)
+	opts.DynamicBinders = maps.Clone(b.opts.DynamicBinders)
+	opts.InterfaceBinders = maps.Clone(b.opts.InterfaceBinders)
+	if b.opts.FieldDynamicBinders != nil {
+		opts.FieldDynamicBinders = make(map[string]map[string]func(map[string]any) (Dynamic, error), len(b.opts.FieldDynamicBinders))
+		for path, binders := range b.opts.FieldDynamicBinders {
+			opts.FieldDynamicBinders[path] = maps.Clone(binders)
+		}
+	}
+	if b.opts.InterfaceBinders != nil {
+		for t, factories := range b.opts.InterfaceBinders {
+			opts.InterfaceBinders[t] = maps.Clone(factories)
+		}
+	}
+	return &opts
+}
+
+// ConverterFor registers c for fields of type T.
+func ConverterFor[T any](c Converter) Option {
+	return func(o *Options) {
+		if o.Converters == nil {
+			o.Converters = make(map[reflect.Type]Converter)
+		}
+		o.Converters[reflect.TypeOf((*T)(nil)).Elem()] = c
+	}
+}
+
+// DynamicFor registers T as the Dynamic implementation for the discriminator name in DynamicBinders, binding each
+// value into a new T as RegisterDynamic does, but scoped to the Options being built.
+func DynamicFor[T Dynamic](name string) Option {
+	return func(o *Options) {
+		if o.DynamicBinders == nil {
+			o.DynamicBinders = make(map[string]func(map[string]any) (Dynamic, error))
+		}
+		o.DynamicBinders[name] = dynamicBinderFor[T]()
+	}
+}
+
+// InterfaceFor registers factory as the implementation of the interface type I for the discriminator name in
+// InterfaceBinders.
+func InterfaceFor[I any](name string, factory func() any) Option {
+	return func(o *Options) {
+		t := reflect.TypeOf((*I)(nil)).Elem()
+		if o.InterfaceBinders == nil {
+			o.InterfaceBinders = make(map[reflect.Type]map[string]func() any)
+		}
+		if o.InterfaceBinders[t] == nil {
+			o.InterfaceBinders[t] = make(map[string]func() any)
+		}
+		o.InterfaceBinders[t][name] = factory
+	}
+}
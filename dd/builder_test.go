@@ -0,0 +1,54 @@
+package dd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsBuilder(t *testing.T) {
+	type pipeline struct {
+		Level  upperString
+		Steps  []Dynamic
+		Sinks  []ifaceSink
+		Ratio  int
+		Legacy []Dynamic
+	}
+	builder := NewOptions().
+		With(
+			ConverterFor[upperString](upperConverter{}),
+			DynamicFor[*dynA]("a"),
+			InterfaceFor[ifaceSink]("file", func() any { return &ifaceFileSink{} }),
+		).
+		WithDynamic("b", func(m map[string]any) (Dynamic, error) { return &dynB{Count: 1}, nil }).
+		WithFieldDynamic("pipeline.Legacy", "x", func(m map[string]any) (Dynamic, error) { return &dynB{Count: 9}, nil }).
+		Strict()
+	opts := builder.Build()
+
+	assert.Contains(t, opts.Converters, reflect.TypeOf(upperString("")))
+	assert.Equal(t, StrictCoercion, opts.Coercion)
+
+	p, err := New[pipeline](map[string]any{
+		"level":  "warn",
+		"steps":  []any{map[string]any{"type": "a", "name": "x"}, map[string]any{"type": "b"}},
+		"sinks":  []any{map[string]any{"type": "file", "path": "/tmp/out"}},
+		"legacy": []any{map[string]any{"type": "x"}},
+	}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, upperString("WARN"), p.Level)
+	assert.Equal(t, []Dynamic{&dynA{Name: "x"}, &dynB{Count: 1}}, p.Steps)
+	assert.Equal(t, []ifaceSink{&ifaceFileSink{Path: "/tmp/out"}}, p.Sinks)
+	assert.Equal(t, []Dynamic{&dynB{Count: 9}}, p.Legacy)
+
+	_, err = New[pipeline](map[string]any{"ratio": 1.5}, opts)
+	assert.Error(t, err)
+
+	// built options do not change as the builder is extended
+	builder.WithDynamic("c", nil).WithNaming(CamelCase).WithSource("defaults")
+	assert.NotContains(t, opts.DynamicBinders, "c")
+	assert.Nil(t, opts.NamingStrategy)
+	rebuilt := builder.Build()
+	assert.Contains(t, rebuilt.DynamicBinders, "c")
+	assert.Equal(t, "defaults", rebuilt.Source)
+}
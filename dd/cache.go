@@ -0,0 +1,65 @@
+package dd
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldMeta holds the reflection metadata for one struct field, computed once per type.
+type fieldMeta struct {
+	index int
+	field reflect.StructField
+	tag   DdTag
+	name  string // external name under the default naming strategy
+}
+
+// externalName returns the field's external name under naming (the default strategy when nil).
+func (f *fieldMeta) externalName(naming NamingStrategy) string {
+	if naming == nil || f.tag.Name != "" {
+		return f.name
+	}
+	return naming(f.field.Name)
+}
+
+var fieldMetaCache sync.Map // reflect.Type → []fieldMeta
+
+// cachedFields returns the metadata for every field of struct type t (including unexported and embedded fields, which
+// callers filter as before), parsing struct tags only on the first call for each type.
+func cachedFields(t reflect.Type) []fieldMeta {
+	if cached, ok := fieldMetaCache.Load(t); ok {
+		return cached.([]fieldMeta)
+	}
+	fields := make([]fieldMeta, t.NumField())
+	for i := range fields {
+		field := t.Field(i)
+		tag := parseDdTag(field)
+		fields[i] = fieldMeta{index: i, field: field, tag: tag, name: externalName(field, tag, nil)}
+	}
+	cached, _ := fieldMetaCache.LoadOrStore(t, fields)
+	return cached.([]fieldMeta)
+}
+
+// WarmCache pre-populates dd's per-type reflection metadata for the given values (structs or pointers to structs) and
+// every struct type reachable from their fields, so the first Bind or Unbind of each type on a hot path does not pay
+// for struct tag parsing. it is optional; metadata is otherwise cached on first use.
+func WarmCache(types ...any) {
+	visited := make(map[reflect.Type]bool)
+	for _, v := range types {
+		if v != nil {
+			warmType(reflect.TypeOf(v), visited)
+		}
+	}
+}
+
+func warmType(t reflect.Type, visited map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] || isScalarStruct(t) {
+		return
+	}
+	visited[t] = true
+	for _, f := range cachedFields(t) {
+		warmType(f.field.Type, visited)
+	}
+}
@@ -0,0 +1,48 @@
+package dd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cacheLeaf struct {
+	Value string `dd:"val,+required"`
+}
+
+type cacheRoot struct {
+	Name    string
+	Leaves  []cacheLeaf
+	ByName  map[string]*cacheLeaf
+	private int
+}
+
+func TestWarmCache(t *testing.T) {
+	WarmCache(&cacheRoot{}, nil, 42)
+
+	for _, typ := range []reflect.Type{reflect.TypeOf(cacheRoot{}), reflect.TypeOf(cacheLeaf{})} {
+		_, ok := fieldMetaCache.Load(typ)
+		assert.True(t, ok, typ.String())
+	}
+
+	fields := cachedFields(reflect.TypeOf(cacheRoot{}))
+	assert.Len(t, fields, 4)
+	assert.Equal(t, "by_name", fields[2].name)
+	assert.Equal(t, "byName", fields[2].externalName(CamelCase))
+
+	leaf := cachedFields(reflect.TypeOf(cacheLeaf{}))
+	assert.True(t, leaf[0].tag.Required)
+	assert.Equal(t, "val", leaf[0].externalName(CamelCase))
+}
+
+func TestCachedBindUnbind(t *testing.T) {
+	data := map[string]any{"name": "root", "leaves": []any{map[string]any{"val": "a"}}, "by_name": map[string]any{}}
+	for i := 0; i < 2; i++ {
+		root, err := New[cacheRoot](data)
+		assert.NoError(t, err)
+		out, err := Unbind(root)
+		assert.NoError(t, err)
+		assert.Equal(t, data, out)
+	}
+}
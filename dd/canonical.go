@@ -0,0 +1,103 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// UnbindCanonical returns a deterministic, normalized encoding of source, suitable for hashing, signing, or comparing
+// configurations across environments. the encoding is compact JSON with object keys sorted, `+secret` fields omitted,
+// `+raw` subtrees re-encoded like any other value, and numbers normalized so that equal values encode identically
+// whatever their Go type: integral numbers as integers (30, not 30.0), and others in their shortest form.
+//
+// opts are optional; pass nil or omit to use defaults. Options.SecretPolicy is ignored.
+func UnbindCanonical(source interface{}, opts ...*Options) ([]byte, error) {
+	o, err := getOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	opt := Options{}
+	if o != nil {
+		opt = *o
+	}
+	opt.SecretPolicy = OmitSecrets
+
+	data, err := Unbind(source, &opt)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalJSON(source, data)
+}
+
+// canonicalJSON encodes data unbound from source in canonical form.
+func canonicalJSON(source interface{}, data map[string]any) ([]byte, error) {
+	if err := resolveSourceRawNodes(source, data, "canonical"); err != nil {
+		return nil, &ConversionError{Type: "JSON", Message: "failed to convert raw node", Cause: err}
+	}
+	normalized, err := canonicalValue(data)
+	if err != nil {
+		return nil, err
+	}
+	// encoding/json writes map keys in sorted order, which makes the encoding canonical
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(normalized); err != nil {
+		return nil, &ConversionError{Type: "JSON", Message: "failed to marshal", Cause: err}
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// canonicalValue replaces the numbers in an unbound value with their normalized json.Number form.
+func canonicalValue(v any) (any, error) {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, item := range t {
+			normalized, err := canonicalValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = normalized
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			normalized, err := canonicalValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = normalized
+		}
+		return out, nil
+	case nil:
+		return nil, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(rv.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Number(strconv.FormatUint(rv.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, &ConversionError{Value: fmt.Sprint(f), Type: "JSON", Message: "non-finite number has no canonical form"}
+		}
+		if f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+			return json.Number(strconv.FormatInt(int64(f), 10)), nil
+		}
+		bits := 64
+		if rv.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, bits)), nil
+	}
+	return v, nil
+}
@@ -0,0 +1,49 @@
+package dd
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnbindCanonical(t *testing.T) {
+	type limits struct {
+		Ratio float32
+		Max   float64
+		Min   uint8
+	}
+	type config struct {
+		Name     string
+		Password string `dd:",+secret"`
+		Limits   limits
+		Note     string
+		Raw      json.RawMessage `dd:",+raw"`
+	}
+	cfg := &config{
+		Name:     "a<b>",
+		Password: "hunter2",
+		Limits:   limits{Ratio: 0.1, Max: 30, Min: 2},
+		Raw:      json.RawMessage(`{ "z": 1.0, "a": [2.50] }`),
+	}
+
+	data, err := UnbindCanonical(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"limits":{"max":30,"min":2,"ratio":0.1},"name":"a<b>","note":"","raw":{"a":[2.5],"z":1}}`, string(data))
+
+	// secrets are omitted regardless of policy
+	again, err := UnbindCanonical(cfg, &Options{SecretPolicy: PlainSecrets})
+	assert.NoError(t, err)
+	assert.Equal(t, data, again)
+}
+
+func TestUnbindCanonicalNonFinite(t *testing.T) {
+	type config struct {
+		Value float64
+	}
+	_, err := UnbindCanonical(&config{Value: math.Inf(1)})
+	assert.Error(t, err)
+	var ce *ConversionError
+	assert.ErrorAs(t, err, &ce)
+}
@@ -0,0 +1,286 @@
+package dd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborEncMode encodes deterministically (RFC 8949 core deterministic encoding), so equal structs produce equal bytes.
+var cborEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+// decodeCBOR parses a CBOR document into a map. map keys that are not text strings (such as the integer keys common
+// in compact IoT payloads) are converted to their string form, so they bind to typed maps like map[int]V and to
+// fields named by their key (e.g. `dd:"1"`). byte strings decode as []byte.
+func decodeCBOR(data []byte) (map[string]any, error) {
+	var v any
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return nil, &ConversionError{Type: "CBOR", Message: "failed to parse", Cause: err}
+	}
+	m, ok := normalizeCBOR(v).(map[string]any)
+	if !ok {
+		return nil, &ConversionError{Type: "CBOR", Message: fmt.Sprintf("expected map at top level, got %T", v)}
+	}
+	return m, nil
+}
+
+// normalizeCBOR converts decoded CBOR maps to map[string]any, recursively.
+func normalizeCBOR(v any) any {
+	switch t := v.(type) {
+	case map[any]any:
+		out := make(map[string]any, len(t))
+		for k, item := range t {
+			var key string
+			switch k := k.(type) {
+			case string:
+				key = k
+			case []byte:
+				key = string(k)
+			default:
+				key = fmt.Sprint(k)
+			}
+			out[key] = normalizeCBOR(item)
+		}
+		return out
+	case []any:
+		for i, item := range t {
+			t[i] = normalizeCBOR(item)
+		}
+	}
+	return v
+}
+
+// cborFields rewrites the unbound fields of struct type t in place so that they encode natively in CBOR: []byte
+// fields become byte strings, and maps with integer keys keep integer keys.
+func cborFields(t reflect.Type, data map[string]any, naming NamingStrategy) {
+	for _, meta := range cachedFields(t) {
+		field := meta.field
+		if field.PkgPath != "" {
+			continue
+		}
+		if isInlined(field, meta.tag) {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				cborFields(ft, data, naming)
+			}
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra || tag.Raw {
+			continue
+		}
+		name := meta.externalName(naming)
+		if v, ok := data[name]; ok && v != nil {
+			data[name] = cborValue(field.Type, v, naming)
+		}
+	}
+}
+
+// cborValue converts the unbound value v of Go type t for CBOR encoding.
+func cborValue(t reflect.Type, v any, naming NamingStrategy) any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isOptionalType(t) {
+		return cborValue(t.Field(0).Type, v, naming)
+	}
+	switch {
+	case isProvenanceStruct(t):
+		if m, ok := v.(map[string]any); ok {
+			cborFields(t, m, naming)
+		}
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		items, ok := v.([]any)
+		if !ok {
+			return v
+		}
+		blob := make([]byte, len(items))
+		for i, item := range items {
+			b, ok := item.(uint8)
+			if !ok {
+				return v
+			}
+			blob[i] = b
+		}
+		return blob
+
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if items, ok := v.([]any); ok {
+			for i, item := range items {
+				if item != nil {
+					items[i] = cborValue(t.Elem(), item, naming)
+				}
+			}
+		}
+
+	case t.Kind() == reflect.Map:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		switch t.Key().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			out := make(map[any]any, len(m))
+			for k, item := range m {
+				var key any = k
+				if n, err := strconv.ParseInt(k, 10, 64); err == nil {
+					key = n
+				} else if n, err := strconv.ParseUint(k, 10, 64); err == nil {
+					key = n
+				}
+				if item != nil {
+					item = cborValue(t.Elem(), item, naming)
+				}
+				out[key] = item
+			}
+			return out
+		}
+		for k, item := range m {
+			if item != nil {
+				m[k] = cborValue(t.Elem(), item, naming)
+			}
+		}
+	}
+	return v
+}
+
+// --- Bytes Layer (base) ---
+
+// BindCBOR parses CBOR data and binds it to the target struct.
+func BindCBOR(target interface{}, data []byte, opts ...*Options) error {
+	m, err := decodeCBOR(data)
+	if err != nil {
+		return err
+	}
+	return Bind(target, m, opts...)
+}
+
+// NewCBOR parses CBOR data and returns a new instance of type T.
+func NewCBOR[T any](data []byte, opts ...*Options) (*T, error) {
+	m, err := decodeCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	return New[T](m, opts...)
+}
+
+// MergeCBOR parses CBOR data and merges it with the target struct.
+func MergeCBOR(target interface{}, data []byte, opts ...*Options) error {
+	m, err := decodeCBOR(data)
+	if err != nil {
+		return err
+	}
+	return Merge(target, m, opts...)
+}
+
+// UnbindCBOR converts a struct to CBOR bytes. []byte fields are encoded as byte strings and maps with integer key
+// types keep integer keys.
+func UnbindCBOR(source interface{}, opts ...*Options) ([]byte, error) {
+	m, err := Unbind(source, opts...)
+	if err != nil {
+		return nil, &ConversionError{Message: "failed to unbind source", Cause: err}
+	}
+	if err := resolveSourceRawNodes(source, m, "cbor"); err != nil {
+		return nil, &ConversionError{Type: "CBOR", Message: "failed to convert raw node", Cause: err}
+	}
+	opt, _ := getOptions(opts...)
+	t := reflect.TypeOf(source)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	cborFields(t, m, opt.naming())
+	data, err := cborEncMode.Marshal(m)
+	if err != nil {
+		return nil, &ConversionError{Type: "CBOR", Message: "failed to marshal", Cause: err}
+	}
+	return data, nil
+}
+
+// --- Reader/Writer Layer ---
+
+// BindCBORReader reads CBOR from an io.Reader and binds it to the target struct.
+func BindCBORReader(target interface{}, r io.Reader, opts ...*Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return BindCBOR(target, data, opts...)
+}
+
+// NewCBORReader reads CBOR from an io.Reader and returns a new instance of type T.
+func NewCBORReader[T any](r io.Reader, opts ...*Options) (*T, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return NewCBOR[T](data, opts...)
+}
+
+// MergeCBORReader reads CBOR from an io.Reader and merges it with the target struct.
+func MergeCBORReader(target interface{}, r io.Reader, opts ...*Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return MergeCBOR(target, data, opts...)
+}
+
+// UnbindCBORWriter converts a struct to CBOR and writes it to an io.Writer.
+func UnbindCBORWriter(source interface{}, w io.Writer, opts ...*Options) error {
+	data, err := UnbindCBOR(source, opts...)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return &ConversionError{Message: "failed to write to writer", Cause: err}
+	}
+	return nil
+}
+
+// --- File Layer ---
+
+// BindCBORFile reads CBOR from the specified file path and binds it to the target struct.
+func BindCBORFile(target interface{}, path string, opts ...*Options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &FileError{Path: path, Operation: "read CBOR", Cause: err}
+	}
+	return BindCBOR(target, data, opts...)
+}
+
+// NewCBORFile reads CBOR from the specified file path and returns a new instance of type T.
+func NewCBORFile[T any](path string, opts ...*Options) (*T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &FileError{Path: path, Operation: "read CBOR", Cause: err}
+	}
+	return NewCBOR[T](data, opts...)
+}
+
+// MergeCBORFile reads CBOR from the specified file path and merges it with the target struct.
+func MergeCBORFile(target interface{}, path string, opts ...*Options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &FileError{Path: path, Operation: "read CBOR", Cause: err}
+	}
+	return MergeCBOR(target, data, opts...)
+}
+
+// UnbindCBORFile converts a struct to CBOR and writes it to the specified file path, replacing any
+// existing file atomically.
+func UnbindCBORFile(source interface{}, path string, opts ...*Options) error {
+	data, err := UnbindCBOR(source, opts...)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data, "write CBOR", opts...)
+}
@@ -0,0 +1,86 @@
+package dd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type cborReading struct {
+	Sensor   string         `dd:"1"`
+	Value    float64        `dd:"2"`
+	Payload  []byte         `dd:"3"`
+	Channels map[int]string `dd:"4"`
+	History  []cborSample
+}
+
+type cborSample struct {
+	At    int64
+	Bytes []byte
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	in := &cborReading{
+		Sensor:   "temp",
+		Value:    21.5,
+		Payload:  []byte{0xde, 0xad, 0xbe, 0xef},
+		Channels: map[int]string{1: "a", 20: "b"},
+		History:  []cborSample{{At: -5, Bytes: []byte{1}}},
+	}
+	data, err := UnbindCBOR(in)
+	assert.NoError(t, err)
+
+	// blobs encode as byte strings and integer-keyed maps keep integer keys
+	var raw map[any]any
+	assert.NoError(t, cbor.Unmarshal(data, &raw))
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, raw["3"])
+	assert.Equal(t, map[any]any{uint64(1): "a", uint64(20): "b"}, raw["4"])
+
+	out, err := NewCBOR[cborReading](data)
+	assert.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	again, err := UnbindCBOR(out)
+	assert.NoError(t, err)
+	assert.Equal(t, data, again)
+}
+
+func TestBindCBORIntegerKeys(t *testing.T) {
+	data, err := cbor.Marshal(map[any]any{1: "humidity", 2: 40.0, 4: map[int]string{7: "x"}})
+	assert.NoError(t, err)
+
+	var out cborReading
+	assert.NoError(t, BindCBOR(&out, data))
+	assert.Equal(t, "humidity", out.Sensor)
+	assert.Equal(t, 40.0, out.Value)
+	assert.Equal(t, map[int]string{7: "x"}, out.Channels)
+}
+
+func TestCBORReaderWriterFile(t *testing.T) {
+	in := &cborReading{Sensor: "io"}
+
+	var buf bytes.Buffer
+	assert.NoError(t, UnbindCBORWriter(in, &buf))
+	out, err := NewCBORReader[cborReading](&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "io", out.Sensor)
+
+	path := filepath.Join(t.TempDir(), "reading.cbor")
+	assert.NoError(t, UnbindCBORFile(in, path))
+	var fromFile cborReading
+	assert.NoError(t, MergeCBORFile(&fromFile, path))
+	assert.Equal(t, "io", fromFile.Sensor)
+}
+
+func TestBindCBORInvalid(t *testing.T) {
+	var out cborReading
+	var convErr *ConversionError
+
+	assert.ErrorAs(t, BindCBOR(&out, []byte{0xff}), &convErr)
+
+	list, _ := cbor.Marshal([]int{1, 2})
+	assert.ErrorAs(t, BindCBOR(&out, list), &convErr)
+}
@@ -0,0 +1,176 @@
+package dd
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Change describes a field whose value was modified by MergeWithChanges.
+type Change struct {
+	Path string // dotted path of external field names, e.g. "server.port"; map keys are addressed like fields
+	Old  any    // unbound value before the merge, nil if absent
+	New  any    // unbound value after the merge, nil if removed
+}
+
+// MergeWithChanges merges data into target exactly like Merge, and reports the fields it modified, sorted by path.
+// values are reported in their unbound form (as produced by Unbind), so a time.Duration appears as "30s". nested
+// structs and maps are compared key by key; lists and other values are compared as a whole.
+//
+// secrets are compared in the clear, but never reported in the clear: a changed secret is reported with the
+// placeholder as its Old and New values, or left out under OmitSecrets.
+//
+// the report is computed by unbinding target before and after the merge, so target must be unbindable.
+func MergeWithChanges(target interface{}, data map[string]any, opts ...*Options) ([]Change, error) {
+	if _, err := validateTarget(target); err != nil {
+		return nil, err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	plain, shown := &Options{}, &Options{}
+	if opt != nil {
+		*plain, *shown = *opt, *opt
+	}
+	// compare secrets in the clear; sealing or masking them would hide changes, or make every secret appear changed
+	plain.SecretPolicy = PlainSecrets
+	plain.SecretKeys = nil
+	if shown.SecretPolicy == PlainSecrets {
+		shown.SecretPolicy = MaskSecrets
+	}
+	shown.SecretKeys = nil
+
+	before, beforeShown, err := unbindChanges(target, plain, shown)
+	if err != nil {
+		return nil, err
+	}
+	if err := Merge(target, data, opts...); err != nil {
+		return nil, err
+	}
+	after, afterShown, err := unbindChanges(target, plain, shown)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	diffUnbound(before, after, beforeShown, afterShown, "", &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// unbindChanges unbinds target twice: with secrets in the clear, for comparison, and as they are to be reported.
+func unbindChanges(target interface{}, plain, shown *Options) (map[string]any, map[string]any, error) {
+	compared, err := Unbind(target, plain)
+	if err != nil {
+		return nil, nil, err
+	}
+	reported, err := Unbind(target, shown)
+	if err != nil {
+		return nil, nil, err
+	}
+	return compared, reported, nil
+}
+
+// diffUnbound records the differences between two unbound maps, descending into nested maps. the values reported are
+// taken from beforeShown and afterShown; differences absent from both (omitted secrets) are not reported.
+func diffUnbound(before, after, beforeShown, afterShown map[string]any, prefix string, changes *[]Change) {
+	report := func(key string) {
+		oldValue, hadOld := beforeShown[key]
+		newValue, hasNew := afterShown[key]
+		if hadOld || hasNew {
+			*changes = append(*changes, Change{Path: joinProvenancePath(prefix, key), Old: oldValue, New: newValue})
+		}
+	}
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if !ok {
+			report(key)
+			continue
+		}
+		oldMap, oldIsMap := oldValue.(map[string]any)
+		newMap, newIsMap := newValue.(map[string]any)
+		if oldIsMap && newIsMap {
+			oldShown, _ := beforeShown[key].(map[string]any)
+			newShown, _ := afterShown[key].(map[string]any)
+			diffUnbound(oldMap, newMap, oldShown, newShown, joinProvenancePath(prefix, key), changes)
+			continue
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			report(key)
+		}
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			report(key)
+		}
+	}
+}
+
+// Diff compares the unbound forms of a and b and returns only the keys whose values differ, in the same nested shape
+// Unbind produces. each differing key carries its value from b; keys present in a but absent from b map to nil. as in
+// MergeWithChanges, nested structs and maps are compared key by key, and lists as a whole.
+//
+// secrets are always compared in the clear, but reported according to Options.SecretPolicy: under MaskSecrets a
+// changed secret appears as the placeholder, and under OmitSecrets it is left out of the result.
+//
+// opts are optional; pass nil or omit to use defaults.
+func Diff(a, b any, opts ...*Options) (map[string]any, error) {
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	plain := &Options{}
+	if opt != nil {
+		*plain = *opt
+	}
+	plain.SecretPolicy = PlainSecrets
+	plain.SecretKeys = nil
+
+	before, err := Unbind(a, plain)
+	if err != nil {
+		return nil, err
+	}
+	after, err := Unbind(b, plain)
+	if err != nil {
+		return nil, err
+	}
+	shown, err := Unbind(b, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return diffMaps(before, after, shown), nil
+}
+
+// diffMaps returns the keys of after (reported from shown) whose values differ from before, descending into nested
+// maps; keys removed in after map to nil.
+func diffMaps(before, after, shown map[string]any) map[string]any {
+	out := make(map[string]any)
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if !ok {
+			out[key] = nil
+			continue
+		}
+		oldMap, oldIsMap := oldValue.(map[string]any)
+		newMap, newIsMap := newValue.(map[string]any)
+		if oldIsMap && newIsMap {
+			shownMap, _ := shown[key].(map[string]any)
+			if nested := diffMaps(oldMap, newMap, shownMap); len(nested) > 0 {
+				out[key] = nested
+			}
+			continue
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			if value, ok := shown[key]; ok {
+				out[key] = value
+			}
+		}
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			if value, ok := shown[key]; ok {
+				out[key] = value
+			}
+		}
+	}
+	return out
+}
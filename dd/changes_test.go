@@ -0,0 +1,141 @@
+package dd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type changesServer struct {
+	Host    string
+	Port    int
+	Timeout time.Duration
+}
+
+type changesConfig struct {
+	Name   string
+	Server changesServer
+	Tags   []string
+	Labels map[string]string
+	Limit  *int
+}
+
+func TestMergeWithChanges(t *testing.T) {
+	cfg := &changesConfig{
+		Name:   "app",
+		Server: changesServer{Host: "localhost", Port: 8080, Timeout: time.Second},
+		Tags:   []string{"a"},
+		Labels: map[string]string{"tier": "web", "zone": "east"},
+	}
+
+	changes, err := MergeWithChanges(cfg, map[string]any{
+		"name":   "app",
+		"server": map[string]any{"port": 9090, "timeout": "5s"},
+		"tags":   []any{"a", "b"},
+		"labels": map[string]any{"tier": "api"},
+		"limit":  10,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Change{
+		{Path: "labels.tier", Old: "web", New: "api"},
+		{Path: "labels.zone", Old: "east"},
+		{Path: "limit", New: 10},
+		{Path: "server.port", Old: 8080, New: 9090},
+		{Path: "server.timeout", Old: "1s", New: "5s"},
+		{Path: "tags", Old: []any{"a"}, New: []any{"a", "b"}},
+	}, changes)
+	assert.Equal(t, 9090, cfg.Server.Port)
+}
+
+func TestMergeWithChangesNoop(t *testing.T) {
+	cfg := &changesConfig{Name: "app"}
+	changes, err := MergeWithChanges(cfg, map[string]any{"name": "app"})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestMergeWithChangesError(t *testing.T) {
+	cfg := &changesConfig{Name: "app"}
+	changes, err := MergeWithChanges(cfg, map[string]any{"server": "not an object"})
+	assert.Error(t, err)
+	assert.Nil(t, changes)
+
+	_, err = MergeWithChanges(nil, map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	limit := 5
+	a := &changesConfig{
+		Name:   "app",
+		Server: changesServer{Host: "localhost", Port: 8080, Timeout: time.Second},
+		Tags:   []string{"a"},
+		Labels: map[string]string{"tier": "web", "zone": "east"},
+		Limit:  &limit,
+	}
+	b := &changesConfig{
+		Name:   "app",
+		Server: changesServer{Host: "localhost", Port: 9090, Timeout: 5 * time.Second},
+		Tags:   []string{"a", "b"},
+		Labels: map[string]string{"tier": "web", "region": "us"},
+	}
+
+	diff, err := Diff(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"server": map[string]any{"port": 9090, "timeout": "5s"},
+		"tags":   []any{"a", "b"},
+		"labels": map[string]any{"zone": nil, "region": "us"},
+		"limit":  nil,
+	}, diff)
+
+	diff, err = Diff(a, a)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestMergeWithChangesSecrets(t *testing.T) {
+	type creds struct {
+		User     string
+		Password string `dd:",+secret"`
+	}
+	cfg := &creds{User: "admin", Password: "one"}
+
+	// secrets are masked by default
+	changes, err := MergeWithChanges(cfg, map[string]any{"password": "two"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Path: "password", Old: DefaultSecretPlaceholder, New: DefaultSecretPlaceholder}}, changes)
+
+	// masking does not hide the change
+	changes, err = MergeWithChanges(cfg, map[string]any{"password": "three"}, &Options{SecretPolicy: MaskSecrets})
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+
+	changes, err = MergeWithChanges(cfg, map[string]any{"password": "four", "user": "root"}, &Options{SecretPolicy: OmitSecrets})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Path: "user", Old: "admin", New: "root"}}, changes)
+	assert.Equal(t, "four", cfg.Password)
+}
+
+func TestDiffSecrets(t *testing.T) {
+	type creds struct {
+		User     string
+		Password string `dd:",+secret"`
+	}
+	a := &creds{User: "admin", Password: "one"}
+	b := &creds{User: "admin", Password: "two"}
+
+	diff, err := Diff(a, b, &Options{SecretPolicy: MaskSecrets})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"password": DefaultSecretPlaceholder}, diff)
+
+	diff, err = Diff(a, b, &Options{SecretPolicy: OmitSecrets})
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+
+	diff, err = Diff(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"password": "two"}, diff)
+}
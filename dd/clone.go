@@ -0,0 +1,164 @@
+package dd
+
+import (
+	"math/big"
+	"reflect"
+)
+
+// Clone returns a deep copy of src. exported fields are copied recursively: pointers, slices, maps, and interface
+// values (including Dynamic fields) are duplicated rather than shared, and pointers that alias one another (or form
+// cycles) in src alias the corresponding copies in the result. unexported fields are copied as in an assignment, so
+// internal state survives, but anything they point to remains shared.
+//
+// fields whose type has a converter in Options.Converters are copied by converting to the raw value and back.
+// Pointer[T] fields keep their Ref but are not resolved; call Link on the copy to resolve them against the new
+// objects.
+//
+// opts are optional; pass nil or omit to use defaults.
+func Clone[T any](src *T, opts ...*Options) (*T, error) {
+	if src == nil {
+		return nil, &ValidationError{Message: "nil source provided"}
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	dst := new(T)
+	c := &cloner{opt: opt, seen: map[clonePtr]reflect.Value{{reflect.TypeOf(src), reflect.ValueOf(src).Pointer()}: reflect.ValueOf(dst)}}
+	if err := c.clone(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(), reflect.TypeOf(dst).Elem().Name()); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+type cloner struct {
+	opt  *Options
+	seen map[clonePtr]reflect.Value // source pointer → cloned pointer
+}
+
+type clonePtr struct {
+	t    reflect.Type
+	addr uintptr
+}
+
+// clone deep copies src into the settable value dst, of the same type.
+func (c *cloner) clone(dst, src reflect.Value, path string) error {
+	t := src.Type()
+	if c.opt != nil {
+		if converter, ok := c.opt.Converters[t]; ok {
+			raw, err := converter.ToRaw(src.Interface())
+			if err != nil {
+				return &ConversionError{Path: path, Message: "custom converter failed", Cause: err}
+			}
+			value, err := converter.FromRaw(raw)
+			if err != nil {
+				return &ConversionError{Path: path, Message: "custom converter failed", Cause: err}
+			}
+			dst.Set(reflect.ValueOf(value))
+			return nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(t))
+			return nil
+		}
+		key := clonePtr{t, src.Pointer()}
+		if cloned, ok := c.seen[key]; ok {
+			dst.Set(cloned)
+			return nil
+		}
+		cloned := reflect.New(t.Elem())
+		c.seen[key] = cloned
+		dst.Set(cloned)
+		return c.clone(cloned.Elem(), src.Elem(), path)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(t))
+			return nil
+		}
+		concrete := reflect.New(src.Elem().Type()).Elem()
+		if err := c.clone(concrete, src.Elem(), path); err != nil {
+			return err
+		}
+		dst.Set(concrete)
+		return nil
+
+	case reflect.Struct:
+		dst.Set(src)
+		switch {
+		case t == bigIntType:
+			v := src.Interface().(big.Int)
+			dst.Set(reflect.ValueOf(*new(big.Int).Set(&v)))
+			return nil
+		case t == bigFloatType:
+			v := src.Interface().(big.Float)
+			dst.Set(reflect.ValueOf(*new(big.Float).Copy(&v)))
+			return nil
+		case t == bigRatType:
+			v := src.Interface().(big.Rat)
+			dst.Set(reflect.ValueOf(*new(big.Rat).Set(&v)))
+			return nil
+		case isPointerType(t):
+			// keep the reference, but leave it unresolved
+			dst.Set(reflect.Zero(t))
+			dst.FieldByName("Ref").Set(src.FieldByName("Ref"))
+			return nil
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if err := c.clone(dst.Field(i), src.Field(i), path+"."+field.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(t))
+			return nil
+		}
+		out := reflect.MakeSlice(t, src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := c.clone(out.Index(i), src.Index(i), path); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+		return nil
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			if err := c.clone(dst.Index(i), src.Index(i), path); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(t))
+			return nil
+		}
+		out := reflect.MakeMapWithSize(t, src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			value := reflect.New(t.Elem()).Elem()
+			if err := c.clone(value, iter.Value(), path); err != nil {
+				return err
+			}
+			out.SetMapIndex(iter.Key(), value)
+		}
+		dst.Set(out)
+		return nil
+	}
+
+	dst.Set(src)
+	return nil
+}
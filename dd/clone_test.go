@@ -0,0 +1,104 @@
+package dd
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cloneShape struct {
+	Sides int
+}
+
+func (s *cloneShape) Type() string                   { return "shape" }
+func (s *cloneShape) ToMap() (map[string]any, error) { return map[string]any{"sides": s.Sides}, nil }
+
+type cloneItem struct {
+	Name string
+	Tags []string
+}
+
+type cloneRoot struct {
+	Name    string
+	Items   []*cloneItem
+	ByName  map[string]*cloneItem
+	Shape   Dynamic
+	Owner   *Pointer[*User]
+	Amount  big.Int
+	Code    testDecimal
+	private string
+}
+
+func TestClone(t *testing.T) {
+	item := &cloneItem{Name: "a", Tags: []string{"x"}}
+	src := &cloneRoot{
+		Name:    "root",
+		Items:   []*cloneItem{item},
+		ByName:  map[string]*cloneItem{"a": item},
+		Shape:   &cloneShape{Sides: 3},
+		Owner:   &Pointer[*User]{Ref: "u1", Resolved: &User{Id: "u1"}},
+		Code:    testDecimal{units: 125, scale: 2},
+		private: "internal",
+	}
+	src.Amount.SetInt64(42)
+
+	dst, err := Clone(src)
+	assert.NoError(t, err)
+	assert.Equal(t, "root", dst.Name)
+	assert.Equal(t, "internal", dst.private)
+	assert.Equal(t, int64(42), dst.Amount.Int64())
+	assert.Equal(t, src.Code, dst.Code)
+
+	// nothing reachable through exported fields is shared
+	assert.NotSame(t, src.Items[0], dst.Items[0])
+	dst.Items[0].Tags[0] = "y"
+	assert.Equal(t, "x", src.Items[0].Tags[0])
+	dst.Shape.(*cloneShape).Sides = 4
+	assert.Equal(t, 3, src.Shape.(*cloneShape).Sides)
+	dst.Amount.SetInt64(7)
+	assert.Equal(t, int64(42), src.Amount.Int64())
+
+	// aliasing within the source is preserved in the copy
+	assert.Same(t, dst.Items[0], dst.ByName["a"])
+
+	// pointer refs are copied but not resolved
+	assert.Equal(t, "u1", dst.Owner.Ref)
+	assert.Nil(t, dst.Owner.Resolved)
+}
+
+func TestCloneCycle(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	a := &node{Name: "a"}
+	a.Next = &node{Name: "b", Next: a}
+
+	c, err := Clone(a)
+	assert.NoError(t, err)
+	assert.NotSame(t, a, c)
+	assert.Equal(t, "b", c.Next.Name)
+	assert.Same(t, c, c.Next.Next)
+}
+
+func TestCloneConverter(t *testing.T) {
+	type account struct {
+		Balance testDecimal
+	}
+	opts := &Options{Converters: map[reflect.Type]Converter{
+		reflect.TypeOf(testDecimal{}): DecimalConverter(parseTestDecimal, testDecimal.String),
+	}}
+
+	c, err := Clone(&account{Balance: testDecimal{units: 1025, scale: 2}}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.25", c.Balance.String())
+}
+
+func TestCloneNil(t *testing.T) {
+	_, err := Clone[cloneRoot](nil)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "nil source"))
+}
@@ -0,0 +1,273 @@
+// ddgen generates reflection-free bind and unbind functions for dd-tagged structs.
+//
+// usage:
+//
+//	ddgen [-type Config,Server] [-output dd_generated.go] [dir]
+//
+// typically run through go generate:
+//
+//	//go:generate go run github.com/michaelquigley/df/dd/cmd/ddgen -type Config
+//
+// for each struct type, ddgen emits BindX and UnbindX functions and registers them with dd.RegisterGenerated, so
+// dd.Bind, dd.New, and dd.Unbind dispatch to them when called without Options. string, bool, int, int64, and float64
+// fields are handled directly; other fields are delegated to dd.BindValue and dd.UnbindValue. types using embedded
+// fields, or tag flags that change how a field binds or unbinds beyond its name, +required, and +omitempty (+extra,
+// +raw, +inline, +plainref, +format, constraints such as +min and +oneof, and so on), are skipped with a warning, and
+// keep using reflection.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/michaelquigley/df/dd"
+)
+
+func main() {
+	types := flag.String("type", "", "comma-separated list of struct types (default: all struct types in the package)")
+	output := flag.String("output", "dd_generated.go", "output file name, relative to the package directory")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	var names []string
+	if *types != "" {
+		names = strings.Split(*types, ",")
+	}
+
+	src, err := generate(dir, names, *output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ddgen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "ddgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// field describes one bindable struct field.
+type field struct {
+	goName    string
+	key       string
+	typ       string // builtin type name, or "" when the field is delegated to dd
+	required  bool
+	omitEmpty bool
+}
+
+// structInfo describes one struct type to generate code for.
+type structInfo struct {
+	name   string
+	fields []field
+}
+
+// generate parses the package in dir and returns the formatted source for the requested types.
+func generate(dir string, names []string, output string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected exactly one package in %s, found %d", dir, len(pkgs))
+	}
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+
+	structs := make(map[string]*ast.StructType)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok && ts.TypeParams == nil {
+					structs[ts.Name.Name] = st
+				}
+			}
+		}
+	}
+	if len(names) == 0 {
+		for name := range structs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	var infos []structInfo
+	for _, name := range names {
+		st, ok := structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %q not found in %s", name, dir)
+		}
+		info, err := inspectStruct(name, st)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ddgen: skipping %s: %v\n", name, err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no struct types to generate")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by ddgen; DO NOT EDIT.\n\npackage %s\n\nimport \"github.com/michaelquigley/df/dd\"\n\n", pkg.Name)
+	buf.WriteString("func init() {\n")
+	for _, info := range infos {
+		fmt.Fprintf(&buf, "\tdd.RegisterGenerated(%s, %s)\n", funcName("Bind", info.name), funcName("Unbind", info.name))
+	}
+	buf.WriteString("}\n")
+	for _, info := range infos {
+		writeBind(&buf, info)
+		writeUnbind(&buf, info)
+	}
+	return format.Source(buf.Bytes())
+}
+
+// inspectStruct collects the bindable fields of a struct, or reports why it cannot be generated.
+func inspectStruct(name string, st *ast.StructType) (structInfo, error) {
+	info := structInfo{name: name}
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return info, fmt.Errorf("embedded fields are not supported")
+		}
+		var rawTag string
+		if f.Tag != nil {
+			unquoted, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return info, err
+			}
+			rawTag = unquoted
+		}
+		typ := ""
+		if ident, ok := f.Type.(*ast.Ident); ok && fastTypes[ident.Name] {
+			typ = ident.Name
+		}
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			tag := dd.ParseTag(reflect.StructField{Name: n.Name, Tag: reflect.StructTag(rawTag)})
+			if tag.Skip {
+				continue
+			}
+			if !generatedTag(tag) {
+				return info, fmt.Errorf("field %s uses a tag that requires reflection", n.Name)
+			}
+			if tag.OmitEmpty && typ == "" {
+				return info, fmt.Errorf("field %s: +omitempty is only supported on builtin scalar fields", n.Name)
+			}
+			key := tag.Name
+			if key == "" {
+				key = dd.SnakeCase(n.Name)
+			}
+			info.fields = append(info.fields, field{goName: n.Name, key: key, typ: typ, required: tag.Required, omitEmpty: tag.OmitEmpty})
+		}
+	}
+	return info, nil
+}
+
+// generatedTag reports whether the generated code implements every flag set in tag. flags are accepted explicitly,
+// so that flags added to dd later keep their types on reflection until ddgen handles them.
+func generatedTag(tag dd.DdTag) bool {
+	tag.Name, tag.Required, tag.OmitEmpty = "", false, false
+	// flags only consulted by Merge, StrategicMerge, RenderTemplates, the Linker, and documentation, which reflect
+	// regardless of generated code
+	tag.Secret, tag.Doc, tag.Template, tag.MergeKey, tag.Merge, tag.Weak, tag.Id = false, "", false, "", "", false, false
+	return reflect.DeepEqual(tag, dd.DdTag{})
+}
+
+// fastTypes are the builtin types whose values are stored directly in unbound maps.
+var fastTypes = map[string]bool{
+	"string": true, "bool": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+func funcName(prefix, typeName string) string {
+	r := []rune(typeName)
+	if unicode.IsUpper(r[0]) {
+		return prefix + typeName
+	}
+	r[0] = unicode.ToUpper(r[0])
+	return strings.ToLower(prefix) + string(r)
+}
+
+func writeBind(buf *bytes.Buffer, info structInfo) {
+	fmt.Fprintf(buf, "\n// %s binds data into t without reflection for the fields it can specialize.\n", funcName("Bind", info.name))
+	fmt.Fprintf(buf, "func %s(t *%s, data map[string]any) error {\n", funcName("Bind", info.name), info.name)
+	for _, f := range info.fields {
+		fmt.Fprintf(buf, "\tif raw, ok := data[%q]; ok {\n", f.key)
+		fallback := fmt.Sprintf("if err := dd.BindValue(&t.%s, raw, %q); err != nil {\n\t\t\treturn &dd.BindingError{Path: %q, Field: %q, Key: %q, Cause: err}\n\t\t}",
+			f.goName, info.name+"."+f.goName, info.name, f.goName, f.key)
+		switch f.typ {
+		case "string", "bool":
+			fmt.Fprintf(buf, "\t\tif v, ok := raw.(%s); ok {\n\t\t\tt.%s = v\n\t\t} else %s\n", f.typ, f.goName, fallback)
+		case "int", "int64":
+			fromInt := "v"
+			if f.typ == "int64" {
+				fromInt = "int64(v)"
+			}
+			fmt.Fprintf(buf, "\t\tif v, ok := raw.(int); ok {\n\t\t\tt.%s = %s\n\t\t} else if v, ok := raw.(float64); ok && v == float64(int64(v)) {\n\t\t\tt.%s = %s(v)\n\t\t} else %s\n",
+				f.goName, fromInt, f.goName, f.typ, fallback)
+		case "float64":
+			fmt.Fprintf(buf, "\t\tif v, ok := raw.(float64); ok {\n\t\t\tt.%s = v\n\t\t} else if v, ok := raw.(int); ok {\n\t\t\tt.%s = float64(v)\n\t\t} else %s\n",
+				f.goName, f.goName, fallback)
+		default:
+			fmt.Fprintf(buf, "\t\t%s\n", fallback)
+		}
+		if f.required {
+			fmt.Fprintf(buf, "\t} else {\n\t\treturn &dd.RequiredFieldError{Path: %q, Field: %q}\n", info.name, f.goName)
+		}
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("\treturn nil\n}\n")
+}
+
+func writeUnbind(buf *bytes.Buffer, info structInfo) {
+	fmt.Fprintf(buf, "\n// %s unbinds t without reflection for the fields it can specialize.\n", funcName("Unbind", info.name))
+	fmt.Fprintf(buf, "func %s(t *%s) (map[string]any, error) {\n", funcName("Unbind", info.name), info.name)
+	fmt.Fprintf(buf, "\tout := make(map[string]any, %d)\n", len(info.fields))
+	for _, f := range info.fields {
+		if f.typ != "" {
+			if f.omitEmpty {
+				cond := fmt.Sprintf("t.%s != 0", f.goName)
+				switch f.typ {
+				case "string":
+					cond = fmt.Sprintf("t.%s != \"\"", f.goName)
+				case "bool":
+					cond = "t." + f.goName
+				}
+				fmt.Fprintf(buf, "\tif %s {\n\t\tout[%q] = t.%s\n\t}\n", cond, f.key, f.goName)
+			} else {
+				fmt.Fprintf(buf, "\tout[%q] = t.%s\n", f.key, f.goName)
+			}
+			continue
+		}
+		fmt.Fprintf(buf, "\tif v, ok, err := dd.UnbindValue(&t.%s); err != nil {\n\t\treturn nil, &dd.UnbindingError{Path: %q, Field: %q, Key: %q, Cause: err}\n\t} else if ok {\n\t\tout[%q] = v\n\t}\n",
+			f.goName, info.name, f.goName, f.key, f.key)
+	}
+	buf.WriteString("\treturn out, nil\n}\n")
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/michaelquigley/df/dd"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/fixture.golden")
+
+func TestGenerateGolden(t *testing.T) {
+	src, err := generate("testdata/fixture", nil, "dd_generated.go")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if *update {
+		if err := os.WriteFile("testdata/fixture.golden", src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile("testdata/fixture.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(golden), string(src))
+	assert.NotContains(t, string(src), "Limited", "types using constraints keep using reflection")
+}
+
+func TestGenerateRefusesTags(t *testing.T) {
+	_, err := generate("testdata/fixture", []string{"Limited"}, "dd_generated.go")
+	assert.EqualError(t, err, "no struct types to generate")
+
+	_, err = generate("testdata/fixture", []string{"Missing"}, "dd_generated.go")
+	assert.Error(t, err)
+}
+
+func TestGeneratedTag(t *testing.T) {
+	type tagged struct {
+		Plain    string
+		Named    string          `dd:"other,+required,+omitempty"`
+		Secret   string          `dd:",+secret"`
+		Merged   []int           `dd:",+merge=append"`
+		Min      int             `dd:",+min=1"`
+		Extra    map[string]any  `dd:",+extra"`
+		Inline   struct{ A int } `dd:",+inline"`
+		PlainRef string          `dd:",+plainref"`
+		Format   string          `dd:",+format=email"`
+	}
+	allowed := map[string]bool{"Plain": true, "Named": true, "Secret": true, "Merged": true}
+	st := reflect.TypeOf(tagged{})
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		assert.Equal(t, allowed[field.Name], generatedTag(dd.ParseTag(field)), field.Name)
+	}
+}
+
+// TestGeneratedBindMatchesReflection compiles the generated code for the fixture into a program, which checks that
+// Bind and Unbind through the generated functions agree with reflective Bind and Unbind.
+func TestGeneratedBindMatchesReflection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a program with the go tool")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	src, err := generate("testdata/fixture", nil, "dd_generated.go")
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	fixture, err := os.ReadFile("testdata/fixture/fixture.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the program lives within the module, so that it builds against this copy of dd
+	dir, err := os.MkdirTemp("testdata", "build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"fixture.go":      string(fixture),
+		"dd_generated.go": string(src),
+		"main.go":         equivalenceProgram,
+	}
+	for name, content := range files {
+		content = strings.Replace(content, "package fixture", "package main", 1)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := exec.Command(goTool, "run", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated code failed: %v\n%s", err, out)
+	}
+	assert.Equal(t, "ok\n", string(out))
+}
+
+const equivalenceProgram = `package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/michaelquigley/df/dd"
+)
+
+func main() {
+	data := map[string]any{
+		"name":    "svc",
+		"port":    8080,
+		"offset":  3.0,
+		"ratio":   1,
+		"debug":   true,
+		"token":   "s3cret",
+		"tags":    []any{"a", "b"},
+		"timeout": "5s",
+		"server":  map[string]any{"host": "db", "port": 5432.0},
+		"labels":  map[string]any{"env": "prod"},
+	}
+
+	generated, err := dd.New[Config](data)
+	check(err)
+	var direct Config
+	check(BindConfig(&direct, data))
+	reflective, err := dd.New[Config](data, &dd.Options{})
+	check(err)
+	if !reflect.DeepEqual(generated, reflective) || !reflect.DeepEqual(&direct, reflective) {
+		fail("bind: generated %+v, reflective %+v", generated, reflective)
+	}
+
+	generatedOut, err := dd.Unbind(generated)
+	check(err)
+	directOut, err := UnbindConfig(generated)
+	check(err)
+	reflectiveOut, err := dd.Unbind(reflective, &dd.Options{})
+	check(err)
+	if !reflect.DeepEqual(generatedOut, reflectiveOut) || !reflect.DeepEqual(directOut, reflectiveOut) {
+		fail("unbind: generated %v, reflective %v", generatedOut, reflectiveOut)
+	}
+
+	_, generatedErr := dd.New[Config](map[string]any{"name": "svc"})
+	_, reflectiveErr := dd.New[Config](map[string]any{"name": "svc"}, &dd.Options{})
+	var required *dd.RequiredFieldError
+	if !errors.As(generatedErr, &required) || !errors.As(reflectiveErr, &required) {
+		fail("missing port: generated %v, reflective %v", generatedErr, reflectiveErr)
+	}
+	fmt.Println("ok")
+}
+
+func check(err error) {
+	if err != nil {
+		fail("%v", err)
+	}
+}
+
+func fail(format string, args ...any) {
+	fmt.Printf(format+"\n", args...)
+	os.Exit(1)
+}
+`
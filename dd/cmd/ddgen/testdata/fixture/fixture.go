@@ -0,0 +1,31 @@
+package fixture
+
+import "time"
+
+// Config exercises every field kind ddgen specializes, and some it delegates to dd.
+type Config struct {
+	Name    string
+	Port    int   `dd:"port,+required"`
+	Offset  int64 `dd:"offset"`
+	Ratio   float64
+	Debug   bool   `dd:",+omitempty"`
+	Region  string `dd:",+omitempty"`
+	Token   string `dd:",+secret"`
+	Tags    []string
+	Timeout time.Duration
+	Server  Server
+	Labels  map[string]string
+	Ignored string `dd:"-"`
+	local   string
+}
+
+// Server is nested within Config.
+type Server struct {
+	Host string
+	Port int
+}
+
+// Limited uses a constraint, which ddgen must refuse.
+type Limited struct {
+	Port int `dd:",+min=1"`
+}
@@ -0,0 +1,147 @@
+package dd
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Coercion controls how strictly Bind converts primitive values. different data sources warrant different tolerance:
+// hand-edited files and environment variables often benefit from lenient booleans, while machine-generated payloads
+// should fail loudly instead of losing precision.
+type Coercion struct {
+	// LenientBools accepts "yes"/"no", "on"/"off", "y"/"n" (case-insensitive) and the numbers 1 and 0 for bool fields,
+	// in addition to the forms accepted by strconv.ParseBool.
+	LenientBools bool
+
+	// NoTruncation rejects values with a fractional part (e.g. 3.7 or "3.7") for integer fields, integer map keys, and
+	// numeric durations, instead of silently truncating them.
+	NoTruncation bool
+
+	// CheckOverflow rejects values that do not fit the target type (e.g. 300 for an int8, -1 for a uint, or 1e40 for a
+	// float32), including integer map keys, instead of silently wrapping them.
+	CheckOverflow bool
+}
+
+// StrictCoercion rejects lossy numeric conversions.
+var StrictCoercion = Coercion{NoTruncation: true, CheckOverflow: true}
+
+func coercionOf(opt *Options) Coercion {
+	if opt == nil {
+		return Coercion{}
+	}
+	return opt.Coercion
+}
+
+// lenientBool interprets the additional boolean spellings accepted under Coercion.LenientBools.
+func lenientBool(raw interface{}) (bool, bool) {
+	switch v := raw.(type) {
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "yes", "y", "on":
+			return true, true
+		case "no", "n", "off":
+			return false, true
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		if f, ok := coerceToFloat64(v); ok && (f == 0 || f == 1) {
+			return f == 1, true
+		}
+	}
+	return false, false
+}
+
+// coercionFloat returns raw as a float when it is a floating-point value, or a string that only parses as one.
+func coercionFloat(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		s := strings.TrimSpace(v)
+		if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return 0, false
+		}
+		if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return 0, false
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// coercionUint returns raw as an unsigned integer when it is one, or a string that parses as one.
+func coercionUint(raw interface{}) (uint64, bool) {
+	if s, ok := raw.(string); ok {
+		u, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		return u, err == nil
+	}
+	rv := reflect.ValueOf(raw)
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), true
+	}
+	return 0, false
+}
+
+// checkIntCoercion validates the conversion of raw to i64 for a signed integer dst, according to opt.Coercion.
+func checkIntCoercion(dst reflect.Value, raw interface{}, i64 int64, path string, opt *Options) error {
+	c := coercionOf(opt)
+	f, isFloat := coercionFloat(raw)
+	if c.NoTruncation && isFloat && f != math.Trunc(f) {
+		return truncationError(dst, raw, path)
+	}
+	if c.CheckOverflow {
+		if isFloat && (f < math.MinInt64 || f >= math.MaxInt64 || math.IsNaN(f)) {
+			return overflowError(dst, raw, path)
+		}
+		if u, ok := coercionUint(raw); ok && u > math.MaxInt64 {
+			return overflowError(dst, raw, path)
+		}
+		if dst.OverflowInt(i64) {
+			return overflowError(dst, raw, path)
+		}
+	}
+	return nil
+}
+
+// checkUintCoercion validates the conversion of raw to u64 for an unsigned integer dst, according to opt.Coercion.
+func checkUintCoercion(dst reflect.Value, raw interface{}, u64 uint64, path string, opt *Options) error {
+	c := coercionOf(opt)
+	f, isFloat := coercionFloat(raw)
+	if c.NoTruncation && isFloat && f != math.Trunc(f) {
+		return truncationError(dst, raw, path)
+	}
+	if c.CheckOverflow {
+		if isFloat && (f >= math.MaxUint64 || math.IsNaN(f)) {
+			return overflowError(dst, raw, path)
+		}
+		if dst.OverflowUint(u64) {
+			return overflowError(dst, raw, path)
+		}
+	}
+	return nil
+}
+
+func truncationError(dst reflect.Value, raw interface{}, path string) error {
+	return &ConversionError{
+		Path:    path,
+		Value:   fmt.Sprint(raw),
+		Type:    dst.Type().String(),
+		Message: fmt.Sprintf("value %v would be truncated converting to %s", raw, dst.Type()),
+	}
+}
+
+func overflowError(dst reflect.Value, raw interface{}, path string) error {
+	return &ConversionError{
+		Path:    path,
+		Value:   fmt.Sprint(raw),
+		Type:    dst.Type().String(),
+		Message: fmt.Sprintf("value %v overflows %s", raw, dst.Type()),
+	}
+}
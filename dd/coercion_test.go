@@ -0,0 +1,119 @@
+package dd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type coercionTarget struct {
+	Enabled bool
+	Count   int
+	Small   int8
+	Size    uint16
+	Ratio   float32
+}
+
+func TestCoercionDefaultsAreLenient(t *testing.T) {
+	target, err := New[coercionTarget](map[string]any{"count": 3.7, "small": 300})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, target.Count)
+	assert.Equal(t, int8(44), target.Small)
+
+	_, err = New[coercionTarget](map[string]any{"enabled": "yes"})
+	assert.Error(t, err)
+}
+
+func TestCoercionLenientBools(t *testing.T) {
+	opts := &Options{Coercion: Coercion{LenientBools: true}}
+	for raw, expected := range map[any]bool{"yes": true, "ON": true, "y": true, "no": false, "Off": false, 1: true, 0: false, 1.0: true, "true": true} {
+		target, err := New[coercionTarget](map[string]any{"enabled": raw}, opts)
+		assert.NoError(t, err, "%v", raw)
+		assert.Equal(t, expected, target.Enabled, "%v", raw)
+	}
+	_, err := New[coercionTarget](map[string]any{"enabled": 2}, opts)
+	assert.Error(t, err)
+	_, err = New[coercionTarget](map[string]any{"enabled": "maybe"}, opts)
+	assert.Error(t, err)
+}
+
+func TestCoercionNoTruncation(t *testing.T) {
+	opts := &Options{Coercion: Coercion{NoTruncation: true}}
+
+	_, err := New[coercionTarget](map[string]any{"count": 3.7}, opts)
+	var ce *ConversionError
+	if assert.True(t, errors.As(err, &ce)) {
+		assert.Equal(t, "3.7", ce.Value)
+		assert.Contains(t, err.Error(), "truncated")
+	}
+	_, err = New[coercionTarget](map[string]any{"size": "2.5"}, opts)
+	assert.Error(t, err)
+
+	target, err := New[coercionTarget](map[string]any{"count": 4.0, "size": "12"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, target.Count)
+	assert.Equal(t, uint16(12), target.Size)
+}
+
+func TestCoercionCheckOverflow(t *testing.T) {
+	opts := &Options{Coercion: StrictCoercion}
+
+	for field, raw := range map[string]any{"small": 300, "size": 70000, "ratio": 1e40, "count": 1e30} {
+		_, err := New[coercionTarget](map[string]any{field: raw}, opts)
+		var ce *ConversionError
+		if assert.True(t, errors.As(err, &ce), field) {
+			assert.Contains(t, err.Error(), "overflows", field)
+		}
+	}
+	_, err := New[coercionTarget](map[string]any{"count": uint64(1 << 63)}, opts)
+	assert.Error(t, err)
+
+	target, err := New[coercionTarget](map[string]any{"small": -128, "size": 65535, "ratio": 0.5}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), target.Small)
+	assert.Equal(t, uint16(65535), target.Size)
+}
+
+func TestStrictCoercionCoverage(t *testing.T) {
+	type nested struct {
+		Levels  map[int8]string
+		Ports   map[uint16]bool
+		Sizes   []int8
+		Small   *int8
+		Timeout time.Duration
+	}
+	opts := &Options{Coercion: StrictCoercion}
+
+	for field, raw := range map[string]any{
+		"levels":  map[string]any{"300": "x"},
+		"ports":   map[string]any{"1.5": true},
+		"sizes":   []any{1, 200},
+		"small":   -129,
+		"timeout": 1.5,
+	} {
+		_, err := New[nested](map[string]any{field: raw}, opts)
+		var ce *ConversionError
+		assert.True(t, errors.As(err, &ce), field)
+	}
+
+	_, err := New[nested](map[string]any{"levels": map[string]any{"300": "x"}}, opts)
+	assert.Contains(t, err.Error(), `nested.Levels["300"]: value 300 overflows int8`)
+	_, err = New[nested](map[string]any{"sizes": []any{1, 200}}, opts)
+	assert.Contains(t, err.Error(), "value 200 overflows int8")
+
+	// json.Number values are checked like any other number
+	_, err = NewJSON[nested]([]byte(`{"small": 1.5}`), &Options{Coercion: StrictCoercion, PreciseNumbers: true})
+	assert.Error(t, err)
+
+	n, err := New[nested](map[string]any{"levels": map[string]any{"-128": "min"}, "timeout": 2e9}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "min", n.Levels[-128])
+	assert.Equal(t, 2*time.Second, n.Timeout)
+
+	// without the option, keys still wrap silently
+	n, err = New[nested](map[string]any{"levels": map[string]any{"300": "x"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "x", n.Levels[44])
+}
@@ -0,0 +1,387 @@
+package dd
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var constraintRegexps sync.Map // pattern → *regexp.Regexp
+
+// checkConstraints validates a freshly bound field against its +min, +max, +regex, and +oneof tag constraints. path
+// is the path of the containing struct and name the field's external name, for error reporting.
+func checkConstraints(fieldVal reflect.Value, tag DdTag, path, name string) error {
+	if tag.Min == "" && tag.Max == "" && tag.Regex == "" && tag.OneOf == nil {
+		return nil
+	}
+	v := fieldVal
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if isOptionalType(v.Type()) {
+		if !v.Field(1).Bool() {
+			return nil
+		}
+		v = v.Field(0)
+	}
+
+	if tag.Min != "" || tag.Max != "" {
+		if err := checkRange(v, tag, path, name); err != nil {
+			return err
+		}
+	}
+	if tag.Regex == "" && tag.OneOf == nil {
+		return nil
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		if isUUIDType(v.Type()) {
+			return checkValue(v, tag, path, name)
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := checkValue(v.Index(i), tag, path, fmt.Sprintf("%s[%d]", name, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return checkValue(v, tag, path, name)
+}
+
+// checkRange applies +min and +max to a number, or to the length of a string, slice, or map.
+func checkRange(v reflect.Value, tag DdTag, path, name string) error {
+	var actual float64
+	var shown string
+	isDuration := v.Type() == reflect.TypeOf(time.Duration(0))
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		actual = float64(v.Len())
+		shown = fmt.Sprintf("of length %d", v.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		actual = float64(v.Int())
+		shown = fmt.Sprint(v.Interface())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		actual = float64(v.Uint())
+		shown = fmt.Sprint(v.Interface())
+	case reflect.Float32, reflect.Float64:
+		actual = v.Float()
+		shown = fmt.Sprint(v.Interface())
+	default:
+		return &ValidationError{Field: path + "." + name, Message: fmt.Sprintf("+min/+max not supported for %s", v.Type())}
+	}
+
+	for _, bound := range []struct {
+		flag  string
+		value string
+		fails func(limit float64) bool
+	}{
+		{"+min", tag.Min, func(limit float64) bool { return actual < limit }},
+		{"+max", tag.Max, func(limit float64) bool { return actual > limit }},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		limit, err := parseBound(bound.value, isDuration)
+		if err != nil {
+			return &ValidationError{Field: path + "." + name, Message: fmt.Sprintf("invalid %s=%s: %v", bound.flag, bound.value, err)}
+		}
+		if bound.fails(limit) {
+			return &ConstraintError{Path: path, Field: name, Constraint: bound.flag + "=" + bound.value, Value: shown}
+		}
+	}
+	return nil
+}
+
+func parseBound(s string, isDuration bool) (float64, error) {
+	if isDuration {
+		if d, err := time.ParseDuration(s); err == nil {
+			return float64(d), nil
+		}
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// checkValue applies +regex and +oneof to a single value, compared in its textual form.
+func checkValue(v reflect.Value, tag DdTag, path, name string) error {
+	text := constraintText(v)
+	if tag.Regex != "" {
+		re, err := constraintRegexp(tag.Regex)
+		if err != nil {
+			return &ValidationError{Field: path + "." + name, Message: fmt.Sprintf("invalid +regex=%s: %v", tag.Regex, err)}
+		}
+		if !re.MatchString(text) {
+			return &ConstraintError{Path: path, Field: name, Constraint: "+regex=" + tag.Regex, Value: strconv.Quote(text)}
+		}
+	}
+	if tag.OneOf != nil {
+		for _, allowed := range tag.OneOf {
+			if text == allowed {
+				return nil
+			}
+		}
+		flag := "+oneof="
+		if tag.Enum {
+			flag = "+enum="
+		}
+		return &ConstraintError{Path: path, Field: name, Constraint: flag + strings.Join(tag.OneOf, "|"), Value: strconv.Quote(text), Suggestion: closestValue(text, tag.OneOf)}
+	}
+	return nil
+}
+
+// closestValue returns the alternative nearest to text by edit distance (ignoring case), or "" when none is close
+// enough to be a plausible typo.
+func closestValue(text string, alternatives []string) string {
+	best, bestDistance := "", -1
+	for _, alternative := range alternatives {
+		d := levenshtein(strings.ToLower(text), strings.ToLower(alternative))
+		if d <= 2 && d < len(alternative) && (bestDistance < 0 || d < bestDistance) {
+			best, bestDistance = alternative, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// constraintText renders v the way it appears in configuration, so constraints compare against what users write.
+func constraintText(v reflect.Value) string {
+	if raw, present, err := valueToInterface(v, nil); err == nil && present {
+		if s, ok := raw.(string); ok {
+			return s
+		}
+		return fmt.Sprint(raw)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func constraintRegexp(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := constraintRegexps.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	constraintRegexps.Store(pattern, re)
+	return re, nil
+}
+
+// fieldGroup is a named +requires-one-of or +exclusive group and the external names of its fields.
+type fieldGroup struct {
+	name   string
+	fields []string
+}
+
+// checkFieldGroups validates the +requires-one-of and +exclusive groups declared by the fields of structType, given
+// which external names are present in its data. inlined structs declare and check their own groups.
+func checkFieldGroups(structType reflect.Type, present func(name string) bool, path string, naming NamingStrategy) error {
+	var anyOf, exclusive []fieldGroup
+	for _, meta := range cachedFields(structType) {
+		if meta.tag.AnyOf == "" && meta.tag.Exclusive == "" {
+			continue
+		}
+		if meta.field.PkgPath != "" || meta.tag.Skip || isInlined(meta.field, meta.tag) {
+			continue
+		}
+		name := meta.externalName(naming)
+		if meta.tag.AnyOf != "" {
+			anyOf = addToGroup(anyOf, meta.tag.AnyOf, name)
+		}
+		if meta.tag.Exclusive != "" {
+			exclusive = addToGroup(exclusive, meta.tag.Exclusive, name)
+		}
+	}
+	for _, group := range anyOf {
+		found := false
+		for _, name := range group.fields {
+			if present(name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return &FieldGroupError{Path: path, Group: group.name, Fields: group.fields}
+		}
+	}
+	for _, group := range exclusive {
+		var set []string
+		for _, name := range group.fields {
+			if present(name) {
+				set = append(set, name)
+			}
+		}
+		if len(set) > 1 {
+			return &FieldGroupError{Path: path, Group: group.name, Fields: set, Exclusive: true}
+		}
+	}
+	return nil
+}
+
+func addToGroup(groups []fieldGroup, group, name string) []fieldGroup {
+	for i := range groups {
+		if groups[i].name == group {
+			groups[i].fields = append(groups[i].fields, name)
+			return groups
+		}
+	}
+	return append(groups, fieldGroup{name: group, fields: []string{name}})
+}
+
+var comparisonOps = []string{"eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield"}
+
+// comparisonOp returns the cross-field comparison named by a "+op=Field" tag token.
+func comparisonOp(token string) (string, bool) {
+	for _, op := range comparisonOps {
+		if strings.HasPrefix(token, "+"+op+"=") {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// checkFieldComparisons validates the +eqfield, +gtfield, etc. constraints of the fields of a populated struct, for
+// fields present in its data.
+func checkFieldComparisons(structValue reflect.Value, present func(name string) bool, path string, naming NamingStrategy) error {
+	for _, meta := range cachedFields(structValue.Type()) {
+		if meta.tag.Compare == nil || meta.field.PkgPath != "" || meta.tag.Skip {
+			continue
+		}
+		name := meta.externalName(naming)
+		if !present(name) {
+			continue
+		}
+		for _, c := range meta.tag.Compare {
+			constraint := "+" + c.Op + "=" + c.Field
+			other, found := structValue.Type().FieldByName(c.Field)
+			if !found {
+				return &ValidationError{Field: path + "." + name, Message: fmt.Sprintf("invalid %s: no such field", constraint)}
+			}
+			otherValue, err := structValue.FieldByIndexErr(other.Index)
+			if err != nil {
+				continue // promoted through a nil embedded pointer, so there is no value
+			}
+			a, okA := comparableValue(structValue.Field(meta.index))
+			b, okB := comparableValue(otherValue)
+			if !okA || !okB {
+				continue // nil or unset values are left to +required
+			}
+			order, ordered := compareValues(a, b)
+			if !ordered && c.Op != "eqfield" && c.Op != "nefield" {
+				return &ValidationError{Field: path + "." + name, Message: fmt.Sprintf("%s not supported for %s", constraint, a.Type())}
+			}
+			var ok bool
+			switch c.Op {
+			case "eqfield":
+				ok = order == 0
+			case "nefield":
+				ok = order != 0
+			case "gtfield":
+				ok = order > 0
+			case "gtefield":
+				ok = order >= 0
+			case "ltfield":
+				ok = order < 0
+			case "ltefield":
+				ok = order <= 0
+			}
+			if !ok {
+				shown := fmt.Sprint(a.Interface())
+				if meta.tag.Secret || parseDdTag(other).Secret {
+					shown = DefaultSecretPlaceholder
+				} else if a.Kind() == reflect.String {
+					shown = strconv.Quote(shown)
+				}
+				return &ConstraintError{Path: path, Field: name, Constraint: constraint, Value: shown}
+			}
+		}
+	}
+	return nil
+}
+
+// comparableValue dereferences pointers and optionals, reporting false when there is no value.
+func comparableValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if isOptionalType(v.Type()) {
+		if !v.Field(1).Bool() {
+			return v, false
+		}
+		v = v.Field(0)
+	}
+	return v, true
+}
+
+// compareValues compares a with b, returning -1, 0, or 1. ordered is false for values that have no order, in which
+// case the result only distinguishes equal (0) from unequal.
+func compareValues(a, b reflect.Value) (order int, ordered bool) {
+	if a.Type() == reflect.TypeOf(time.Time{}) && b.Type() == a.Type() {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), true
+	}
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return cmp.Compare(a.Int(), b.Int()), true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case isNumberKind(a.Kind()) && isNumberKind(b.Kind()):
+		return cmp.Compare(numberValue(a), numberValue(b)), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	}
+	if a.Type() == b.Type() && reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return 0, false
+	}
+	return 1, false
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func numberValue(v reflect.Value) float64 {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int())
+	case isUintKind(v.Kind()):
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
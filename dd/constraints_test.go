@@ -0,0 +1,295 @@
+package dd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type constraintsServer struct {
+	Port int `dd:",+min=1,+max=65535"`
+}
+
+type constraintsConfig struct {
+	Name    string        `dd:",+regex=^[a-z]+$"`
+	Env     string        `dd:",+oneof=dev|prod"`
+	Code    string        `dd:",+regex=\"^[A-Z]{2,3}$\""`
+	Ratio   *float64      `dd:",+min=0,+max=1"`
+	Timeout time.Duration `dd:",+min=1s,+max=1m"`
+	Tags    []string      `dd:",+max=2,+oneof=a|b|c"`
+	Level   Optional[int] `dd:",+oneof=1|2|3"`
+	Server  constraintsServer
+}
+
+func TestConstraintsSatisfied(t *testing.T) {
+	cfg, err := New[constraintsConfig](map[string]any{
+		"name":    "app",
+		"env":     "prod",
+		"code":    "USA",
+		"ratio":   0.5,
+		"timeout": "30s",
+		"tags":    []any{"a", "c"},
+		"level":   2,
+		"server":  map[string]any{"port": 8080},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Server.Port)
+
+	// absent fields are not checked
+	_, err = New[constraintsConfig](map[string]any{})
+	assert.NoError(t, err)
+}
+
+func TestConstraintsViolated(t *testing.T) {
+	tests := []struct {
+		data       map[string]any
+		field      string
+		constraint string
+		value      string
+	}{
+		{map[string]any{"name": "App1"}, "name", "+regex=^[a-z]+$", `"App1"`},
+		{map[string]any{"env": "staging"}, "env", "+oneof=dev|prod", `"staging"`},
+		{map[string]any{"code": "usa"}, "code", "+regex=^[A-Z]{2,3}$", `"usa"`},
+		{map[string]any{"ratio": 1.5}, "ratio", "+max=1", "1.5"},
+		{map[string]any{"timeout": "500ms"}, "timeout", "+min=1s", "500ms"},
+		{map[string]any{"tags": []any{"a", "b", "c"}}, "tags", "+max=2", "of length 3"},
+		{map[string]any{"tags": []any{"a", "z"}}, "tags[1]", "+oneof=a|b|c", `"z"`},
+		{map[string]any{"level": 7}, "level", "+oneof=1|2|3", `"7"`},
+		{map[string]any{"server": map[string]any{"port": 0}}, "port", "+min=1", "0"},
+	}
+	for _, tt := range tests {
+		_, err := New[constraintsConfig](tt.data)
+		var ce *ConstraintError
+		if assert.True(t, errors.As(err, &ce), "%v", tt.data) {
+			assert.Equal(t, tt.field, ce.Field)
+			assert.Equal(t, tt.constraint, ce.Constraint)
+			assert.Equal(t, tt.value, ce.Value)
+		}
+	}
+
+	_, err := New[constraintsConfig](map[string]any{"server": map[string]any{"port": 70000}})
+	var ce *ConstraintError
+	if assert.True(t, errors.As(err, &ce)) {
+		assert.Equal(t, "constraintsConfig.Server", ce.Path)
+		assert.Contains(t, err.Error(), "constraintsConfig.Server.port: value 70000 violates +max=65535")
+	}
+}
+
+func TestConstraintsOnMerge(t *testing.T) {
+	cfg := &constraintsConfig{Env: "dev"}
+	assert.Error(t, Merge(cfg, map[string]any{"env": "qa"}))
+}
+
+func TestConstraintsInvalidTag(t *testing.T) {
+	type bad struct {
+		Count int    `dd:",+min=lots"`
+		Name  string `dd:",+regex=\"[\""`
+	}
+	_, err := New[bad](map[string]any{"count": 1})
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	_, err = New[bad](map[string]any{"name": "x"})
+	assert.True(t, errors.As(err, &ve))
+}
+
+func TestEnumConstraint(t *testing.T) {
+	type logging struct {
+		Level string `dd:",+enum=debug|info|warn|error"`
+	}
+
+	cfg, err := New[logging](map[string]any{"level": "warn"})
+	assert.NoError(t, err)
+	assert.Equal(t, "warn", cfg.Level)
+
+	_, err = New[logging](map[string]any{"level": "wran"})
+	var ce *ConstraintError
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, "+enum=debug|info|warn|error", ce.Constraint)
+	assert.Equal(t, "warn", ce.Suggestion)
+	assert.Contains(t, err.Error(), `violates +enum=debug|info|warn|error; did you mean "warn"?`)
+
+	_, err = New[logging](map[string]any{"level": "INFO"})
+	assert.ErrorAs(t, err, &ce)
+	assert.Equal(t, "info", ce.Suggestion)
+
+	// no suggestion when nothing is close
+	_, err = New[logging](map[string]any{"level": "verbose"})
+	assert.ErrorAs(t, err, &ce)
+	assert.Empty(t, ce.Suggestion)
+	assert.NotContains(t, err.Error(), "did you mean")
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("warn", "warn"))
+	assert.Equal(t, 2, levenshtein("wran", "warn"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 4, levenshtein("", "info"))
+}
+
+type groupedCredentials struct {
+	User         string
+	Password     string `dd:",+requires-one-of=auth,+exclusive=auth"`
+	PasswordFile string `dd:",+requires-one-of=auth,+exclusive=auth"`
+	Token        string `dd:",+exclusive=bearer"`
+	TokenFile    string `dd:",+exclusive=bearer"`
+}
+
+func TestFieldGroups(t *testing.T) {
+	creds, err := New[groupedCredentials](map[string]any{"password_file": "/run/secrets/db"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/run/secrets/db", creds.PasswordFile)
+
+	_, err = New[groupedCredentials](map[string]any{"user": "app"})
+	var fge *FieldGroupError
+	if assert.ErrorAs(t, err, &fge) {
+		assert.False(t, fge.Exclusive)
+		assert.Equal(t, []string{"password", "password_file"}, fge.Fields)
+	}
+	assert.Equal(t, `groupedCredentials: one of password, password_file is required (group "auth")`, err.Error())
+
+	_, err = New[groupedCredentials](map[string]any{"password": "x", "password_file": "y"})
+	assert.ErrorAs(t, err, &fge)
+	assert.Equal(t, `groupedCredentials: password, password_file are mutually exclusive (group "auth")`, err.Error())
+
+	_, err = New[groupedCredentials](map[string]any{"password": "x", "token": "t", "token_file": "f"})
+	assert.ErrorAs(t, err, &fge)
+	assert.Equal(t, "bearer", fge.Group)
+
+	// groups are checked per nested struct, using its own data
+	type database struct {
+		Credentials groupedCredentials
+	}
+	_, err = New[database](map[string]any{"credentials": map[string]any{}})
+	assert.ErrorAs(t, err, &fge)
+	assert.Equal(t, "database.Credentials", fge.Path)
+
+	tag := parseDdTag(reflect.TypeOf(groupedCredentials{}).Field(1))
+	assert.Equal(t, "auth", tag.AnyOf)
+	assert.Equal(t, "auth", tag.Exclusive)
+}
+
+func TestLoadLayeredFieldGroups(t *testing.T) {
+	// a group is satisfied by any layer, and its exclusivity holds across layers
+	_, _, err := LoadLayered[groupedCredentials]([]Layer{
+		MapLayer("defaults", map[string]any{"user": "app"}),
+		MapLayer("override", map[string]any{"password": "x"}),
+	})
+	assert.NoError(t, err)
+
+	_, _, err = LoadLayered[groupedCredentials]([]Layer{
+		MapLayer("defaults", map[string]any{"password_file": "/run/secrets/db"}),
+		MapLayer("override", map[string]any{"password": "x"}),
+	})
+	var fge *FieldGroupError
+	assert.ErrorAs(t, err, &fge)
+	assert.True(t, fge.Exclusive)
+}
+
+func TestFieldComparisons(t *testing.T) {
+	type window struct {
+		Start    time.Time
+		End      time.Time `dd:",+gtfield=Start"`
+		Min      int
+		Max      *int64 `dd:",+gtefield=Min"`
+		Retry    time.Duration
+		Timeout  time.Duration `dd:",+ltfield=Retry"`
+		Password string        `dd:",+secret"`
+		Confirm  string        `dd:"confirm_password,+eqfield=Password"`
+		Previous string
+		Next     string `dd:",+nefield=Previous"`
+		Tags     []string
+		Labels   []string `dd:",+eqfield=Tags"`
+	}
+
+	valid := map[string]any{
+		"start":            "2024-01-01T00:00:00Z",
+		"end":              "2024-01-02T00:00:00Z",
+		"min":              3,
+		"max":              3,
+		"retry":            "10s",
+		"timeout":          "5s",
+		"password":         "hunter2",
+		"confirm_password": "hunter2",
+		"previous":         "v1",
+		"next":             "v2",
+		"tags":             []any{"a"},
+		"labels":           []any{"a"},
+	}
+	_, err := New[window](valid)
+	assert.NoError(t, err)
+
+	// comparisons apply only to fields present in the data
+	_, err = New[window](map[string]any{"start": "2024-01-01T00:00:00Z"})
+	assert.NoError(t, err)
+
+	var ce *ConstraintError
+	for key, value := range map[string]any{
+		"end":              "2023-12-31T00:00:00Z",
+		"max":              2,
+		"timeout":          "10s",
+		"confirm_password": "hunter3",
+		"next":             "v1",
+		"labels":           []any{"b"},
+	} {
+		data := map[string]any{}
+		for k, v := range valid {
+			data[k] = v
+		}
+		data[key] = value
+		_, err = New[window](data)
+		if assert.ErrorAs(t, err, &ce, key) {
+			assert.Equal(t, key, ce.Field)
+		}
+	}
+
+	data := map[string]any{"password": "hunter2", "confirm_password": "hunter3"}
+	_, err = New[window](data)
+	assert.EqualError(t, err, "window.confirm_password: value <redacted> violates +eqfield=Password")
+	data = map[string]any{"previous": "v1", "next": "v1"}
+	_, err = New[window](data)
+	assert.EqualError(t, err, `window.next: value "v1" violates +nefield=Previous`)
+}
+
+type ComparisonBase struct {
+	Start int
+}
+
+func TestFieldComparisonsNilEmbedded(t *testing.T) {
+	type config struct {
+		*ComparisonBase
+		End int `dd:",+gtfield=Start"`
+	}
+	var c config
+	assert.NotPanics(t, func() {
+		assert.NoError(t, Bind(&c, map[string]any{"end": 5}))
+	})
+	assert.Equal(t, 5, c.End)
+
+	_, err := New[config](map[string]any{"start": 6, "end": 5})
+	var ce *ConstraintError
+	assert.ErrorAs(t, err, &ce)
+}
+
+func TestFieldComparisonErrors(t *testing.T) {
+	type unknown struct {
+		A int `dd:",+gtfield=Missing"`
+	}
+	_, err := New[unknown](map[string]any{"a": 1})
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+	assert.Contains(t, err.Error(), "no such field")
+
+	type unordered struct {
+		A []string
+		B []string `dd:",+gtfield=A"`
+	}
+	_, err = New[unordered](map[string]any{"a": []any{"x"}, "b": []any{"y"}})
+	assert.ErrorAs(t, err, &ve)
+	assert.Contains(t, err.Error(), "not supported")
+
+	tag := parseDdTag(reflect.StructField{Tag: `dd:"end,+gtfield=Start,+ltefield=Deadline"`})
+	assert.Equal(t, []FieldComparison{{Op: "gtfield", Field: "Start"}, {Op: "ltefield", Field: "Deadline"}}, tag.Compare)
+}
@@ -0,0 +1,82 @@
+package dd
+
+import (
+	"context"
+)
+
+// ConverterCtx is implemented by Converters that do I/O, e.g. resolving a value from a secret manager or an ID
+// lookup service. dd calls FromRawCtx and ToRawCtx in place of FromRaw and ToRaw, passing the context given to
+// BindCtx, NewCtx, MergeCtx, or UnbindCtx (context.Background() under the context-free entry points).
+type ConverterCtx interface {
+	Converter
+	FromRawCtx(ctx context.Context, raw interface{}) (interface{}, error)
+	ToRawCtx(ctx context.Context, value interface{}) (interface{}, error)
+}
+
+// BindCtx is Bind with a context, which is handed to ConverterCtx implementations and checked before each struct is
+// bound, so binding stops once ctx is cancelled or its deadline passes. the error then wraps ctx.Err().
+func BindCtx(ctx context.Context, target interface{}, data map[string]any, opts ...*Options) error {
+	opt, err := withContext(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	return Bind(target, data, opt)
+}
+
+// NewCtx is New with a context; see BindCtx.
+func NewCtx[T any](ctx context.Context, data map[string]any, opts ...*Options) (*T, error) {
+	opt, err := withContext(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return New[T](data, opt)
+}
+
+// MergeCtx is Merge with a context; see BindCtx.
+func MergeCtx(ctx context.Context, target interface{}, data map[string]any, opts ...*Options) error {
+	opt, err := withContext(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	return Merge(target, data, opt)
+}
+
+// UnbindCtx is Unbind with a context, which is handed to ConverterCtx implementations and checked before each struct
+// is unbound.
+func UnbindCtx(ctx context.Context, source interface{}, opts ...*Options) (map[string]any, error) {
+	opt, err := withContext(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return Unbind(source, opt)
+}
+
+// withContext returns a copy of the options carrying ctx.
+func withContext(ctx context.Context, opts ...*Options) (*Options, error) {
+	base, err := getOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	opt := Options{}
+	if base != nil {
+		opt = *base
+	}
+	opt.ctx = ctx
+	return &opt, nil
+}
+
+// context returns the context binding runs under, or context.Background() when there is none.
+func (o *Options) context() context.Context {
+	if o == nil || o.ctx == nil {
+		return context.Background()
+	}
+	return o.ctx
+}
+
+// checkContext reports the cancellation of the context binding runs under, if any.
+func checkContext(opt *Options) error {
+	if opt == nil || opt.ctx == nil {
+		return nil
+	}
+	return opt.ctx.Err()
+}
@@ -0,0 +1,103 @@
+package dd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+type vaultRef string
+
+// vaultConverter resolves "vault:" references from a tenant-scoped store carried in the context.
+type vaultConverter struct{}
+
+func (c vaultConverter) FromRaw(raw interface{}) (interface{}, error) {
+	return c.FromRawCtx(context.Background(), raw)
+}
+
+func (c vaultConverter) ToRaw(value interface{}) (interface{}, error) {
+	return c.ToRawCtx(context.Background(), value)
+}
+
+func (vaultConverter) FromRawCtx(ctx context.Context, raw interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	store, _ := ctx.Value(ctxKey{}).(map[string]string)
+	v, ok := store[fmt.Sprint(raw)]
+	if !ok {
+		return nil, fmt.Errorf("no secret %q", raw)
+	}
+	return vaultRef(v), nil
+}
+
+func (vaultConverter) ToRawCtx(ctx context.Context, value interface{}) (interface{}, error) {
+	if ctx.Value(ctxKey{}) == nil {
+		return nil, errors.New("no store in context")
+	}
+	return "redacted", nil
+}
+
+type vaultConfig struct {
+	Password vaultRef
+	Nested   struct {
+		Token vaultRef
+	}
+}
+
+func vaultOptions() *Options {
+	return &Options{Converters: map[reflect.Type]Converter{reflect.TypeOf(vaultRef("")): vaultConverter{}}}
+}
+
+func TestBindCtx(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, map[string]string{"db": "hunter2", "api": "t0k3n"})
+	data := map[string]any{"password": "db", "nested": map[string]any{"token": "api"}}
+
+	cfg, err := NewCtx[vaultConfig](ctx, data, vaultOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, vaultRef("hunter2"), cfg.Password)
+	assert.Equal(t, vaultRef("t0k3n"), cfg.Nested.Token)
+
+	cfg = &vaultConfig{}
+	assert.NoError(t, BindCtx(ctx, cfg, map[string]any{"password": "api"}, vaultOptions()))
+	assert.Equal(t, vaultRef("t0k3n"), cfg.Password)
+	assert.NoError(t, MergeCtx(ctx, cfg, map[string]any{"nested": map[string]any{"token": "db"}}, vaultOptions()))
+	assert.Equal(t, vaultRef("t0k3n"), cfg.Password)
+	assert.Equal(t, vaultRef("hunter2"), cfg.Nested.Token)
+
+	out, err := UnbindCtx(ctx, cfg, vaultOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, "redacted", out["password"])
+
+	// the context-free entry points hand ConverterCtx a background context
+	_, err = New[vaultConfig](data, vaultOptions())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `no secret "db"`)
+	_, err = Unbind(cfg, vaultOptions())
+	assert.Error(t, err)
+}
+
+func TestBindCtxCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewCtx[vaultConfig](ctx, map[string]any{"password": "db"}, vaultOptions())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err = UnbindCtx(ctx, &vaultConfig{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// options are copied, not modified
+	opts := vaultOptions()
+	_, _ = NewCtx[vaultConfig](ctx, map[string]any{}, opts)
+	assert.Nil(t, opts.ctx)
+}
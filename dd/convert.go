@@ -1,6 +1,7 @@
 package dd
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -30,7 +31,16 @@ func convertAndSet(dst reflect.Value, raw interface{}, path string, opt *Options
 		case int, int32, int64:
 			dst.SetInt(reflect.ValueOf(v).Int())
 			return nil
+		case json.Number:
+			if i, err := v.Int64(); err == nil {
+				dst.SetInt(i)
+				return nil
+			}
+			return &ConversionError{Path: path, Value: v.String(), Type: "duration", Message: "not an integer number of nanoseconds"}
 		case float32, float64:
+			if err := checkIntCoercion(dst, raw, int64(reflect.ValueOf(v).Float()), path, opt); err != nil {
+				return err
+			}
 			dst.SetInt(int64(reflect.ValueOf(v).Float()))
 			return nil
 		default:
@@ -61,6 +71,34 @@ func convertAndSet(dst reflect.Value, raw interface{}, path string, opt *Options
 		}
 	}
 
+	if handled, err := bindBig(dst, raw, path); handled {
+		return err
+	}
+
+	// json.Number (see Options.PreciseNumbers) keeps the literal digits; coerce from them as from a numeric string
+	if n, ok := raw.(json.Number); ok {
+		raw = string(n)
+	}
+
+	if handled, err := bindUUID(dst, raw, path); handled {
+		return err
+	}
+	if handled, err := bindNetip(dst, raw, path); handled {
+		return err
+	}
+	if handled, err := bindText(dst, raw, path); handled {
+		return err
+	}
+	if handled, err := bindJSON(dst, raw, path, opt); handled {
+		return err
+	}
+	if handled, err := bindInterface(dst, raw, path, opt); handled {
+		return err
+	}
+	if handled, err := bindRaw(dst, raw, path); handled {
+		return err
+	}
+
 	dstKind := dst.Kind()
 	switch dstKind {
 	case reflect.String:
@@ -80,6 +118,12 @@ func convertAndSet(dst reflect.Value, raw interface{}, path string, opt *Options
 		}
 
 	case reflect.Bool:
+		if coercionOf(opt).LenientBools {
+			if b, ok := lenientBool(raw); ok {
+				dst.SetBool(b)
+				return nil
+			}
+		}
 		switch v := raw.(type) {
 		case bool:
 			dst.SetBool(v)
@@ -106,6 +150,9 @@ func convertAndSet(dst reflect.Value, raw interface{}, path string, opt *Options
 		if !ok {
 			return &TypeMismatchError{Path: path, Expected: "integer", Actual: fmt.Sprintf("%T", raw)}
 		}
+		if err := checkIntCoercion(dst, raw, i64, path, opt); err != nil {
+			return err
+		}
 		dst.SetInt(i64)
 		return nil
 
@@ -114,6 +161,9 @@ func convertAndSet(dst reflect.Value, raw interface{}, path string, opt *Options
 		if !ok {
 			return &TypeMismatchError{Path: path, Expected: "unsigned integer", Actual: fmt.Sprintf("%T", raw)}
 		}
+		if err := checkUintCoercion(dst, raw, u64, path, opt); err != nil {
+			return err
+		}
 		dst.SetUint(u64)
 		return nil
 
@@ -122,6 +172,9 @@ func convertAndSet(dst reflect.Value, raw interface{}, path string, opt *Options
 		if !ok {
 			return &TypeMismatchError{Path: path, Expected: "float", Actual: fmt.Sprintf("%T", raw)}
 		}
+		if coercionOf(opt).CheckOverflow && dst.OverflowFloat(f64) {
+			return overflowError(dst, raw, path)
+		}
 		dst.SetFloat(f64)
 		return nil
 	}
@@ -259,9 +312,17 @@ func coerceToFloat64(raw interface{}) (float64, bool) {
 	return 0, false
 }
 
-// stringToKey converts a string key (from JSON/YAML) to the target key type.
-// returns the converted key as a reflect.Value.
-func stringToKey(keyStr string, keyType reflect.Type) (reflect.Value, error) {
+// keyError attaches the path of a map to an error from stringToKey; coercion errors already carry the entry's path.
+func keyError(path string, err error) error {
+	if _, ok := err.(*ConversionError); ok {
+		return err
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// stringToKey converts a string key (from JSON/YAML) to the target key type, applying opt.Coercion to numeric keys.
+// path is the path of the map entry, for error reporting. returns the converted key as a reflect.Value.
+func stringToKey(keyStr string, keyType reflect.Type, path string, opt *Options) (reflect.Value, error) {
 	keyKind := keyType.Kind()
 
 	switch keyKind {
@@ -274,6 +335,9 @@ func stringToKey(keyStr string, keyType reflect.Type) (reflect.Value, error) {
 			return reflect.Value{}, fmt.Errorf("cannot convert key %q to %v", keyStr, keyKind)
 		}
 		keyVal := reflect.New(keyType).Elem()
+		if err := checkIntCoercion(keyVal, keyStr, i64, path, opt); err != nil {
+			return reflect.Value{}, err
+		}
 		keyVal.SetInt(i64)
 		return keyVal, nil
 
@@ -283,6 +347,9 @@ func stringToKey(keyStr string, keyType reflect.Type) (reflect.Value, error) {
 			return reflect.Value{}, fmt.Errorf("cannot convert key %q to %v", keyStr, keyKind)
 		}
 		keyVal := reflect.New(keyType).Elem()
+		if err := checkUintCoercion(keyVal, keyStr, u64, path, opt); err != nil {
+			return reflect.Value{}, err
+		}
 		keyVal.SetUint(u64)
 		return keyVal, nil
 
@@ -295,6 +362,16 @@ func stringToKey(keyStr string, keyType reflect.Type) (reflect.Value, error) {
 		keyVal.SetFloat(f64)
 		return keyVal, nil
 
+	case reflect.Array:
+		if !isUUIDType(keyType) {
+			return reflect.Value{}, fmt.Errorf("unsupported map key type: %v", keyKind)
+		}
+		u, err := parseUUID(keyStr)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot convert key %q to uuid: %w", keyStr, err)
+		}
+		return reflect.ValueOf(u).Convert(keyType), nil
+
 	case reflect.Bool:
 		switch strings.ToLower(strings.TrimSpace(keyStr)) {
 		case "true", "1", "yes":
@@ -323,6 +400,11 @@ func keyToString(key reflect.Value) string {
 		return strconv.FormatFloat(key.Float(), 'g', -1, 64)
 	case reflect.Bool:
 		return strconv.FormatBool(key.Bool())
+	case reflect.Array:
+		if out, ok := unbindUUID(key); ok {
+			return out.(string)
+		}
+		return fmt.Sprintf("%v", key.Interface())
 	default:
 		return fmt.Sprintf("%v", key.Interface())
 	}
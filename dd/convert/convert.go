@@ -0,0 +1,53 @@
+// Package convert provides optional dd converters for common configuration value types: byte sizes, percentages, and
+// the standard library's net.IP, net.IPNet, url.URL, mail.Address, *time.Location, and *regexp.Regexp. call Install to
+// register them all with a dd.Options, or register individual converters in Options.Converters.
+package convert
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/michaelquigley/df/dd"
+)
+
+// Converters returns the converters provided by this package, keyed by the type they convert. net.IPNet, url.URL, and
+// mail.Address are registered for both value and pointer fields.
+func Converters() map[reflect.Type]dd.Converter {
+	return map[reflect.Type]dd.Converter{
+		reflect.TypeOf(ByteSize(0)):      ByteSizeConverter(),
+		reflect.TypeOf(Percent(0)):       PercentConverter(),
+		reflect.TypeOf(net.IP{}):         IPConverter(),
+		reflect.TypeOf(net.IPNet{}):      IPNetConverter(),
+		reflect.TypeOf(&net.IPNet{}):     ipNetPtrConverter(),
+		reflect.TypeOf(url.URL{}):        URLConverter(),
+		reflect.TypeOf(&url.URL{}):       urlPtrConverter(),
+		reflect.TypeOf(mail.Address{}):   MailAddressConverter(),
+		reflect.TypeOf(&mail.Address{}):  mailAddressPtrConverter(),
+		reflect.TypeOf(&time.Location{}): LocationConverter(),
+		reflect.TypeOf(&regexp.Regexp{}): RegexpConverter(),
+	}
+}
+
+// Install registers the converters provided by this package with opts, keeping any converter already registered for
+// the same type, and returns opts. a nil opts is allocated.
+//
+//	opts := convert.Install(&dd.Options{})
+//	cfg, err := dd.NewYAMLFile[Config]("config.yaml", opts)
+func Install(opts *dd.Options) *dd.Options {
+	if opts == nil {
+		opts = &dd.Options{}
+	}
+	if opts.Converters == nil {
+		opts.Converters = make(map[reflect.Type]dd.Converter)
+	}
+	for t, c := range Converters() {
+		if _, ok := opts.Converters[t]; !ok {
+			opts.Converters[t] = c
+		}
+	}
+	return opts
+}
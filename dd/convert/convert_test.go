@@ -0,0 +1,17 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/michaelquigley/df/dd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallKeepsExisting(t *testing.T) {
+	custom := PercentConverter()
+	opts := &dd.Options{Converters: map[reflect.Type]dd.Converter{reflect.TypeOf(Percent(0)): custom}}
+	assert.Same(t, opts, Install(opts))
+	assert.Same(t, custom, opts.Converters[reflect.TypeOf(Percent(0))])
+	assert.Len(t, opts.Converters, len(Converters()))
+}
@@ -0,0 +1,114 @@
+package convert
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/michaelquigley/df/dd"
+)
+
+// IPConverter converts net.IP fields from and to their string form, e.g. "10.0.0.1" or "::1".
+func IPConverter() dd.Converter {
+	return &stringConverter[net.IP]{
+		name: "ip address",
+		parse: func(s string) (net.IP, error) {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip address %q", s)
+			}
+			return ip, nil
+		},
+		format: net.IP.String,
+	}
+}
+
+// IPNetConverter converts net.IPNet fields from and to CIDR notation, e.g. "10.0.0.0/8".
+func IPNetConverter() dd.Converter {
+	return valueConverter(ipNetPtrConverter())
+}
+
+func ipNetPtrConverter() *stringConverter[*net.IPNet] {
+	return &stringConverter[*net.IPNet]{
+		name: "cidr",
+		parse: func(s string) (*net.IPNet, error) {
+			_, ipNet, err := net.ParseCIDR(s)
+			return ipNet, err
+		},
+		format: (*net.IPNet).String,
+	}
+}
+
+// URLConverter converts url.URL fields from and to their string form.
+func URLConverter() dd.Converter {
+	return valueConverter(urlPtrConverter())
+}
+
+func urlPtrConverter() *stringConverter[*url.URL] {
+	return &stringConverter[*url.URL]{name: "url", parse: url.Parse, format: (*url.URL).String}
+}
+
+// MailAddressConverter converts mail.Address fields from and to RFC 5322 addresses, e.g. "Ops <ops@example.com>".
+func MailAddressConverter() dd.Converter {
+	return valueConverter(mailAddressPtrConverter())
+}
+
+func mailAddressPtrConverter() *stringConverter[*mail.Address] {
+	return &stringConverter[*mail.Address]{name: "email address", parse: mail.ParseAddress, format: (*mail.Address).String}
+}
+
+// LocationConverter converts *time.Location fields from and to IANA time zone names, e.g. "America/New_York". an
+// empty name or "UTC" yields time.UTC, and "Local" yields time.Local.
+func LocationConverter() dd.Converter {
+	return &stringConverter[*time.Location]{name: "time zone", parse: time.LoadLocation, format: (*time.Location).String}
+}
+
+// RegexpConverter converts *regexp.Regexp fields from and to their pattern; patterns are compiled during binding, so
+// invalid expressions are reported as binding errors.
+func RegexpConverter() dd.Converter {
+	return &stringConverter[*regexp.Regexp]{name: "regular expression", parse: regexp.Compile, format: (*regexp.Regexp).String}
+}
+
+// stringConverter converts T from and to its string form.
+type stringConverter[T any] struct {
+	name   string
+	parse  func(string) (T, error)
+	format func(T) string
+}
+
+func (c *stringConverter[T]) FromRaw(raw interface{}) (interface{}, error) {
+	switch v := raw.(type) {
+	case T:
+		return v, nil
+	case string:
+		return c.parse(v)
+	}
+	return nil, fmt.Errorf("expected %s string, got %T", c.name, raw)
+}
+
+func (c *stringConverter[T]) ToRaw(value interface{}) (interface{}, error) {
+	v, ok := value.(T)
+	if !ok {
+		return nil, fmt.Errorf("expected %T, got %T", v, value)
+	}
+	return c.format(v), nil
+}
+
+// valueConverter adapts a converter for *T to fields of type T.
+func valueConverter[T any](ptr *stringConverter[*T]) dd.Converter {
+	return &stringConverter[T]{
+		name: ptr.name,
+		parse: func(s string) (T, error) {
+			p, err := ptr.parse(s)
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+			return *p, nil
+		},
+		format: func(v T) string { return ptr.format(&v) },
+	}
+}
@@ -0,0 +1,90 @@
+package convert
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/michaelquigley/df/dd"
+	"github.com/stretchr/testify/assert"
+)
+
+type stdlibConfig struct {
+	Gateway   net.IP
+	Subnet    net.IPNet
+	Allowed   []*net.IPNet
+	Endpoint  url.URL
+	Proxy     *url.URL
+	Owner     mail.Address
+	Reply     *mail.Address
+	Zone      *time.Location
+	Pattern   *regexp.Regexp
+	Excludes  []*regexp.Regexp
+	Unrelated string
+}
+
+func TestStdlibConverters(t *testing.T) {
+	data := map[string]any{
+		"gateway":   "10.0.0.1",
+		"subnet":    "10.0.0.0/8",
+		"allowed":   []any{"192.168.0.0/16", "fd00::/8"},
+		"endpoint":  "https://api.example.com/v1?x=1",
+		"proxy":     "http://proxy:3128",
+		"owner":     "Ops <ops@example.com>",
+		"reply":     "noreply@example.com",
+		"zone":      "America/New_York",
+		"pattern":   "^app-[0-9]+$",
+		"excludes":  []any{"^tmp", "bak$"},
+		"unrelated": "x",
+	}
+	cfg, err := dd.New[stdlibConfig](data, Install(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", cfg.Gateway.String())
+	assert.Equal(t, "10.0.0.0/8", cfg.Subnet.String())
+	if assert.Len(t, cfg.Allowed, 2) {
+		assert.Equal(t, "fd00::/8", cfg.Allowed[1].String())
+	}
+	assert.Equal(t, "api.example.com", cfg.Endpoint.Host)
+	assert.Equal(t, "proxy:3128", cfg.Proxy.Host)
+	assert.Equal(t, mail.Address{Name: "Ops", Address: "ops@example.com"}, cfg.Owner)
+	assert.Equal(t, "noreply@example.com", cfg.Reply.Address)
+	assert.Equal(t, "America/New_York", cfg.Zone.String())
+	assert.True(t, cfg.Pattern.MatchString("app-42"))
+	if assert.Len(t, cfg.Excludes, 2) {
+		assert.True(t, cfg.Excludes[1].MatchString("file.bak"))
+	}
+
+	out, err := dd.Unbind(cfg, Install(nil))
+	assert.NoError(t, err)
+	data["owner"] = `"Ops" <ops@example.com>`
+	data["reply"] = "<noreply@example.com>"
+	assert.Equal(t, data, out)
+}
+
+func TestStdlibConverterErrors(t *testing.T) {
+	opts := Install(nil)
+	for field, value := range map[string]any{
+		"gateway": "10.0.0.256",
+		"subnet":  "10.0.0.0",
+		"owner":   "not an address",
+		"zone":    "Mars/Olympus_Mons",
+		"pattern": "([",
+		"proxy":   42,
+	} {
+		_, err := dd.New[stdlibConfig](map[string]any{field: value}, opts)
+		assert.Error(t, err, field)
+	}
+}
+
+func TestLocationConverterSpecialNames(t *testing.T) {
+	c := LocationConverter()
+	loc, err := c.FromRaw("")
+	assert.NoError(t, err)
+	assert.Same(t, time.UTC, loc)
+	loc, err = c.FromRaw("Local")
+	assert.NoError(t, err)
+	assert.Same(t, time.Local, loc)
+}
@@ -0,0 +1,156 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/michaelquigley/df/dd"
+)
+
+// ByteSize is a number of bytes, written in configuration as a size such as "512MB" or "1.5GiB".
+//
+// decimal units (k/kb, m/mb, g/gb, t/tb, p/pb) are powers of 1000 and binary units (ki/kib, mi/mib, gi/gib, ti/tib,
+// pi/pib) are powers of 1024; units are case-insensitive and "b" or no unit means bytes. fractional sizes are rounded
+// to the nearest byte.
+type ByteSize int64
+
+var byteUnits = map[string]float64{
+	"": 1, "b": 1,
+	"k": 1e3, "kb": 1e3, "m": 1e6, "mb": 1e6, "g": 1e9, "gb": 1e9, "t": 1e12, "tb": 1e12, "p": 1e15, "pb": 1e15,
+	"ki": 1 << 10, "kib": 1 << 10, "mi": 1 << 20, "mib": 1 << 20, "gi": 1 << 30, "gib": 1 << 30,
+	"ti": 1 << 40, "tib": 1 << 40, "pi": 1 << 50, "pib": 1 << 50,
+}
+
+// byteFormats lists the units used by String, largest first; binary units are preferred at each magnitude.
+var byteFormats = []struct {
+	unit string
+	size int64
+}{
+	{"PiB", 1 << 50}, {"PB", 1e15}, {"TiB", 1 << 40}, {"TB", 1e12}, {"GiB", 1 << 30}, {"GB", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6}, {"KiB", 1 << 10}, {"KB", 1e3},
+}
+
+// ParseByteSize parses a size such as "512MB", "1.5GiB", or "4096".
+func ParseByteSize(s string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(s)
+	i := strings.IndexFunc(trimmed, unicode.IsLetter)
+	if i < 0 {
+		i = len(trimmed)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(trimmed[:i]), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	unit, ok := byteUnits[strings.ToLower(trimmed[i:])]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, trimmed[i:])
+	}
+	bytes := math.Round(n * unit)
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q overflows int64", s)
+	}
+	return ByteSize(bytes), nil
+}
+
+// String formats the size in the largest unit that represents it exactly, e.g. "512MB" or "1536MiB".
+func (b ByteSize) String() string {
+	if b != 0 {
+		for _, f := range byteFormats {
+			if int64(b)%f.size == 0 {
+				return strconv.FormatInt(int64(b)/f.size, 10) + f.unit
+			}
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// Percent is a fraction written in configuration as a percentage: "15%" is Percent(0.15). numbers and strings
+// without a "%" suffix are taken as fractions.
+type Percent float64
+
+// ParsePercent parses a percentage such as "15%" or "12.5 %", or a fraction such as "0.15".
+func ParsePercent(s string) (Percent, error) {
+	trimmed := strings.TrimSpace(s)
+	number, isPercent := strings.CutSuffix(trimmed, "%")
+	f, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("invalid percentage %q", s)
+	}
+	if isPercent {
+		f /= 100
+	}
+	return Percent(f), nil
+}
+
+// String formats the fraction as a percentage, e.g. "15%".
+func (p Percent) String() string {
+	// round away the binary noise introduced by scaling, e.g. 0.155*100 = 15.500000000000002
+	scaled := strconv.FormatFloat(float64(p)*100, 'g', 12, 64)
+	f, _ := strconv.ParseFloat(scaled, 64)
+	return strconv.FormatFloat(f, 'f', -1, 64) + "%"
+}
+
+// ByteSizeConverter converts ByteSize fields from sizes such as "512MB" (or plain numbers of bytes), and back to
+// strings on unbind.
+func ByteSizeConverter() dd.Converter {
+	return &unitConverter[ByteSize]{
+		name:  "byte size",
+		parse: ParseByteSize,
+		fromNumber: func(f float64) (ByteSize, error) {
+			if f < 0 || f >= math.MaxInt64 {
+				return 0, fmt.Errorf("byte size %v out of range", f)
+			}
+			return ByteSize(math.Round(f)), nil
+		},
+	}
+}
+
+// PercentConverter converts Percent fields from percentages such as "15%" (or fractions), and back to percentage
+// strings on unbind.
+func PercentConverter() dd.Converter {
+	return &unitConverter[Percent]{
+		name:       "percentage",
+		parse:      ParsePercent,
+		fromNumber: func(f float64) (Percent, error) { return Percent(f), nil },
+	}
+}
+
+type unitConverter[T interface {
+	~int64 | ~float64
+	fmt.Stringer
+}] struct {
+	name       string
+	parse      func(string) (T, error)
+	fromNumber func(float64) (T, error)
+}
+
+func (c *unitConverter[T]) FromRaw(raw interface{}) (interface{}, error) {
+	switch v := raw.(type) {
+	case T:
+		return v, nil
+	case string:
+		return c.parse(v)
+	}
+	rv := reflect.ValueOf(raw)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.fromNumber(float64(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return c.fromNumber(float64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return c.fromNumber(rv.Float())
+	}
+	return nil, fmt.Errorf("expected %s string or number, got %T", c.name, raw)
+}
+
+func (c *unitConverter[T]) ToRaw(value interface{}) (interface{}, error) {
+	v, ok := value.(T)
+	if !ok {
+		return nil, fmt.Errorf("expected %T, got %T", v, value)
+	}
+	return v.String(), nil
+}
@@ -0,0 +1,84 @@
+package convert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/michaelquigley/df/dd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]ByteSize{
+		"0":       0,
+		"4096":    4096,
+		"512MB":   512_000_000,
+		"512mb":   512_000_000,
+		"1.5GiB":  1_610_612_736,
+		"64 KiB":  65_536,
+		"2Gi":     2 << 30,
+		"10b":     10,
+		"1.5k":    1500,
+		"0.5 TiB": 1 << 39,
+	}
+	for s, expected := range cases {
+		actual, err := ParseByteSize(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, actual, s)
+	}
+	for _, s := range []string{"", "MB", "-1KB", "12 parsecs", "1e30PB"} {
+		_, err := ParseByteSize(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	assert.Equal(t, "0B", ByteSize(0).String())
+	assert.Equal(t, "512MB", ByteSize(512_000_000).String())
+	assert.Equal(t, "1536MiB", ByteSize(1_610_612_736).String())
+	assert.Equal(t, "1KiB", ByteSize(1024).String())
+	assert.Equal(t, "1001B", ByteSize(1001).String())
+}
+
+func TestPercent(t *testing.T) {
+	p, err := ParsePercent("15%")
+	assert.NoError(t, err)
+	assert.Equal(t, Percent(0.15), p)
+	p, err = ParsePercent("12.5 %")
+	assert.NoError(t, err)
+	assert.Equal(t, Percent(0.125), p)
+	p, err = ParsePercent("0.3")
+	assert.NoError(t, err)
+	assert.Equal(t, Percent(0.3), p)
+	_, err = ParsePercent("lots%")
+	assert.Error(t, err)
+
+	assert.Equal(t, "15%", Percent(0.15).String())
+	assert.Equal(t, "15.5%", Percent(0.155).String())
+}
+
+func TestUnitConverters(t *testing.T) {
+	type limits struct {
+		Memory    ByteSize
+		Disk      ByteSize
+		Threshold Percent
+		Timeout   time.Duration
+	}
+	opts := Install(nil)
+
+	cfg, err := dd.New[limits](map[string]any{
+		"memory":    "512MB",
+		"disk":      1024,
+		"threshold": "15%",
+		"timeout":   "2h45m",
+	}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, &limits{Memory: 512_000_000, Disk: 1024, Threshold: 0.15, Timeout: 2*time.Hour + 45*time.Minute}, cfg)
+
+	data, err := dd.Unbind(cfg, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"memory": "512MB", "disk": "1KiB", "threshold": "15%", "timeout": "2h45m0s"}, data)
+
+	_, err = dd.New[limits](map[string]any{"memory": true}, opts)
+	assert.Error(t, err)
+}
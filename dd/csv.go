@@ -0,0 +1,268 @@
+package dd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// CSVOptions configures BindCSV and UnbindCSV.
+type CSVOptions struct {
+	// Comma is the field delimiter; defaults to ','.
+	Comma rune
+	// Columns maps a column name to a Converter for that column's cells. on binding, FromRaw receives the cell text and
+	// returns the value to bind (e.g. parsing "1,234.50" into 1234.5); on unbinding, ToRaw receives the unbound value
+	// and returns the cell contents.
+	Columns map[string]Converter
+	// Options is passed to Bind and Unbind (e.g. for converters or a naming strategy); may be nil.
+	Options *Options
+}
+
+// BindCSV reads CSV rows from r and binds each into an element of target, which must be a pointer to a slice of
+// structs (or of pointers to structs). the first row is a header naming the column of each field by its external
+// name (tag, or naming strategy); nested struct fields use dotted names such as "address.city". the slice is replaced
+// with one element per data row.
+//
+// cells are bound with the usual coercion rules, so "42" binds to an int field. empty cells are treated as absent,
+// leaving the field at its zero value (and failing `+required`). columns that match no field are ignored.
+func BindCSV(target interface{}, r io.Reader, opts ...*CSVOptions) error {
+	co := csvOptions(opts...)
+	slice, elemType, err := csvSliceTarget(target)
+	if err != nil {
+		return err
+	}
+
+	reader := csv.NewReader(r)
+	if co.Comma != 0 {
+		reader.Comma = co.Comma
+	}
+	header, err := reader.Read()
+	if err == io.EOF {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+		return nil
+	}
+	if err != nil {
+		return &ConversionError{Type: "CSV", Message: "failed to parse header", Cause: err}
+	}
+	header = append([]string(nil), header...)
+
+	var bindOpts []*Options
+	if co.Options != nil {
+		bindOpts = append(bindOpts, co.Options)
+	}
+	out := reflect.MakeSlice(slice.Type(), 0, 0)
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &ConversionError{Type: "CSV", Message: "failed to parse", Cause: err}
+		}
+		data, err := csvRowToMap(header, record, co.Columns)
+		if err != nil {
+			return &IndexError{Index: row, Cause: err}
+		}
+		elem := reflect.New(elemType)
+		if err := Bind(elem.Interface(), data, bindOpts...); err != nil {
+			return &IndexError{Index: row, Cause: err}
+		}
+		if slice.Type().Elem().Kind() == reflect.Ptr {
+			out = reflect.Append(out, elem)
+		} else {
+			out = reflect.Append(out, elem.Elem())
+		}
+	}
+	slice.Set(out)
+	return nil
+}
+
+// UnbindCSV writes source, a slice of structs (or of pointers to structs, or a pointer to such a slice), to w as CSV.
+// the header row lists one column per scalar field in declaration order, named as in BindCSV; slice, map, and Dynamic
+// fields have no column. absent values (nil pointers, unset Optionals) are written as empty cells.
+func UnbindCSV(source interface{}, w io.Writer, opts ...*CSVOptions) error {
+	co := csvOptions(opts...)
+	if source == nil {
+		return &ValidationError{Message: "nil source provided"}
+	}
+	slice := reflect.ValueOf(source)
+	if slice.Kind() == reflect.Ptr {
+		slice = slice.Elem()
+	}
+	if slice.Kind() != reflect.Slice || csvElemType(slice.Type().Elem()) == nil {
+		return &TypeMismatchError{Expected: "slice of structs", Actual: fmt.Sprintf("%T", source)}
+	}
+	columns := csvColumns(csvElemType(slice.Type().Elem()), nil, co.Options)
+
+	writer := csv.NewWriter(w)
+	if co.Comma != 0 {
+		writer.Comma = co.Comma
+	}
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.Join(column, ".")
+	}
+	if err := writer.Write(header); err != nil {
+		return &ConversionError{Message: "failed to write to writer", Cause: err}
+	}
+
+	var unbindOpts []*Options
+	if co.Options != nil {
+		unbindOpts = append(unbindOpts, co.Options)
+	}
+	for i := 0; i < slice.Len(); i++ {
+		elem := slice.Index(i)
+		record := make([]string, len(columns))
+		if elem.Kind() == reflect.Ptr && elem.IsNil() {
+			if err := writer.Write(record); err != nil {
+				return &ConversionError{Message: "failed to write to writer", Cause: err}
+			}
+			continue
+		}
+		data, err := Unbind(elem.Interface(), unbindOpts...)
+		if err != nil {
+			return &IndexError{Index: i, Cause: err}
+		}
+		for j, column := range columns {
+			cell, err := csvCell(data, column, co.Columns[header[j]])
+			if err != nil {
+				return &IndexError{Index: i, Cause: &ConversionError{Path: header[j], Message: "column converter failed", Cause: err}}
+			}
+			record[j] = cell
+		}
+		if err := writer.Write(record); err != nil {
+			return &ConversionError{Message: "failed to write to writer", Cause: err}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return &ConversionError{Message: "failed to write to writer", Cause: err}
+	}
+	return nil
+}
+
+func csvOptions(opts ...*CSVOptions) *CSVOptions {
+	if len(opts) > 0 && opts[0] != nil {
+		return opts[0]
+	}
+	return &CSVOptions{}
+}
+
+// csvSliceTarget validates that target is a pointer to a slice of structs, returning the slice and its struct type.
+func csvSliceTarget(target interface{}) (reflect.Value, reflect.Type, error) {
+	if target == nil {
+		return reflect.Value{}, nil, &ValidationError{Message: "nil target provided"}
+	}
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, nil, &TypeMismatchError{Expected: "non-nil pointer to slice of structs", Actual: fmt.Sprintf("%T", target)}
+	}
+	elemType := csvElemType(value.Elem().Type().Elem())
+	if elemType == nil {
+		return reflect.Value{}, nil, &TypeMismatchError{Expected: "non-nil pointer to slice of structs", Actual: fmt.Sprintf("%T", target)}
+	}
+	return value.Elem(), elemType, nil
+}
+
+// csvElemType returns the struct type held by slice elements of type t (a struct or pointer to struct), or nil.
+func csvElemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !isProvenanceStruct(t) {
+		return nil
+	}
+	return t
+}
+
+// csvRowToMap builds the data map for one row, nesting dotted column names.
+func csvRowToMap(header, record []string, converters map[string]Converter) (map[string]any, error) {
+	data := make(map[string]any)
+	for i, column := range header {
+		if i >= len(record) || record[i] == "" {
+			continue
+		}
+		var value any = record[i]
+		if converter, ok := converters[column]; ok {
+			converted, err := converter.FromRaw(record[i])
+			if err != nil {
+				return nil, &ConversionError{Path: column, Value: record[i], Message: "column converter failed", Cause: err}
+			}
+			value = converted
+		}
+		parts := strings.Split(column, ".")
+		current := data
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				current[part] = next
+			}
+			current = next
+		}
+		current[parts[len(parts)-1]] = value
+	}
+	return data, nil
+}
+
+// csvColumns lists the column paths for the scalar fields of structType, in declaration order.
+func csvColumns(structType reflect.Type, path []string, opt *Options) [][]string {
+	var columns [][]string
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if isInlined(field, meta.tag) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				columns = append(columns, csvColumns(embedded, path, opt)...)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra || tag.Raw {
+			continue
+		}
+		column := append(append([]string(nil), path...), meta.externalName(opt.naming()))
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if isFlagScalar(fieldType, opt) {
+			columns = append(columns, column)
+		} else if isProvenanceStruct(fieldType) {
+			columns = append(columns, csvColumns(fieldType, column, opt)...)
+		}
+	}
+	return columns
+}
+
+// csvCell renders the unbound value at column within data.
+func csvCell(data map[string]any, column []string, converter Converter) (string, error) {
+	var value any = data
+	for _, part := range column {
+		m, ok := value.(map[string]any)
+		if !ok {
+			value = nil
+			break
+		}
+		value = m[part]
+	}
+	if converter != nil {
+		converted, err := converter.ToRaw(value)
+		if err != nil {
+			return "", err
+		}
+		value = converted
+	}
+	if value == nil {
+		return "", nil
+	}
+	return fmt.Sprint(value), nil
+}
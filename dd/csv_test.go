@@ -0,0 +1,106 @@
+package dd
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type csvAddress struct {
+	City string
+	Zip  string
+}
+
+type csvRecord struct {
+	ID      int `dd:"id,+required"`
+	Name    string
+	Active  bool
+	Amount  float64
+	Joined  time.Time
+	Address csvAddress
+	Note    *string
+	Tags    []string
+}
+
+func TestBindCSV(t *testing.T) {
+	input := "id,name,active,amount,joined,address.city,unknown\n" +
+		"1,Alice,true,12.5,2024-01-02T03:04:05Z,Paris,x\n" +
+		"2,Bob,false,,,,\n"
+
+	var records []csvRecord
+	assert.NoError(t, BindCSV(&records, strings.NewReader(input)))
+	assert.Len(t, records, 2)
+	assert.Equal(t, csvRecord{
+		ID: 1, Name: "Alice", Active: true, Amount: 12.5,
+		Joined:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Address: csvAddress{City: "Paris"},
+	}, records[0])
+	assert.Equal(t, csvRecord{ID: 2, Name: "Bob"}, records[1])
+
+	var pointers []*csvRecord
+	assert.NoError(t, BindCSV(&pointers, strings.NewReader(input)))
+	assert.Equal(t, "Bob", pointers[1].Name)
+}
+
+func TestBindCSVErrors(t *testing.T) {
+	var records []csvRecord
+	err := BindCSV(&records, strings.NewReader("id,name\n1,a\n,b\n"))
+	var indexErr *IndexError
+	assert.ErrorAs(t, err, &indexErr)
+	assert.Equal(t, 1, indexErr.Index)
+
+	err = BindCSV(&records, strings.NewReader("id\nnope\n"))
+	assert.Error(t, err)
+
+	err = BindCSV(records, strings.NewReader("id\n1\n"))
+	assert.Error(t, err)
+
+	var ints []int
+	err = BindCSV(&ints, strings.NewReader("id\n1\n"))
+	assert.Error(t, err)
+}
+
+func TestUnbindCSVRoundTrip(t *testing.T) {
+	note := "hi"
+	records := []csvRecord{
+		{ID: 1, Name: "Alice, A.", Active: true, Amount: 12.5, Address: csvAddress{City: "Paris", Zip: "75001"}, Note: &note},
+		{ID: 2, Name: "Bob"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, UnbindCSV(records, &buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, "id,name,active,amount,joined,address.city,address.zip,note", lines[0])
+	assert.Equal(t, `1,"Alice, A.",true,12.5,0001-01-01T00:00:00Z,Paris,75001,hi`, lines[1])
+
+	var out []csvRecord
+	assert.NoError(t, BindCSV(&out, &buf))
+	assert.Equal(t, records, out)
+}
+
+type csvCents struct{}
+
+func (csvCents) FromRaw(raw interface{}) (interface{}, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(raw.(string), ",", ""), 64)
+}
+
+func (csvCents) ToRaw(value interface{}) (interface{}, error) {
+	return fmt.Sprintf("%.2f", value), nil
+}
+
+func TestCSVColumnConverters(t *testing.T) {
+	opts := &CSVOptions{Comma: ';', Columns: map[string]Converter{"amount": csvCents{}}}
+
+	var records []csvRecord
+	assert.NoError(t, BindCSV(&records, strings.NewReader("id;amount\n7;1,234.5\n"), opts))
+	assert.Equal(t, 1234.5, records[0].Amount)
+
+	var buf bytes.Buffer
+	assert.NoError(t, UnbindCSV(&records, &buf, opts))
+	assert.Contains(t, buf.String(), "7;;false;1234.50;")
+}
@@ -0,0 +1,193 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CycleStep is one reference within a Cycle: Source refers, through its Field, to the Source of the next step (or of
+// the first step, for the last).
+type CycleStep struct {
+	// Source is the object holding the reference: an Identifiable object, or a struct referred to by a path reference.
+	Source any
+	// Id is Source's Id, or empty when Source is not Identifiable.
+	Id string
+	// Field is the path of the Pointer within Source, as in ReferenceSite.
+	Field string
+}
+
+// Cycle is a loop of resolved references, as found by FindCycles.
+type Cycle []CycleStep
+
+// String formats the cycle as in "User:alice (Manager) -> User:bob (Manager) -> User:alice".
+func (c Cycle) String() string {
+	var b strings.Builder
+	for _, step := range c {
+		fmt.Fprintf(&b, "%s (%s) -> ", cycleStepName(step), step.Field)
+	}
+	if len(c) > 0 {
+		b.WriteString(cycleStepName(c[0]))
+	}
+	return b.String()
+}
+
+func cycleStepName(step CycleStep) string {
+	name := typeShortName(reflect.TypeOf(step.Source).Elem())
+	if step.Id != "" {
+		return name + ":" + step.Id
+	}
+	return name
+}
+
+// FindCycles reports the cycles among the resolved Pointers within roots (pointers to structs, linked with Link), for
+// data that must be acyclic. a reference belongs to the nearest Identifiable object enclosing it, so an object
+// holding a Pointer to itself is a cycle of one step; objects nested within an object are not part of it, nor
+// referred to by it. unresolved Pointers are not followed, nor are Pointers to values (Pointer[User] rather than
+// Pointer[*User]), which are copies rather than references.
+//
+// one cycle is reported for each reference that closes a loop, in the order the objects are found in roots, so a
+// graph without cycles reports none; objects taking part in several loops may appear in more than one cycle.
+func FindCycles(roots ...interface{}) ([]Cycle, error) {
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no targets provided")
+	}
+	f := &cycleFinder{state: make(map[any]int), index: make(map[any]int)}
+	var nodes []reflect.Value
+	for i, root := range roots {
+		elem, err := validateTarget(root)
+		if err != nil {
+			return nil, fmt.Errorf("target at index %d: %w", i, err)
+		}
+		nodes = append(nodes, elem.Addr())
+		identifiablesWithin(elem, &nodes)
+	}
+	for _, node := range nodes {
+		f.visit(node)
+	}
+	return f.cycles, nil
+}
+
+// identifiablesWithin appends the Identifiable objects within value to nodes, walking the same fields as Link but not
+// following Pointers.
+func identifiablesWithin(value reflect.Value, nodes *[]reflect.Value) {
+	switch value.Kind() {
+	case reflect.Struct:
+		if isPointerType(value.Type()) {
+			return
+		}
+		if _, ok := objectId(value); ok && value.CanAddr() {
+			*nodes = append(*nodes, value.Addr())
+		}
+		for _, meta := range cachedFields(value.Type()) {
+			field := meta.field
+			if field.PkgPath != "" || meta.tag.Skip {
+				continue
+			}
+			identifiablesWithin(value.Field(meta.index), nodes)
+		}
+
+	case reflect.Ptr:
+		if !value.IsNil() {
+			identifiablesWithin(value.Elem(), nodes)
+		}
+
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			identifiablesWithin(value.Index(i), nodes)
+		}
+	}
+}
+
+// cycleEdge is a resolved reference from a node, through field, to target.
+type cycleEdge struct {
+	field  string
+	target reflect.Value
+}
+
+// cycleFinder finds cycles by depth-first search, recording a cycle for each reference back to an object on the
+// current path.
+type cycleFinder struct {
+	state  map[any]int // 1 while an object is on the current path, 2 once all its references are explored
+	stack  []CycleStep
+	index  map[any]int // the position of each object on the current path
+	cycles []Cycle
+}
+
+func (f *cycleFinder) visit(node reflect.Value) {
+	key := node.Interface()
+	if f.state[key] != 0 {
+		return
+	}
+	f.state[key] = 1
+	f.index[key] = len(f.stack)
+	step := CycleStep{Source: key}
+	step.Id, _ = objectId(node)
+	f.stack = append(f.stack, step)
+
+	var edges []cycleEdge
+	cycleEdges(node.Elem(), "", true, &edges)
+	for _, edge := range edges {
+		f.stack[len(f.stack)-1].Field = edge.field
+		target := edge.target.Interface()
+		switch f.state[target] {
+		case 0:
+			f.visit(edge.target)
+		case 1:
+			cycle := make(Cycle, len(f.stack)-f.index[target])
+			copy(cycle, f.stack[f.index[target]:])
+			f.cycles = append(f.cycles, cycle)
+		}
+	}
+
+	f.stack = f.stack[:len(f.stack)-1]
+	delete(f.index, key)
+	f.state[key] = 2
+}
+
+// cycleEdges appends the resolved references within value, at path, to edges, stopping at nested Identifiable
+// objects. top is true for the node itself.
+func cycleEdges(value reflect.Value, path string, top bool, edges *[]cycleEdge) {
+	switch value.Kind() {
+	case reflect.Struct:
+		if isPointerType(value.Type()) {
+			resolved := value.FieldByName("Resolved")
+			if resolved.Kind() == reflect.Interface && !resolved.IsNil() {
+				resolved = resolved.Elem()
+			}
+			if resolved.Kind() == reflect.Ptr && !resolved.IsNil() && resolved.Elem().Kind() == reflect.Struct {
+				*edges = append(*edges, cycleEdge{field: path, target: resolved})
+			}
+			return
+		}
+		if _, ok := objectId(value); ok && !top {
+			return
+		}
+		for _, meta := range cachedFields(value.Type()) {
+			field := meta.field
+			if field.PkgPath != "" || meta.tag.Skip {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			cycleEdges(value.Field(meta.index), fieldPath, false, edges)
+		}
+
+	case reflect.Ptr:
+		if !value.IsNil() {
+			cycleEdges(value.Elem(), path, false, edges)
+		}
+
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			cycleEdges(value.Index(i), fmt.Sprintf("%s[%d]", path, i), false, edges)
+		}
+
+	case reflect.Map:
+		for _, key := range sortedMapKeys(value) {
+			cycleEdges(value.MapIndex(key), fmt.Sprintf("%s[%v]", path, key), false, edges)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cycleTask struct {
+	Id        string                 `dd:"id"`
+	DependsOn []*Pointer[*cycleTask] `dd:"depends_on,omitempty"`
+}
+
+func (t *cycleTask) GetId() string { return t.Id }
+
+type cyclePlan struct {
+	Tasks []*cycleTask         `dd:"tasks"`
+	Start *Pointer[*cycleTask] `dd:"start,omitempty"`
+}
+
+func TestFindCycles(t *testing.T) {
+	plan, err := New[cyclePlan](map[string]any{
+		"tasks": []any{
+			map[string]any{"id": "build", "depends_on": []any{map[string]any{"$ref": "fetch"}}},
+			map[string]any{"id": "fetch", "depends_on": []any{map[string]any{"$ref": "configure"}}},
+			map[string]any{"id": "configure", "depends_on": []any{map[string]any{"$ref": "build"}}},
+			map[string]any{"id": "lint", "depends_on": []any{map[string]any{"$ref": "fetch"}, map[string]any{"$ref": "lint"}}},
+		},
+		"start": map[string]any{"$ref": "build"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, Link(plan))
+
+	cycles, err := FindCycles(plan)
+	assert.NoError(t, err)
+	if assert.Len(t, cycles, 2) {
+		assert.Equal(t, Cycle{
+			{Source: plan.Tasks[0], Id: "build", Field: "DependsOn[0]"},
+			{Source: plan.Tasks[1], Id: "fetch", Field: "DependsOn[0]"},
+			{Source: plan.Tasks[2], Id: "configure", Field: "DependsOn[0]"},
+		}, cycles[0])
+		assert.Equal(t, "cycleTask:build (DependsOn[0]) -> cycleTask:fetch (DependsOn[0]) -> cycleTask:configure (DependsOn[0]) -> cycleTask:build", cycles[0].String())
+		assert.Equal(t, Cycle{{Source: plan.Tasks[3], Id: "lint", Field: "DependsOn[1]"}}, cycles[1])
+	}
+
+	// breaking the loops leaves none; unresolved pointers are not followed
+	plan.Tasks[2].DependsOn = nil
+	plan.Tasks[3].DependsOn[1] = &Pointer[*cycleTask]{Ref: "lint"}
+	cycles, err = FindCycles(plan)
+	assert.NoError(t, err)
+	assert.Empty(t, cycles)
+
+	_, err = FindCycles(*plan)
+	assert.Error(t, err)
+}
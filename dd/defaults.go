@@ -0,0 +1,21 @@
+package dd
+
+import "sync/atomic"
+
+var defaultOptions atomic.Pointer[Options]
+
+// SetDefaultOptions registers the Options used by Bind, New, Merge, Unbind, and the other entry points when called
+// without options (or with nil), so converter packs, naming strategies, and similar settings configured once at program
+// start apply at every call site. Inspect takes its NamingStrategy from them when InspectOptions does not set one.
+// passing nil clears the defaults; passing &Options{} explicitly opts a single call out of them.
+//
+// the options are shared, not copied: configure them fully before registering them, typically from main or an init
+// function, and do not modify them afterwards.
+func SetDefaultOptions(opts *Options) {
+	defaultOptions.Store(opts)
+}
+
+// DefaultOptions returns the Options registered with SetDefaultOptions, or nil.
+func DefaultOptions() *Options {
+	return defaultOptions.Load()
+}
@@ -0,0 +1,59 @@
+package dd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type defaultsConfig struct {
+	MaxConns int
+	Level    upperString
+}
+
+type upperString string
+
+type upperConverter struct{}
+
+func (upperConverter) FromRaw(raw interface{}) (interface{}, error) {
+	return upperString(strings.ToUpper(raw.(string))), nil
+}
+
+func (upperConverter) ToRaw(value interface{}) (interface{}, error) {
+	return strings.ToLower(string(value.(upperString))), nil
+}
+
+func TestSetDefaultOptions(t *testing.T) {
+	SetDefaultOptions(&Options{
+		NamingStrategy: CamelCase,
+		Converters: map[reflect.Type]Converter{
+			reflect.TypeOf(upperString("")): upperConverter{},
+		},
+	})
+	defer SetDefaultOptions(nil)
+
+	data := map[string]any{"maxConns": 5, "level": "warn"}
+	cfg, err := New[defaultsConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, &defaultsConfig{MaxConns: 5, Level: "WARN"}, cfg)
+
+	// an explicit nil also takes the defaults
+	out, err := Unbind(cfg, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, data, out)
+
+	assert.Contains(t, MustInspect(cfg), "maxConns")
+
+	// explicit options replace the defaults entirely
+	cfg, err = New[defaultsConfig](map[string]any{"max_conns": 7, "level": "info"}, &Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, &defaultsConfig{MaxConns: 7, Level: "info"}, cfg)
+
+	SetDefaultOptions(nil)
+	assert.Nil(t, DefaultOptions())
+	out, err = Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, out, "max_conns")
+}
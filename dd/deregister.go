@@ -0,0 +1,102 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Deregister removes the Identifiable objects within targets from the Linker's registry, so that references to them no
+// longer resolve, as when objects are deleted from a long-lived graph. the Pointers that Link or ResolveReferences
+// resolved to them are left dangling: each is reset to unresolved, keeping its Ref, and reported in the returned
+// sites, so that it can be removed, or re-linked with ResolveReferences once a replacement is registered. references
+// held by the removed objects are forgotten by ReferencesTo. lazily resolved Pointers, and Pointers held by value in
+// maps, are left as they are.
+func (l *Linker) Deregister(targets ...interface{}) ([]ReferenceSite, error) {
+	if len(targets) == 0 {
+		return nil, &ValidationError{Message: "no targets provided"}
+	}
+	var objects []reflect.Value
+	for i, target := range targets {
+		elem, err := validateTarget(target)
+		if err != nil {
+			return nil, fmt.Errorf("target at index %d: %w", i, err)
+		}
+		identifiablesWithin(elem, &objects)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.deregister(objects), nil
+}
+
+// DeregisterID removes the object of type t (User or *User) with the given Id from the Linker's registry, leaving the
+// Pointers resolved to it dangling; see Deregister. nothing is removed when no such object is registered.
+func (l *Linker) DeregisterID(t reflect.Type, id string) []ReferenceSite {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	target, found := l.cache[t.String()+":"+id]
+	if !found {
+		return nil
+	}
+	return l.deregister([]reflect.Value{target})
+}
+
+// deregister removes objects, pointers to Identifiable objects, from the registry and unresolves the Pointers resolved
+// to them, returning their sites.
+func (l *Linker) deregister(objects []reflect.Value) []ReferenceSite {
+	removed := make(map[any]bool, len(objects))
+	var dangling []ReferenceSite
+	for _, object := range objects {
+		key := object.Interface()
+		removed[key] = true
+		if l.cache != nil {
+			id, _ := objectId(object)
+			unregisterIdentifiable(l.cache, object, id)
+		}
+		for _, site := range l.sites[key] {
+			resolved := site.pointer.FieldByName("Resolved")
+			resolved.Set(reflect.Zero(resolved.Type()))
+			dangling = append(dangling, site.ReferenceSite)
+		}
+		l.forgetSites(key, func(linkSite) bool { return true })
+	}
+
+	// forget the references held by the removed objects
+	for target := range l.sites {
+		l.forgetSites(target, func(site linkSite) bool { return removed[site.Source] })
+	}
+	return dangling
+}
+
+// unregisterIdentifiable reverses registerIdentifiable for target, when it is the object registered under its Id. a
+// short name left ambiguous is re-registered from the remaining qualified names.
+func unregisterIdentifiable(registry map[string]reflect.Value, target reflect.Value, id string) {
+	t := target.Type().Elem()
+	key := t.String() + ":" + id
+	if existing, found := registry[key]; !found || existing.Pointer() != target.Pointer() {
+		return
+	}
+	delete(registry, key)
+	if t.Name() == "" {
+		return
+	}
+	short := typeShortName(t) + ":" + id
+	existing, found := registry[short]
+	if !found || (existing.IsValid() && existing.Pointer() != target.Pointer()) {
+		return
+	}
+	delete(registry, short)
+	if !existing.IsValid() {
+		var remaining []reflect.Value
+		for key, value := range registry {
+			if value.IsValid() && typeShortName(value.Type().Elem()) == t.Name() && key == value.Type().Elem().String()+":"+id {
+				remaining = append(remaining, value)
+			}
+		}
+		for _, value := range remaining {
+			registerIdentifiable(registry, value, id)
+		}
+	}
+}
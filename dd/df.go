@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -14,7 +15,8 @@ type Dynamic interface {
 	ToMap() (map[string]any, error)
 }
 
-// Identifiable objects can participate in pointer references by providing a unique Id.
+// Identifiable objects can participate in pointer references by providing a unique Id. types that cannot implement
+// it may tag a field +id instead.
 type Identifiable interface {
 	GetId() string
 }
@@ -29,6 +31,38 @@ type Unmarshaler interface {
 	UnmarshalDd(data map[string]any) error
 }
 
+// Validator allows a type to check itself once binding has populated it. Bind, New, and Merge call Validate on every
+// struct after its fields (including nested structs, which are validated first) are bound, so cross-field rules can be
+// enforced without a full UnmarshalDd override.
+type Validator interface {
+	Validate() error
+}
+
+// BeforeBinder allows a type to inspect or rewrite its data before dd binds it, e.g. to migrate renamed keys. the map
+// is a shallow copy of the struct's object, so keys may be added, renamed, or removed without affecting the caller's
+// data; nested maps are shared.
+type BeforeBinder interface {
+	BeforeBind(data map[string]any) error
+}
+
+// AfterBinder allows a type to derive state once dd has bound its fields, e.g. to compile a pattern or fill computed
+// defaults. AfterBind runs before Validate.
+type AfterBinder interface {
+	AfterBind() error
+}
+
+// BeforeUnbinder allows a type to prepare itself before dd unbinds its fields, e.g. to sync derived state back into
+// exported fields.
+type BeforeUnbinder interface {
+	BeforeUnbind() error
+}
+
+// AfterUnbinder allows a type to adjust the map dd produced from its fields, e.g. to add computed keys or drop
+// defaults.
+type AfterUnbinder interface {
+	AfterUnbind(data map[string]any) error
+}
+
 // Converter defines a bidirectional type conversion interface for custom field types.
 // it allows users to define how their custom types should be converted to/from the raw data.
 type Converter interface {
@@ -43,33 +77,91 @@ type Converter interface {
 
 // DdTag holds the parsed values from a `dd` struct tag.
 type DdTag struct {
-	Name       string // external field name override, empty means use default
-	Required   bool   // true if field is required during binding
-	Secret     bool   // true if field contains sensitive data
-	Skip       bool   // true if field should be skipped entirely
-	MatchValue string // expected value that must match during binding, empty means no constraint
-	HasMatch   bool   // true if a match constraint is specified
-	Extra      bool   // true if field should capture unmatched keys
-	OmitEmpty  bool   // true if field should be omitted when zero during unbinding
+	Name       string            // external field name override, empty means use default
+	Required   bool              // true if field is required during binding
+	Secret     bool              // true if field contains sensitive data
+	Skip       bool              // true if field should be skipped entirely
+	MatchValue string            // expected value that must match during binding, empty means no constraint
+	HasMatch   bool              // true if a match constraint is specified
+	Extra      bool              // true if field should capture unmatched keys
+	OmitEmpty  bool              // true if field should be omitted when zero during unbinding
+	Raw        bool              // true if field should capture its subtree as an unparsed node
+	Template   bool              // true if field should be rendered as a Go template by RenderTemplates
+	MergeKey   string            // external name of the element field identifying list items during StrategicMerge
+	Format     string            // wire format of a time.Time field: a time.Parse layout, "unix", or "unixmilli"; empty means RFC3339
+	Merge      string            // slice merge strategy for this field during Merge ("replace", "append", or "union"), empty means Options.SliceMergeStrategy
+	Doc        string            // human-readable description of the field, used by generated documentation and templates
+	Min        string            // minimum value (or length, for strings, slices, and maps), empty means no constraint
+	Max        string            // maximum value (or length, for strings, slices, and maps), empty means no constraint
+	Regex      string            // regular expression string values must match, empty means no constraint
+	OneOf      []string          // allowed values, nil means no constraint
+	Enum       bool              // true if OneOf was declared with +enum rather than +oneof
+	Inline     bool              // true if a nested struct field's keys are read from and written to its parent's namespace
+	Keyed      bool              // true if the dynamic type of each slice element or map value is taken from its map key
+	Weak       bool              // true if Link should leave the field's Pointers unresolved when their targets are missing
+	Id         bool              // true if the field holds the struct's Id for Pointer references, in place of Identifiable
+	PlainRef   bool              // true if the field's Pointers should be unbound as plain reference strings, as with Options.PlainRefs
+	AnyOf      string            // name of a field group of which at least one field must be present during binding
+	Exclusive  string            // name of a field group of which at most one field may be present during binding
+	Compare    []FieldComparison // comparisons against other fields of the struct, checked once it is bound
+}
+
+// FieldComparison is a cross-field constraint from a +eqfield, +nefield, +gtfield, +gtefield, +ltfield, or +ltefield
+// tag token: the tagged field's value must compare to the named field's value as Op requires.
+type FieldComparison struct {
+	Op    string // "eqfield", "nefield", "gtfield", "gtefield", "ltfield", or "ltefield"
+	Field string // Go name of the field compared against
 }
 
 // parseDdTag parses the `dd` struct tag on a field.
 //
-// tag format: dd:"[name][,+required][,+secret][,+extra][,+omitempty][,+match=\"expected_value\"|+match=expected_value]"
+// tag format: dd:"[name][,+required][,+secret][,+extra][,+omitempty][,+raw][,+template][,+inline][,+keyed][,+weak][,+id][,+plainref][,+match=\"expected_value\"|+match=expected_value][,+doc=\"description\"][,+mergekey=name][,+merge=append][,+format=layout][,+min=n][,+max=n][,+regex=pattern][,+oneof=a|b|+enum=a|b][,+requires-one-of=group][,+exclusive=group][,+eqfield=Field|+gtfield=Field|...]"
 //
 // special cases:
 // - "-"          → skip the field entirely (skip=true)
 // - missing/empty → no override (default name, required=false, secret=false, no match constraint)
 //
 // rules:
-// - tokens are comma-separated; surrounding whitespace is ignored.
-// - if the first token is not "+required", "+secret", "+extra", "+omitempty", or "+match=...", it is taken as the external field name.
-// - the presence of a "+required" token (any position) sets required=true.
-// - the presence of a "+secret" token (any position) sets secret=true.
-// - the presence of a "+extra" token (any position) sets extra=true; the field must be map[string]any and will capture unmatched keys.
-// - the presence of a "+omitempty" token (any position) sets omitEmpty=true; the field will be omitted during unbinding if it has a zero value.
-// - a "+match=\"value\"" or "+match=value" token sets a value constraint that must be satisfied during binding.
-// - unrecognized tokens are ignored.
+//   - tokens are comma-separated; surrounding whitespace is ignored. commas inside double quotes do not split tokens.
+//   - if the first token does not start with "+", it is taken as the external field name.
+//   - the presence of a "+required" token (any position) sets required=true.
+//   - the presence of a "+secret" token (any position) sets secret=true.
+//   - the presence of a "+extra" token (any position) sets extra=true; the field must be map[string]any and will capture unmatched keys.
+//   - the presence of a "+omitempty" token (any position) sets omitEmpty=true; the field will be omitted during unbinding if it has a zero value.
+//   - the presence of a "+raw" token (any position) sets raw=true; the field must be yaml.Node, *yaml.Node, or json.RawMessage and will capture its subtree unparsed.
+//   - the presence of a "+template" token (any position) sets template=true; the field's string contents are rendered by RenderTemplates.
+//   - the presence of a "+inline" token (any position) sets inline=true; a struct (or pointer to struct) field's keys
+//     are then read from and written to its parent's namespace, as for embedded structs.
+//   - the presence of a "+keyed" token (any position) sets keyed=true; a slice or map of Dynamic (or registered
+//     interface) values then takes each value's type from its map key, e.g. {"email": {...}, "slack": {...}}.
+//   - the presence of a "+weak" token (any position) sets weak=true; Link then leaves the field's Pointers (or those
+//     in its slice) unresolved, rather than failing, when their targets are missing.
+//   - the presence of a "+id" token (any position) sets id=true; the field (a string or integer) then identifies the
+//     struct for Pointer references, for types that cannot implement Identifiable.
+//   - the presence of a "+plainref" token (any position) sets plainref=true; the field's Pointers (or those in its
+//     slice or map) are then unbound as plain reference strings, e.g. "author": "alice".
+//   - a "+match=\"value\"" or "+match=value" token sets a value constraint that must be satisfied during binding.
+//   - a "+doc=\"description\"" or "+doc=description" token sets the field's description.
+//   - a "+mergekey=name" token sets the element key used to merge list items during StrategicMerge.
+//   - a "+merge=replace", "+merge=append", or "+merge=union" token sets how Merge combines the field's slice with the
+//     existing one, overriding Options.SliceMergeStrategy.
+//   - a "+format=layout" token sets the wire format of a time.Time (or *time.Time) field for Bind and Unbind: a
+//     time.Parse layout such as "+format=2006-01-02", or "+format=unix" / "+format=unixmilli" for integer timestamps.
+//   - "+min=n" and "+max=n" tokens bound numeric values, or the length of strings, slices, and maps; duration fields
+//     accept durations (e.g. "+min=1s").
+//   - a "+regex=\"pattern\"" or "+regex=pattern" token requires string values to match the pattern; quote patterns
+//     containing commas.
+//   - a "+oneof=a|b|c" token restricts values to the listed alternatives; "+enum=a|b|c" is a synonym. when a value is
+//     rejected, the error suggests the closest alternative.
+//   - a "+requires-one-of=group" token places the field in a named group of which at least one field must be present
+//     (and not null) in the bound data, e.g. either password or password_file.
+//   - a "+exclusive=group" token places the field in a named group of which at most one field may be present. a
+//     field in both kinds of group under the same name makes exactly one of the group required.
+//   - "+eqfield=Field", "+nefield=Field", "+gtfield=Field", "+gtefield=Field", "+ltfield=Field", and
+//     "+ltefield=Field" tokens compare the field, when present in the bound data, with another field of the same
+//     struct (named by its Go name) once the struct is populated. numbers, strings, durations, and times are ordered;
+//     other types support only +eqfield and +nefield.
+//   - unrecognized tokens are ignored.
 func parseDdTag(sf reflect.StructField) DdTag {
 	tag := sf.Tag.Get("dd")
 	if tag == "-" {
@@ -80,7 +172,7 @@ func parseDdTag(sf reflect.StructField) DdTag {
 	}
 
 	var result DdTag
-	parts := strings.Split(tag, ",")
+	parts := splitTag(tag)
 	for i, p := range parts {
 		p = strings.TrimSpace(p)
 		if p == "" {
@@ -89,41 +181,169 @@ func parseDdTag(sf reflect.StructField) DdTag {
 
 		// check for +match="value" or +match=value pattern
 		if strings.HasPrefix(p, "+match=") {
-			matchPart := strings.TrimPrefix(p, "+match=")
-			if len(matchPart) >= 2 && matchPart[0] == '"' && matchPart[len(matchPart)-1] == '"' {
-				// properly quoted value: remove quotes
-				result.MatchValue = matchPart[1 : len(matchPart)-1]
-				result.HasMatch = true
-			} else if len(matchPart) > 0 && !strings.Contains(matchPart, "\"") {
-				// unquoted value (no quotes at all): use as-is
-				result.MatchValue = matchPart
+			if v, ok := tagValue(strings.TrimPrefix(p, "+match=")); ok {
+				result.MatchValue = v
 				result.HasMatch = true
 			}
 			// malformed quoted values (incomplete quotes) are ignored
 			continue
 		}
 
-		if i == 0 && p != "+required" && p != "+secret" && p != "+extra" && p != "+omitempty" && !strings.HasPrefix(p, "+match=") {
-			// first token as name unless it's literally "+required", "+secret", "+extra", "+omitempty", or "+match=..."
+		if strings.HasPrefix(p, "+doc=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+doc=")); ok {
+				result.Doc = v
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+min=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+min=")); ok {
+				result.Min = v
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+max=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+max=")); ok {
+				result.Max = v
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+regex=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+regex=")); ok {
+				result.Regex = v
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+oneof=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+oneof=")); ok {
+				result.OneOf = strings.Split(v, "|")
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+enum=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+enum=")); ok {
+				result.OneOf = strings.Split(v, "|")
+				result.Enum = true
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+requires-one-of=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+requires-one-of=")); ok {
+				result.AnyOf = v
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+exclusive=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+exclusive=")); ok {
+				result.Exclusive = v
+			}
+			continue
+		}
+
+		if op, ok := comparisonOp(p); ok {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+"+op+"=")); ok {
+				result.Compare = append(result.Compare, FieldComparison{Op: op, Field: v})
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+mergekey=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+mergekey=")); ok {
+				result.MergeKey = v
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+format=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+format=")); ok {
+				result.Format = v
+			}
+			continue
+		}
+
+		if strings.HasPrefix(p, "+merge=") {
+			if v, ok := tagValue(strings.TrimPrefix(p, "+merge=")); ok {
+				result.Merge = v
+			}
+			continue
+		}
+
+		if i == 0 && !strings.HasPrefix(p, "+") {
+			// first token as name unless it's a flag
 			result.Name = p
 			continue
 		}
-		if p == "+required" {
+		switch p {
+		case "+required":
 			result.Required = true
-		}
-		if p == "+secret" {
+		case "+secret":
 			result.Secret = true
-		}
-		if p == "+extra" {
+		case "+extra":
 			result.Extra = true
-		}
-		if p == "+omitempty" {
+		case "+omitempty":
 			result.OmitEmpty = true
+		case "+raw":
+			result.Raw = true
+		case "+template":
+			result.Template = true
+		case "+inline":
+			result.Inline = true
+		case "+keyed":
+			result.Keyed = true
+		case "+weak":
+			result.Weak = true
+		case "+id":
+			result.Id = true
+		case "+plainref":
+			result.PlainRef = true
 		}
 	}
 	return result
 }
 
+// ParseTag parses the `dd` struct tag of a field, following the rules described for Bind and Unbind. it is exported for
+// tools, such as ddgen, that need to interpret dd tags the same way dd does.
+func ParseTag(sf reflect.StructField) DdTag {
+	return parseDdTag(sf)
+}
+
+// splitTag splits a tag on commas, ignoring commas that appear inside double-quoted values.
+func splitTag(tag string) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, tag[start:])
+}
+
+// tagValue extracts the value of a "+flag=value" token. properly quoted values have their quotes removed; unquoted
+// values are used as-is. returns false for malformed (partially quoted) values.
+func tagValue(v string) (string, bool) {
+	if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+		return v[1 : len(v)-1], true
+	}
+	if len(v) > 0 && !strings.Contains(v, "\"") {
+		return v, true
+	}
+	return "", false
+}
+
 func toSnakeCase(in string) string {
 	if in == "" {
 		return ""
@@ -167,9 +387,39 @@ const (
 )
 
 var dynamicInterfaceType = reflect.TypeOf((*Dynamic)(nil)).Elem()
-var identifiableInterfaceType = reflect.TypeOf((*Identifiable)(nil)).Elem()
 var marshalerInterfaceType = reflect.TypeOf((*Marshaler)(nil)).Elem()
 var unmarshalerInterfaceType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+var validatorInterfaceType = reflect.TypeOf((*Validator)(nil)).Elem()
+var beforeBinderInterfaceType = reflect.TypeOf((*BeforeBinder)(nil)).Elem()
+var afterBinderInterfaceType = reflect.TypeOf((*AfterBinder)(nil)).Elem()
+var beforeUnbinderInterfaceType = reflect.TypeOf((*BeforeUnbinder)(nil)).Elem()
+var afterUnbinderInterfaceType = reflect.TypeOf((*AfterUnbinder)(nil)).Elem()
+
+// isScalarStruct reports whether t is a struct type that dd treats as a single scalar value (bound from and unbound to
+// a string or number) rather than as a nested object.
+func isScalarStruct(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(time.Time{}), bigIntType, bigFloatType, bigRatType, netipAddrType, netipAddrPortType, netipPrefixType, rawType:
+		return true
+	}
+	return t.Kind() == reflect.Struct && isTextType(t)
+}
+
+// isInlined reports whether the keys of field are flattened into its parent's namespace: embedded structs, and
+// exported struct (or pointer to struct) fields tagged `+inline`.
+func isInlined(field reflect.StructField, tag DdTag) bool {
+	if field.Anonymous {
+		return true
+	}
+	if !tag.Inline || field.PkgPath != "" {
+		return false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isScalarStruct(t)
+}
 
 // validateTarget validates that the target is a non-nil pointer to a struct.
 // returns the struct element and any validation error.
@@ -188,11 +438,11 @@ func validateTarget(target interface{}) (reflect.Value, error) {
 	return elem, nil
 }
 
-// getOptions extracts and validates options from variadic parameters.
-// returns the options and any validation error.
+// getOptions extracts and validates options from variadic parameters, falling back to DefaultOptions when none are
+// given. returns the options and any validation error.
 func getOptions(opts ...*Options) (*Options, error) {
-	if len(opts) == 0 {
-		return nil, nil
+	if len(opts) == 0 || (len(opts) == 1 && opts[0] == nil) {
+		return DefaultOptions(), nil
 	}
 	if len(opts) == 1 {
 		return opts[0], nil
@@ -200,6 +450,15 @@ func getOptions(opts ...*Options) (*Options, error) {
 	return nil, &ValidationError{Message: fmt.Sprintf("only one option allowed, got %d", len(opts))}
 }
 
+// hasConverter reports whether opt registers a converter for t.
+func hasConverter(t reflect.Type, opt *Options) bool {
+	if opt == nil || opt.Converters == nil {
+		return false
+	}
+	_, ok := opt.Converters[t]
+	return ok
+}
+
 // tryCustomConverter attempts to use a custom converter for the given field and raw value.
 // returns (convertedValue, wasConverted, error).
 func tryCustomConverter(fieldType reflect.Type, raw interface{}, opt *Options, forBinding bool) (interface{}, bool, error) {
@@ -215,8 +474,13 @@ func tryCustomConverter(fieldType reflect.Type, raw interface{}, opt *Options, f
 	var result interface{}
 	var err error
 
+	ctxConverter, withCtx := converter.(ConverterCtx)
 	if forBinding {
-		result, err = converter.FromRaw(raw)
+		if withCtx {
+			result, err = ctxConverter.FromRawCtx(opt.context(), raw)
+		} else {
+			result, err = converter.FromRaw(raw)
+		}
 		if err != nil {
 			return nil, true, &ConversionError{Message: "custom converter failed", Cause: err}
 		}
@@ -226,7 +490,11 @@ func tryCustomConverter(fieldType reflect.Type, raw interface{}, opt *Options, f
 			return nil, true, &TypeMismatchError{Expected: fieldType.String(), Actual: fmt.Sprintf("%T", result)}
 		}
 	} else {
-		result, err = converter.ToRaw(raw)
+		if withCtx {
+			result, err = ctxConverter.ToRawCtx(opt.context(), raw)
+		} else {
+			result, err = converter.ToRaw(raw)
+		}
 		if err != nil {
 			return nil, true, &ConversionError{Message: "custom converter failed", Cause: err}
 		}
@@ -0,0 +1,113 @@
+package dd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DOTOptions configures Linker.ExportDOT.
+type DOTOptions struct {
+	// Name is the name of the graph, "links" by default.
+	Name string
+	// Label returns the label of an object's node, by default its type and Id, as in "User:alice" (or its type alone,
+	// for objects without an Id).
+	Label func(object any) string
+}
+
+// ExportDOT writes the linked object graph to w in the Graphviz DOT language, for visualizing with dot(1) and similar
+// tools: each object is a node, and each reference resolved by the most recent Link (and by ResolveReferences since)
+// is an edge from the object holding it to its target, labelled with the Pointer's field, as in ReferencesTo. objects
+// registered with Register, or cached with EnableCaching, are included whether or not they are referred to. nodes and
+// edges are written in order of their labels, so the output of an unchanged graph is stable.
+func (l *Linker) ExportDOT(w io.Writer, opts ...DOTOptions) error {
+	var options DOTOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Name == "" {
+		options.Name = "links"
+	}
+	if options.Label == nil {
+		options.Label = dotLabel
+	}
+
+	type dotEdge struct {
+		source, target any
+		field          string
+	}
+	labels := make(map[any]string)
+	addNode := func(object any) {
+		if _, found := labels[object]; !found {
+			labels[object] = options.Label(object)
+		}
+	}
+	var edges []dotEdge
+
+	l.mu.RLock()
+	for _, value := range l.cache {
+		if value.IsValid() {
+			addNode(value.Interface())
+		}
+	}
+	for target, sites := range l.sites {
+		addNode(target)
+		for _, site := range sites {
+			addNode(site.Source)
+			edges = append(edges, dotEdge{source: site.Source, target: target, field: site.Field})
+		}
+	}
+	l.mu.RUnlock()
+
+	nodes := make([]any, 0, len(labels))
+	for object := range labels {
+		nodes = append(nodes, object)
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return labels[nodes[i]] < labels[nodes[j]] })
+	index := make(map[any]int, len(nodes))
+	for i, object := range nodes {
+		index[object] = i
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.source != b.source {
+			return index[a.source] < index[b.source]
+		}
+		if a.field != b.field {
+			return a.field < b.field
+		}
+		return index[a.target] < index[b.target]
+	})
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "digraph %s {\n", dotQuote(options.Name))
+	for _, object := range nodes {
+		fmt.Fprintf(out, "  n%d [label=%s];\n", index[object], dotQuote(labels[object]))
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(out, "  n%d -> n%d [label=%s];\n", index[edge.source], index[edge.target], dotQuote(edge.field))
+	}
+	out.WriteString("}\n")
+	return out.Flush()
+}
+
+// dotLabel returns the default label of object's node: its type and Id, or its type alone.
+func dotLabel(object any) string {
+	value := reflect.ValueOf(object)
+	t := value.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if id, ok := objectId(value); ok {
+		return typeShortName(t) + ":" + id
+	}
+	return typeShortName(t)
+}
+
+// dotQuote returns s as a quoted DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,58 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkerExportDOT(t *testing.T) {
+	plan, err := New[cyclePlan](map[string]any{
+		"tasks": []any{
+			map[string]any{"id": "build", "depends_on": []any{map[string]any{"$ref": "fetch"}}},
+			map[string]any{"id": "fetch", "depends_on": []any{map[string]any{"$ref": "configure"}}},
+			map[string]any{"id": "configure", "depends_on": []any{map[string]any{"$ref": "build"}}},
+			map[string]any{"id": "lint", "depends_on": []any{map[string]any{"$ref": "fetch"}, map[string]any{"$ref": "lint"}}},
+			map[string]any{"id": "idle"},
+		},
+		"start": map[string]any{"$ref": "build"},
+	})
+	assert.NoError(t, err)
+
+	linker := NewLinker()
+	assert.NoError(t, linker.Register(plan))
+	assert.NoError(t, linker.ResolveReferences(plan))
+
+	var b strings.Builder
+	assert.NoError(t, linker.ExportDOT(&b))
+	assert.Equal(t, `digraph "links" {
+  n0 [label="cyclePlan"];
+  n1 [label="cycleTask:build"];
+  n2 [label="cycleTask:configure"];
+  n3 [label="cycleTask:fetch"];
+  n4 [label="cycleTask:idle"];
+  n5 [label="cycleTask:lint"];
+  n0 -> n1 [label="Start"];
+  n1 -> n3 [label="DependsOn[0]"];
+  n2 -> n1 [label="DependsOn[0]"];
+  n3 -> n2 [label="DependsOn[0]"];
+  n5 -> n3 [label="DependsOn[0]"];
+  n5 -> n5 [label="DependsOn[1]"];
+}
+`, b.String())
+
+	// custom names and labels are quoted
+	b.Reset()
+	assert.NoError(t, linker.ExportDOT(&b, DOTOptions{
+		Name: "plan",
+		Label: func(object any) string {
+			if task, ok := object.(*cycleTask); ok {
+				return `task "` + task.Id + `"`
+			}
+			return "plan"
+		},
+	}))
+	assert.Contains(t, b.String(), `digraph "plan" {`)
+	assert.Contains(t, b.String(), `n1 [label="task \"build\""];`)
+}
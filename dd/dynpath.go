@@ -0,0 +1,89 @@
+package dd
+
+import (
+	"sort"
+	"strings"
+)
+
+// matchFieldPath returns the entries of sets whose key matches path, most specific first. keys are structured field
+// paths like those used by Bind ("Root.Stages.Actions"); array indices are ignored on both sides, so
+// "Root.Stages[].Actions" is equivalent. a key may contain wildcard segments: "*" matches exactly one path segment,
+// and "**" matches any number of segments, including none. so "*.Steps" matches "Workflow.Steps" and "Pipeline.Steps",
+// while "**.Steps" also matches "Workflow.Stages.Steps".
+//
+// an exact key always comes first. patterns follow, ordered so that those with fewer "**" segments, then fewer "*"
+// segments, then more segments overall are preferred; remaining ties are broken by key.
+func matchFieldPath[V any](sets map[string]V, path string) []V {
+	if len(sets) == 0 {
+		return nil
+	}
+	path = stripIndices(path)
+	var out []V
+	if v, ok := sets[path]; ok {
+		out = append(out, v)
+	}
+
+	type candidate struct {
+		key         string
+		doubleStars int
+		stars       int
+		segments    int
+	}
+	var candidates []candidate
+	pathSegments := strings.Split(path, ".")
+	for key := range sets {
+		if !strings.Contains(key, "*") && !strings.Contains(key, "[") {
+			continue
+		}
+		segments := strings.Split(stripIndices(key), ".")
+		if !matchPathSegments(segments, pathSegments) {
+			continue
+		}
+		c := candidate{key: key, segments: len(segments)}
+		for _, s := range segments {
+			switch s {
+			case "**":
+				c.doubleStars++
+			case "*":
+				c.stars++
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.doubleStars != b.doubleStars {
+			return a.doubleStars < b.doubleStars
+		}
+		if a.stars != b.stars {
+			return a.stars < b.stars
+		}
+		if a.segments != b.segments {
+			return a.segments > b.segments
+		}
+		return a.key < b.key
+	})
+	for _, c := range candidates {
+		out = append(out, sets[c.key])
+	}
+	return out
+}
+
+// matchPathSegments reports whether the pattern segments match the path segments.
+func matchPathSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchPathSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 || (pattern[0] != "*" && pattern[0] != path[0]) {
+		return false
+	}
+	return matchPathSegments(pattern[1:], path[1:])
+}
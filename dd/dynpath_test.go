@@ -0,0 +1,73 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFieldPath(t *testing.T) {
+	sets := map[string]string{
+		"Workflow.Stages.Actions":   "exact",
+		"Workflow.Stages[].Actions": "brackets",
+		"Workflow.*.Actions":        "star",
+		"*.*.Actions":               "stars",
+		"**.Actions":                "doublestar",
+		"Workflow.**":               "prefix",
+		"*.Steps":                   "steps",
+	}
+
+	assert.Equal(t, []string{"exact", "brackets", "star", "stars", "doublestar", "prefix"},
+		matchFieldPath(sets, "Workflow.Stages[2].Actions[0]"))
+	assert.Equal(t, []string{"steps", "prefix"}, matchFieldPath(sets, "Workflow.Steps"))
+	assert.Equal(t, []string{"steps"}, matchFieldPath(sets, "Pipeline.Steps"))
+	assert.Equal(t, []string{"doublestar"}, matchFieldPath(sets, "Pipeline.Jobs.Tasks.Actions"))
+	assert.Empty(t, matchFieldPath(sets, "Pipeline.Jobs.Steps"))
+	assert.Empty(t, matchFieldPath(map[string]string(nil), "Workflow.Steps"))
+}
+
+func TestFieldDynamicBindersWildcard(t *testing.T) {
+	type stage struct {
+		Actions []Dynamic
+	}
+	type workflow struct {
+		Setup  []Dynamic
+		Stages []stage
+	}
+
+	bindA := func(m map[string]any) (Dynamic, error) {
+		name, _ := m["name"].(string)
+		return &dynA{Name: name}, nil
+	}
+	bindB := func(m map[string]any) (Dynamic, error) {
+		count, _ := m["count"].(int)
+		return &dynB{Count: count}, nil
+	}
+	opts := &Options{
+		FieldDynamicBinders: map[string]map[string]func(map[string]any) (Dynamic, error){
+			"workflow.Stages[].Actions": {"a": bindA},
+			"**.Actions":                {"b": bindB},
+			"*.Setup":                   {"a": bindA},
+		},
+	}
+
+	data := map[string]any{
+		"setup": []any{map[string]any{"type": "a", "name": "init"}},
+		"stages": []any{
+			map[string]any{"actions": []any{
+				map[string]any{"type": "a", "name": "build"},
+				map[string]any{"type": "b", "count": 3},
+			}},
+		},
+	}
+	w, err := New[workflow](data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []Dynamic{&dynA{Name: "init"}}, w.Setup)
+	assert.Equal(t, []Dynamic{&dynA{Name: "build"}, &dynB{Count: 3}}, w.Stages[0].Actions)
+
+	// "b" is only registered for actions
+	data["setup"] = []any{map[string]any{"type": "b", "count": 1}}
+	_, err = New[workflow](data, opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown Dynamic type "b"`)
+}
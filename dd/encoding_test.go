@@ -0,0 +1,79 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type encodingServer struct {
+	Host  string
+	Ports []int
+}
+
+type encodingConfig struct {
+	Query   string
+	Tags    []string
+	Servers []encodingServer
+}
+
+func TestJSONEncodingOptions(t *testing.T) {
+	cfg := &encodingConfig{Query: "a < b && c", Tags: []string{"x"}}
+
+	out, err := UnbindJSON(cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"query": "a \u003c b \u0026\u0026 c"`)
+	assert.Contains(t, string(out), "\n  \"tags\": [\n    \"x\"\n  ]")
+
+	opts := &Options{JSONIndent: "\t", JSONDisableHTMLEscape: true}
+	out, err = UnbindJSON(cfg, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "\n\t\"query\": \"a < b && c\"")
+	assert.Contains(t, string(out), "\n\t\"tags\": [\n\t\t\"x\"\n\t]")
+
+	// ordered output honors the same settings
+	opts.PreserveOrder = true
+	out, err = UnbindJSON(cfg, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "{\n\t\"query\": \"a < b && c\",\n\t\"tags\"")
+	out, err = UnbindJSON(cfg, &Options{PreserveOrder: true})
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"a \u003c b \u0026\u0026 c"`)
+}
+
+func TestYAMLEncodingOptions(t *testing.T) {
+	cfg := &encodingConfig{
+		Tags:    []string{"a", "b", "c"},
+		Servers: []encodingServer{{Host: "h", Ports: []int{80, 443}}},
+	}
+
+	out, err := UnbindYAML(cfg, &Options{PreserveOrder: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `query: ""
+tags:
+    - a
+    - b
+    - c
+servers:
+    - host: h
+      ports:
+        - 80
+        - 443
+`, string(out))
+
+	out, err = UnbindYAML(cfg, &Options{PreserveOrder: true, YAMLIndent: 2, YAMLFlowLists: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, `query: ""
+tags:
+  - a
+  - b
+  - c
+servers:
+  - host: h
+    ports: [80, 443]
+`, string(out))
+
+	again, err := NewYAML[encodingConfig](out)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, again)
+}
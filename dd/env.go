@@ -0,0 +1,137 @@
+package dd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvExpansion configures the expansion of environment variable references in string values during Bind and Merge.
+//
+// a reference has the form ${VAR}, or ${VAR:-default} to substitute default when VAR is unset or empty; unset
+// variables without a default expand to the empty string. "$$" produces a literal "$", so "$${VAR}" is left as the
+// text "${VAR}". a "$" not followed by "{" or "$" is kept as is. keys are never expanded.
+type EnvExpansion struct {
+	// Allow lists the variables that may be referenced; empty allows all. a trailing "*" matches a prefix, e.g.
+	// "APP_*".
+	Allow []string
+	// Deny lists variables that may not be referenced, with the same pattern syntax; it takes precedence over Allow.
+	// referencing a variable that is not allowed is an error.
+	Deny []string
+	// Lookup resolves a variable; defaults to os.LookupEnv.
+	Lookup func(name string) (string, bool)
+}
+
+// expandEnvData returns a copy of data with the environment references in its string values expanded.
+func expandEnvData(data map[string]any, e *EnvExpansion) (map[string]any, error) {
+	expanded, err := e.expandValue(data, "")
+	if err != nil {
+		return nil, err
+	}
+	return expanded.(map[string]any), nil
+}
+
+func (e *EnvExpansion) expandValue(v any, path string) (any, error) {
+	switch t := v.(type) {
+	case string:
+		s, err := e.expand(t)
+		if err != nil {
+			return nil, &ValidationError{Field: path, Message: err.Error()}
+		}
+		return s, nil
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, item := range t {
+			expanded, err := e.expandValue(item, joinProvenancePath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			out[k] = expanded
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			expanded, err := e.expandValue(item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = expanded
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// expand replaces the references in s.
+func (e *EnvExpansion) expand(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in %q", s)
+			}
+			value, err := e.resolve(s[i+2 : i+2+end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i += end + 2
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// resolve returns the value of a reference body, "VAR" or "VAR:-default".
+func (e *EnvExpansion) resolve(ref string) (string, error) {
+	name, def, hasDefault := strings.Cut(ref, ":-")
+	if name == "" {
+		return "", fmt.Errorf("empty variable name in ${%s}", ref)
+	}
+	if !e.allowed(name) {
+		return "", fmt.Errorf("environment variable %q is not allowed", name)
+	}
+	lookup := e.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	value, _ := lookup(name)
+	if value == "" && hasDefault {
+		return def, nil
+	}
+	return value, nil
+}
+
+func (e *EnvExpansion) allowed(name string) bool {
+	if matchesEnvPattern(e.Deny, name) {
+		return false
+	}
+	return len(e.Allow) == 0 || matchesEnvPattern(e.Allow, name)
+}
+
+func matchesEnvPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if pattern == name {
+			return true
+		}
+	}
+	return false
+}
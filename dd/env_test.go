@@ -0,0 +1,88 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEnv(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	type database struct {
+		URL  string
+		Port int
+	}
+	type config struct {
+		Name     string
+		Database database
+		Hosts    []string
+		Price    string
+	}
+	opts := &Options{ExpandEnv: &EnvExpansion{Lookup: testEnv(map[string]string{
+		"DB_HOST": "db.internal",
+		"DB_PORT": "5433",
+		"EMPTY":   "",
+	})}}
+
+	cfg, err := New[config](map[string]any{
+		"name": "${APP_NAME:-app}",
+		"database": map[string]any{
+			"url":  "postgres://${DB_HOST}:${DB_PORT}/main",
+			"port": "${DB_PORT}",
+		},
+		"hosts": []any{"${DB_HOST}", "${EMPTY:-fallback}", "${MISSING}"},
+		"price": "$$5 for $${DB_HOST}, $9",
+	}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "app", cfg.Name)
+	assert.Equal(t, database{URL: "postgres://db.internal:5433/main", Port: 5433}, cfg.Database)
+	assert.Equal(t, []string{"db.internal", "fallback", ""}, cfg.Hosts)
+	assert.Equal(t, "$5 for ${DB_HOST}, $9", cfg.Price)
+
+	// without the option, references are left alone
+	cfg, err = New[config](map[string]any{"name": "${APP_NAME}"})
+	assert.NoError(t, err)
+	assert.Equal(t, "${APP_NAME}", cfg.Name)
+}
+
+func TestExpandEnvAllowDeny(t *testing.T) {
+	type config struct {
+		Value string
+	}
+	lookup := testEnv(map[string]string{"APP_TOKEN": "t", "APP_HOST": "h", "HOME": "/root"})
+
+	opts := &Options{ExpandEnv: &EnvExpansion{Allow: []string{"APP_*"}, Deny: []string{"APP_TOKEN"}, Lookup: lookup}}
+	cfg, err := New[config](map[string]any{"value": "${APP_HOST}"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "h", cfg.Value)
+
+	for _, ref := range []string{"${HOME}", "${APP_TOKEN}"} {
+		_, err = New[config](map[string]any{"value": ref}, opts)
+		assert.Error(t, err)
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		assert.Equal(t, "value", ve.Field)
+		assert.Contains(t, err.Error(), "not allowed")
+	}
+}
+
+func TestExpandEnvMerge(t *testing.T) {
+	type config struct {
+		Host string
+		Port int
+	}
+	cfg := &config{Host: "localhost", Port: 80}
+	opts := &Options{ExpandEnv: &EnvExpansion{Lookup: testEnv(map[string]string{"PORT": "8080"})}}
+	assert.NoError(t, Merge(cfg, map[string]any{"port": "${PORT}"}, opts))
+	assert.Equal(t, &config{Host: "localhost", Port: 8080}, cfg)
+
+	_, err := New[config](map[string]any{"host": "${HOST"}, opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unterminated")
+}
@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 )
 
 // ValidationError represents errors in input validation
 type ValidationError struct {
 	Field   string
 	Message string
+	Cause   error
 }
 
 func (e *ValidationError) Error() string {
@@ -19,6 +22,10 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error: %s", e.Message)
 }
 
+func (e *ValidationError) Unwrap() error {
+	return e.Cause
+}
+
 // TypeMismatchError represents type conversion errors
 type TypeMismatchError struct {
 	Path     string
@@ -136,6 +143,9 @@ func (e *PointerError) Error() string {
 		return e.Cause.Error()
 	}
 	if e.Reference != "" {
+		if e.Path != "" {
+			return fmt.Sprintf("%s: unresolved reference: %s", e.Path, e.Reference)
+		}
 		return fmt.Sprintf("unresolved reference: %s", e.Reference)
 	}
 	if e.Path != "" {
@@ -148,6 +158,22 @@ func (e *PointerError) Unwrap() error {
 	return e.Cause
 }
 
+// PointerTypeError reports a reference to an object of a type other than the one its Pointer refers to. it is the
+// Cause of a PointerError.
+type PointerTypeError struct {
+	Path      string       // field path of the Pointer from the linked target, e.g. "Documents[1].Author"; empty when resolved lazily
+	Reference string       // the reference, as written
+	Expected  reflect.Type // the type the Pointer refers to: User for both Pointer[User] and Pointer[*User]
+	Found     reflect.Type // the type of the object found
+}
+
+func (e *PointerTypeError) Error() string {
+	if isPathRef(e.Reference) {
+		return fmt.Sprintf("path %q refers to %s, expected %s", e.Reference, e.Found, e.Expected)
+	}
+	return fmt.Sprintf("reference %q is to %s, expected %s", e.Reference, e.Found, e.Expected)
+}
+
 // UnsupportedError represents unsupported operation errors
 type UnsupportedError struct {
 	Path      string
@@ -172,6 +198,21 @@ func (e *RequiredFieldError) Error() string {
 	return fmt.Sprintf("%s.%s: required field missing", e.Path, e.Field)
 }
 
+// FieldGroupError represents a violated +requires-one-of or +exclusive field group
+type FieldGroupError struct {
+	Path      string
+	Group     string
+	Fields    []string // external names of the group's fields; for an exclusive group, those that were present
+	Exclusive bool
+}
+
+func (e *FieldGroupError) Error() string {
+	if e.Exclusive {
+		return fmt.Sprintf("%s: %s are mutually exclusive (group %q)", e.Path, strings.Join(e.Fields, ", "), e.Group)
+	}
+	return fmt.Sprintf("%s: one of %s is required (group %q)", e.Path, strings.Join(e.Fields, ", "), e.Group)
+}
+
 // MultipleExtraFieldsError represents the error when a struct has more than one +extra field
 type MultipleExtraFieldsError struct {
 	Path string
@@ -206,3 +247,70 @@ func (e *IndexError) Error() string {
 func (e *IndexError) Unwrap() error {
 	return e.Cause
 }
+
+// ConstraintError represents a bound value that violates a +min, +max, +regex, +oneof, or +enum constraint
+type ConstraintError struct {
+	Path       string
+	Field      string
+	Constraint string
+	Value      string
+	Suggestion string // closest allowed value for +oneof and +enum, empty when none is close
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("%s.%s: value %s violates %s; did you mean %q?", e.Path, e.Field, e.Value, e.Constraint, e.Suggestion)
+	}
+	return fmt.Sprintf("%s.%s: value %s violates %s", e.Path, e.Field, e.Value, e.Constraint)
+}
+
+// HookError represents an error returned by a BeforeBind, AfterBind, BeforeUnbind, or AfterUnbind hook
+type HookError struct {
+	Path  string
+	Hook  string
+	Cause error
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Path, e.Hook, e.Cause.Error())
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Cause
+}
+
+// SyntaxError represents malformed JSON, JSONC, or YAML input, located by line and column (1-based; zero when the
+// decoder does not report them). File is set by the file variants (BindJSONFile, FileLayer, etc.), and Snippet holds
+// the offending source line.
+type SyntaxError struct {
+	File    string
+	Format  string
+	Line    int
+	Column  int
+	Snippet string
+	Message string
+	Cause   error
+}
+
+func (e *SyntaxError) Error() string {
+	var location string
+	switch {
+	case e.File != "" && e.Line > 0 && e.Column > 0:
+		location = fmt.Sprintf("%s:%d:%d", e.File, e.Line, e.Column)
+	case e.File != "" && e.Line > 0:
+		location = fmt.Sprintf("%s:%d", e.File, e.Line)
+	case e.File != "":
+		location = e.File
+	case e.Line > 0 && e.Column > 0:
+		location = fmt.Sprintf("%s line %d, column %d", e.Format, e.Line, e.Column)
+	case e.Line > 0:
+		location = fmt.Sprintf("%s line %d", e.Format, e.Line)
+	default:
+		return fmt.Sprintf("invalid %s: %s", e.Format, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, e.Message)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Cause
+}
@@ -0,0 +1,47 @@
+package dd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// FingerprintOptions configures Fingerprint.
+type FingerprintOptions struct {
+	// ExcludeSecrets leaves `+secret` fields out of the hash, so the fingerprint can be exposed publicly and does not
+	// change when only credentials are rotated.
+	ExcludeSecrets bool
+	// Options is passed to Unbind (e.g. for converters); may be nil.
+	Options *Options
+}
+
+// Fingerprint returns a stable hash of source's unbound form, as "sha256:<hex>". two structs that unbind to the same
+// data have the same fingerprint regardless of map ordering, so services can detect whether a reload actually changed
+// the configuration, and expose the hash in health or version endpoints. the hash is computed over the encoding
+// produced by UnbindCanonical (including secrets, unless ExcludeSecrets is set).
+//
+// opts are optional; pass nil or omit to use defaults.
+func Fingerprint(source interface{}, opts ...*FingerprintOptions) (string, error) {
+	fo := &FingerprintOptions{}
+	if len(opts) > 0 && opts[0] != nil {
+		fo = opts[0]
+	}
+	opt := Options{}
+	if fo.Options != nil {
+		opt = *fo.Options
+	}
+	opt.SecretKeys = nil // sealing uses a random nonce, which would make the hash unstable
+	if fo.ExcludeSecrets {
+		opt.SecretPolicy = OmitSecrets
+	}
+
+	data, err := Unbind(source, &opt)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := canonicalJSON(source, data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
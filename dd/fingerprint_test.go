@@ -0,0 +1,55 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fingerprintConfig struct {
+	Name     string
+	Password string `dd:",+secret"`
+	Timeout  time.Duration
+	Labels   map[string]string
+}
+
+func TestFingerprintStable(t *testing.T) {
+	a := &fingerprintConfig{Name: "app", Timeout: time.Second, Labels: map[string]string{"a": "1", "b": "2", "c": "3"}}
+	b := &fingerprintConfig{Name: "app", Timeout: time.Second, Labels: map[string]string{"c": "3", "b": "2", "a": "1"}}
+
+	fa, err := Fingerprint(a)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(fa, "sha256:"))
+	assert.Len(t, fa, len("sha256:")+64)
+
+	fb, err := Fingerprint(b)
+	assert.NoError(t, err)
+	assert.Equal(t, fa, fb)
+
+	b.Timeout = 2 * time.Second
+	fb, err = Fingerprint(b)
+	assert.NoError(t, err)
+	assert.NotEqual(t, fa, fb)
+}
+
+func TestFingerprintExcludeSecrets(t *testing.T) {
+	a := &fingerprintConfig{Name: "app", Password: "one"}
+	b := &fingerprintConfig{Name: "app", Password: "two"}
+
+	fa, _ := Fingerprint(a)
+	fb, _ := Fingerprint(b)
+	assert.NotEqual(t, fa, fb)
+
+	fa, _ = Fingerprint(a, &FingerprintOptions{ExcludeSecrets: true})
+	fb, _ = Fingerprint(b, &FingerprintOptions{ExcludeSecrets: true})
+	assert.Equal(t, fa, fb)
+}
+
+func TestFingerprintErrors(t *testing.T) {
+	_, err := Fingerprint(nil)
+	assert.Error(t, err)
+	_, err = Fingerprint(42)
+	assert.Error(t, err)
+}
@@ -0,0 +1,231 @@
+package dd
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindFlags registers a flag on fs for every scalar field of target (a pointer to a struct), so command-line flags can
+// override configuration without manual flag plumbing. flag names are the dotted paths of external field names (tag
+// or naming strategy), e.g. `-server.port`, and the help text comes from the field's `+doc` tag, or describes the
+// field's type when it has none. nested structs, pointers to structs, and embedded structs are descended into; map,
+// Dynamic, `+extra`, and `+raw` fields get no flag.
+//
+// flags write straight into target as fs.Parse encounters them, using the normal binding rules, so a flag for an int
+// field accepts "8080" and bool fields may be given as a bare `-debug`. slice fields accept a comma-separated list;
+// the first occurrence replaces the whole slice, and repeating the flag (`-tags a -tags b`) appends to it. a value
+// that fails to bind or violates a constraint such as `+max` is an error, and leaves the field as it was. flags that
+// are not given leave target unchanged.
+//
+// to build a file → environment → flag precedence chain, load and merge the earlier layers into target first, then
+// call BindFlags (so help output shows the effective defaults) and fs.Parse. opts are passed through to Merge; set
+// Options.Source (e.g. "flags") and Options.Provenance to record flag values in provenance.
+func BindFlags(target interface{}, fs *flag.FlagSet, opts ...*Options) error {
+	elem, err := validateTarget(target)
+	if err != nil {
+		return err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	current, err := Unbind(target, opts...)
+	if err != nil {
+		return err
+	}
+	registerFlags(target, fs, elem.Type(), nil, current, opt)
+	return nil
+}
+
+// registerFlags registers flags for the fields of structType, found at path within target. current holds the
+// unbound values of the struct (nil when the struct is absent), used for the flag defaults.
+func registerFlags(target interface{}, fs *flag.FlagSet, structType reflect.Type, path []string, current map[string]any, opt *Options) {
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if isInlined(field, meta.tag) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				registerFlags(target, fs, embedded, path, current, opt)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra || tag.Raw {
+			continue
+		}
+		name := meta.externalName(opt.naming())
+		fieldPath := append(append([]string(nil), path...), name)
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if isFlagScalar(fieldType, opt) {
+			f := &flagValue{target: target, path: fieldPath, opt: opt, value: formatFlagValue(current[name])}
+			usage := tag.Doc
+			if usage == "" {
+				usage = fmt.Sprintf("%s value", fieldType)
+			}
+			if fieldType.Kind() == reflect.Bool {
+				fs.Var(&boolFlagValue{f}, strings.Join(fieldPath, "."), usage)
+			} else {
+				fs.Var(f, strings.Join(fieldPath, "."), usage)
+			}
+			continue
+		}
+		if fieldType.Kind() == reflect.Slice && isFlagScalar(fieldType.Elem(), opt) {
+			f := &flagValue{target: target, path: fieldPath, opt: opt, list: true, value: formatFlagValue(current[name])}
+			usage := tag.Doc
+			if usage == "" {
+				usage = fmt.Sprintf("%s values, comma-separated or repeated", fieldType.Elem())
+			}
+			fs.Var(f, strings.Join(fieldPath, "."), usage)
+			continue
+		}
+		if isProvenanceStruct(fieldType) {
+			sub, _ := current[name].(map[string]any)
+			registerFlags(target, fs, fieldType, fieldPath, sub, opt)
+		}
+	}
+}
+
+// isFlagScalar reports whether values of type t can be given as a single flag argument.
+func isFlagScalar(t reflect.Type, opt *Options) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if opt != nil && opt.Converters[t] != nil {
+		return true
+	}
+	if isOptionalType(t) {
+		return isFlagScalar(t.Field(0).Type, opt)
+	}
+	if isScalarStruct(t) || isUUIDType(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// formatFlagValue renders an unbound value as a flag default.
+func formatFlagValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// flagValue is a flag.Value that merges each value it is given into its field of target.
+type flagValue struct {
+	target interface{}
+	path   []string
+	opt    *Options
+	list   bool
+	value  string
+	items  []any // the list given so far, when list is set
+	set    bool  // whether the flag has been given
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(s string) error {
+	var raw any = s
+	var items []any
+	if f.list {
+		// a repeated list flag appends to the items given before
+		if f.set {
+			items = append(items, f.items...)
+		}
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		raw = items
+	}
+	patch := map[string]any{f.path[len(f.path)-1]: raw}
+	for i := len(f.path) - 2; i >= 0; i-- {
+		patch = map[string]any{f.path[i]: patch}
+	}
+	var opts []*Options
+	if f.opt != nil {
+		opts = append(opts, f.opt)
+	}
+	// Merge checks constraints once the value is in place, so an invalid value is undone
+	field := flagFieldAt(reflect.ValueOf(f.target).Elem(), f.path, f.opt.naming())
+	var previous reflect.Value
+	if field.IsValid() {
+		previous = reflect.New(field.Type()).Elem()
+		previous.Set(field)
+	}
+	if err := Merge(f.target, patch, opts...); err != nil {
+		if field.IsValid() {
+			field.Set(previous)
+		}
+		return err
+	}
+	if f.list {
+		f.items = items
+		s = formatFlagValue(items)
+	}
+	f.value, f.set = s, true
+	return nil
+}
+
+// flagFieldAt returns the field at path within structValue, or the nil pointer on the way to it, which Merge would
+// allocate; restoring the value it returns undoes a Merge of path.
+func flagFieldAt(structValue reflect.Value, path []string, naming NamingStrategy) reflect.Value {
+	v := structValue
+	for _, key := range path {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return v
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+		field, ok := externalFieldValue(v, key, naming)
+		if !ok {
+			return reflect.Value{}
+		}
+		v = field
+	}
+	return v
+}
+
+// boolFlagValue lets bool fields be given as a bare flag.
+type boolFlagValue struct {
+	*flagValue
+}
+
+func (f *boolFlagValue) IsBoolFlag() bool {
+	return true
+}
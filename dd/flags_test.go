@@ -0,0 +1,121 @@
+package dd
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flagsServer struct {
+	Host string `dd:",+doc=\"listen host\""`
+	Port int    `dd:",+doc=\"listen port\""`
+}
+
+type flagsConfig struct {
+	Name    string `dd:"app_name"`
+	Debug   bool
+	Timeout time.Duration
+	Server  flagsServer
+	Backup  *flagsServer
+	Tags    []string
+	Limits  map[string]int
+	Token   string `dd:"-"`
+}
+
+func TestBindFlags(t *testing.T) {
+	cfg := &flagsConfig{Name: "from-file", Server: flagsServer{Host: "localhost", Port: 8080}, Tags: []string{"a"}}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(cfg, fs))
+
+	for _, name := range []string{"app_name", "debug", "timeout", "server.host", "server.port", "backup.host", "backup.port", "tags"} {
+		assert.NotNil(t, fs.Lookup(name), name)
+	}
+	assert.Nil(t, fs.Lookup("limits"))
+	assert.Nil(t, fs.Lookup("token"))
+	assert.Equal(t, "listen port", fs.Lookup("server.port").Usage)
+	assert.Equal(t, "8080", fs.Lookup("server.port").DefValue)
+
+	err := fs.Parse([]string{"-debug", "-server.port", "9090", "-timeout=5s", "-backup.host", "standby", "-tags", "x, y"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "from-file", cfg.Name)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, 5*time.Second, cfg.Timeout)
+	assert.Equal(t, flagsServer{Host: "localhost", Port: 9090}, cfg.Server)
+	if assert.NotNil(t, cfg.Backup) {
+		assert.Equal(t, "standby", cfg.Backup.Host)
+	}
+	assert.Equal(t, []string{"x", "y"}, cfg.Tags)
+}
+
+func TestBindFlagsInvalidValue(t *testing.T) {
+	cfg := &flagsConfig{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	assert.NoError(t, BindFlags(cfg, fs))
+	assert.Error(t, fs.Parse([]string{"-server.port", "eighty"}))
+}
+
+func TestBindFlagsProvenance(t *testing.T) {
+	cfg := &flagsConfig{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	provenance := Provenance{}
+	assert.NoError(t, BindFlags(cfg, fs, &Options{Source: "flags", Provenance: provenance}))
+	assert.NoError(t, fs.Parse([]string{"-server.port", "1"}))
+	assert.Equal(t, "flags", provenance["server.port"])
+}
+
+func TestBindFlagsErrors(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.Error(t, BindFlags(nil, fs))
+	assert.Error(t, BindFlags(flagsConfig{}, fs))
+}
+
+func TestBindFlagsConstraintLeavesValue(t *testing.T) {
+	type db struct {
+		Port int `dd:",+min=1,+max=65535"`
+	}
+	type config struct {
+		DB      db
+		Replica *db
+	}
+	cfg := &config{DB: db{Port: 5432}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	assert.NoError(t, BindFlags(cfg, fs))
+
+	assert.Error(t, fs.Parse([]string{"-db.port", "99999"}))
+	assert.Equal(t, 5432, cfg.DB.Port)
+	assert.Error(t, fs.Parse([]string{"-replica.port", "0"}))
+	assert.Nil(t, cfg.Replica)
+
+	assert.NoError(t, fs.Parse([]string{"-db.port", "6543"}))
+	assert.Equal(t, 6543, cfg.DB.Port)
+}
+
+func TestBindFlagsRepeatedList(t *testing.T) {
+	cfg := &flagsConfig{Tags: []string{"from-file"}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(cfg, fs))
+	assert.NoError(t, fs.Parse([]string{"-tags", "a", "-tags", "b,c"}))
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Tags)
+	assert.Equal(t, "a,b,c", fs.Lookup("tags").Value.String())
+}
+
+func TestBindFlagsUsage(t *testing.T) {
+	cfg := &flagsConfig{Name: "from-file"}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	assert.NoError(t, BindFlags(cfg, fs))
+	assert.Equal(t, "string value", fs.Lookup("app_name").Usage)
+	assert.Equal(t, "string values, comma-separated or repeated", fs.Lookup("tags").Usage)
+
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), "\tstring value (default from-file)\n")
+	assert.NotContains(t, out.String(), "\t (default")
+}
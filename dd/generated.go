@@ -0,0 +1,67 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// generatedCodec holds the binding functions generated by ddgen for one struct type.
+type generatedCodec struct {
+	bind   func(target any, data map[string]any) error
+	unbind func(source any) (map[string]any, error)
+}
+
+var generatedCodecs sync.Map // reflect.Type → *generatedCodec
+
+// RegisterGenerated registers reflection-free bind and unbind functions for struct type T. it is called from the
+// init function of code generated by the ddgen command, and is not normally called directly.
+//
+// Bind, New, and Unbind dispatch to the registered functions whenever T is bound or unbound without Options, at the
+// top level or as a nested field; Merge and calls with Options always use reflection, so the generated functions only
+// need to implement the default behavior. Validator is still honored after a generated bind.
+func RegisterGenerated[T any](bind func(target *T, data map[string]any) error, unbind func(source *T) (map[string]any, error)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	generatedCodecs.Store(t, &generatedCodec{
+		bind:   func(target any, data map[string]any) error { return bind(target.(*T), data) },
+		unbind: func(source any) (map[string]any, error) { return unbind(source.(*T)) },
+	})
+}
+
+// lookupGenerated returns the generated codec registered for struct type t, if any.
+func lookupGenerated(t reflect.Type) (*generatedCodec, bool) {
+	codec, ok := generatedCodecs.Load(t)
+	if !ok {
+		return nil, false
+	}
+	return codec.(*generatedCodec), true
+}
+
+// BindValue binds raw into target, a pointer to a value of any type supported by Bind, using the same rules as for a
+// struct field; path is used in error messages. generated code calls it for fields it does not specialize.
+func BindValue(target any, raw any, path string, opts ...*Options) error {
+	value := reflect.ValueOf(target)
+	if target == nil || value.Kind() != reflect.Ptr || value.IsNil() {
+		return &TypeMismatchError{Path: path, Expected: "non-nil pointer", Actual: fmt.Sprintf("%T", target)}
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	return setField(value.Elem(), raw, path, opt, false)
+}
+
+// UnbindValue converts the value source points to into the value Unbind would emit for a struct field holding it. the
+// boolean result is false when nothing would be emitted (e.g. for a nil pointer). generated code calls it for fields
+// it does not specialize.
+func UnbindValue(source any, opts ...*Options) (any, bool, error) {
+	value := reflect.ValueOf(source)
+	if source == nil || value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil, false, &TypeMismatchError{Expected: "non-nil pointer", Actual: fmt.Sprintf("%T", source)}
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return nil, false, err
+	}
+	return valueToInterface(value.Elem(), opt)
+}
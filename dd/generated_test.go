@@ -0,0 +1,113 @@
+package dd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type generatedPoint struct {
+	X int
+	Y int
+}
+
+type generatedShape struct {
+	Name   string
+	Origin generatedPoint
+}
+
+func (s *generatedShape) Validate() error {
+	if s.Name == "invalid" {
+		return errors.New("invalid shape")
+	}
+	return nil
+}
+
+var generatedCalls struct{ bind, unbind int }
+
+func init() {
+	RegisterGenerated(func(p *generatedPoint, data map[string]any) error {
+		generatedCalls.bind++
+		if err := BindValue(&p.X, data["x"], "generatedPoint.X"); err != nil {
+			return err
+		}
+		return BindValue(&p.Y, data["y"], "generatedPoint.Y")
+	}, func(p *generatedPoint) (map[string]any, error) {
+		generatedCalls.unbind++
+		return map[string]any{"x": p.X, "y": p.Y}, nil
+	})
+	RegisterGenerated(func(s *generatedShape, data map[string]any) error {
+		generatedCalls.bind++
+		if raw, ok := data["name"]; ok {
+			if err := BindValue(&s.Name, raw, "generatedShape.Name"); err != nil {
+				return err
+			}
+		}
+		if raw, ok := data["origin"]; ok {
+			return BindValue(&s.Origin, raw, "generatedShape.Origin")
+		}
+		return nil
+	}, func(s *generatedShape) (map[string]any, error) {
+		generatedCalls.unbind++
+		origin, _, err := UnbindValue(&s.Origin)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"name": s.Name, "origin": origin}, nil
+	})
+}
+
+func TestGeneratedDispatch(t *testing.T) {
+	generatedCalls.bind, generatedCalls.unbind = 0, 0
+	data := map[string]any{"name": "square", "origin": map[string]any{"x": 1, "y": 2}}
+
+	shape, err := New[generatedShape](data)
+	assert.NoError(t, err)
+	assert.Equal(t, &generatedShape{Name: "square", Origin: generatedPoint{X: 1, Y: 2}}, shape)
+	assert.Equal(t, 2, generatedCalls.bind) // shape and nested point
+
+	out, err := Unbind(shape)
+	assert.NoError(t, err)
+	assert.Equal(t, data, out)
+	assert.Equal(t, 2, generatedCalls.unbind)
+
+	_, err = Unbind(*shape) // non-addressable values are copied
+	assert.NoError(t, err)
+	assert.Equal(t, 4, generatedCalls.unbind)
+
+	_, err = New[generatedShape](map[string]any{"name": "invalid"})
+	assert.Error(t, err)
+}
+
+func TestGeneratedBypassed(t *testing.T) {
+	generatedCalls.bind, generatedCalls.unbind = 0, 0
+	data := map[string]any{"name": "square", "origin": map[string]any{"x": 1}}
+
+	// options and Merge use reflection
+	shape, err := New[generatedShape](data, &Options{})
+	assert.NoError(t, err)
+	assert.NoError(t, Merge(shape, map[string]any{"name": "circle"}))
+	assert.Equal(t, generatedShape{Name: "circle", Origin: generatedPoint{X: 1}}, *shape)
+	_, err = Unbind(shape, &Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, generatedCalls.bind)
+	assert.Equal(t, 0, generatedCalls.unbind)
+}
+
+func TestBindValue(t *testing.T) {
+	var n int
+	assert.NoError(t, BindValue(&n, "42", "n"))
+	assert.Equal(t, 42, n)
+	assert.Error(t, BindValue(n, 1, "n"))
+
+	v, ok, err := UnbindValue(&n)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 42, v)
+
+	var p *int
+	_, ok, err = UnbindValue(&p)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,63 @@
+package dd
+
+import (
+	"maps"
+	"reflect"
+)
+
+// structHook returns structValue, or its address, as the hook interface I described by iface.
+func structHook[I any](structValue reflect.Value, iface reflect.Type) (I, bool) {
+	if structValue.CanAddr() && structValue.Addr().Type().Implements(iface) {
+		return structValue.Addr().Interface().(I), true
+	}
+	if structValue.Type().Implements(iface) {
+		return structValue.Interface().(I), true
+	}
+	var zero I
+	return zero, false
+}
+
+// beforeBind calls BeforeBind on a struct implementing BeforeBinder, and returns the copy of data it may have
+// rewritten. data is returned unchanged for other structs.
+func beforeBind(structValue reflect.Value, data map[string]any, path string) (map[string]any, error) {
+	hook, ok := structHook[BeforeBinder](structValue, beforeBinderInterfaceType)
+	if !ok {
+		return data, nil
+	}
+	copied := make(map[string]any, len(data))
+	maps.Copy(copied, data)
+	if err := hook.BeforeBind(copied); err != nil {
+		return nil, &HookError{Path: path, Hook: "BeforeBind", Cause: err}
+	}
+	return copied, nil
+}
+
+// afterBind finishes a bound struct: AfterBind, then Validate.
+func afterBind(structValue reflect.Value, path string) error {
+	if hook, ok := structHook[AfterBinder](structValue, afterBinderInterfaceType); ok {
+		if err := hook.AfterBind(); err != nil {
+			return &HookError{Path: path, Hook: "AfterBind", Cause: err}
+		}
+	}
+	return validateStruct(structValue, path)
+}
+
+// beforeUnbind calls BeforeUnbind on a struct implementing BeforeUnbinder.
+func beforeUnbind(structVal reflect.Value, path string) error {
+	if hook, ok := structHook[BeforeUnbinder](structVal, beforeUnbinderInterfaceType); ok {
+		if err := hook.BeforeUnbind(); err != nil {
+			return &HookError{Path: path, Hook: "BeforeUnbind", Cause: err}
+		}
+	}
+	return nil
+}
+
+// afterUnbind calls AfterUnbind on a struct implementing AfterUnbinder with the map unbound from it.
+func afterUnbind(structVal reflect.Value, out map[string]any, path string) error {
+	if hook, ok := structHook[AfterUnbinder](structVal, afterUnbinderInterfaceType); ok {
+		if err := hook.AfterUnbind(out); err != nil {
+			return &HookError{Path: path, Hook: "AfterUnbind", Cause: err}
+		}
+	}
+	return nil
+}
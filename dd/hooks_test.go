@@ -0,0 +1,139 @@
+package dd
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hookedFilter struct {
+	Pattern string
+	Labels  []string
+	re      *regexp.Regexp
+}
+
+// BeforeBind migrates the legacy "regex" key and accepts a single "label" in place of "labels".
+func (f *hookedFilter) BeforeBind(data map[string]any) error {
+	if v, found := data["regex"]; found {
+		data["pattern"] = v
+		delete(data, "regex")
+	}
+	if v, found := data["label"]; found {
+		data["labels"] = []any{v}
+		delete(data, "label")
+	}
+	return nil
+}
+
+func (f *hookedFilter) AfterBind() error {
+	re, err := regexp.Compile(f.Pattern)
+	if err != nil {
+		return err
+	}
+	f.re = re
+	return nil
+}
+
+func (f *hookedFilter) Validate() error {
+	if f.re == nil {
+		return errors.New("AfterBind must run before Validate")
+	}
+	return nil
+}
+
+func (f *hookedFilter) BeforeUnbind() error {
+	if f.re != nil {
+		f.Pattern = f.re.String()
+	}
+	return nil
+}
+
+func (f *hookedFilter) AfterUnbind(data map[string]any) error {
+	if labels, ok := data["labels"].([]any); ok && len(labels) == 1 {
+		data["label"] = labels[0]
+		delete(data, "labels")
+	}
+	return nil
+}
+
+type hookedConfig struct {
+	Name    string
+	Filters []hookedFilter
+	Primary *hookedFilter
+	Extra   map[string]any `dd:",+extra"`
+}
+
+func TestBindHooks(t *testing.T) {
+	primary := map[string]any{"regex": "^a", "label": "x"}
+	data := map[string]any{
+		"name":    "hooks",
+		"filters": []any{map[string]any{"pattern": "b$", "labels": []any{"y", "z"}}},
+		"primary": primary,
+	}
+	cfg, err := New[hookedConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, "^a", cfg.Primary.Pattern)
+	assert.Equal(t, []string{"x"}, cfg.Primary.Labels)
+	assert.True(t, cfg.Primary.re.MatchString("abc"))
+	assert.True(t, cfg.Filters[0].re.MatchString("ab"))
+	assert.Nil(t, cfg.Extra)
+
+	// hooks rewrite a copy; the caller's data is untouched
+	assert.Equal(t, map[string]any{"regex": "^a", "label": "x"}, primary)
+}
+
+func TestUnbindHooks(t *testing.T) {
+	cfg := &hookedConfig{Primary: &hookedFilter{Labels: []string{"x"}, re: regexp.MustCompile("^a")}}
+	out, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"pattern": "^a", "label": "x"}, out["primary"])
+}
+
+type failingHooks struct {
+	Name string
+}
+
+func (f *failingHooks) AfterBind() error {
+	if f.Name == "" {
+		return errors.New("name is empty")
+	}
+	return nil
+}
+
+func (f failingHooks) BeforeUnbind() error {
+	return errors.New("not unbindable")
+}
+
+func TestHookErrors(t *testing.T) {
+	_, err := New[failingHooks](map[string]any{})
+	var hookErr *HookError
+	if assert.ErrorAs(t, err, &hookErr) {
+		assert.Equal(t, "AfterBind", hookErr.Hook)
+	}
+	assert.Equal(t, "failingHooks: AfterBind: name is empty", err.Error())
+
+	_, err = Unbind(failingHooks{Name: "x"})
+	assert.ErrorAs(t, err, &hookErr)
+	assert.Equal(t, "BeforeUnbind", hookErr.Hook)
+}
+
+type inlineHooked struct {
+	Host  string
+	ready bool
+}
+
+func (h *inlineHooked) AfterBind() error {
+	h.ready = true
+	return nil
+}
+
+func TestInlineAfterBind(t *testing.T) {
+	type config struct {
+		Server inlineHooked `dd:",+inline"`
+	}
+	cfg, err := New[config](map[string]any{"host": "h"})
+	assert.NoError(t, err)
+	assert.True(t, cfg.Server.ready)
+}
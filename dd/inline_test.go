@@ -0,0 +1,100 @@
+package dd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type inlineServer struct {
+	Host string
+	Port int
+}
+
+func (s *inlineServer) Validate() error {
+	if s.Port < 0 {
+		return errors.New("port must not be negative")
+	}
+	return nil
+}
+
+type inlineDatabase struct {
+	DSN string `dd:"db_dsn,+required"`
+}
+
+type inlineConfig struct {
+	Name     string
+	Server   inlineServer    `dd:",+inline"`
+	Database *inlineDatabase `dd:",+inline"`
+	Extra    map[string]any  `dd:",+extra"`
+}
+
+func TestInlineBindUnbind(t *testing.T) {
+	data := map[string]any{
+		"name":    "legacy",
+		"host":    "localhost",
+		"port":    8080,
+		"db_dsn":  "postgres://",
+		"unknown": true,
+	}
+	cfg, err := New[inlineConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", cfg.Name)
+	assert.Equal(t, inlineServer{Host: "localhost", Port: 8080}, cfg.Server)
+	assert.Equal(t, &inlineDatabase{DSN: "postgres://"}, cfg.Database)
+	assert.Equal(t, map[string]any{"unknown": true}, cfg.Extra)
+
+	out, err := Unbind(&inlineConfig{Name: "legacy", Server: cfg.Server, Database: cfg.Database})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":   "legacy",
+		"host":   "localhost",
+		"port":   8080,
+		"db_dsn": "postgres://",
+	}, out)
+}
+
+func TestInlinePointerAllocation(t *testing.T) {
+	// an inline pointer is only allocated when the data has keys for it
+	cfg, err := New[inlineConfig](map[string]any{"host": "h"})
+	assert.NoError(t, err)
+	assert.Nil(t, cfg.Database)
+
+	out, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.NotContains(t, out, "database")
+	assert.NotContains(t, out, "db_dsn")
+}
+
+func TestInlineValidation(t *testing.T) {
+	_, err := New[inlineConfig](map[string]any{"port": -1})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "port must not be negative")
+}
+
+func TestInlineMerge(t *testing.T) {
+	cfg := &inlineConfig{Server: inlineServer{Host: "localhost", Port: 80}}
+	assert.NoError(t, Merge(cfg, map[string]any{"port": 9090}))
+	assert.Equal(t, inlineServer{Host: "localhost", Port: 9090}, cfg.Server)
+}
+
+func TestInlineSchema(t *testing.T) {
+	schema, err := Schema[inlineConfig]()
+	assert.NoError(t, err)
+	properties := schema["properties"].(map[string]any)
+	assert.Contains(t, properties, "host")
+	assert.Contains(t, properties, "db_dsn")
+	assert.NotContains(t, properties, "server")
+}
+
+func TestInlineIgnoredOnScalars(t *testing.T) {
+	// +inline only applies to nested structs
+	type config struct {
+		Name string `dd:",+inline"`
+	}
+	cfg, err := New[config](map[string]any{"name": "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, "x", cfg.Name)
+}
@@ -16,6 +16,8 @@ type InspectOptions struct {
 	Indent string
 	// ShowSecrets includes secret fields in output when true.
 	ShowSecrets bool
+	// NamingStrategy derives displayed field names, matching Options.NamingStrategy; defaults to SnakeCase.
+	NamingStrategy NamingStrategy
 }
 
 // Inspect returns a human-readable representation of a struct's resolved state.
@@ -79,28 +81,124 @@ func MustInspect(source interface{}, opts ...*InspectOptions) string {
 }
 
 func getInspectOptions(opts ...*InspectOptions) *InspectOptions {
-	if len(opts) == 0 || opts[0] == nil {
-		return &InspectOptions{
-			MaxDepth:    10,
-			Indent:      "  ",
-			ShowSecrets: false,
-		}
+	var opt InspectOptions
+	if len(opts) > 0 && opts[0] != nil {
+		opt = *opts[0]
 	}
-	opt := *opts[0]
 	if opt.MaxDepth <= 0 {
 		opt.MaxDepth = 10
 	}
 	if opt.Indent == "" {
 		opt.Indent = "  "
 	}
+	if opt.NamingStrategy == nil {
+		if defaults := DefaultOptions(); defaults != nil {
+			opt.NamingStrategy = defaults.NamingStrategy
+		}
+	}
 	return &opt
 }
 
+// inspectField is a bindable field of an inspected struct, with embedded struct fields flattened into the parent.
+type inspectField struct {
+	name        string
+	tag         DdTag
+	fieldVal    reflect.Value
+	displayName string
+}
+
+// collectInspectFields returns the fields of structVal shown by Inspect.
+func collectInspectFields(structVal reflect.Value, naming NamingStrategy) []inspectField {
+	structType := structVal.Type()
+	var fields []inspectField
+
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+
+		fieldVal := structVal.Field(meta.index)
+
+		// handle embedded structs by flattening their fields into the parent
+		if isInlined(field, meta.tag) {
+			var embeddedVal reflect.Value
+			if field.Type.Kind() == reflect.Ptr {
+				if fieldVal.IsNil() {
+					continue // skip nil embedded pointer
+				}
+				embeddedVal = fieldVal.Elem()
+			} else {
+				embeddedVal = fieldVal
+			}
+
+			if embeddedVal.Kind() == reflect.Struct {
+				// recursively collect embedded struct fields
+				embeddedType := embeddedVal.Type()
+				for _, embedded := range cachedFields(embeddedType) {
+					embeddedField := embedded.field
+					if embeddedField.PkgPath != "" { // unexported
+						continue
+					}
+
+					embeddedTag := embedded.tag
+					if embeddedTag.Skip {
+						continue
+					}
+
+					embeddedName := embedded.externalName(naming)
+
+					embeddedFieldVal := embeddedVal.Field(embedded.index)
+
+					// calculate display name with secret annotation
+					embeddedDisplayName := embeddedName
+					if embeddedTag.Secret {
+						embeddedDisplayName += " (secret)"
+					}
+
+					fields = append(fields, inspectField{
+						name:        embeddedName,
+						tag:         embeddedTag,
+						fieldVal:    embeddedFieldVal,
+						displayName: embeddedDisplayName,
+					})
+				}
+			}
+			continue
+		}
+
+		tag := meta.tag
+		if tag.Skip {
+			continue
+		}
+		name := meta.externalName(naming)
+
+		// calculate display name with secret annotation
+		displayName := name
+		if tag.Secret {
+			displayName += " (secret)"
+		}
+
+		fields = append(fields, inspectField{
+			name:        name,
+			tag:         tag,
+			fieldVal:    fieldVal,
+			displayName: displayName,
+		})
+	}
+	return fields
+}
+
 func calculateMaxDepth(val reflect.Value, depth int, opt *InspectOptions) int {
 	if depth > opt.MaxDepth {
 		return depth
 	}
 
+	// optionals are laid out as their value
+	if isOptionalType(val.Type()) {
+		val = val.Field(0)
+	}
+
 	maxDepth := depth
 
 	// handle different value types
@@ -134,16 +232,16 @@ func calculateMaxDepthFromStruct(structVal reflect.Value, depth int, opt *Inspec
 	structType := structVal.Type()
 	maxDepth := depth
 
-	for i := 0; i < structVal.NumField(); i++ {
-		field := structType.Field(i)
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
 		if field.PkgPath != "" { // unexported
 			continue
 		}
 
-		fieldVal := structVal.Field(i)
+		fieldVal := structVal.Field(meta.index)
 
 		// handle embedded structs by recursively calculating their depth
-		if field.Anonymous {
+		if isInlined(field, meta.tag) {
 			var embeddedVal reflect.Value
 			if field.Type.Kind() == reflect.Ptr {
 				if fieldVal.IsNil() {
@@ -160,7 +258,7 @@ func calculateMaxDepthFromStruct(structVal reflect.Value, depth int, opt *Inspec
 			continue
 		}
 
-		tag := parseDdTag(field)
+		tag := meta.tag
 		if tag.Skip {
 			continue
 		}
@@ -177,6 +275,11 @@ func calculateMaxFieldNameLength(val reflect.Value, depth int, opt *InspectOptio
 		return 0
 	}
 
+	// optionals are laid out as their value
+	if isOptionalType(val.Type()) {
+		val = val.Field(0)
+	}
+
 	maxLength := 0
 
 	// handle different value types
@@ -210,16 +313,16 @@ func calculateMaxFieldNameLengthFromStruct(structVal reflect.Value, depth int, o
 	structType := structVal.Type()
 	maxLength := 0
 
-	for i := 0; i < structVal.NumField(); i++ {
-		field := structType.Field(i)
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
 		if field.PkgPath != "" { // unexported
 			continue
 		}
 
-		fieldVal := structVal.Field(i)
+		fieldVal := structVal.Field(meta.index)
 
 		// handle embedded structs by recursively calculating their field name lengths
-		if field.Anonymous {
+		if isInlined(field, meta.tag) {
 			var embeddedVal reflect.Value
 			if field.Type.Kind() == reflect.Ptr {
 				if fieldVal.IsNil() {
@@ -236,15 +339,12 @@ func calculateMaxFieldNameLengthFromStruct(structVal reflect.Value, depth int, o
 			continue
 		}
 
-		tag := parseDdTag(field)
+		tag := meta.tag
 		if tag.Skip {
 			continue
 		}
 
-		name := tag.Name
-		if name == "" {
-			name = toSnakeCase(field.Name)
-		}
+		name := meta.externalName(opt.NamingStrategy)
 
 		// calculate display name with secret annotation
 		displayName := name
@@ -276,96 +376,7 @@ func inspectStructWithAlignment(structVal reflect.Value, builder *strings.Builde
 	builder.WriteString(typeName)
 	builder.WriteString(" {\n")
 
-	// collect field info
-	type fieldInfo struct {
-		name        string
-		tag         DdTag
-		fieldVal    reflect.Value
-		displayName string
-	}
-
-	var fields []fieldInfo
-
-	for i := 0; i < structVal.NumField(); i++ {
-		field := structType.Field(i)
-		if field.PkgPath != "" { // unexported
-			continue
-		}
-
-		fieldVal := structVal.Field(i)
-
-		// handle embedded structs by flattening their fields into the parent
-		if field.Anonymous {
-			var embeddedVal reflect.Value
-			if field.Type.Kind() == reflect.Ptr {
-				if fieldVal.IsNil() {
-					continue // skip nil embedded pointer
-				}
-				embeddedVal = fieldVal.Elem()
-			} else {
-				embeddedVal = fieldVal
-			}
-
-			if embeddedVal.Kind() == reflect.Struct {
-				// recursively collect embedded struct fields
-				embeddedType := embeddedVal.Type()
-				for j := 0; j < embeddedVal.NumField(); j++ {
-					embeddedField := embeddedType.Field(j)
-					if embeddedField.PkgPath != "" { // unexported
-						continue
-					}
-
-					embeddedTag := parseDdTag(embeddedField)
-					if embeddedTag.Skip {
-						continue
-					}
-
-					embeddedName := embeddedTag.Name
-					if embeddedName == "" {
-						embeddedName = toSnakeCase(embeddedField.Name)
-					}
-
-					embeddedFieldVal := embeddedVal.Field(j)
-
-					// calculate display name with secret annotation
-					embeddedDisplayName := embeddedName
-					if embeddedTag.Secret {
-						embeddedDisplayName += " (secret)"
-					}
-
-					fields = append(fields, fieldInfo{
-						name:        embeddedName,
-						tag:         embeddedTag,
-						fieldVal:    embeddedFieldVal,
-						displayName: embeddedDisplayName,
-					})
-				}
-			}
-			continue
-		}
-
-		tag := parseDdTag(field)
-		if tag.Skip {
-			continue
-		}
-		name := tag.Name
-		if name == "" {
-			name = toSnakeCase(field.Name)
-		}
-
-		// calculate display name with secret annotation
-		displayName := name
-		if tag.Secret {
-			displayName += " (secret)"
-		}
-
-		fields = append(fields, fieldInfo{
-			name:        name,
-			tag:         tag,
-			fieldVal:    fieldVal,
-			displayName: displayName,
-		})
-	}
+	fields := collectInspectFields(structVal, opt.NamingStrategy)
 
 	hasFields := len(fields) > 0
 	for _, f := range fields {
@@ -394,6 +405,13 @@ func inspectStructWithAlignment(structVal reflect.Value, builder *strings.Builde
 			} else {
 				builder.WriteString("<set>")
 			}
+		} else if f.tag.Raw {
+			// raw nodes are opaque; show whether one was captured
+			if _, ok := unbindRawNode(f.fieldVal); ok {
+				builder.WriteString("<raw>")
+			} else {
+				builder.WriteString("<unset>")
+			}
 		} else {
 			if err := inspectValueWithAlignment(f.fieldVal, builder, depth+1, opt, globalColonPos); err != nil {
 				return err
@@ -440,6 +458,29 @@ func inspectValueWithAlignment(val reflect.Value, builder *strings.Builder, dept
 		return inspectPointerTypeWithAlignment(val, builder, depth, opt, globalColonPos)
 	}
 
+	// optionals show their value, or <unset>
+	if isOptionalType(val.Type()) {
+		if !val.Field(1).Bool() {
+			builder.WriteString("<unset>")
+			return nil
+		}
+		return inspectValueWithAlignment(val.Field(0), builder, depth, opt, globalColonPos)
+	}
+
+	// big values, UUIDs, and netip values are shown by their string form
+	if out, ok := unbindBig(val); ok {
+		builder.WriteString(out.(string))
+		return nil
+	}
+	if out, ok := unbindUUID(val); ok {
+		builder.WriteString(out.(string))
+		return nil
+	}
+	if out, ok := unbindNetip(val); ok {
+		builder.WriteString(out.(string))
+		return nil
+	}
+
 	// check for Dynamic interface
 	if val.Type() == dynamicInterfaceType {
 		if val.IsNil() {
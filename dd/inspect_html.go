@@ -0,0 +1,176 @@
+package dd
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InspectHTMLStyle is a default stylesheet for the markup produced by InspectHTML. embed it in a <style> element, or
+// style the dd-* classes directly.
+const InspectHTMLStyle = `.dd-inspect { font-family: monospace; font-size: 13px; }
+.dd-inspect ul { list-style: none; margin: 0; padding-left: 1.5em; }
+.dd-inspect summary { cursor: pointer; }
+.dd-inspect .dd-key { font-weight: bold; }
+.dd-inspect .dd-type { color: #888; font-style: italic; margin-left: 0.5em; }
+.dd-inspect .dd-value { color: #07a; }
+.dd-inspect .dd-secret, .dd-inspect .dd-empty { color: #a50; }
+`
+
+// InspectHTML returns an HTML rendering of a struct's resolved state, for embedding a live view of bound
+// configuration in an admin dashboard. nested structs, slices, and maps are rendered as collapsible <details>
+// elements, and every field is annotated with its Go type. secret fields are masked as in Inspect unless ShowSecrets
+// is true. the markup uses dd-* CSS classes; see InspectHTMLStyle for a default stylesheet.
+//
+// the output is a single <div class="dd-inspect"> fragment; all values are HTML-escaped.
+//
+// opts are optional; pass nil or omit to use defaults. Indent is ignored.
+func InspectHTML(source interface{}, opts ...*InspectOptions) (string, error) {
+	opt := getInspectOptions(opts...)
+
+	var builder strings.Builder
+	builder.WriteString(`<div class="dd-inspect">`)
+	if source == nil {
+		builder.WriteString(`<span class="dd-empty">&lt;nil&gt;</span></div>`)
+		return builder.String(), nil
+	}
+	val := reflect.ValueOf(source)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			builder.WriteString(`<span class="dd-empty">&lt;nil&gt;</span></div>`)
+			return builder.String(), nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return "", &TypeMismatchError{Expected: "struct or pointer to struct", Actual: fmt.Sprintf("%T", source)}
+	}
+
+	typeName := val.Type().Name()
+	if typeName == "" {
+		typeName = "struct"
+	}
+	if err := inspectHTMLStruct(val, `<span class="dd-type">`+html.EscapeString(typeName)+`</span>`, &builder, 0, opt); err != nil {
+		return "", err
+	}
+	builder.WriteString("</div>")
+	return builder.String(), nil
+}
+
+// inspectHTMLStruct renders the fields of structVal as a collapsible list headed by summary.
+func inspectHTMLStruct(structVal reflect.Value, summary string, builder *strings.Builder, depth int, opt *InspectOptions) error {
+	fields := collectInspectFields(structVal, opt.NamingStrategy)
+	children := make([]func() error, 0, len(fields))
+	for _, f := range fields {
+		f := f
+		children = append(children, func() error {
+			label := `<span class="dd-key">` + html.EscapeString(f.name) + `</span>` + inspectHTMLType(f.fieldVal.Type())
+			if f.tag.Secret && !opt.ShowSecrets {
+				masked := "&lt;set&gt;"
+				if isSecretFieldEmpty(f.fieldVal) {
+					masked = "&lt;unset&gt;"
+				}
+				builder.WriteString(label + `: <span class="dd-secret">` + masked + `</span>`)
+				return nil
+			}
+			if f.tag.Raw {
+				shown := "&lt;unset&gt;"
+				if _, ok := unbindRawNode(f.fieldVal); ok {
+					shown = "&lt;raw&gt;"
+				}
+				builder.WriteString(label + `: <span class="dd-empty">` + shown + `</span>`)
+				return nil
+			}
+			return inspectHTMLValue(f.fieldVal, label, builder, depth+1, opt)
+		})
+	}
+	return inspectHTMLList(summary, children, builder)
+}
+
+// inspectHTMLValue renders val: containers as collapsible lists, everything else as a single labelled value.
+func inspectHTMLValue(val reflect.Value, label string, builder *strings.Builder, depth int, opt *InspectOptions) error {
+	if depth > opt.MaxDepth {
+		builder.WriteString(label + `: <span class="dd-empty">&lt;max depth reached&gt;</span>`)
+		return nil
+	}
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			builder.WriteString(label + `: <span class="dd-empty">&lt;nil&gt;</span>`)
+			return nil
+		}
+		if val.Type() == dynamicInterfaceType {
+			break
+		}
+		val = val.Elem()
+	}
+	if isOptionalType(val.Type()) {
+		if !val.Field(1).Bool() {
+			builder.WriteString(label + `: <span class="dd-empty">&lt;unset&gt;</span>`)
+			return nil
+		}
+		return inspectHTMLValue(val.Field(0), label, builder, depth, opt)
+	}
+
+	t := val.Type()
+	switch {
+	case t.Kind() == reflect.Struct && !isScalarStruct(t) && !isPointerType(t):
+		return inspectHTMLStruct(val, label, builder, depth, opt)
+
+	case t.Kind() == reflect.Slice && !val.IsNil() && t != jsonRawMessageType:
+		children := make([]func() error, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			item := val.Index(i)
+			itemLabel := `<span class="dd-key">[` + strconv.Itoa(i) + `]</span>`
+			children = append(children, func() error { return inspectHTMLValue(item, itemLabel, builder, depth+1, opt) })
+		}
+		return inspectHTMLList(label, children, builder)
+
+	case t.Kind() == reflect.Map && !val.IsNil():
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+		children := make([]func() error, 0, len(keys))
+		for _, key := range keys {
+			item := val.MapIndex(key)
+			keyText := fmt.Sprint(key.Interface())
+			if key.Kind() == reflect.String {
+				keyText = strconv.Quote(key.String())
+			}
+			itemLabel := `<span class="dd-key">` + html.EscapeString(keyText) + `</span>`
+			children = append(children, func() error { return inspectHTMLValue(item, itemLabel, builder, depth+1, opt) })
+		}
+		return inspectHTMLList(label, children, builder)
+	}
+
+	// scalars use the same textual form as Inspect
+	var text strings.Builder
+	if err := inspectValueWithAlignment(val, &text, depth, opt, 0); err != nil {
+		return err
+	}
+	builder.WriteString(label + `: <span class="dd-value">` + html.EscapeString(text.String()) + `</span>`)
+	return nil
+}
+
+// inspectHTMLList writes a <details> element headed by summary, with one list item per child.
+func inspectHTMLList(summary string, children []func() error, builder *strings.Builder) error {
+	builder.WriteString("<details open><summary>" + summary + "</summary><ul>")
+	if len(children) == 0 {
+		builder.WriteString(`<li><span class="dd-empty">&lt;empty&gt;</span></li>`)
+	}
+	for _, child := range children {
+		builder.WriteString("<li>")
+		if err := child(); err != nil {
+			return err
+		}
+		builder.WriteString("</li>")
+	}
+	builder.WriteString("</ul></details>")
+	return nil
+}
+
+// inspectHTMLType renders a type annotation.
+func inspectHTMLType(t reflect.Type) string {
+	return `<span class="dd-type">` + html.EscapeString(t.String()) + `</span>`
+}
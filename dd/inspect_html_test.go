@@ -0,0 +1,65 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type htmlServer struct {
+	Host string
+	Port int
+}
+
+type htmlConfig struct {
+	Name     string
+	Password string `dd:",+secret"`
+	Timeout  time.Duration
+	Server   *htmlServer
+	Backup   *htmlServer
+	Tags     []string
+	Labels   map[string]string
+}
+
+func TestInspectHTML(t *testing.T) {
+	cfg := &htmlConfig{
+		Name:     "<app>",
+		Password: "hunter2",
+		Timeout:  30 * time.Second,
+		Server:   &htmlServer{Host: "localhost", Port: 8080},
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]string{"zone": "east", "tier": "web"},
+	}
+
+	out, err := InspectHTML(cfg)
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(out, `<div class="dd-inspect"><details open><summary><span class="dd-type">htmlConfig</span></summary>`))
+	assert.Contains(t, out, `<span class="dd-key">name</span><span class="dd-type">string</span>: <span class="dd-value">&#34;&lt;app&gt;&#34;</span>`)
+	assert.Contains(t, out, `<span class="dd-key">password</span><span class="dd-type">string</span>: <span class="dd-secret">&lt;set&gt;</span>`)
+	assert.NotContains(t, out, "hunter2")
+	assert.Contains(t, out, `<span class="dd-value">30s</span>`)
+	assert.Contains(t, out, `<details open><summary><span class="dd-key">server</span><span class="dd-type">*dd.htmlServer</span></summary><ul><li><span class="dd-key">host</span>`)
+	assert.Contains(t, out, `<span class="dd-key">backup</span><span class="dd-type">*dd.htmlServer</span>: <span class="dd-empty">&lt;nil&gt;</span>`)
+	assert.Contains(t, out, `<span class="dd-key">[1]</span>: <span class="dd-value">&#34;b&#34;</span>`)
+	// map keys are sorted
+	assert.Less(t, strings.Index(out, "&#34;tier&#34;"), strings.Index(out, "&#34;zone&#34;"))
+	assert.True(t, strings.HasSuffix(out, "</div>"))
+}
+
+func TestInspectHTMLShowSecrets(t *testing.T) {
+	out, err := InspectHTML(&htmlConfig{Password: "hunter2"}, &InspectOptions{ShowSecrets: true})
+	assert.NoError(t, err)
+	assert.Contains(t, out, "hunter2")
+}
+
+func TestInspectHTMLErrors(t *testing.T) {
+	out, err := InspectHTML(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `<div class="dd-inspect"><span class="dd-empty">&lt;nil&gt;</span></div>`, out)
+
+	_, err = InspectHTML(42)
+	assert.Error(t, err)
+}
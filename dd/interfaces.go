@@ -0,0 +1,87 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// interfaceBinders returns the implementations registered for the interface type t in Options.InterfaceBinders, or nil.
+func interfaceBinders(t reflect.Type, opt *Options) map[string]func() any {
+	if opt == nil || opt.InterfaceBinders == nil || t.Kind() != reflect.Interface {
+		return nil
+	}
+	return opt.InterfaceBinders[t]
+}
+
+// bindInterface sets an interface-typed destination by selecting an implementation registered in
+// Options.InterfaceBinders using the `type` discriminator in raw, and binding the remaining keys into it. returns
+// false if no implementations are registered for the destination type.
+func bindInterface(dst reflect.Value, raw interface{}, path string, opt *Options) (bool, error) {
+	binders := interfaceBinders(dst.Type(), opt)
+	if binders == nil {
+		return false, nil
+	}
+	ifaceName := dst.Type().String()
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return true, &TypeMismatchError{Path: path, Expected: "object for " + ifaceName, Actual: fmt.Sprintf("%T", raw)}
+	}
+	tVal, ok := m[TypeKey]
+	if !ok {
+		return true, fmt.Errorf("%s: missing '%v' discriminator for %s field", path, TypeKey, ifaceName)
+	}
+	typeStr, ok := tVal.(string)
+	if !ok || strings.TrimSpace(typeStr) == "" {
+		return true, fmt.Errorf("%s: invalid '%v' discriminator for %s field: %v", path, TypeKey, ifaceName, tVal)
+	}
+	factory := binders[typeStr]
+	if factory == nil {
+		return true, fmt.Errorf("%s: unknown %s type %q", path, ifaceName, typeStr)
+	}
+
+	// bind into the pointed-to struct, or into an addressable copy of a struct value
+	var impl, target reflect.Value
+	produced := reflect.ValueOf(factory())
+	switch {
+	case produced.Kind() == reflect.Ptr && !produced.IsNil() && produced.Elem().Kind() == reflect.Struct:
+		impl, target = produced, produced.Elem()
+	case produced.Kind() == reflect.Struct:
+		target = reflect.New(produced.Type()).Elem()
+		target.Set(produced)
+		impl = target
+	default:
+		return true, fmt.Errorf("%s: factory for %s type %q must return a struct or a non-nil pointer to one", path, ifaceName, typeStr)
+	}
+	if !impl.Type().Implements(dst.Type()) {
+		return true, fmt.Errorf("%s: %v does not implement %s", path, impl.Type(), ifaceName)
+	}
+
+	fields := make(map[string]any, len(m))
+	for k, v := range m {
+		if k != TypeKey {
+			fields[k] = v
+		}
+	}
+	if err := bindStruct(target, fields, path, opt, false, nil); err != nil {
+		return true, err
+	}
+	dst.Set(impl)
+	return true, nil
+}
+
+// interfaceTypeName returns the discriminator under which the concrete type of v is registered for the interface type
+// t in Options.InterfaceBinders. factories are invoked to learn the types they produce.
+func interfaceTypeName(t reflect.Type, v reflect.Value, opt *Options) (string, bool) {
+	binders := interfaceBinders(t, opt)
+	if binders == nil || !v.IsValid() {
+		return "", false
+	}
+	concrete := v.Type()
+	for name, factory := range binders {
+		if reflect.TypeOf(factory()) == concrete {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,87 @@
+package dd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ifaceSink interface {
+	Write(msg string) string
+}
+
+type ifaceFileSink struct {
+	Path string
+}
+
+func (s *ifaceFileSink) Write(msg string) string { return s.Path + ": " + msg }
+
+type ifaceConsoleSink struct {
+	Color bool
+}
+
+func (s ifaceConsoleSink) Write(msg string) string { return msg }
+
+type ifacePipeline struct {
+	Primary ifaceSink
+	Sinks   []ifaceSink
+	Named   map[string]ifaceSink
+}
+
+var ifaceSinkType = reflect.TypeOf((*ifaceSink)(nil)).Elem()
+
+func ifaceOptions() *Options {
+	return &Options{InterfaceBinders: map[reflect.Type]map[string]func() any{
+		ifaceSinkType: {
+			"file":    func() any { return &ifaceFileSink{} },
+			"console": func() any { return ifaceConsoleSink{} },
+		},
+	}}
+}
+
+func TestInterfaceBinders(t *testing.T) {
+	data := map[string]any{
+		"primary": map[string]any{"type": "file", "path": "/var/log/app"},
+		"sinks": []any{
+			map[string]any{"type": "console", "color": true},
+			map[string]any{"type": "file", "path": "/tmp/out"},
+		},
+		"named": map[string]any{"debug": map[string]any{"type": "console", "color": false}},
+	}
+	p, err := New[ifacePipeline](data, ifaceOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, &ifaceFileSink{Path: "/var/log/app"}, p.Primary)
+	assert.Equal(t, []ifaceSink{ifaceConsoleSink{Color: true}, &ifaceFileSink{Path: "/tmp/out"}}, p.Sinks)
+	assert.Equal(t, map[string]ifaceSink{"debug": ifaceConsoleSink{}}, p.Named)
+
+	out, err := Unbind(p, ifaceOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, data, out)
+}
+
+func TestInterfaceBindersErrors(t *testing.T) {
+	_, err := New[ifacePipeline](map[string]any{"primary": map[string]any{"type": "syslog"}}, ifaceOptions())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown dd.ifaceSink type "syslog"`)
+
+	_, err = New[ifacePipeline](map[string]any{"primary": map[string]any{"path": "x"}}, ifaceOptions())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing 'type' discriminator")
+
+	_, err = New[ifacePipeline](map[string]any{"primary": "file"}, ifaceOptions())
+	var mismatch *TypeMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+
+	// unregistered interfaces remain unsupported
+	_, err = New[ifacePipeline](map[string]any{"primary": map[string]any{"type": "file"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "interface fields are not supported")
+}
+
+func TestInterfaceBindersUnregisteredUnbind(t *testing.T) {
+	// without a registration, the concrete value is unbound without a discriminator
+	out, err := Unbind(&ifacePipeline{Primary: &ifaceFileSink{Path: "p"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"path": "p"}, out["primary"])
+}
@@ -1,9 +1,12 @@
 package dd
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"os"
+	"reflect"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,56 +15,90 @@ import (
 
 // BindJSON parses JSON data and binds it to the target struct.
 func BindJSON(target interface{}, data []byte, opts ...*Options) error {
-	var m map[string]any
-	if err := json.Unmarshal(data, &m); err != nil {
-		return &ConversionError{Type: "JSON", Message: "failed to parse", Cause: err}
+	m, bindOpts, err := decodeDocument("json", data, reflect.TypeOf(target), opts)
+	if err != nil {
+		return parseError("JSON", data, err)
 	}
-	return Bind(target, m, opts...)
+	return Bind(target, m, bindOpts...)
 }
 
 // BindYAML parses YAML data and binds it to the target struct.
 func BindYAML(target interface{}, data []byte, opts ...*Options) error {
-	var m map[string]any
-	if err := yaml.Unmarshal(data, &m); err != nil {
-		return &ConversionError{Type: "YAML", Message: "failed to parse", Cause: err}
+	m, bindOpts, err := decodeDocument("yaml", data, reflect.TypeOf(target), opts)
+	if err != nil {
+		return parseError("YAML", data, err)
 	}
-	return Bind(target, m, opts...)
+	return Bind(target, m, bindOpts...)
 }
 
 // NewJSON parses JSON data and returns a new instance of type T.
 func NewJSON[T any](data []byte, opts ...*Options) (*T, error) {
-	var m map[string]any
-	if err := json.Unmarshal(data, &m); err != nil {
-		return nil, &ConversionError{Type: "JSON", Message: "failed to parse", Cause: err}
+	m, bindOpts, err := decodeDocument("json", data, reflect.TypeOf((*T)(nil)), opts)
+	if err != nil {
+		return nil, parseError("JSON", data, err)
 	}
-	return New[T](m, opts...)
+	return New[T](m, bindOpts...)
 }
 
 // NewYAML parses YAML data and returns a new instance of type T.
 func NewYAML[T any](data []byte, opts ...*Options) (*T, error) {
-	var m map[string]any
-	if err := yaml.Unmarshal(data, &m); err != nil {
-		return nil, &ConversionError{Type: "YAML", Message: "failed to parse", Cause: err}
+	m, bindOpts, err := decodeDocument("yaml", data, reflect.TypeOf((*T)(nil)), opts)
+	if err != nil {
+		return nil, parseError("YAML", data, err)
 	}
-	return New[T](m, opts...)
+	return New[T](m, bindOpts...)
 }
 
 // MergeJSON parses JSON data and merges it with the target struct.
 func MergeJSON(target interface{}, data []byte, opts ...*Options) error {
-	var m map[string]any
-	if err := json.Unmarshal(data, &m); err != nil {
-		return &ConversionError{Type: "JSON", Message: "failed to parse", Cause: err}
+	m, bindOpts, err := decodeDocument("json", data, reflect.TypeOf(target), opts)
+	if err != nil {
+		return parseError("JSON", data, err)
 	}
-	return Merge(target, m, opts...)
+	return Merge(target, m, bindOpts...)
 }
 
 // MergeYAML parses YAML data and merges it with the target struct.
 func MergeYAML(target interface{}, data []byte, opts ...*Options) error {
+	m, bindOpts, err := decodeDocument("yaml", data, reflect.TypeOf(target), opts)
+	if err != nil {
+		return parseError("YAML", data, err)
+	}
+	return Merge(target, m, bindOpts...)
+}
+
+// decodeDocument decodes data in format ("json" or "yaml") into a map for binding into a value of type t. when t has
+// `+raw` fields, the document's nodes are indexed in the options returned, so that the bind walk captures the
+// original nodes; otherwise opts are returned as given.
+func decodeDocument(format string, data []byte, t reflect.Type, opts []*Options) (map[string]any, []*Options, error) {
+	opt, err := getOptions(opts...)
+	if err != nil || t == nil || !hasRawFields(t) {
+		var m map[string]any
+		if format == "json" {
+			err = decodeJSON(data, &m, opts...)
+		} else {
+			err = yaml.Unmarshal(data, &m)
+		}
+		return m, opts, err
+	}
+	indexed := Options{}
+	if opt != nil {
+		indexed = *opt
+	}
+	indexed.rawNodes = &rawNodeIndex{}
 	var m map[string]any
-	if err := yaml.Unmarshal(data, &m); err != nil {
-		return &ConversionError{Type: "YAML", Message: "failed to parse", Cause: err}
+	if format == "json" {
+		if m, err = decodeJSONIndexed(data, indexed.PreciseNumbers, indexed.rawNodes); err != nil {
+			// report the error as decodeJSON does
+			if plainErr := decodeJSON(data, &m, opts...); plainErr != nil {
+				err = plainErr
+			}
+			return nil, nil, err
+		}
+	} else if m, err = decodeYAMLIndexed(data, indexed.rawNodes); err != nil {
+		return nil, nil, err
 	}
-	return Merge(target, m, opts...)
+	return m, []*Options{&indexed}, nil
 }
 
 // UnbindJSON converts a struct to JSON bytes.
@@ -70,7 +107,15 @@ func UnbindJSON(source interface{}, opts ...*Options) ([]byte, error) {
 	if err != nil {
 		return nil, &ConversionError{Message: "failed to unbind source", Cause: err}
 	}
-	data, err := json.MarshalIndent(m, "", "  ")
+	if err := resolveSourceRawNodes(source, m, "json"); err != nil {
+		return nil, &ConversionError{Type: "JSON", Message: "failed to convert raw node", Cause: err}
+	}
+	out, err := orderedOutput(source, m, opts...)
+	if err != nil {
+		return nil, err
+	}
+	opt, _ := getOptions(opts...)
+	data, err := encodeJSON(out, opt)
 	if err != nil {
 		return nil, &ConversionError{Type: "JSON", Message: "failed to marshal", Cause: err}
 	}
@@ -83,7 +128,15 @@ func UnbindYAML(source interface{}, opts ...*Options) ([]byte, error) {
 	if err != nil {
 		return nil, &ConversionError{Message: "failed to unbind source", Cause: err}
 	}
-	data, err := yaml.Marshal(m)
+	if err := resolveSourceRawNodes(source, m, "yaml"); err != nil {
+		return nil, &ConversionError{Type: "YAML", Message: "failed to convert raw node", Cause: err}
+	}
+	out, err := orderedOutput(source, m, opts...)
+	if err != nil {
+		return nil, err
+	}
+	opt, _ := getOptions(opts...)
+	data, err := encodeYAML(out, opt)
 	if err != nil {
 		return nil, &ConversionError{Type: "YAML", Message: "failed to marshal", Cause: err}
 	}
@@ -178,7 +231,7 @@ func BindJSONFile(target interface{}, path string, opts ...*Options) error {
 	if err != nil {
 		return &FileError{Path: path, Operation: "read JSON", Cause: err}
 	}
-	return BindJSON(target, data, opts...)
+	return withFile(BindJSON(target, data, opts...), path)
 }
 
 // BindYAMLFile reads YAML from the specified file path and binds it to the target struct.
@@ -187,7 +240,7 @@ func BindYAMLFile(target interface{}, path string, opts ...*Options) error {
 	if err != nil {
 		return &FileError{Path: path, Operation: "read YAML", Cause: err}
 	}
-	return BindYAML(target, data, opts...)
+	return withFile(BindYAML(target, data, opts...), path)
 }
 
 // NewJSONFile reads JSON from the specified file path and returns a new instance of type T.
@@ -196,7 +249,8 @@ func NewJSONFile[T any](path string, opts ...*Options) (*T, error) {
 	if err != nil {
 		return nil, &FileError{Path: path, Operation: "read JSON", Cause: err}
 	}
-	return NewJSON[T](data, opts...)
+	target, err := NewJSON[T](data, opts...)
+	return target, withFile(err, path)
 }
 
 // NewYAMLFile reads YAML from the specified file path and returns a new instance of type T.
@@ -205,7 +259,8 @@ func NewYAMLFile[T any](path string, opts ...*Options) (*T, error) {
 	if err != nil {
 		return nil, &FileError{Path: path, Operation: "read YAML", Cause: err}
 	}
-	return NewYAML[T](data, opts...)
+	target, err := NewYAML[T](data, opts...)
+	return target, withFile(err, path)
 }
 
 // MergeJSONFile reads JSON from the specified file path and merges it with the target struct.
@@ -214,7 +269,7 @@ func MergeJSONFile(target interface{}, path string, opts ...*Options) error {
 	if err != nil {
 		return &FileError{Path: path, Operation: "read JSON", Cause: err}
 	}
-	return MergeJSON(target, data, opts...)
+	return withFile(MergeJSON(target, data, opts...), path)
 }
 
 // MergeYAMLFile reads YAML from the specified file path and merges it with the target struct.
@@ -223,29 +278,108 @@ func MergeYAMLFile(target interface{}, path string, opts ...*Options) error {
 	if err != nil {
 		return &FileError{Path: path, Operation: "read YAML", Cause: err}
 	}
-	return MergeYAML(target, data, opts...)
+	return withFile(MergeYAML(target, data, opts...), path)
 }
 
-// UnbindJSONFile converts a struct to JSON and writes it to the specified file path.
+// UnbindJSONFile converts a struct to JSON and writes it to the specified file path, replacing any
+// existing file atomically.
 func UnbindJSONFile(source interface{}, path string, opts ...*Options) error {
 	data, err := UnbindJSON(source, opts...)
 	if err != nil {
 		return err
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return &FileError{Path: path, Operation: "write JSON", Cause: err}
-	}
-	return nil
+	return writeFile(path, data, "write JSON", opts...)
 }
 
-// UnbindYAMLFile converts a struct to YAML and writes it to the specified file path.
+// UnbindYAMLFile converts a struct to YAML and writes it to the specified file path, replacing any
+// existing file atomically.
 func UnbindYAMLFile(source interface{}, path string, opts ...*Options) error {
 	data, err := UnbindYAML(source, opts...)
 	if err != nil {
 		return err
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return &FileError{Path: path, Operation: "write YAML", Cause: err}
+	return writeFile(path, data, "write YAML", opts...)
+}
+
+// decodeJSON unmarshals data into v, decoding numbers as json.Number when Options.PreciseNumbers is set, so that
+// integers beyond 2^53 (e.g. int64 IDs) reach the coercion layer with every digit intact.
+func decodeJSON(data []byte, v any, opts ...*Options) error {
+	opt, _ := getOptions(opts...)
+	if opt == nil || !opt.PreciseNumbers {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
 	}
 	return nil
 }
+
+// encodeJSON marshals v as indented JSON, following Options.JSONIndent and Options.JSONDisableHTMLEscape.
+func encodeJSON(v any, opt *Options) ([]byte, error) {
+	indent := "  "
+	escapeHTML := true
+	if opt != nil {
+		if opt.JSONIndent != "" {
+			indent = opt.JSONIndent
+		}
+		escapeHTML = !opt.JSONDisableHTMLEscape
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", indent)
+	enc.SetEscapeHTML(escapeHTML)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// encodeYAML marshals v as YAML, following Options.YAMLIndent, Options.YAMLFlowLists, and Options.YAMLAnchors.
+func encodeYAML(v any, opt *Options) ([]byte, error) {
+	if opt != nil && (opt.YAMLAnchors || opt.YAMLFlowLists > 0) {
+		node := &yaml.Node{}
+		if err := node.Encode(v); err != nil {
+			return nil, err
+		}
+		if opt.YAMLFlowLists > 0 {
+			flowShortLists(node, opt.YAMLFlowLists)
+		}
+		if opt.YAMLAnchors {
+			anchorSharedSubtrees(node)
+		}
+		v = node
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	if opt != nil && opt.YAMLIndent > 0 {
+		enc.SetIndent(opt.YAMLIndent)
+	}
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// flowShortLists sets the flow style ("[a, b]") on every sequence within node of at most max scalars.
+func flowShortLists(node *yaml.Node, max int) {
+	if node.Kind == yaml.SequenceNode && len(node.Content) > 0 && len(node.Content) <= max {
+		scalars := true
+		for _, item := range node.Content {
+			scalars = scalars && item.Kind == yaml.ScalarNode
+		}
+		if scalars {
+			node.Style |= yaml.FlowStyle
+		}
+	}
+	for _, child := range node.Content {
+		flowShortLists(child, max)
+	}
+}
@@ -705,3 +705,62 @@ func TestMergeYAMLFileNotFound(t *testing.T) {
 		t.Errorf("expected FileError, got %T", err)
 	}
 }
+
+func TestPreciseNumbersIntegers(t *testing.T) {
+	type record struct {
+		ID      int64
+		Serial  uint64
+		Offsets []int64
+		Score   float64
+		Counts  map[string]uint64
+	}
+	data := []byte(`{"id": 9007199254740993, "serial": 18446744073709551615, "offsets": [-9223372036854775808],
+		"score": 0.1, "counts": {"a": 12345678901234567}}`)
+	opts := &Options{PreciseNumbers: true}
+
+	r, err := NewJSON[record](data, opts)
+	if err != nil {
+		t.Fatalf("NewJSON failed: %v", err)
+	}
+	if r.ID != 9007199254740993 || r.Serial != 18446744073709551615 || r.Offsets[0] != -9223372036854775808 {
+		t.Errorf("integers not exact: %+v", r)
+	}
+	if r.Score != 0.1 || r.Counts["a"] != 12345678901234567 {
+		t.Errorf("unexpected values: %+v", r)
+	}
+
+	// through float64, the same id is rounded
+	r, err = NewJSON[record](data)
+	if err != nil {
+		t.Fatalf("NewJSON failed: %v", err)
+	}
+	if r.ID == 9007199254740993 {
+		t.Errorf("expected float64 decoding to round the id")
+	}
+
+	// layered JSON files and streams decode the same way
+	path := filepath.Join(t.TempDir(), "record.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, _, err = LoadLayered[record]([]Layer{FileLayer(path)}, opts)
+	if err != nil {
+		t.Fatalf("LoadLayered failed: %v", err)
+	}
+	if r.ID != 9007199254740993 {
+		t.Errorf("expected exact id from layer, got %d", r.ID)
+	}
+
+	var ids []int64
+	stream := bytes.NewReader([]byte(`{"id": 9007199254740993} {"id": 9007199254740995}`))
+	err = BindStream[record](stream, func(r *record) error {
+		ids = append(ids, r.ID)
+		return nil
+	}, opts)
+	if err != nil {
+		t.Fatalf("BindStream failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 9007199254740993 || ids[1] != 9007199254740995 {
+		t.Errorf("expected exact ids from stream, got %v", ids)
+	}
+}
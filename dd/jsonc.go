@@ -0,0 +1,181 @@
+package dd
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// stripJSONC converts JSONC (JSON with "//" and "/* */" comments and trailing commas in objects and arrays) to plain
+// JSON. comments and trailing commas are replaced by spaces rather than removed, so that offsets reported by the
+// JSON decoder still point into the original document.
+func stripJSONC(data []byte) ([]byte, error) {
+	out := make([]byte, len(data))
+	copy(out, data)
+	comma := -1   // offset of a comma following a value, not yet followed by another
+	var last byte // the last significant character
+	for i := 0; i < len(out); i++ {
+		switch c := out[i]; {
+		case c == '"':
+			comma, last = -1, c
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			start := i
+			for i += 2; i+1 < len(out) && !(out[i] == '*' && out[i+1] == '/'); i++ {
+			}
+			if i+1 >= len(out) {
+				return nil, &offsetError{msg: "unterminated comment", offset: int64(start)}
+			}
+			for j := start; j <= i+1; j++ {
+				if out[j] != '\n' {
+					out[j] = ' '
+				}
+			}
+			i++
+
+		case c == ',':
+			if comma < 0 && last != 0 && last != '[' && last != '{' && last != ',' && last != ':' {
+				comma = i
+			} else {
+				comma = -1
+			}
+			last = c
+
+		case c == '}' || c == ']':
+			if comma >= 0 {
+				out[comma] = ' '
+			}
+			comma, last = -1, c
+
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			comma, last = -1, c
+		}
+	}
+	return out, nil
+}
+
+// decodeJSONC parses JSONC data into plain JSON, reporting errors as a JSONC ConversionError.
+func decodeJSONC(data []byte) ([]byte, error) {
+	plain, err := stripJSONC(data)
+	if err != nil {
+		return nil, parseError("JSONC", data, err)
+	}
+	return plain, nil
+}
+
+// jsoncError relabels a SyntaxError within err, reported against the plain JSON produced by stripJSONC, as JSONC with
+// its snippet taken from the original data. stripJSONC leaves lines and columns unchanged.
+func jsoncError(err error, data []byte) error {
+	var se *SyntaxError
+	if errors.As(err, &se) {
+		var ce *ConversionError
+		if errors.As(err, &ce) && ce.Cause == se {
+			ce.Type = "JSONC"
+		}
+		se.Format = "JSONC"
+		if se.Line > 0 {
+			se.Snippet = sourceLine(data, se.Line)
+		}
+	}
+	return err
+}
+
+// --- Bytes Layer (base) ---
+
+// BindJSONC parses JSONC data (JSON with comments and trailing commas, as in hand-edited configuration files) and
+// binds it to the target struct. there is no UnbindJSONC; the output of UnbindJSON is valid JSONC.
+func BindJSONC(target interface{}, data []byte, opts ...*Options) error {
+	plain, err := decodeJSONC(data)
+	if err != nil {
+		return err
+	}
+	return jsoncError(BindJSON(target, plain, opts...), data)
+}
+
+// NewJSONC parses JSONC data and returns a new instance of type T.
+func NewJSONC[T any](data []byte, opts ...*Options) (*T, error) {
+	plain, err := decodeJSONC(data)
+	if err != nil {
+		return nil, err
+	}
+	target, err := NewJSON[T](plain, opts...)
+	return target, jsoncError(err, data)
+}
+
+// MergeJSONC parses JSONC data and merges it with the target struct.
+func MergeJSONC(target interface{}, data []byte, opts ...*Options) error {
+	plain, err := decodeJSONC(data)
+	if err != nil {
+		return err
+	}
+	return jsoncError(MergeJSON(target, plain, opts...), data)
+}
+
+// --- Reader/Writer Layer ---
+
+// BindJSONCReader reads JSONC from an io.Reader and binds it to the target struct.
+func BindJSONCReader(target interface{}, r io.Reader, opts ...*Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return BindJSONC(target, data, opts...)
+}
+
+// NewJSONCReader reads JSONC from an io.Reader and returns a new instance of type T.
+func NewJSONCReader[T any](r io.Reader, opts ...*Options) (*T, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return NewJSONC[T](data, opts...)
+}
+
+// MergeJSONCReader reads JSONC from an io.Reader and merges it with the target struct.
+func MergeJSONCReader(target interface{}, r io.Reader, opts ...*Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return MergeJSONC(target, data, opts...)
+}
+
+// --- File Layer ---
+
+// BindJSONCFile reads JSONC from the specified file path and binds it to the target struct.
+func BindJSONCFile(target interface{}, path string, opts ...*Options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &FileError{Path: path, Operation: "read JSONC", Cause: err}
+	}
+	return withFile(BindJSONC(target, data, opts...), path)
+}
+
+// NewJSONCFile reads JSONC from the specified file path and returns a new instance of type T.
+func NewJSONCFile[T any](path string, opts ...*Options) (*T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &FileError{Path: path, Operation: "read JSONC", Cause: err}
+	}
+	target, err := NewJSONC[T](data, opts...)
+	return target, withFile(err, path)
+}
+
+// MergeJSONCFile reads JSONC from the specified file path and merges it with the target struct.
+func MergeJSONCFile(target interface{}, path string, opts ...*Options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &FileError{Path: path, Operation: "read JSONC", Cause: err}
+	}
+	return withFile(MergeJSONC(target, data, opts...), path)
+}
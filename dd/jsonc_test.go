@@ -0,0 +1,71 @@
+package dd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsoncConfig struct {
+	Name  string
+	URL   string `dd:"url"`
+	Ports []int
+	Tags  map[string]string
+}
+
+func TestBindJSONC(t *testing.T) {
+	input := `{
+  // the service name
+  "name": "relay /* not a comment */",
+  "url": "http://example.com//path", /* trailing block */
+  "ports": [
+    80,
+    443, // https
+  ],
+  "tags": {"tier": "web",},
+}
+`
+	cfg, err := NewJSONC[jsoncConfig]([]byte(input))
+	assert.NoError(t, err)
+	assert.Equal(t, &jsoncConfig{
+		Name:  "relay /* not a comment */",
+		URL:   "http://example.com//path",
+		Ports: []int{80, 443},
+		Tags:  map[string]string{"tier": "web"},
+	}, cfg)
+
+	assert.NoError(t, MergeJSONC(cfg, []byte(`{"name": "edge", /* "url": "x" */}`)))
+	assert.Equal(t, "edge", cfg.Name)
+	assert.Equal(t, "http://example.com//path", cfg.URL)
+}
+
+func TestStripJSONC(t *testing.T) {
+	plain, err := stripJSONC([]byte("{\"a\": \"\\\"//\", /* x\ny */ \"b\": [1,],}"))
+	assert.NoError(t, err)
+	// comments and trailing commas become spaces, keeping offsets and line numbers
+	assert.Equal(t, "{\"a\": \"\\\"//\",     \n     \"b\": [1 ] }", string(plain))
+
+	_, err = NewJSONC[jsoncConfig]([]byte(`{"name": "x"} /* open`))
+	var conversionErr *ConversionError
+	assert.ErrorAs(t, err, &conversionErr)
+	assert.Contains(t, err.Error(), "unterminated comment")
+
+	// a comma with nothing before it is still an error
+	_, err = NewJSONC[jsoncConfig]([]byte(`{"ports": [,]}`))
+	assert.Error(t, err)
+}
+
+func TestJSONCFileLayer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.jsonc")
+	assert.NoError(t, os.WriteFile(path, []byte("{\n  \"name\": \"layered\", // from file\n}\n"), 0644))
+	cfg, provenance, err := LoadLayered[jsoncConfig]([]Layer{FileLayer(path)})
+	assert.NoError(t, err)
+	assert.Equal(t, "layered", cfg.Name)
+	assert.Equal(t, path, provenance["name"])
+
+	cfg, err = NewJSONCFile[jsoncConfig](path)
+	assert.NoError(t, err)
+	assert.Equal(t, "layered", cfg.Name)
+}
@@ -0,0 +1,72 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// isJSONType reports whether t implements both json.Marshaler and json.Unmarshaler (the latter typically on the
+// pointer receiver).
+func isJSONType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return false
+	}
+	if !t.Implements(jsonMarshalerType) && !reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return false
+	}
+	return reflect.PointerTo(t).Implements(jsonUnmarshalerType)
+}
+
+// jsonFallback reports whether values of type t are round-tripped through their JSON representation under opt.
+func jsonFallback(t reflect.Type, opt *Options) bool {
+	return opt != nil && opt.JSONMarshalers && isJSONType(t)
+}
+
+// bindJSON sets a json type destination by encoding raw as JSON and calling UnmarshalJSON. returns false if
+// Options.JSONMarshalers is not set or dst is not a json type.
+func bindJSON(dst reflect.Value, raw interface{}, path string, opt *Options) (bool, error) {
+	if !jsonFallback(dst.Type(), opt) || !dst.CanAddr() {
+		return false, nil
+	}
+	if rv := reflect.ValueOf(raw); rv.IsValid() && rv.Type() == dst.Type() {
+		dst.Set(rv)
+		return true, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return true, &ConversionError{Path: path, Value: fmt.Sprintf("%v", raw), Type: dst.Type().String(), Cause: err}
+	}
+	if err := dst.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data); err != nil {
+		return true, &ConversionError{Path: path, Value: string(data), Type: dst.Type().String(), Cause: err}
+	}
+	return true, nil
+}
+
+// unbindJSON calls MarshalJSON and decodes the result into maps, slices, and primitives, as BindJSON would. returns
+// false if Options.JSONMarshalers is not set or v is not a json type.
+func unbindJSON(v reflect.Value, opt *Options) (interface{}, bool, error) {
+	if !jsonFallback(v.Type(), opt) {
+		return nil, false, nil
+	}
+	var m json.Marshaler
+	if v.Type().Implements(jsonMarshalerType) {
+		m = v.Interface().(json.Marshaler)
+	} else {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		m = ptr.Interface().(json.Marshaler)
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, true, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, true, err
+	}
+	return out, true, nil
+}
@@ -0,0 +1,104 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonPoint is encoded as a two-element array by encoding/json.
+type jsonPoint struct {
+	X, Y int
+}
+
+func (p jsonPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]int{p.X, p.Y})
+}
+
+func (p *jsonPoint) UnmarshalJSON(data []byte) error {
+	var xy []int
+	if err := json.Unmarshal(data, &xy); err != nil {
+		return err
+	}
+	if len(xy) != 2 {
+		return fmt.Errorf("expected 2 coordinates, got %d", len(xy))
+	}
+	p.X, p.Y = xy[0], xy[1]
+	return nil
+}
+
+type jsonShape struct {
+	Origin jsonPoint
+	Corner *jsonPoint
+	Path   []jsonPoint
+}
+
+func TestJSONMarshalersRoundTrip(t *testing.T) {
+	opts := &Options{JSONMarshalers: true}
+	data := map[string]any{
+		"origin": []any{1, 2},
+		"corner": []any{3, 4},
+		"path":   []any{[]any{5, 6}, []any{7, 8}},
+	}
+	shape, err := New[jsonShape](data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonPoint{1, 2}, shape.Origin)
+	assert.Equal(t, &jsonPoint{3, 4}, shape.Corner)
+	assert.Equal(t, []jsonPoint{{5, 6}, {7, 8}}, shape.Path)
+
+	out, err := Unbind(shape, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{float64(1), float64(2)}, out["origin"])
+	assert.Equal(t, []any{float64(3), float64(4)}, out["corner"])
+	assert.Equal(t, []any{[]any{float64(5), float64(6)}, []any{float64(7), float64(8)}}, out["path"])
+
+	// the decoded form binds back
+	again, err := New[jsonShape](out, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, shape, again)
+}
+
+func TestJSONMarshalersDisabled(t *testing.T) {
+	// without the option, the struct is bound field by field
+	shape, err := New[jsonShape](map[string]any{"origin": map[string]any{"x": 1, "y": 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, jsonPoint{1, 2}, shape.Origin)
+
+	out, err := Unbind(shape)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"x": 1, "y": 2}, out["origin"])
+}
+
+func TestJSONMarshalersError(t *testing.T) {
+	_, err := New[jsonShape](map[string]any{"origin": []any{1}}, &Options{JSONMarshalers: true})
+	var convErr *ConversionError
+	assert.ErrorAs(t, err, &convErr)
+	assert.Contains(t, err.Error(), "expected 2 coordinates")
+}
+
+func TestJSONMarshalersConverterPrecedence(t *testing.T) {
+	opts := &Options{JSONMarshalers: true, Converters: map[reflect.Type]Converter{reflect.TypeOf(jsonPoint{}): jsonPointConverter{}}}
+	shape, err := New[jsonShape](map[string]any{"origin": "1,2"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonPoint{1, 2}, shape.Origin)
+
+	out, err := Unbind(shape, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2", out["origin"])
+}
+
+type jsonPointConverter struct{}
+
+func (jsonPointConverter) FromRaw(raw interface{}) (interface{}, error) {
+	var p jsonPoint
+	_, err := fmt.Sscanf(raw.(string), "%d,%d", &p.X, &p.Y)
+	return p, err
+}
+
+func (jsonPointConverter) ToRaw(value interface{}) (interface{}, error) {
+	p := value.(jsonPoint)
+	return fmt.Sprintf("%d,%d", p.X, p.Y), nil
+}
@@ -0,0 +1,139 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// keyedToDiscriminated rewrites the data of a `+keyed` field, where each map key names the dynamic type of its value
+// (e.g. {"email": {...}, "slack": {...}}), into the shape Dynamic values bind from, with the key moved under TypeKey.
+// slices accept either a map, whose entries are taken in key order, or a list of single-key maps, which preserves
+// order; maps keep their keys.
+func keyedToDiscriminated(raw any, t reflect.Type) (any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		switch v := raw.(type) {
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			out := make([]any, 0, len(keys))
+			for _, k := range keys {
+				item, err := withDiscriminator(k, v[k])
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, item)
+			}
+			return out, nil
+		case []any:
+			out := make([]any, 0, len(v))
+			for i, entry := range v {
+				m, ok := entry.(map[string]any)
+				if !ok || len(m) != 1 {
+					return nil, &IndexError{Index: i, Cause: &TypeMismatchError{Expected: "single-key object", Actual: fmt.Sprintf("%T", entry)}}
+				}
+				for k, body := range m {
+					item, err := withDiscriminator(k, body)
+					if err != nil {
+						return nil, &IndexError{Index: i, Cause: err}
+					}
+					out = append(out, item)
+				}
+			}
+			return out, nil
+		}
+		return nil, &TypeMismatchError{Expected: "object or array for +keyed field", Actual: fmt.Sprintf("%T", raw)}
+
+	case reflect.Map:
+		v, ok := raw.(map[string]any)
+		if !ok {
+			return nil, &TypeMismatchError{Expected: "object for +keyed field", Actual: fmt.Sprintf("%T", raw)}
+		}
+		out := make(map[string]any, len(v))
+		for k, body := range v {
+			item, err := withDiscriminator(k, body)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = item
+		}
+		return out, nil
+	}
+	return nil, &UnsupportedError{Operation: "+keyed fields of type " + t.String(), Type: t.String()}
+}
+
+// withDiscriminator returns a copy of body with key stored under TypeKey. a nil body (e.g. `slack:` with no settings
+// in YAML) yields just the discriminator.
+func withDiscriminator(key string, body any) (map[string]any, error) {
+	var m map[string]any
+	switch v := body.(type) {
+	case nil:
+	case map[string]any:
+		m = v
+	default:
+		return nil, &TypeMismatchError{Path: key, Expected: "object", Actual: fmt.Sprintf("%T", body)}
+	}
+	if t, ok := m[TypeKey]; ok && t != key {
+		return nil, fmt.Errorf("%s: '%v' discriminator %v conflicts with key", key, TypeKey, t)
+	}
+	out := make(map[string]any, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	out[TypeKey] = key
+	return out, nil
+}
+
+// discriminatedToKeyed reverses keyedToDiscriminated for Unbind: slices become lists of single-key maps, and map values
+// lose their TypeKey. values without a string discriminator are left unchanged.
+func discriminatedToKeyed(v any) any {
+	switch v := v.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			if key, body, ok := splitDiscriminator(item); ok {
+				out[i] = map[string]any{key: body}
+			} else {
+				out[i] = item
+			}
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			if _, body, ok := splitDiscriminator(item); ok {
+				out[k] = body
+			} else {
+				out[k] = item
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// splitDiscriminator separates the TypeKey of an unbound dynamic value from its remaining keys.
+func splitDiscriminator(item any) (string, map[string]any, bool) {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return "", nil, false
+	}
+	key, ok := m[TypeKey].(string)
+	if !ok {
+		return "", nil, false
+	}
+	body := make(map[string]any, len(m)-1)
+	for k, v := range m {
+		if k != TypeKey {
+			body[k] = v
+		}
+	}
+	return key, body, true
+}
@@ -0,0 +1,91 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type keyedRoutes struct {
+	Notify []Dynamic          `dd:",+keyed"`
+	Steps  []Dynamic          `dd:",+keyed"`
+	Routes map[string]Dynamic `dd:",+keyed"`
+}
+
+func keyedOptions() *Options {
+	return &Options{DynamicBinders: map[string]func(map[string]any) (Dynamic, error){
+		"a": func(m map[string]any) (Dynamic, error) {
+			name, _ := m["name"].(string)
+			return &dynA{Name: name}, nil
+		},
+		"b": func(m map[string]any) (Dynamic, error) {
+			count, _ := m["count"].(int)
+			return &dynB{Count: count}, nil
+		},
+	}}
+}
+
+func TestKeyedBind(t *testing.T) {
+	data := map[string]any{
+		// a map binds in key order
+		"notify": map[string]any{"b": map[string]any{"count": 2}, "a": map[string]any{"name": "ops"}},
+		// a list of single-key maps preserves order, and may repeat types
+		"steps": []any{
+			map[string]any{"b": map[string]any{"count": 1}},
+			map[string]any{"a": nil},
+			map[string]any{"b": map[string]any{"count": 3}},
+		},
+		"routes": map[string]any{"a": map[string]any{"name": "default"}},
+	}
+	r, err := New[keyedRoutes](data, keyedOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, []Dynamic{&dynA{Name: "ops"}, &dynB{Count: 2}}, r.Notify)
+	assert.Equal(t, []Dynamic{&dynB{Count: 1}, &dynA{}, &dynB{Count: 3}}, r.Steps)
+	assert.Equal(t, map[string]Dynamic{"a": &dynA{Name: "default"}}, r.Routes)
+
+	out, err := Unbind(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"a": map[string]any{"name": "ops"}},
+		map[string]any{"b": map[string]any{"count": 2}},
+	}, out["notify"])
+	assert.Equal(t, []any{
+		map[string]any{"b": map[string]any{"count": 1}},
+		map[string]any{"a": map[string]any{"name": ""}},
+		map[string]any{"b": map[string]any{"count": 3}},
+	}, out["steps"])
+	assert.Equal(t, map[string]any{"a": map[string]any{"name": "default"}}, out["routes"])
+
+	// the unbound form binds back
+	again, err := New[keyedRoutes](out, keyedOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, r, again)
+}
+
+func TestKeyedErrors(t *testing.T) {
+	_, err := New[keyedRoutes](map[string]any{"steps": []any{map[string]any{"a": nil, "b": nil}}}, keyedOptions())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "single-key object")
+
+	_, err = New[keyedRoutes](map[string]any{"routes": map[string]any{"a": "x"}}, keyedOptions())
+	var mismatch *TypeMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+
+	_, err = New[keyedRoutes](map[string]any{"routes": map[string]any{"a": map[string]any{"type": "b"}}}, keyedOptions())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with key")
+
+	_, err = New[keyedRoutes](map[string]any{"routes": map[string]any{"zz": nil}}, keyedOptions())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown Dynamic type "zz"`)
+}
+
+func TestKeyedSchema(t *testing.T) {
+	schema, err := Schema[keyedRoutes](&SchemaOptions{DynamicTypes: map[string]any{"a": dynA{}}})
+	assert.NoError(t, err)
+	properties := schema["properties"].(map[string]any)
+	routes := properties["routes"].(map[string]any)
+	assert.Equal(t, "object", routes["type"])
+	assert.Equal(t, map[string]any{"a": map[string]any{"$ref": "#/$defs/dynA"}}, routes["properties"])
+	assert.Contains(t, properties["steps"], "oneOf")
+}
@@ -0,0 +1,241 @@
+package dd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layer is one source of configuration data for LoadLayered.
+type Layer struct {
+	// Source names the layer in the provenance returned by LoadLayered, e.g. "defaults", "env", or a file path.
+	Source string
+	// Load returns the layer's data, given a pointer to the struct being loaded and the effective options; it returns
+	// nil data (and no error) to skip the layer.
+	Load func(target any, opt *Options) (map[string]any, error)
+}
+
+// MapLayer returns a layer supplying data as-is.
+func MapLayer(source string, data map[string]any) Layer {
+	return Layer{Source: source, Load: func(any, *Options) (map[string]any, error) {
+		return data, nil
+	}}
+}
+
+// FileLayer returns a layer reading the JSON, JSONC, or YAML file at path, selecting the format by its extension
+// (.json, .jsonc, .yaml, or .yml). a missing file is an error.
+func FileLayer(path string) Layer {
+	return fileLayer(path, false)
+}
+
+// OptionalFileLayer is like FileLayer, but skips the layer when the file does not exist, as for an optional local
+// override file.
+func OptionalFileLayer(path string) Layer {
+	return fileLayer(path, true)
+}
+
+func fileLayer(path string, optional bool) Layer {
+	return Layer{Source: path, Load: func(_ any, opt *Options) (map[string]any, error) {
+		format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if format == "yml" {
+			format = "yaml"
+		}
+		if format != "json" && format != "jsonc" && format != "yaml" {
+			return nil, &UnsupportedError{Path: path, Operation: "load layer", Type: filepath.Ext(path)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if optional && os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, &FileError{Path: path, Operation: "read " + strings.ToUpper(format), Cause: err}
+		}
+		m, err := decodeLayer(data, format, opt)
+		return m, withFile(err, path)
+	}}
+}
+
+// ReaderLayer returns a layer reading a "json", "jsonc", or "yaml" document from r.
+func ReaderLayer(source string, r io.Reader, format string) Layer {
+	return Layer{Source: source, Load: func(_ any, opt *Options) (map[string]any, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, &ConversionError{Message: "failed to read from reader", Cause: err}
+		}
+		return decodeLayer(data, format, opt)
+	}}
+}
+
+// EnvLayer returns a layer reading environment variables named after the scalar fields of the struct being loaded:
+// prefix followed by the field's dotted external path in upper case, with dots and dashes replaced by underscores.
+// for example, with prefix "APP_", the field bound from "server.port" is read from APP_SERVER_PORT. values are bound
+// with the usual coercion rules; unset variables are skipped.
+func EnvLayer(prefix string) Layer {
+	return Layer{Source: "env", Load: func(target any, opt *Options) (map[string]any, error) {
+		t := reflect.TypeOf(target)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		data := make(map[string]any)
+		for _, column := range csvColumns(t, nil, opt) {
+			name := prefix + strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(strings.Join(column, "_")))
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				continue
+			}
+			current := data
+			for _, part := range column[:len(column)-1] {
+				next, ok := current[part].(map[string]any)
+				if !ok {
+					next = make(map[string]any)
+					current[part] = next
+				}
+				current = next
+			}
+			current[column[len(column)-1]] = value
+		}
+		return data, nil
+	}}
+}
+
+func decodeLayer(data []byte, format string, opt *Options) (map[string]any, error) {
+	m := make(map[string]any)
+	switch format {
+	case "json":
+		if err := decodeJSON(data, &m, opt); err != nil {
+			return nil, parseError("JSON", data, err)
+		}
+	case "jsonc":
+		plain, err := decodeJSONC(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeJSON(plain, &m, opt); err != nil {
+			return nil, jsoncError(parseError("JSON", plain, err), data)
+		}
+	case "yaml":
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return nil, parseError("YAML", data, err)
+		}
+	default:
+		return nil, &UnsupportedError{Operation: "load layer", Type: format}
+	}
+	return m, nil
+}
+
+// LoadLayered builds a T from an ordered list of layers, such as defaults, then a file, then the environment, then an
+// override file: the first layer is bound into a fresh T, and each later layer is merged over it, so later layers win.
+// layers that return nil data are skipped. it returns the result together with its provenance: the Source of the
+// layer that supplied each field's final value, keyed by dotted field path, recorded in Options.Provenance when one
+// is given.
+//
+// `+required` fields must be supplied by at least one layer, rather than by every layer. opts apply to every layer;
+// Options.Source is replaced by each layer's Source.
+func LoadLayered[T any](layers []Layer, opts ...*Options) (*T, Provenance, error) {
+	base, err := getOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	opt := Options{}
+	if base != nil {
+		opt = *base
+	}
+	opt.deferRequired = true
+	if opt.Provenance == nil {
+		opt.Provenance = make(Provenance)
+	}
+
+	target := new(T)
+	var loaded []map[string]any
+	for _, layer := range layers {
+		data, err := layer.Load(target, &opt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if data == nil {
+			continue
+		}
+		opt.Source = layer.Source
+		if len(loaded) == 0 {
+			err = Bind(target, data, &opt)
+		} else {
+			err = Merge(target, data, &opt)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		loaded = append(loaded, data)
+	}
+	t := reflect.TypeOf(target).Elem()
+	if err := checkLayeredRequired(t, loaded, t.Name(), opt.naming()); err != nil {
+		return nil, nil, err
+	}
+	return target, opt.Provenance, nil
+}
+
+// checkLayeredRequired reports the first `+required` field of structType absent from every layer, and the first field
+// group violated by the layers taken together.
+func checkLayeredRequired(structType reflect.Type, layers []map[string]any, path string, naming NamingStrategy) error {
+	present := func(name string) bool {
+		for _, layer := range layers {
+			if layer[name] != nil {
+				return true
+			}
+		}
+		return false
+	}
+	if err := checkFieldGroups(structType, present, path, naming); err != nil {
+		return err
+	}
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if isInlined(field, meta.tag) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := checkLayeredRequired(embedded, layers, path, naming); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" || meta.tag.Skip || meta.tag.Extra {
+			continue
+		}
+		name := meta.externalName(naming)
+		var present []map[string]any
+		found := false
+		for _, layer := range layers {
+			raw, ok := layer[name]
+			if !ok {
+				continue
+			}
+			found = true
+			if sub, ok := raw.(map[string]any); ok {
+				present = append(present, sub)
+			}
+		}
+		if !found {
+			if meta.tag.Required {
+				return &RequiredFieldError{Path: path, Field: field.Name}
+			}
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if len(present) > 0 && isProvenanceStruct(fieldType) {
+			if err := checkLayeredRequired(fieldType, present, path+"."+field.Name, naming); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package dd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type layeredServer struct {
+	Host string
+	Port int
+}
+
+type layeredConfig struct {
+	Name     string `dd:",+required"`
+	Debug    bool
+	Server   layeredServer
+	Features []string
+}
+
+func TestLoadLayered(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("name: app\nserver:\n  host: example.com\n"), 0644))
+	t.Setenv("LAYERED_SERVER_PORT", "9090")
+	t.Setenv("LAYERED_DEBUG", "true")
+
+	cfg, provenance, err := LoadLayered[layeredConfig]([]Layer{
+		MapLayer("defaults", map[string]any{"server": map[string]any{"host": "localhost", "port": 8080}, "features": []any{"a"}}),
+		FileLayer(file),
+		EnvLayer("LAYERED_"),
+		OptionalFileLayer(filepath.Join(dir, "missing.yaml")),
+		ReaderLayer("override", strings.NewReader(`{"features": ["b"]}`), "json"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &layeredConfig{
+		Name:     "app",
+		Debug:    true,
+		Server:   layeredServer{Host: "example.com", Port: 9090},
+		Features: []string{"b"},
+	}, cfg)
+	assert.Equal(t, Provenance{
+		"name":        file,
+		"debug":       "env",
+		"server.host": file,
+		"server.port": "env",
+		"features":    "override",
+	}, provenance)
+}
+
+func TestLoadLayeredRequired(t *testing.T) {
+	// a required field may come from any layer
+	_, _, err := LoadLayered[layeredConfig]([]Layer{
+		MapLayer("defaults", map[string]any{"debug": true}),
+		MapLayer("override", map[string]any{"name": "app"}),
+	})
+	assert.NoError(t, err)
+
+	_, _, err = LoadLayered[layeredConfig]([]Layer{MapLayer("defaults", map[string]any{"debug": true})})
+	assert.Error(t, err)
+	var rfe *RequiredFieldError
+	assert.ErrorAs(t, err, &rfe)
+	assert.Equal(t, "Name", rfe.Field)
+}
+
+func TestLoadLayeredErrors(t *testing.T) {
+	_, _, err := LoadLayered[layeredConfig]([]Layer{FileLayer(filepath.Join(t.TempDir(), "missing.yaml"))})
+	var fe *FileError
+	assert.ErrorAs(t, err, &fe)
+
+	_, _, err = LoadLayered[layeredConfig]([]Layer{FileLayer("config.toml")})
+	var ue *UnsupportedError
+	assert.ErrorAs(t, err, &ue)
+
+	_, _, err = LoadLayered[layeredConfig]([]Layer{MapLayer("defaults", map[string]any{"name": "app", "server": map[string]any{"port": "x"}})})
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LinkIssueKind classifies a LinkIssue.
+type LinkIssueKind string
+
+const (
+	// UnresolvedRef is a reference to an object that cannot be found, or that cannot be resolved unambiguously.
+	UnresolvedRef LinkIssueKind = "unresolved reference"
+	// DuplicateId is an Id shared by two objects of the same type, only one of which references can resolve to.
+	DuplicateId LinkIssueKind = "duplicate id"
+	// RefTypeMismatch is a typed or path reference to an object of a type other than the Pointer's.
+	RefTypeMismatch LinkIssueKind = "type mismatch"
+)
+
+// LinkIssue is a problem found by Linker.Validate.
+type LinkIssue struct {
+	Kind LinkIssueKind
+	// Source is the Identifiable object holding the reference (or the root, when no Identifiable object encloses it);
+	// for a DuplicateId, it is the duplicate object.
+	Source any
+	// Field is the path of the Pointer within Source, as in ReferenceSite; empty for a DuplicateId.
+	Field string
+	// Ref is the reference as written, or the duplicated Id.
+	Ref     string
+	Message string
+}
+
+func (i LinkIssue) String() string {
+	source := fmt.Sprintf("%T", i.Source)
+	if identifiable, ok := i.Source.(Identifiable); ok && identifiable.GetId() != "" {
+		source = fmt.Sprintf("%s %q", source, identifiable.GetId())
+	}
+	if i.Field != "" {
+		source += " " + i.Field
+	}
+	return fmt.Sprintf("%s %q in %s: %s", i.Kind, i.Ref, source, i.Message)
+}
+
+// LinkReport lists the problems found by Linker.Validate, in the order they were found.
+type LinkReport struct {
+	Issues []LinkIssue
+}
+
+// OK reports whether no problems were found.
+func (r *LinkReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Err returns a ValidationError listing the problems found, or nil when there are none.
+func (r *LinkReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+	issues := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		issues[i] = issue.String()
+	}
+	return &ValidationError{Message: fmt.Sprintf("%d link issues: %s", len(r.Issues), strings.Join(issues, "; "))}
+}
+
+func (r *LinkReport) add(kind LinkIssueKind, source reflect.Value, field, ref, message string) {
+	r.Issues = append(r.Issues, LinkIssue{Kind: kind, Source: source.Interface(), Field: field, Ref: ref, Message: message})
+}
+
+// Validate checks that roots would link cleanly, without resolving any Pointer, reporting every unresolved reference,
+// duplicate Id and mismatched reference type rather than stopping at the first, as Link does; for linting data files
+// before they are deployed. references resolve as with Link, against roots and any objects registered with Register
+// (which are not modified), then through the external Resolver, using the Linker's options; Lazy and
+// AllowPartialResolution are ignored. the error is for
+// invalid roots, not for problems in them.
+func (l *Linker) Validate(roots ...interface{}) (*LinkReport, error) {
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no targets provided")
+	}
+	report := &LinkReport{}
+	v := &Linker{options: l.options, report: report}
+	v.options.OnResolve, v.options.OnMissing = nil, nil
+	l.mu.RLock()
+	registry := make(map[string]reflect.Value, len(l.cache))
+	for key, value := range l.cache {
+		registry[key] = value
+	}
+	l.mu.RUnlock()
+	elems := make([]reflect.Value, len(roots))
+	for i, root := range roots {
+		elem, err := validateTarget(root)
+		if err != nil {
+			return nil, fmt.Errorf("target at index %d: %w", i, err)
+		}
+		elems[i] = elem
+		v.collectIdentifiableObjects(elem, registry)
+	}
+	for _, elem := range elems {
+		v.root, v.owner, v.path, v.ownerPath = elem, elem.Addr(), "", 0
+		if err := v.resolvePointers(elem, registry); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
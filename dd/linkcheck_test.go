@@ -0,0 +1,97 @@
+package dd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkerValidate(t *testing.T) {
+	type container struct {
+		Users     []*User         `dd:"users"`
+		Documents []*Document     `dd:"documents"`
+		Featured  *Pointer[*User] `dd:"featured"`
+		Pinned    *Pointer[*User] `dd:"pinned"`
+	}
+	c := &container{
+		Users: []*User{{Id: "alice"}, {Id: "bob"}, {Id: "alice", Name: "Other Alice"}},
+		Documents: []*Document{
+			{Id: "doc1", Author: &Pointer[*User]{Ref: "alice"}, Editor: &Pointer[*User]{Ref: "carol"}},
+			{Id: "doc2", Author: &Pointer[*User]{Ref: "Document:doc1"}},
+		},
+		Featured: &Pointer[*User]{Ref: "/documents/doc1"},
+		Pinned:   &Pointer[*User]{Ref: "bob"},
+	}
+
+	report, err := NewLinker().Validate(c)
+	assert.NoError(t, err)
+	assert.False(t, report.OK())
+	if assert.Len(t, report.Issues, 4) {
+		assert.Equal(t, DuplicateId, report.Issues[0].Kind)
+		assert.Same(t, c.Users[2], report.Issues[0].Source)
+		assert.Equal(t, "alice", report.Issues[0].Ref)
+
+		assert.Equal(t, UnresolvedRef, report.Issues[1].Kind)
+		assert.Same(t, c.Documents[0], report.Issues[1].Source)
+		assert.Equal(t, "Editor", report.Issues[1].Field)
+		assert.Equal(t, "carol", report.Issues[1].Ref)
+		assert.Equal(t, "unresolved reference: carol (looking for dd.User:carol)", report.Issues[1].Message)
+		assert.Equal(t, `unresolved reference "carol" in *dd.Document "doc1" Editor: unresolved reference: carol (looking for dd.User:carol)`,
+			report.Issues[1].String())
+
+		assert.Equal(t, RefTypeMismatch, report.Issues[2].Kind)
+		assert.Same(t, c.Documents[1], report.Issues[2].Source)
+		assert.Equal(t, "Author", report.Issues[2].Field)
+
+		assert.Equal(t, RefTypeMismatch, report.Issues[3].Kind)
+		assert.Same(t, c, report.Issues[3].Source)
+		assert.Equal(t, "Featured", report.Issues[3].Field)
+	}
+	var validationErr *ValidationError
+	if assert.True(t, errors.As(report.Err(), &validationErr)) {
+		assert.Contains(t, validationErr.Error(), "4 link issues")
+	}
+
+	// no pointer is resolved, even those that could be
+	assert.False(t, c.Pinned.IsResolved())
+	assert.False(t, c.Documents[0].Author.IsResolved())
+
+	// a clean graph reports nothing
+	c.Users = c.Users[:2]
+	c.Documents[0].Editor = nil
+	c.Documents[1].Author = &Pointer[*User]{Ref: "User:bob"}
+	c.Featured = &Pointer[*User]{Ref: "/users/alice"}
+	report, err = NewLinker().Validate(c)
+	assert.NoError(t, err)
+	assert.True(t, report.OK(), "unexpected issues: %v", report.Issues)
+	assert.NoError(t, report.Err())
+	assert.False(t, c.Pinned.IsResolved())
+
+	// registered objects are found, and invalid roots are errors
+	linker := NewLinker()
+	assert.NoError(t, linker.Register(&container{Users: []*User{{Id: "carol"}}}))
+	report, err = linker.Validate(&container{Pinned: &Pointer[*User]{Ref: "carol"}})
+	assert.NoError(t, err)
+	assert.True(t, report.OK())
+	_, err = linker.Validate(container{})
+	assert.Error(t, err)
+
+	// the external Resolver is consulted for the rest
+	asked := 0
+	linker = NewLinker(LinkerOptions{Resolver: ResolverFunc(func(_ reflect.Type, ref string) (any, error) {
+		asked++
+		if ref == "dave" {
+			return &User{Id: "dave"}, nil
+		}
+		return nil, nil
+	})})
+	report, err = linker.Validate(&container{Pinned: &Pointer[*User]{Ref: "dave"}, Featured: &Pointer[*User]{Ref: "erin"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, asked)
+	if assert.Len(t, report.Issues, 1) {
+		assert.Equal(t, UnresolvedRef, report.Issues[0].Kind)
+		assert.Equal(t, "erin", report.Issues[0].Ref)
+	}
+}
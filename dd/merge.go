@@ -0,0 +1,64 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SliceMergeStrategy controls how Merge combines a slice in the data with the slice already held by a field.
+type SliceMergeStrategy int
+
+const (
+	// SliceReplace replaces the existing slice with the incoming one (the default).
+	SliceReplace SliceMergeStrategy = iota
+	// SliceAppend appends the incoming elements to the existing slice.
+	SliceAppend
+	// SliceUnion appends only the incoming elements not already present (by deep equality), so repeated layers do not
+	// introduce duplicates.
+	SliceUnion
+)
+
+// sliceMergeStrategy returns the strategy for a slice field: its `+merge` tag when present, otherwise
+// Options.SliceMergeStrategy.
+func sliceMergeStrategy(tag DdTag, opt *Options) (SliceMergeStrategy, error) {
+	if opt != nil && opt.replaceSlices {
+		return SliceReplace, nil
+	}
+	switch tag.Merge {
+	case "":
+		if opt != nil {
+			return opt.SliceMergeStrategy, nil
+		}
+		return SliceReplace, nil
+	case "replace":
+		return SliceReplace, nil
+	case "append":
+		return SliceAppend, nil
+	case "union":
+		return SliceUnion, nil
+	}
+	return SliceReplace, fmt.Errorf("unknown merge strategy %q (expected replace, append, or union)", tag.Merge)
+}
+
+// mergeSlices combines the existing elements with the incoming slice according to strategy.
+func mergeSlices(existing, incoming reflect.Value, strategy SliceMergeStrategy) reflect.Value {
+	out := reflect.MakeSlice(existing.Type(), 0, existing.Len()+incoming.Len())
+	out = reflect.AppendSlice(out, existing)
+	for i := 0; i < incoming.Len(); i++ {
+		item := incoming.Index(i)
+		if strategy == SliceUnion && containsElement(out, item) {
+			continue
+		}
+		out = reflect.Append(out, item)
+	}
+	return out
+}
+
+func containsElement(slice, item reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if reflect.DeepEqual(slice.Index(i).Interface(), item.Interface()) {
+			return true
+		}
+	}
+	return false
+}
@@ -259,3 +259,115 @@ func stringPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestMergeSliceStrategies(t *testing.T) {
+	type config struct {
+		Features []string
+		Ports    []int    `dd:",+merge=union"`
+		Hosts    []string `dd:",+merge=replace"`
+	}
+	newConfig := func() *config {
+		return &config{Features: []string{"a", "b"}, Ports: []int{80}, Hosts: []string{"x"}}
+	}
+	data := map[string]any{
+		"features": []any{"b", "c"},
+		"ports":    []any{80, 443},
+		"hosts":    []any{"y"},
+	}
+
+	cfg := newConfig()
+	assert.NoError(t, Merge(cfg, data))
+	assert.Equal(t, []string{"b", "c"}, cfg.Features)
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+	assert.Equal(t, []string{"y"}, cfg.Hosts)
+
+	cfg = newConfig()
+	assert.NoError(t, Merge(cfg, data, &Options{SliceMergeStrategy: SliceAppend}))
+	assert.Equal(t, []string{"a", "b", "b", "c"}, cfg.Features)
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+	assert.Equal(t, []string{"y"}, cfg.Hosts)
+
+	cfg = newConfig()
+	assert.NoError(t, Merge(cfg, data, &Options{SliceMergeStrategy: SliceUnion}))
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Features)
+
+	// Bind always replaces
+	cfg = newConfig()
+	assert.NoError(t, Bind(cfg, data, &Options{SliceMergeStrategy: SliceAppend}))
+	assert.Equal(t, []string{"b", "c"}, cfg.Features)
+}
+
+func TestMergeSliceStructUnion(t *testing.T) {
+	type endpoint struct {
+		Host string
+		Port int
+	}
+	cfg := &struct {
+		Endpoints []endpoint `dd:",+merge=union"`
+	}{Endpoints: []endpoint{{Host: "a", Port: 1}}}
+
+	err := Merge(cfg, map[string]any{"endpoints": []any{
+		map[string]any{"host": "a", "port": 1},
+		map[string]any{"host": "b", "port": 2},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []endpoint{{Host: "a", Port: 1}, {Host: "b", Port: 2}}, cfg.Endpoints)
+}
+
+func TestMergeSliceUnknownStrategy(t *testing.T) {
+	cfg := &struct {
+		Tags []string `dd:",+merge=prepend"`
+	}{}
+	err := Merge(cfg, map[string]any{"tags": []any{"a"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown merge strategy")
+}
+
+func TestMergeNullDeletes(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type config struct {
+		Name    string
+		Port    int
+		Server  server
+		Backup  *server
+		Tags    []string
+		Labels  map[string]string
+		Timeout int
+	}
+	newConfig := func() *config {
+		return &config{
+			Name:    "app",
+			Port:    8080,
+			Server:  server{Host: "localhost", Port: 80},
+			Backup:  &server{Host: "standby"},
+			Tags:    []string{"a"},
+			Labels:  map[string]string{"env": "prod", "tier": "web"},
+			Timeout: 30,
+		}
+	}
+	data := map[string]any{
+		"port":   nil,
+		"server": nil,
+		"backup": nil,
+		"tags":   nil,
+		"labels": map[string]any{"tier": nil, "zone": "east"},
+	}
+
+	cfg := newConfig()
+	assert.NoError(t, Merge(cfg, data, &Options{NullDeletes: true}))
+	assert.Equal(t, "app", cfg.Name)
+	assert.Equal(t, 0, cfg.Port)
+	assert.Equal(t, server{}, cfg.Server)
+	assert.Nil(t, cfg.Backup)
+	assert.Nil(t, cfg.Tags)
+	assert.Equal(t, map[string]string{"env": "prod", "zone": "east"}, cfg.Labels)
+	assert.Equal(t, 30, cfg.Timeout)
+
+	// without NullDeletes, maps are replaced wholesale
+	cfg = newConfig()
+	assert.NoError(t, Merge(cfg, map[string]any{"labels": map[string]any{"zone": "east"}}))
+	assert.Equal(t, map[string]string{"zone": "east"}, cfg.Labels)
+}
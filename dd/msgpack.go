@@ -0,0 +1,146 @@
+package dd
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// decodeMsgpack parses a MessagePack document into a map. integers decode as int64 or uint64 and floats as float64,
+// matching the values produced by the JSON and YAML decoders.
+func decodeMsgpack(data []byte) (map[string]any, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.UseLooseInterfaceDecoding(true)
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		return nil, &ConversionError{Type: "MessagePack", Message: "failed to parse", Cause: err}
+	}
+	return m, nil
+}
+
+// --- Bytes Layer (base) ---
+
+// BindMsgpack parses MessagePack data and binds it to the target struct.
+func BindMsgpack(target interface{}, data []byte, opts ...*Options) error {
+	m, err := decodeMsgpack(data)
+	if err != nil {
+		return err
+	}
+	return Bind(target, m, opts...)
+}
+
+// NewMsgpack parses MessagePack data and returns a new instance of type T.
+func NewMsgpack[T any](data []byte, opts ...*Options) (*T, error) {
+	m, err := decodeMsgpack(data)
+	if err != nil {
+		return nil, err
+	}
+	return New[T](m, opts...)
+}
+
+// MergeMsgpack parses MessagePack data and merges it with the target struct.
+func MergeMsgpack(target interface{}, data []byte, opts ...*Options) error {
+	m, err := decodeMsgpack(data)
+	if err != nil {
+		return err
+	}
+	return Merge(target, m, opts...)
+}
+
+// UnbindMsgpack converts a struct to MessagePack bytes.
+func UnbindMsgpack(source interface{}, opts ...*Options) ([]byte, error) {
+	m, err := Unbind(source, opts...)
+	if err != nil {
+		return nil, &ConversionError{Message: "failed to unbind source", Cause: err}
+	}
+	if err := resolveSourceRawNodes(source, m, "msgpack"); err != nil {
+		return nil, &ConversionError{Type: "MessagePack", Message: "failed to convert raw node", Cause: err}
+	}
+	data, err := msgpack.Marshal(m)
+	if err != nil {
+		return nil, &ConversionError{Type: "MessagePack", Message: "failed to marshal", Cause: err}
+	}
+	return data, nil
+}
+
+// --- Reader/Writer Layer ---
+
+// BindMsgpackReader reads MessagePack from an io.Reader and binds it to the target struct.
+func BindMsgpackReader(target interface{}, r io.Reader, opts ...*Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return BindMsgpack(target, data, opts...)
+}
+
+// NewMsgpackReader reads MessagePack from an io.Reader and returns a new instance of type T.
+func NewMsgpackReader[T any](r io.Reader, opts ...*Options) (*T, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return NewMsgpack[T](data, opts...)
+}
+
+// MergeMsgpackReader reads MessagePack from an io.Reader and merges it with the target struct.
+func MergeMsgpackReader(target interface{}, r io.Reader, opts ...*Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &ConversionError{Message: "failed to read from reader", Cause: err}
+	}
+	return MergeMsgpack(target, data, opts...)
+}
+
+// UnbindMsgpackWriter converts a struct to MessagePack and writes it to an io.Writer.
+func UnbindMsgpackWriter(source interface{}, w io.Writer, opts ...*Options) error {
+	data, err := UnbindMsgpack(source, opts...)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return &ConversionError{Message: "failed to write to writer", Cause: err}
+	}
+	return nil
+}
+
+// --- File Layer ---
+
+// BindMsgpackFile reads MessagePack from the specified file path and binds it to the target struct.
+func BindMsgpackFile(target interface{}, path string, opts ...*Options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &FileError{Path: path, Operation: "read MessagePack", Cause: err}
+	}
+	return BindMsgpack(target, data, opts...)
+}
+
+// NewMsgpackFile reads MessagePack from the specified file path and returns a new instance of type T.
+func NewMsgpackFile[T any](path string, opts ...*Options) (*T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &FileError{Path: path, Operation: "read MessagePack", Cause: err}
+	}
+	return NewMsgpack[T](data, opts...)
+}
+
+// MergeMsgpackFile reads MessagePack from the specified file path and merges it with the target struct.
+func MergeMsgpackFile(target interface{}, path string, opts ...*Options) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &FileError{Path: path, Operation: "read MessagePack", Cause: err}
+	}
+	return MergeMsgpack(target, data, opts...)
+}
+
+// UnbindMsgpackFile converts a struct to MessagePack and writes it to the specified file path, replacing any
+// existing file atomically.
+func UnbindMsgpackFile(source interface{}, path string, opts ...*Options) error {
+	data, err := UnbindMsgpack(source, opts...)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data, "write MessagePack", opts...)
+}
@@ -0,0 +1,95 @@
+package dd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type msgpackNested struct {
+	Enabled bool
+	Weight  float64
+}
+
+type msgpackPayload struct {
+	Name     string
+	Count    int8
+	Total    uint64
+	Interval time.Duration
+	Tags     []string
+	Labels   map[string]int
+	Nested   msgpackNested
+	Blob     []byte
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	in := &msgpackPayload{
+		Name:     "sensor",
+		Count:    -3,
+		Total:    1 << 40,
+		Interval: 5 * time.Second,
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]int{"x": 1},
+		Nested:   msgpackNested{Enabled: true, Weight: 0.5},
+		Blob:     []byte{0x00, 0xff, 0x10},
+	}
+	data, err := UnbindMsgpack(in)
+	assert.NoError(t, err)
+
+	out, err := NewMsgpack[msgpackPayload](data)
+	assert.NoError(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestBindMsgpackFromLibrary(t *testing.T) {
+	data, err := msgpack.Marshal(map[string]any{"name": "lib", "count": int8(7), "nested": map[string]any{"weight": float32(1.5)}})
+	assert.NoError(t, err)
+
+	var out msgpackPayload
+	assert.NoError(t, BindMsgpack(&out, data))
+	assert.Equal(t, "lib", out.Name)
+	assert.Equal(t, int8(7), out.Count)
+	assert.Equal(t, 1.5, out.Nested.Weight)
+
+	assert.NoError(t, MergeMsgpack(&out, mustMsgpack(t, map[string]any{"total": 9})))
+	assert.Equal(t, "lib", out.Name)
+	assert.Equal(t, uint64(9), out.Total)
+}
+
+func TestMsgpackReaderWriterFile(t *testing.T) {
+	in := &msgpackPayload{Name: "io", Tags: []string{"t"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, UnbindMsgpackWriter(in, &buf))
+	out, err := NewMsgpackReader[msgpackPayload](&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "io", out.Name)
+
+	path := filepath.Join(t.TempDir(), "payload.msgpack")
+	assert.NoError(t, UnbindMsgpackFile(in, path))
+	var fromFile msgpackPayload
+	assert.NoError(t, BindMsgpackFile(&fromFile, path))
+	assert.Equal(t, []string{"t"}, fromFile.Tags)
+
+	err = BindMsgpackFile(&fromFile, filepath.Join(t.TempDir(), "missing.msgpack"))
+	var fileErr *FileError
+	assert.ErrorAs(t, err, &fileErr)
+	assert.True(t, fileErr.IsNotFound())
+}
+
+func TestBindMsgpackInvalid(t *testing.T) {
+	var out msgpackPayload
+	err := BindMsgpack(&out, []byte{0xc1})
+	var convErr *ConversionError
+	assert.ErrorAs(t, err, &convErr)
+}
+
+func mustMsgpack(t *testing.T, v any) []byte {
+	data, err := msgpack.Marshal(v)
+	assert.NoError(t, err)
+	return data
+}
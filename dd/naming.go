@@ -0,0 +1,81 @@
+package dd
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy derives the external name of a struct field that has no explicit name in its dd tag. it receives
+// the Go field name (e.g. "MaxConnections") and returns the key used in data maps.
+type NamingStrategy func(fieldName string) string
+
+var (
+	// SnakeCase names fields like "max_connections"; this is the default.
+	SnakeCase NamingStrategy = toSnakeCase
+	// CamelCase names fields like "maxConnections".
+	CamelCase NamingStrategy = toCamelCase
+	// PascalCase names fields like "MaxConnections".
+	PascalCase NamingStrategy = toPascalCase
+	// KebabCase names fields like "max-connections".
+	KebabCase NamingStrategy = toKebabCase
+	// ScreamingSnakeCase names fields like "MAX_CONNECTIONS".
+	ScreamingSnakeCase NamingStrategy = toScreamingSnakeCase
+)
+
+// externalName returns the external name of a field: the name from its dd tag, or the field name converted by naming
+// (snake_case when nil).
+func externalName(field reflect.StructField, tag DdTag, naming NamingStrategy) string {
+	if tag.Name != "" {
+		return tag.Name
+	}
+	if naming == nil {
+		return toSnakeCase(field.Name)
+	}
+	return naming(field.Name)
+}
+
+func (o *Options) naming() NamingStrategy {
+	if o == nil {
+		return nil
+	}
+	return o.NamingStrategy
+}
+
+// nameWords splits a Go identifier into lowercase words, using the same boundaries as toSnakeCase.
+func nameWords(in string) []string {
+	return strings.FieldsFunc(toSnakeCase(in), func(r rune) bool { return r == '_' })
+}
+
+func toCamelCase(in string) string {
+	words := nameWords(in)
+	for i := 1; i < len(words); i++ {
+		words[i] = capitalize(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+func toPascalCase(in string) string {
+	words := nameWords(in)
+	for i := range words {
+		words[i] = capitalize(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+func toKebabCase(in string) string {
+	return strings.Join(nameWords(in), "-")
+}
+
+func toScreamingSnakeCase(in string) string {
+	return strings.ToUpper(strings.Join(nameWords(in), "_"))
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
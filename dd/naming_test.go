@@ -0,0 +1,80 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type namingServer struct {
+	HTTPPort       int
+	MaxConnections int
+}
+
+type namingConfig struct {
+	AppName string
+	Server  namingServer
+	Legacy  string `dd:"legacy_name"`
+}
+
+func TestNamingStrategies(t *testing.T) {
+	tests := []struct {
+		strategy NamingStrategy
+		in       string
+		expected string
+	}{
+		{SnakeCase, "MaxConnections", "max_connections"},
+		{CamelCase, "MaxConnections", "maxConnections"},
+		{CamelCase, "HTTPServer", "httpServer"},
+		{PascalCase, "maxConnections", "MaxConnections"},
+		{PascalCase, "HTTPServer", "HttpServer"},
+		{KebabCase, "HTTPServer", "http-server"},
+		{ScreamingSnakeCase, "MaxConnections", "MAX_CONNECTIONS"},
+		{CamelCase, "ID", "id"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.strategy(tt.in), tt.in)
+	}
+}
+
+func TestNamingStrategyRoundTrip(t *testing.T) {
+	opts := &Options{NamingStrategy: CamelCase}
+	data := map[string]any{
+		"appName":     "demo",
+		"server":      map[string]any{"httpPort": 8080, "maxConnections": 100},
+		"legacy_name": "kept",
+	}
+
+	cfg, err := New[namingConfig](data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "demo", cfg.AppName)
+	assert.Equal(t, 8080, cfg.Server.HTTPPort)
+	assert.Equal(t, 100, cfg.Server.MaxConnections)
+	assert.Equal(t, "kept", cfg.Legacy)
+
+	out, err := Unbind(cfg, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, data, out)
+
+	// the default strategy does not recognize camelCase keys
+	cfg, err = New[namingConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, "", cfg.AppName)
+}
+
+func TestNamingStrategyCustom(t *testing.T) {
+	opts := &Options{NamingStrategy: strings.ToLower}
+	out, err := Unbind(&namingConfig{AppName: "demo"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "demo", out["appname"])
+	assert.Contains(t, out, "legacy_name")
+}
+
+func TestNamingStrategyInspect(t *testing.T) {
+	out, err := Inspect(&namingConfig{AppName: "demo"}, &InspectOptions{NamingStrategy: KebabCase})
+	assert.NoError(t, err)
+	assert.Contains(t, out, "app-name")
+	assert.Contains(t, out, "max-connections")
+	assert.Contains(t, out, "legacy_name")
+}
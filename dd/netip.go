@@ -0,0 +1,76 @@
+package dd
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+)
+
+var netipAddrType = reflect.TypeOf(netip.Addr{})
+var netipAddrPortType = reflect.TypeOf(netip.AddrPort{})
+var netipPrefixType = reflect.TypeOf(netip.Prefix{})
+
+// bindNetip sets a netip.Addr, netip.AddrPort, or netip.Prefix destination from its string form; an empty string
+// yields the zero value. returns false if dst is not a supported netip type.
+func bindNetip(dst reflect.Value, raw interface{}, path string) (bool, error) {
+	var parse func(string) (any, error)
+	var typeName string
+	switch dst.Type() {
+	case netipAddrType:
+		typeName = "ip address"
+		parse = func(s string) (any, error) { return netip.ParseAddr(s) }
+	case netipAddrPortType:
+		typeName = "ip address and port"
+		parse = func(s string) (any, error) { return netip.ParseAddrPort(s) }
+	case netipPrefixType:
+		typeName = "ip prefix"
+		parse = func(s string) (any, error) { return netip.ParsePrefix(s) }
+	default:
+		return false, nil
+	}
+
+	if rv := reflect.ValueOf(raw); rv.IsValid() && rv.Type() == dst.Type() {
+		dst.Set(rv)
+		return true, nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return true, &TypeMismatchError{Path: path, Expected: typeName + " string", Actual: fmt.Sprintf("%T", raw)}
+	}
+	if s == "" {
+		dst.Set(reflect.Zero(dst.Type()))
+		return true, nil
+	}
+	v, err := parse(s)
+	if err != nil {
+		return true, &ConversionError{Path: path, Value: s, Type: typeName, Cause: err}
+	}
+	dst.Set(reflect.ValueOf(v))
+	return true, nil
+}
+
+// unbindNetip formats netip values in their string form. zero values unbind as an empty string. returns false if v
+// is not a supported netip type.
+func unbindNetip(v reflect.Value) (interface{}, bool) {
+	switch v.Type() {
+	case netipAddrType:
+		a := v.Interface().(netip.Addr)
+		if !a.IsValid() {
+			return "", true
+		}
+		return a.String(), true
+	case netipAddrPortType:
+		ap := v.Interface().(netip.AddrPort)
+		if !ap.IsValid() {
+			return "", true
+		}
+		return ap.String(), true
+	case netipPrefixType:
+		p := v.Interface().(netip.Prefix)
+		if !p.IsValid() {
+			return "", true
+		}
+		return p.String(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,72 @@
+package dd
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type netipConfig struct {
+	Bind    netip.AddrPort
+	Gateway *netip.Addr
+	Allow   []netip.Prefix
+	Peers   map[string]netip.Addr
+}
+
+func TestBindNetip(t *testing.T) {
+	data := map[string]any{
+		"bind":    "0.0.0.0:8080",
+		"gateway": "fe80::1",
+		"allow":   []any{"10.0.0.0/8", "2001:db8::/32"},
+		"peers":   map[string]any{"a": "192.168.1.10"},
+	}
+
+	cfg, err := New[netipConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddrPort("0.0.0.0:8080"), cfg.Bind)
+	assert.Equal(t, netip.MustParseAddr("fe80::1"), *cfg.Gateway)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")}, cfg.Allow)
+	assert.Equal(t, netip.MustParseAddr("192.168.1.10"), cfg.Peers["a"])
+}
+
+func TestBindNetipInvalid(t *testing.T) {
+	_, err := New[netipConfig](map[string]any{"gateway": "300.1.1.1"})
+	assert.Error(t, err)
+	var convErr *ConversionError
+	assert.ErrorAs(t, err, &convErr)
+
+	_, err = New[netipConfig](map[string]any{"bind": 8080})
+	assert.Error(t, err)
+}
+
+func TestUnbindNetipRoundTrip(t *testing.T) {
+	gw := netip.MustParseAddr("10.0.0.1")
+	cfg := &netipConfig{
+		Bind:    netip.MustParseAddrPort("[::1]:443"),
+		Gateway: &gw,
+		Allow:   []netip.Prefix{netip.MustParsePrefix("172.16.0.0/12")},
+	}
+
+	data, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "[::1]:443", data["bind"])
+	assert.Equal(t, "10.0.0.1", data["gateway"])
+	assert.Equal(t, []any{"172.16.0.0/12"}, data["allow"])
+
+	out, err := New[netipConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.Bind, out.Bind)
+	assert.Equal(t, gw, *out.Gateway)
+	assert.Equal(t, cfg.Allow, out.Allow)
+}
+
+func TestUnbindNetipZero(t *testing.T) {
+	data, err := Unbind(&netipConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", data["bind"])
+
+	out, err := New[netipConfig](data)
+	assert.NoError(t, err)
+	assert.False(t, out.Bind.IsValid())
+}
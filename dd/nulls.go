@@ -0,0 +1,121 @@
+package dd
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExplicitNulls records the pointer fields of a target that were set to nil by an explicit null in the input, as
+// opposed to being absent, keyed by the dotted path of external field names (e.g. "server.tls").
+//
+// an ExplicitNulls is owned by the caller: set Options.ExplicitNulls to a non-nil map, and Bind (which clears the map
+// first) and Merge record into it, a later non-null value for the same field removing its entry. Unbind, given the
+// same map, emits those fields as null instead of omitting them. keep one ExplicitNulls per target.
+type ExplicitNulls map[string]bool
+
+// Paths returns the recorded paths in sorted order, or nil if there are none.
+func (n ExplicitNulls) Paths() []string {
+	if len(n) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(n))
+	for path := range n {
+		out = append(out, path)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// recordNulls records the explicit nulls in data for pointer fields of structType, in opt.ExplicitNulls. when reset is
+// true, previously recorded nulls are discarded first; otherwise paths given a non-null value in data are removed.
+func recordNulls(structType reflect.Type, data map[string]any, opt *Options, reset bool) {
+	if opt == nil || opt.ExplicitNulls == nil {
+		return
+	}
+	if reset {
+		clear(opt.ExplicitNulls)
+	}
+	collectNulls(structType, data, "", opt.ExplicitNulls, opt.naming())
+}
+
+// collectNulls walks data alongside structType, marking pointer fields given an explicit null and unmarking those
+// given a value.
+func collectNulls(structType reflect.Type, data map[string]any, prefix string, out map[string]bool, naming NamingStrategy) {
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		if isInlined(field, meta.tag) {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				collectNulls(embeddedType, data, prefix, out, naming)
+			}
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra {
+			continue
+		}
+		name := meta.externalName(naming)
+		raw, ok := data[name]
+		if !ok {
+			continue
+		}
+		path := joinProvenancePath(prefix, name)
+		if raw == nil {
+			deleteNullsUnder(out, path)
+			if field.Type.Kind() == reflect.Ptr {
+				out[path] = true
+			}
+			continue
+		}
+		delete(out, path)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if subMap, ok := raw.(map[string]any); ok && !tag.Raw && isProvenanceStruct(fieldType) {
+			collectNulls(fieldType, subMap, path, out, naming)
+		} else {
+			deleteNullsUnder(out, path)
+		}
+	}
+}
+
+// deleteNullsUnder discards the nulls recorded beneath path, whose subtree has been replaced.
+func deleteNullsUnder(out map[string]bool, path string) {
+	for recorded := range out {
+		if strings.HasPrefix(recorded, path+".") {
+			delete(out, recorded)
+		}
+	}
+}
+
+// restoreNulls inserts an explicit null into data for each recorded path whose field unbound to nothing.
+func restoreNulls(nulls ExplicitNulls, data map[string]any) {
+	for _, path := range nulls.Paths() {
+		segments := strings.Split(path, ".")
+		node := data
+		for _, segment := range segments[:len(segments)-1] {
+			next, ok := node[segment].(map[string]any)
+			if !ok {
+				node = nil
+				break
+			}
+			node = next
+		}
+		if node == nil {
+			continue
+		}
+		last := segments[len(segments)-1]
+		if _, exists := node[last]; !exists {
+			node[last] = nil
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nullsTLS struct {
+	Cert string
+}
+
+type nullsServer struct {
+	Host    *string
+	Timeout *int
+	TLS     *nullsTLS
+}
+
+type nullsConfig struct {
+	Name   *string
+	Server nullsServer
+	Ports  []*int `dd:",+omitempty"`
+}
+
+func TestExplicitNullClearsPointer(t *testing.T) {
+	name := "app"
+	timeout := 30
+	cfg := &nullsConfig{Name: &name, Server: nullsServer{Timeout: &timeout, TLS: &nullsTLS{Cert: "a.pem"}}}
+
+	err := Merge(cfg, map[string]any{
+		"name":   nil,
+		"server": map[string]any{"tls": nil},
+	})
+	assert.NoError(t, err)
+
+	assert.Nil(t, cfg.Name)
+	assert.Nil(t, cfg.Server.TLS)
+	// absent keys leave the pointer unchanged
+	if assert.NotNil(t, cfg.Server.Timeout) {
+		assert.Equal(t, 30, *cfg.Server.Timeout)
+	}
+}
+
+func TestExplicitNullSliceElement(t *testing.T) {
+	cfg := &nullsConfig{}
+	assert.NoError(t, Bind(cfg, map[string]any{"ports": []any{80, nil}}))
+	if assert.Len(t, cfg.Ports, 2) {
+		assert.Equal(t, 80, *cfg.Ports[0])
+		assert.Nil(t, cfg.Ports[1])
+	}
+}
+
+func TestExplicitNullNonPointerStillFails(t *testing.T) {
+	cfg := &struct{ Port int }{}
+	assert.Error(t, Bind(cfg, map[string]any{"port": nil}))
+}
+
+func TestPreserveNullsRoundTrip(t *testing.T) {
+	cfg := &nullsConfig{}
+	nulls := ExplicitNulls{}
+	opts := &Options{ExplicitNulls: nulls}
+	err := Bind(cfg, map[string]any{
+		"name":   nil,
+		"server": map[string]any{"host": "localhost", "tls": nil},
+	}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "server.tls"}, nulls.Paths())
+
+	data, err := Unbind(cfg, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":   nil,
+		"server": map[string]any{"host": "localhost", "tls": nil},
+	}, data)
+
+	// the record belongs to the caller, so it applies to a copy of the target as well
+	copied := *cfg
+	data, err = Unbind(&copied, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":   nil,
+		"server": map[string]any{"host": "localhost", "tls": nil},
+	}, data)
+
+	// without the option, nil pointers are omitted as before
+	data, err = Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"server": map[string]any{"host": "localhost"}}, data)
+}
+
+func TestPreserveNullsMergeUpdates(t *testing.T) {
+	cfg := &nullsConfig{}
+	nulls := ExplicitNulls{}
+	opts := &Options{ExplicitNulls: nulls}
+	assert.NoError(t, Bind(cfg, map[string]any{"name": nil, "server": map[string]any{"tls": nil, "timeout": nil}}, opts))
+	assert.NoError(t, Merge(cfg, map[string]any{"name": "app", "server": map[string]any{"tls": map[string]any{"cert": "b.pem"}}}, opts))
+	assert.Equal(t, []string{"server.timeout"}, nulls.Paths())
+
+	assert.NoError(t, Merge(cfg, map[string]any{"server": map[string]any{"timeout": 5}}, opts))
+	assert.Nil(t, nulls.Paths())
+
+	// a fresh Bind discards previously recorded nulls
+	assert.NoError(t, Merge(cfg, map[string]any{"name": nil}, opts))
+	assert.NoError(t, Bind(cfg, map[string]any{"server": map[string]any{"host": nil}}, opts))
+	assert.Equal(t, []string{"server.host"}, nulls.Paths())
+}
@@ -0,0 +1,87 @@
+package dd
+
+import "reflect"
+
+// Optional holds a value of type T together with whether its key was present in the input. it answers "was this
+// field actually set?" for non-pointer fields, where the zero value is otherwise indistinguishable from absence.
+//
+// Bind and Merge set Set to true when the key is present (an explicit null sets it with the zero value); absent keys
+// leave the Optional unchanged. Unbind omits an Optional that is not Set, and otherwise emits Value.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// Get returns the value and whether it was set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set
+}
+
+// OrElse returns the value if set, otherwise fallback.
+func (o Optional[T]) OrElse(fallback T) T {
+	if o.Set {
+		return o.Value
+	}
+	return fallback
+}
+
+// Clear resets the Optional to the unset state.
+func (o *Optional[T]) Clear() {
+	var zero T
+	o.Value = zero
+	o.Set = false
+}
+
+func (o Optional[T]) isOptional() {}
+
+// optionalMarker is implemented by every Optional[T] instantiation.
+type optionalMarker interface {
+	isOptional()
+}
+
+var optionalMarkerType = reflect.TypeOf((*optionalMarker)(nil)).Elem()
+
+// isOptionalType reports whether t is an Optional[T] instantiation.
+func isOptionalType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(optionalMarkerType)
+}
+
+// bindOptional binds raw into the Value of an Optional and marks it as set. a null raw sets the zero value.
+func bindOptional(fieldVal reflect.Value, raw interface{}, path string, opt *Options, preserveExisting bool) error {
+	value := fieldVal.Field(0)
+	if raw == nil {
+		value.Set(reflect.Zero(value.Type()))
+	} else {
+		preserve := preserveExisting && fieldVal.Field(1).Bool()
+		if err := setField(value, raw, path, opt, preserve); err != nil {
+			return err
+		}
+	}
+	fieldVal.Field(1).SetBool(true)
+	return nil
+}
+
+// unbindOptional returns the value held by an Optional. returns present=false when it is not set, and handled=false
+// if v is not an Optional.
+func unbindOptional(v reflect.Value, opt *Options) (out interface{}, present bool, handled bool, err error) {
+	if !isOptionalType(v.Type()) {
+		return nil, false, false, nil
+	}
+	if !v.Field(1).Bool() {
+		return nil, false, true, nil
+	}
+	out, present, err = valueToInterface(v.Field(0), opt)
+	if err != nil {
+		return nil, false, true, err
+	}
+	if !present {
+		// set to nil (e.g. an Optional[*T] holding nil) is emitted as an explicit null
+		return nil, true, true, nil
+	}
+	return out, true, true, nil
+}
@@ -0,0 +1,139 @@
+package dd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type optionalConfig struct {
+	Port    Optional[int]
+	Debug   Optional[bool]
+	Name    Optional[string]
+	Timeout Optional[time.Duration]
+	Limit   *Optional[int]
+	Parent  Optional[*optionalInner]
+	Inner   Optional[optionalInner]
+}
+
+type optionalInner struct {
+	Host string
+	Port int
+}
+
+func TestOptionalBindPresence(t *testing.T) {
+	cfg := &optionalConfig{}
+	err := Bind(cfg, map[string]any{
+		"port":    0,
+		"debug":   false,
+		"timeout": "5s",
+		"inner":   map[string]any{"host": "localhost"},
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, cfg.Port.Set)
+	assert.Equal(t, 0, cfg.Port.Value)
+	assert.True(t, cfg.Debug.Set)
+	assert.False(t, cfg.Name.Set)
+	assert.Equal(t, 5*time.Second, cfg.Timeout.Value)
+	assert.Nil(t, cfg.Limit)
+	assert.Equal(t, "localhost", cfg.Inner.Value.Host)
+
+	_, ok := cfg.Name.Get()
+	assert.False(t, ok)
+	assert.Equal(t, "default", cfg.Name.OrElse("default"))
+}
+
+func TestOptionalBindExplicitNull(t *testing.T) {
+	cfg := &optionalConfig{Name: Some("old"), Parent: Some(&optionalInner{Host: "old"})}
+	err := Bind(cfg, map[string]any{"name": nil, "parent": nil})
+	assert.NoError(t, err)
+
+	assert.True(t, cfg.Name.Set)
+	assert.Equal(t, "", cfg.Name.Value)
+	assert.True(t, cfg.Parent.Set)
+	assert.Nil(t, cfg.Parent.Value)
+}
+
+func TestOptionalPointer(t *testing.T) {
+	cfg := &optionalConfig{}
+	err := Bind(cfg, map[string]any{"limit": 10})
+	assert.NoError(t, err)
+	if assert.NotNil(t, cfg.Limit) {
+		assert.Equal(t, Some(10), *cfg.Limit)
+	}
+}
+
+func TestOptionalMerge(t *testing.T) {
+	cfg := &optionalConfig{
+		Port:  Some(8080),
+		Inner: Some(optionalInner{Host: "localhost", Port: 80}),
+	}
+	err := Merge(cfg, map[string]any{
+		"debug": true,
+		"inner": map[string]any{"port": 443},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, Some(8080), cfg.Port)
+	assert.Equal(t, Some(true), cfg.Debug)
+	assert.False(t, cfg.Name.Set)
+	assert.Equal(t, Some(optionalInner{Host: "localhost", Port: 443}), cfg.Inner)
+}
+
+func TestOptionalUnbind(t *testing.T) {
+	cfg := &optionalConfig{
+		Port:    Some(0),
+		Timeout: Some(time.Minute),
+		Parent:  Some[*optionalInner](nil),
+	}
+	data, err := Unbind(cfg)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"port":    0,
+		"timeout": "1m0s",
+		"parent":  nil,
+	}, data)
+
+	roundTrip := &optionalConfig{}
+	assert.NoError(t, Bind(roundTrip, data))
+	assert.Equal(t, cfg.Port, roundTrip.Port)
+	assert.Equal(t, cfg.Timeout, roundTrip.Timeout)
+	assert.Equal(t, cfg.Parent, roundTrip.Parent)
+	assert.False(t, roundTrip.Debug.Set)
+}
+
+func TestOptionalClear(t *testing.T) {
+	o := Some("value")
+	o.Clear()
+	assert.Equal(t, Optional[string]{}, o)
+}
+
+func TestOptionalInspect(t *testing.T) {
+	cfg := &struct {
+		Port Optional[int]
+		Name Optional[string]
+	}{Port: Some(8080)}
+
+	out, err := Inspect(cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, out, "port: 8080")
+	assert.Contains(t, out, "name: <unset>")
+}
+
+func TestOptionalProvenanceAndSkeleton(t *testing.T) {
+	type config struct {
+		Port Optional[int] `dd:"+doc=\"listen port\""`
+	}
+	cfg := &config{}
+	provenance := Provenance{}
+	assert.NoError(t, Bind(cfg, map[string]any{"port": 9090}, &Options{Source: "file", Provenance: provenance}))
+	assert.Equal(t, Provenance{"port": "file"}, provenance)
+
+	out, err := Skeleton[config](SkeletonYAML)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "port: 0")
+	assert.Contains(t, string(out), "listen port (integer)")
+}
@@ -0,0 +1,193 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OrderedMap is an unbound object that remembers the order of its keys. UnbindOrdered returns one with keys in struct
+// field declaration order, and it marshals to JSON and YAML in that order.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// Keys returns the keys in order.
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+// Get returns the value stored under key, and whether it is present.
+func (m *OrderedMap) Get(key string) (any, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Set stores value under key, appending key to the order if it is new.
+func (m *OrderedMap) Set(key string, value any) {
+	if m.values == nil {
+		m.values = make(map[string]any)
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Len returns the number of keys.
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// MarshalJSON encodes the map as a JSON object with keys in order.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := marshalJSONUnescaped(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := marshalJSONUnescaped(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalJSONUnescaped is json.Marshal without HTML escaping. the enclosing encoder escapes the output of
+// MarshalJSON itself when its escaping is enabled, so leaving it to the encoder honors Options.JSONDisableHTMLEscape.
+func marshalJSONUnescaped(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// MarshalYAML encodes the map as a YAML mapping with keys in order.
+func (m *OrderedMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, key := range m.keys {
+		var k, v yaml.Node
+		if err := k.Encode(key); err != nil {
+			return nil, err
+		}
+		if err := v.Encode(m.values[key]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &k, &v)
+	}
+	return node, nil
+}
+
+// UnbindOrdered is like Unbind, but returns an OrderedMap whose keys, and those of every nested struct, follow the
+// struct field declaration order, with embedded fields in place and `+extra` keys last. map keys are sorted. use it
+// (or Options.PreserveOrder with UnbindJSON and UnbindYAML) so that generated configuration diffs cleanly in version
+// control.
+//
+// opts are optional; pass nil or omit to use defaults.
+func UnbindOrdered(source interface{}, opts ...*Options) (*OrderedMap, error) {
+	data, err := Unbind(source, opts...)
+	if err != nil {
+		return nil, err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return orderUnbound(data, reflect.TypeOf(source), opt.naming()).(*OrderedMap), nil
+}
+
+// orderedOutput returns data unchanged, or as an OrderedMap when Options.PreserveOrder is set.
+func orderedOutput(source interface{}, data map[string]any, opts ...*Options) (any, error) {
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if opt == nil || !opt.PreserveOrder {
+		return data, nil
+	}
+	return orderUnbound(data, reflect.TypeOf(source), opt.naming()), nil
+}
+
+// orderUnbound converts the unbound value v of type t (nil when unknown) into nested OrderedMaps.
+func orderUnbound(v any, t reflect.Type, naming NamingStrategy) any {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && isOptionalType(t) {
+		t = t.Field(0).Type
+	}
+	switch data := v.(type) {
+	case map[string]any:
+		om := &OrderedMap{}
+		if t != nil && t.Kind() == reflect.Struct && !isScalarStruct(t) {
+			orderStructFields(om, data, t, naming)
+		}
+		var elemType reflect.Type
+		if t != nil && t.Kind() == reflect.Map {
+			elemType = t.Elem()
+		}
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			if _, ok := om.values[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			om.Set(key, orderUnbound(data[key], elemType, naming))
+		}
+		return om
+	case []any:
+		var elemType reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elemType = t.Elem()
+		}
+		out := make([]any, len(data))
+		for i, item := range data {
+			out[i] = orderUnbound(item, elemType, naming)
+		}
+		return out
+	}
+	return v
+}
+
+// orderStructFields adds the keys of data belonging to the fields of struct type t to om, in declaration order.
+func orderStructFields(om *OrderedMap, data map[string]any, t reflect.Type, naming NamingStrategy) {
+	for _, meta := range cachedFields(t) {
+		field := meta.field
+		if isInlined(field, meta.tag) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				orderStructFields(om, data, embedded, naming)
+			}
+			continue
+		}
+		if field.PkgPath != "" || meta.tag.Skip || meta.tag.Extra {
+			continue
+		}
+		name := meta.externalName(naming)
+		if value, ok := data[name]; ok {
+			om.Set(name, orderUnbound(value, field.Type, naming))
+		}
+	}
+}
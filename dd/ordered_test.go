@@ -0,0 +1,98 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type OrderedBase struct {
+	Version int
+}
+
+type orderedServer struct {
+	Port int
+	Host string
+}
+
+type orderedConfig struct {
+	Zone string
+	OrderedBase
+	Servers []orderedServer
+	Labels  map[string]string
+	Backup  *orderedServer
+	Extra   map[string]any `dd:",+extra"`
+}
+
+func newOrderedConfig() *orderedConfig {
+	return &orderedConfig{
+		Zone:        "east",
+		OrderedBase: OrderedBase{Version: 2},
+		Servers:     []orderedServer{{Port: 80, Host: "a"}},
+		Labels:      map[string]string{"tier": "web", "env": "prod"},
+		Backup:      &orderedServer{Port: 81, Host: "b"},
+		Extra:       map[string]any{"notes": "x"},
+	}
+}
+
+func TestUnbindOrdered(t *testing.T) {
+	om, err := UnbindOrdered(newOrderedConfig())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zone", "version", "servers", "labels", "backup", "notes"}, om.Keys())
+	assert.Equal(t, 6, om.Len())
+
+	backup, ok := om.Get("backup")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"port", "host"}, backup.(*OrderedMap).Keys())
+	labels, _ := om.Get("labels")
+	assert.Equal(t, []string{"env", "tier"}, labels.(*OrderedMap).Keys())
+	servers, _ := om.Get("servers")
+	assert.Equal(t, []string{"port", "host"}, servers.([]any)[0].(*OrderedMap).Keys())
+}
+
+func TestUnbindPreserveOrder(t *testing.T) {
+	opts := &Options{PreserveOrder: true}
+
+	data, err := UnbindJSON(newOrderedConfig(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "zone": "east",
+  "version": 2,
+  "servers": [
+    {
+      "port": 80,
+      "host": "a"
+    }
+  ],
+  "labels": {
+    "env": "prod",
+    "tier": "web"
+  },
+  "backup": {
+    "port": 81,
+    "host": "b"
+  },
+  "notes": "x"
+}`, string(data))
+
+	data, err = UnbindYAML(newOrderedConfig(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, `zone: east
+version: 2
+servers:
+    - port: 80
+      host: a
+labels:
+    env: prod
+    tier: web
+backup:
+    port: 81
+    host: b
+notes: x
+`, string(data))
+
+	// the ordered output binds back to the same struct
+	cfg, err := NewYAML[orderedConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, newOrderedConfig(), cfg)
+}
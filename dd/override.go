@@ -0,0 +1,382 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ApplyOverrides applies "path=value" assignments to target (a pointer to a struct), in the style of helm and
+// kubectl `--set` flags. paths use external (tag or naming strategy) field names separated by dots; list elements are
+// addressed by index (`servers[0].port=8080`), and `[+]` appends a new element (`features[+]=premium`). map keys are
+// addressed like fields (`labels.tier=web`).
+//
+// values are supplied as strings and coerced to the target field's type using the normal binding rules, so
+// `server.port=9090` sets an int field and `debug=true` sets a bool field. fields not mentioned are left unchanged.
+// overrides are applied in order, so later assignments win. an override whose path does not name a field of target
+// (a misspelled `nosuch=3`, say) is rejected with a *ValidationError.
+//
+// opts supply the binding options, as for Merge; set Options.Source and Options.Provenance to record the overrides
+// in provenance.
+func ApplyOverrides(target interface{}, overrides []string, opts ...*Options) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	assignments := make([]pathAssignment, 0, len(overrides))
+	for _, override := range overrides {
+		path, value, found := strings.Cut(override, "=")
+		if !found {
+			return &ValidationError{Field: override, Message: "override must have the form path=value"}
+		}
+		assignments = append(assignments, pathAssignment{path: strings.TrimSpace(path), value: value})
+	}
+	return assignPaths(target, assignments, opts...)
+}
+
+// pathAssignment is a value to assign at a dotted field path; see assignPaths.
+type pathAssignment struct {
+	path  string
+	value any
+}
+
+// assignPaths assigns each value at its field path within target, in order, for ApplyOverrides and Patch. each path
+// is followed through the target itself, so only the addressed field, list element, or map entry is written, and
+// everything beside it (including unexported fields and the other elements of a list) is left untouched. a field is
+// assigned by merging it into its struct, with the normal binding rules; an element or map value is bound in place,
+// and a value spliced in wholesale replaces a slice whatever Options.SliceMergeStrategy or a `+merge` tag says.
+//
+// a path that does not resolve to a field of target is an error.
+func assignPaths(target interface{}, assignments []pathAssignment, opts ...*Options) error {
+	elem, err := validateTarget(target)
+	if err != nil {
+		return err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	var merge Options
+	if opt != nil {
+		merge = *opt
+	}
+	merge.replaceSlices = true
+
+	assigned := make(map[string]any)
+	for _, assignment := range assignments {
+		segments, err := parseFieldPath(assignment.path)
+		if err != nil {
+			return &ValidationError{Field: assignment.path, Message: err.Error()}
+		}
+		if segments[0].isIndex {
+			return &ValidationError{Field: assignment.path, Message: "path must begin with a field name"}
+		}
+		value := assignment.value
+		if merge.ExpandEnv != nil {
+			if value, err = merge.ExpandEnv.expandValue(value, assignment.path); err != nil {
+				return err
+			}
+		}
+		a := pathAssigner{path: assignment.path, opt: &merge}
+		if err := a.assign(elem, segments, value, elem.Type().Name()); err != nil {
+			return err
+		}
+		noteAssignedPath(assigned, segments, value)
+	}
+	recordProvenance(elem.Type(), assigned, opt, false)
+	recordNulls(elem.Type(), assigned, opt, false)
+	return nil
+}
+
+// pathAssigner assigns a value at one field path; see assignPaths.
+type pathAssigner struct {
+	path string
+	opt  *Options
+}
+
+// assign sets value at segments within v, where prefix is the binding path of v. v is only written once the value
+// has been bound, and values that are not addressable in place (map entries, new list elements, unallocated
+// pointers) are built aside and stored on success, so a failed assignment leaves v as it was.
+func (a pathAssigner) assign(v reflect.Value, segments []pathSegment, value any, prefix string) error {
+	if len(segments) == 0 {
+		if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+			if value == nil {
+				v.Set(reflect.Zero(v.Type()))
+			} else {
+				v.Set(reflect.ValueOf(value))
+			}
+			return nil
+		}
+		return setField(v, value, prefix, a.opt, true)
+	}
+	seg := segments[0]
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.Type().Elem().Kind() != reflect.Struct || bindsAsValue(v.Type().Elem(), a.opt) {
+			break
+		}
+		if v.IsNil() {
+			fresh := reflect.New(v.Type().Elem())
+			if err := a.assign(fresh.Elem(), segments, value, prefix); err != nil {
+				return err
+			}
+			v.Set(fresh)
+			return nil
+		}
+		return a.assign(v.Elem(), segments, value, prefix)
+
+	case reflect.Struct:
+		if seg.isIndex || bindsAsValue(v.Type(), a.opt) {
+			break
+		}
+		naming := a.opt.naming()
+		if _, found := externalFieldType(v.Type(), seg.key, naming); !found {
+			if !hasExtraField(v.Type()) {
+				return a.errorf("no field %q", seg.key)
+			}
+			// unknown keys are captured by the +extra field
+			doc, err := assignPath(nil, segments, value)
+			if err != nil {
+				return a.errorf("%v", err)
+			}
+			return bindStruct(v, doc.(map[string]any), prefix, a.opt, true, nil)
+		}
+		if len(segments) == 1 {
+			return bindStruct(v, map[string]any{seg.key: value}, prefix, a.opt, true, nil)
+		}
+		field, ok := externalFieldValue(v, seg.key, naming)
+		if !ok {
+			return a.errorf("field %q is within a nil +inline struct", seg.key)
+		}
+		return a.assign(field, segments[1:], value, prefix+"."+seg.key)
+
+	case reflect.Slice, reflect.Array:
+		if !seg.isIndex {
+			break
+		}
+		index := seg.index
+		if seg.append {
+			index = v.Len()
+		}
+		elemPrefix := fmt.Sprintf("%s[%d]", prefix, index)
+		if index < v.Len() {
+			return a.assign(v.Index(index), segments[1:], value, elemPrefix)
+		}
+		if index > v.Len() || v.Kind() == reflect.Array {
+			cause := &IndexError{Index: index, Cause: fmt.Errorf("out of range for list of length %d", v.Len())}
+			return a.errorf("%v", cause)
+		}
+		fresh := reflect.New(v.Type().Elem()).Elem()
+		if err := a.assign(fresh, segments[1:], value, elemPrefix); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(v, fresh))
+		return nil
+
+	case reflect.Map:
+		if seg.isIndex {
+			break
+		}
+		key := reflect.New(v.Type().Key()).Elem()
+		if err := setNonPtrValue(key, seg.key, prefix, a.opt, false); err != nil {
+			return err
+		}
+		entry := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			entry.Set(existing)
+		}
+		if err := a.assign(entry, segments[1:], value, prefix+"."+seg.key); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(key, entry)
+		return nil
+
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			break
+		}
+		var node any
+		if !v.IsNil() {
+			node = v.Interface()
+		}
+		updated, err := assignPath(node, segments, value)
+		if err != nil {
+			return a.errorf("%v", err)
+		}
+		v.Set(reflect.ValueOf(updated))
+		return nil
+	}
+
+	if seg.isIndex {
+		return a.errorf("cannot index %s", v.Type())
+	}
+	return a.errorf("cannot set key %q on %s", seg.key, v.Type())
+}
+
+// errorf returns a ValidationError for the path being assigned.
+func (a pathAssigner) errorf(format string, args ...any) error {
+	return &ValidationError{Field: a.path, Message: fmt.Sprintf(format, args...)}
+}
+
+// hasExtraField reports whether structType has a `+extra` field capturing unmatched keys.
+func hasExtraField(structType reflect.Type) bool {
+	for _, meta := range cachedFields(structType) {
+		if meta.field.PkgPath == "" && meta.tag.Extra {
+			return true
+		}
+	}
+	return false
+}
+
+// noteAssignedPath adds the path of segments to doc, for recording provenance and explicit nulls. the path stops at
+// its first list index, since lists are tracked whole; doc never shares maps with value.
+func noteAssignedPath(doc map[string]any, segments []pathSegment, value any) {
+	key := segments[0].key
+	rest := segments[1:]
+	switch {
+	case len(rest) == 0:
+		doc[key] = value
+	case rest[0].isIndex:
+		doc[key] = []any{}
+	default:
+		sub := make(map[string]any)
+		if existing, ok := doc[key].(map[string]any); ok {
+			for k, v := range existing {
+				sub[k] = v
+			}
+		}
+		doc[key] = sub
+		noteAssignedPath(sub, rest, value)
+	}
+}
+
+// pathSegment is one step of a field path: either a map key or a list index.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+	append  bool // `[+]`: append a new element
+}
+
+// parseFieldPath parses a dotted field path such as `servers[0].port` or `features[+]`.
+func parseFieldPath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		var indexes string
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key, indexes = part[:i], part[i:]
+		}
+		if key == "" && (indexes == "" || len(segments) == 0) {
+			return nil, fmt.Errorf("empty path segment in %q", path)
+		}
+		if key != "" {
+			segments = append(segments, pathSegment{key: key})
+		}
+		for indexes != "" {
+			end := strings.IndexByte(indexes, ']')
+			if indexes[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("malformed index in %q", path)
+			}
+			token := indexes[1:end]
+			indexes = indexes[end+1:]
+			if token == "+" {
+				segments = append(segments, pathSegment{isIndex: true, append: true})
+				continue
+			}
+			n, err := strconv.Atoi(token)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid index %q in %q", token, path)
+			}
+			segments = append(segments, pathSegment{isIndex: true, index: n})
+		}
+	}
+	return segments, nil
+}
+
+// assignPath sets value at segments within node (a map[string]any, []any, or nil), creating intermediate maps and
+// lists as needed, and returns the updated node.
+func assignPath(node any, segments []pathSegment, value any) (any, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	seg := segments[0]
+
+	if !seg.isIndex {
+		m, ok := node.(map[string]any)
+		if node == nil {
+			m, ok = make(map[string]any), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot set key %q on %T", seg.key, node)
+		}
+		updated, err := assignPath(m[seg.key], segments[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		m[seg.key] = updated
+		return m, nil
+	}
+
+	list, ok := node.([]any)
+	if node == nil {
+		list, ok = []any{}, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("cannot index %T", node)
+	}
+	index := seg.index
+	if seg.append {
+		index = len(list)
+	}
+	if index > len(list) {
+		return nil, &IndexError{Index: index, Cause: fmt.Errorf("out of range for list of length %d", len(list))}
+	}
+	if index == len(list) {
+		list = append(list, nil)
+	}
+	updated, err := assignPath(list[index], segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	list[index] = updated
+	return list, nil
+}
+
+// externalFieldType returns the type of the field of structType bound from key, searching embedded structs.
+func externalFieldType(structType reflect.Type, key string, naming NamingStrategy) (reflect.Type, bool) {
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if isInlined(field, meta.tag) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if t, ok := externalFieldType(embedded, key, naming); ok {
+					return t, true
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra {
+			continue
+		}
+		name := meta.externalName(naming)
+		if name == key {
+			return field.Type, true
+		}
+	}
+	return nil, false
+}
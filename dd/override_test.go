@@ -0,0 +1,110 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type overrideServer struct {
+	Host string
+	Port int
+}
+
+type overrideConfig struct {
+	Server   overrideServer
+	Backup   *overrideServer
+	Debug    bool
+	Features []string
+	Servers  []overrideServer
+	Labels   map[string]string
+}
+
+func TestApplyOverrides(t *testing.T) {
+	cfg := &overrideConfig{
+		Server:   overrideServer{Host: "localhost", Port: 8080},
+		Features: []string{"basic"},
+		Servers:  []overrideServer{{Host: "a", Port: 1}, {Host: "b", Port: 2}},
+		Labels:   map[string]string{"env": "prod"},
+	}
+
+	err := ApplyOverrides(cfg, []string{
+		"server.port=9090",
+		"debug=true",
+		"features[+]=premium",
+		"servers[1].port=22",
+		"labels.tier=web",
+		"backup.host=standby",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, overrideServer{Host: "localhost", Port: 9090}, cfg.Server)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"basic", "premium"}, cfg.Features)
+	assert.Equal(t, []overrideServer{{Host: "a", Port: 1}, {Host: "b", Port: 22}}, cfg.Servers)
+	assert.Equal(t, map[string]string{"env": "prod", "tier": "web"}, cfg.Labels)
+	assert.Equal(t, &overrideServer{Host: "standby"}, cfg.Backup)
+}
+
+func TestApplyOverridesAppendToEmpty(t *testing.T) {
+	cfg := &overrideConfig{}
+	assert.NoError(t, ApplyOverrides(cfg, []string{"features[+]=a", "features[+]=b", "servers[0].host=x"}))
+	assert.Equal(t, []string{"a", "b"}, cfg.Features)
+	assert.Equal(t, []overrideServer{{Host: "x"}}, cfg.Servers)
+}
+
+func TestApplyOverridesSliceMergeStrategy(t *testing.T) {
+	// the spliced slices replace the existing ones, whatever the strategy
+	for _, strategy := range []SliceMergeStrategy{SliceAppend, SliceUnion} {
+		cfg := &overrideConfig{Features: []string{"x"}, Servers: []overrideServer{{Host: "a"}, {Host: "b"}}}
+		assert.NoError(t, ApplyOverrides(cfg, []string{"servers[0].host=z", "features[+]=y"}, &Options{SliceMergeStrategy: strategy}))
+		assert.Equal(t, []overrideServer{{Host: "z"}, {Host: "b"}}, cfg.Servers)
+		assert.Equal(t, []string{"x", "y"}, cfg.Features)
+	}
+
+	type tagged struct {
+		Features []string `dd:"features,+merge=append"`
+	}
+	cfg := &tagged{Features: []string{"x"}}
+	assert.NoError(t, ApplyOverrides(cfg, []string{"features[0]=w"}))
+	assert.Equal(t, []string{"w"}, cfg.Features)
+}
+
+func TestApplyOverridesErrors(t *testing.T) {
+	cfg := &overrideConfig{Features: []string{"basic"}}
+	for _, bad := range []string{"server.port", "=1", "features[x]=1", "features[5]=1", "features[0.a=1", "debug.x=1"} {
+		assert.Error(t, ApplyOverrides(cfg, []string{bad}), bad)
+	}
+	assert.Error(t, ApplyOverrides(cfg, []string{"server.port=abc"}))
+	assert.Equal(t, []string{"basic"}, cfg.Features)
+}
+
+func TestApplyOverridesUnknownPath(t *testing.T) {
+	cfg := &overrideConfig{Server: overrideServer{Port: 8080}}
+	for _, bad := range []string{"nosuch=3", "server.prot=9090", "servers[0].nosuch=1"} {
+		err := ApplyOverrides(cfg, []string{"server.port=9090", bad})
+		var ve *ValidationError
+		if assert.ErrorAs(t, err, &ve, bad) {
+			assert.Equal(t, strings.Split(bad, "=")[0], ve.Field)
+		}
+	}
+
+	type extra struct {
+		Name  string
+		Extra map[string]any `dd:",+extra"`
+	}
+	e := &extra{}
+	assert.NoError(t, ApplyOverrides(e, []string{"name=x", "nosuch=3"}))
+	assert.Equal(t, &extra{Name: "x", Extra: map[string]any{"nosuch": "3"}}, e)
+}
+
+func TestParseFieldPath(t *testing.T) {
+	segments, err := parseFieldPath("servers[0].tags[+]")
+	assert.NoError(t, err)
+	assert.Equal(t, []pathSegment{
+		{key: "servers"},
+		{isIndex: true, index: 0},
+		{key: "tags"},
+		{isIndex: true, append: true},
+	}, segments)
+}
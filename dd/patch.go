@@ -0,0 +1,32 @@
+package dd
+
+import (
+	"sort"
+)
+
+// Patch applies changes to target (a pointer to a struct), where each key of changes is a dotted field path in the
+// syntax of ApplyOverrides (`server.port`, `servers[0].host`, `features[+]`) and each value is assigned at that path.
+// it is a finer-grained alternative to Merge: only the addressed fields, list elements, and map keys change, and
+// everything else is left as is.
+//
+// values are bound with the normal coercion rules, so they may be typed (9090) or strings ("9090"). paths are applied
+// in sorted order, so a path always follows any shorter path that prefixes it. a path that does not resolve to a
+// field, map key, or list index of target is rejected with a *ValidationError naming it.
+//
+// opts supply the binding options, as for ApplyOverrides.
+func Patch(target interface{}, changes map[string]any, opts ...*Options) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	assignments := make([]pathAssignment, 0, len(paths))
+	for _, path := range paths {
+		assignments = append(assignments, pathAssignment{path: path, value: changes[path]})
+	}
+	return assignPaths(target, assignments, opts...)
+}
@@ -0,0 +1,108 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch(t *testing.T) {
+	cfg := &overrideConfig{
+		Server:   overrideServer{Host: "localhost", Port: 8080},
+		Features: []string{"basic"},
+		Servers:  []overrideServer{{Host: "a", Port: 1}, {Host: "b", Port: 2}},
+		Labels:   map[string]string{"env": "prod"},
+	}
+
+	err := Patch(cfg, map[string]any{
+		"server.port":     9090,
+		"debug":           true,
+		"features[+]":     "premium",
+		"servers[1].port": "22",
+		"labels.tier":     "web",
+		"backup.host":     "standby",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, overrideServer{Host: "localhost", Port: 9090}, cfg.Server)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"basic", "premium"}, cfg.Features)
+	assert.Equal(t, []overrideServer{{Host: "a", Port: 1}, {Host: "b", Port: 22}}, cfg.Servers)
+	assert.Equal(t, map[string]string{"env": "prod", "tier": "web"}, cfg.Labels)
+	assert.Equal(t, &overrideServer{Host: "standby"}, cfg.Backup)
+}
+
+func TestPatchSliceMergeStrategy(t *testing.T) {
+	cfg := &overrideConfig{Features: []string{"x"}, Servers: []overrideServer{{Host: "a"}, {Host: "b"}}}
+	err := Patch(cfg, map[string]any{"servers[0].host": "z", "features[+]": "y"}, &Options{SliceMergeStrategy: SliceAppend})
+	assert.NoError(t, err)
+	assert.Equal(t, []overrideServer{{Host: "z"}, {Host: "b"}}, cfg.Servers)
+	assert.Equal(t, []string{"x", "y"}, cfg.Features)
+
+	// the strategy still applies to Merge itself
+	assert.NoError(t, Merge(cfg, map[string]any{"features": []any{"z"}}, &Options{SliceMergeStrategy: SliceAppend}))
+	assert.Equal(t, []string{"x", "y", "z"}, cfg.Features)
+}
+
+func TestPatchNested(t *testing.T) {
+	cfg := &overrideConfig{Servers: []overrideServer{{Host: "a", Port: 1}}}
+	err := Patch(cfg, map[string]any{
+		"servers[0]":      map[string]any{"host": "x", "port": 5},
+		"servers[0].port": 6,
+		"server":          map[string]any{"host": "main"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []overrideServer{{Host: "x", Port: 6}}, cfg.Servers)
+	assert.Equal(t, overrideServer{Host: "main"}, cfg.Server)
+}
+
+func TestPatchErrors(t *testing.T) {
+	cfg := &overrideConfig{}
+
+	err := Patch(cfg, map[string]any{"servers[3].host": "x"})
+	assert.Error(t, err)
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+	assert.Equal(t, "servers[3].host", ve.Field)
+
+	err = Patch(cfg, map[string]any{"server.port": "not-a-number"})
+	assert.Error(t, err)
+
+	assert.NoError(t, Patch(cfg, nil))
+}
+
+type patchServer struct {
+	Host  string
+	Port  int
+	Token string `dd:",+secret"`
+	state string
+}
+
+type patchConfig struct {
+	Servers []patchServer
+}
+
+func TestPatchLeavesSiblingsUntouched(t *testing.T) {
+	for _, opt := range []*Options{nil, {SecretPolicy: MaskSecrets}} {
+		cfg := &patchConfig{Servers: []patchServer{
+			{Host: "a", Port: 1, Token: "tok", state: "keep"},
+			{Host: "b", Port: 2, Token: "tok2", state: "keep2"},
+		}}
+		assert.NoError(t, Patch(cfg, map[string]any{"servers[0].port": 5}, opt))
+		assert.Equal(t, []patchServer{
+			{Host: "a", Port: 5, Token: "tok", state: "keep"},
+			{Host: "b", Port: 2, Token: "tok2", state: "keep2"},
+		}, cfg.Servers)
+	}
+}
+
+func TestPatchUnresolvedPath(t *testing.T) {
+	cfg := &overrideConfig{Servers: []overrideServer{{Host: "a", Port: 1}}}
+	for _, path := range []string{"servrs[0].port", "server.prot", "servers[0].port.x", "labels[0]"} {
+		err := Patch(cfg, map[string]any{path: 5})
+		var ve *ValidationError
+		if assert.ErrorAs(t, err, &ve, path) {
+			assert.Equal(t, path, ve.Field)
+		}
+	}
+	assert.Equal(t, []overrideServer{{Host: "a", Port: 1}}, cfg.Servers)
+}
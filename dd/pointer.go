@@ -1,21 +1,102 @@
 package dd
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"unicode"
 )
 
-// Pointer represents a reference to an object of type T that implements Identifiable.
+// Pointer represents a reference to an object of type T that implements Identifiable, or that has a field tagged +id.
 // During binding, the reference is stored as a string. During linking, it's resolved to the actual object.
-type Pointer[T Identifiable] struct {
+// A reference beginning with "/" is a path within the linked document, as in "/departments/eng/teams/frontend":
+// fields are named as they are bound, list elements by index or Id, and map entries by key (see RFC 6901).
+type Pointer[T any] struct {
 	Ref      string `dd:"$ref"`
 	Resolved T      // internal resolved reference (exported for reflection)
+	resolver Resolver
 }
 
-// Resolve returns the resolved object, or the zero value of T if not yet resolved.
+// Resolver looks up the object a Pointer refers to, for resolution on first access rather than by an upfront Link
+// pass; see Options.PointerResolver and LinkerOptions.Lazy.
+type Resolver interface {
+	// ResolveRef returns the object identified by ref, where t is a pointer to the referenced type (*User, for both
+	// Pointer[*User] and Pointer[User]). the object may be returned as a *User or a User; a nil result with a nil
+	// error means no such object exists.
+	ResolveRef(t reflect.Type, ref string) (any, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface.
+type ResolverFunc func(t reflect.Type, ref string) (any, error)
+
+// ResolveRef calls f(t, ref).
+func (f ResolverFunc) ResolveRef(t reflect.Type, ref string) (any, error) {
+	return f(t, ref)
+}
+
+// Resolve returns the resolved object, or the zero value of T if not yet resolved. a lazily resolved pointer is
+// resolved on first access, and the zero value returned when that fails; use Lookup to see the error.
 func (p *Pointer[T]) Resolve() T {
-	return p.Resolved
+	resolved, _ := p.Lookup()
+	return resolved
+}
+
+// Lookup is like Resolve, but reports why a lazily resolved pointer could not be resolved. lazy resolution is not
+// synchronized: resolve pointers shared between goroutines before sharing them, or use Link.
+func (p *Pointer[T]) Lookup() (T, error) {
+	if p.resolver == nil || p.Ref == "" || p.IsResolved() {
+		return p.Resolved, nil
+	}
+	var zero T
+	t := reflect.TypeOf(&zero).Elem()
+	elemType := t
+	if t.Kind() == reflect.Ptr {
+		elemType = t.Elem()
+	}
+	found, err := p.resolver.ResolveRef(reflect.PointerTo(elemType), p.Ref)
+	var typeErr *PointerTypeError
+	if errors.As(err, &typeErr) {
+		return zero, &PointerError{Reference: p.Ref, Cause: typeErr}
+	}
+	if err != nil {
+		return zero, &PointerError{Reference: p.Ref, Cause: fmt.Errorf("resolving reference %s: %w", p.Ref, err)}
+	}
+	if found == nil {
+		return zero, &PointerError{Reference: p.Ref, Message: fmt.Sprintf("unresolved reference: %s (looking for %s)", p.Ref, t)}
+	}
+	if t.Kind() == reflect.Interface {
+		resolved, ok := found.(T)
+		if !ok {
+			return zero, &PointerError{Reference: p.Ref, Message: fmt.Sprintf("resolver returned %T, which does not implement %s", found, t)}
+		}
+		p.Resolved = resolved
+		p.resolver = nil
+		return p.Resolved, nil
+	}
+	value, err := resolvedPointer(found, elemType)
+	if err != nil {
+		return zero, &PointerError{Reference: p.Ref, Cause: fmt.Errorf("resolving reference %s: %w", p.Ref, err)}
+	}
+	if t.Kind() == reflect.Ptr {
+		p.Resolved = value.Interface().(T)
+	} else {
+		p.Resolved = value.Elem().Interface().(T)
+	}
+	p.resolver = nil
+	return p.Resolved, nil
+}
+
+// setResolver makes the pointer resolve itself through r on first access.
+func (p *Pointer[T]) setResolver(r Resolver) {
+	p.resolver = r
+}
+
+// lazyPointer is implemented by every *Pointer[T], for attaching a Resolver through reflection.
+type lazyPointer interface {
+	setResolver(Resolver)
 }
 
 // IsResolved returns true if the pointer has been resolved to an actual object.
@@ -34,14 +115,93 @@ func (p *Pointer[T]) IsResolved() bool {
 type LinkerOptions struct {
 	// EnableCaching enables registry caching for repeated linking operations
 	EnableCaching bool
-	// AllowPartialResolution allows linking to succeed even if some references can't be resolved
+	// AllowPartialResolution allows linking to succeed even if some references can't be resolved; the +weak tag allows
+	// it for individual fields
 	AllowPartialResolution bool
+	// Lazy makes Link collect the Identifiable objects but defer resolving each Pointer until it is first accessed
+	// through Resolve or Lookup, for large object graphs of which only a fraction of references are followed.
+	// unresolvable references are then reported by Lookup rather than by Link.
+	Lazy bool
+	// Resolver, if set, is consulted for references to objects that are not among the linked or registered objects,
+	// so that they can be loaded from a database or a remote API. each object it returns is remembered by the Linker.
+	// it is called once the references among the linked objects are resolved, with the Linker released, so it may
+	// call back into the Linker.
+	Resolver Resolver
+	// RequireTypedRefs rejects references that do not name the type they refer to, as in "User:alice" or
+	// "dd.User:alice", rather than relying on the type of the Pointer. path references are unaffected.
+	RequireTypedRefs bool
+	// OnResolve, if set, is called for each reference Link or ResolveReferences resolves, with the object it resolved
+	// to (as a pointer), for logging or metrics.
+	OnResolve func(site ReferenceSite, target any)
+	// OnMissing, if set, is called for each reference Link or ResolveReferences cannot resolve, with a pointer to the
+	// referenced type (as for Resolver), and may return an object (a *T or T) to resolve it to, such as a placeholder.
+	// a nil object leaves the reference missing, as without OnMissing; an error fails the link as a HookError.
+	//
+	// neither is called for lazily resolved pointers, nor by Validate. like Resolver, both are called with the Linker
+	// released, once the references among the linked objects are resolved, so they may call back into the Linker (to
+	// query ReferencesTo, for instance); OnResolve is not called when linking fails.
+	OnMissing func(site ReferenceSite, t reflect.Type) (any, error)
+	// NamingStrategy names struct fields in path references, as Options.NamingStrategy does when binding; set it to
+	// the strategy the linked document was bound with. snake_case when nil.
+	NamingStrategy NamingStrategy
 }
 
 // Linker encapsulates the linking process, providing enhanced state management and advanced features.
+//
+// a Linker is safe for concurrent use: objects may be registered from several loader goroutines while others link or
+// query. Register, Link, ResolveReferences, Relink, Deregister and ClearCache each hold the Linker exclusively, so they
+// run one at a time; ReferencesTo, Validate and ExportDOT run alongside each other. Pointers linked with Lazy resolve
+// concurrently with each other, and with Register, though each Pointer must still be resolved before it is shared (see
+// Pointer.Lookup).
 type Linker struct {
-	options LinkerOptions
-	cache   map[string]reflect.Value // cached registry for repeated operations
+	mu        sync.RWMutex
+	options   LinkerOptions
+	cache     map[string]reflect.Value // cached registry for repeated operations
+	root      reflect.Value            // the target whose pointers are being resolved
+	sites     map[any][]linkSite       // the resolved references to each object, by its pointer
+	sited     map[any]any              // the object each recorded Pointer refers to, by the Pointer's address
+	owner     reflect.Value            // the object holding the pointers being resolved
+	path      string                   // the path of the field being resolved within root
+	ownerPath int                      // the length of path at owner
+	weak      bool                     // the field being resolved is tagged +weak
+	report    *LinkReport              // problems found by Validate, which resolves nothing when set
+	deferred  []deferredRef            // references left to the Resolver and OnMissing, resolved once the Linker is released
+	resolved  []resolvedRef            // references resolved, for OnResolve once the Linker is released
+}
+
+// deferredRef is a reference not found among the linked objects, to be resolved through the external Resolver or
+// OnMissing with the Linker released.
+type deferredRef struct {
+	site       ReferenceSite
+	pointer    reflect.Value // the Pointer[T] holding the reference
+	targetType reflect.Type  // T, or T's element type when T is a pointer
+	id         string        // the Id to ask the Resolver for, or empty
+	registry   map[string]reflect.Value
+	path       string
+	weak       bool
+}
+
+// resolvedRef is a resolved reference to pass to OnResolve.
+type resolvedRef struct {
+	site   ReferenceSite
+	target any
+}
+
+// ReferenceSite is a resolved reference to an object; see Linker.ReferencesTo.
+type ReferenceSite struct {
+	// Source is the Identifiable object holding the reference, or the linked target when no Identifiable object
+	// encloses it.
+	Source any
+	// Field is the path of the Pointer within Source, as in "Author" or "Meta.Reviewers[2]".
+	Field string
+	// Ref is the reference as written.
+	Ref string
+}
+
+// linkSite is a ReferenceSite with the Pointer it describes.
+type linkSite struct {
+	ReferenceSite
+	pointer reflect.Value
 }
 
 // NewLinker creates a new Linker with optional options.
@@ -63,6 +223,8 @@ func NewLinker(opts ...LinkerOptions) *Linker {
 
 // ClearCache clears the internal registry cache if caching is enabled.
 func (l *Linker) ClearCache() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	if l.cache != nil {
 		l.cache = make(map[string]reflect.Value)
 	}
@@ -75,6 +237,8 @@ func (l *Linker) Register(targets ...interface{}) error {
 	if len(targets) == 0 {
 		return &ValidationError{Message: "no targets provided"}
 	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
 	// ensure cache is available for collection
 	if l.cache == nil {
@@ -113,12 +277,34 @@ func (l *Linker) ResolveReferences(target interface{}) error {
 	if elem.Kind() != reflect.Struct {
 		return fmt.Errorf("target must be a pointer to struct; got %T", target)
 	}
+	return l.resolveLocked(func() error {
+		if l.cache == nil {
+			return fmt.Errorf("no registry available - call Register first")
+		}
 
-	if l.cache == nil {
-		return fmt.Errorf("no registry available - call Register first")
-	}
+		if l.sites == nil {
+			l.sites, l.sited = make(map[any][]linkSite), make(map[any]any)
+		}
+		l.root, l.owner, l.path, l.ownerPath = elem, elem.Addr(), "", 0
+		return l.resolvePointers(elem, l.cache)
+	})
+}
 
-	return l.resolvePointers(elem, l.cache)
+// ReferencesTo returns every reference resolved to target (a pointer to a linked object, as held by the resolved
+// Pointers) by the most recent Link, and by ResolveReferences calls since, in the order they were resolved. references
+// left to lazy resolution are not included.
+func (l *Linker) ReferencesTo(target any) []ReferenceSite {
+	value := reflect.ValueOf(target)
+	if !value.IsValid() || !value.Comparable() {
+		return nil
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var sites []ReferenceSite
+	for _, site := range l.sites[target] {
+		sites = append(sites, site.ReferenceSite)
+	}
+	return sites
 }
 
 // Link resolves all pointer references in the target objects by building a registry of all
@@ -128,7 +314,11 @@ func (l *Linker) Link(targets ...interface{}) error {
 	if len(targets) == 0 {
 		return fmt.Errorf("no targets provided")
 	}
+	return l.resolveLocked(func() error { return l.link(targets) })
+}
 
+// link performs Link with the Linker held.
+func (l *Linker) link(targets []interface{}) error {
 	// phase 1: collect all Identifiable objects with type-prefixed keys
 	var registry map[string]reflect.Value
 	if l.options.EnableCaching && l.cache != nil {
@@ -150,7 +340,16 @@ func (l *Linker) Link(targets ...interface{}) error {
 		}
 	}
 
-	// phase 2: resolve all pointer references in all targets
+	// phase 2: resolve all pointer references in all targets, or attach the registry to resolve them on first access
+	l.sites, l.sited = make(map[any][]linkSite), make(map[any]any)
+	var lazy *sync.RWMutex // guards the registry shared by lazily resolved pointers
+	if l.options.Lazy {
+		lazy = &sync.RWMutex{}
+		if l.options.EnableCaching && l.cache != nil {
+			// the cache is shared with Register
+			lazy = &l.mu
+		}
+	}
 	for i, target := range targets {
 		if target == nil {
 			return fmt.Errorf("nil target provided at index %d", i)
@@ -164,6 +363,12 @@ func (l *Linker) Link(targets ...interface{}) error {
 			return fmt.Errorf("target at index %d must be a pointer to struct; got %T", i, target)
 		}
 
+		if lazy != nil {
+			// path references resolve against the target holding them
+			attachResolver(elem, l.linkResolver(registry, elem, lazy))
+			continue
+		}
+		l.root, l.owner, l.path, l.ownerPath = elem, elem.Addr(), "", 0
 		if err := l.resolvePointers(elem, registry); err != nil {
 			return fmt.Errorf("resolving pointers in target %d: %w", i, err)
 		}
@@ -171,6 +376,266 @@ func (l *Linker) Link(targets ...interface{}) error {
 	return nil
 }
 
+// linkResolver resolves references against a registry collected by a Linker, falling back to an external Resolver for
+// references not in the registry. externally resolved objects are added to the registry, so each is looked up once.
+type linkResolver struct {
+	registry     map[string]reflect.Value
+	mu           *sync.RWMutex // guards registry, when it is shared with concurrent lookups
+	external     Resolver
+	root         reflect.Value // the document path references resolve against
+	naming       NamingStrategy
+	requireTyped bool
+}
+
+// linkResolver returns a linkResolver for registry with the Linker's options, resolving path references against root.
+// mu guards registry, or is nil while the Linker is held exclusively.
+func (l *Linker) linkResolver(registry map[string]reflect.Value, root reflect.Value, mu *sync.RWMutex) linkResolver {
+	return linkResolver{
+		registry:     registry,
+		mu:           mu,
+		external:     l.options.Resolver,
+		root:         root,
+		naming:       l.options.NamingStrategy,
+		requireTyped: l.options.RequireTypedRefs,
+	}
+}
+
+// ResolveRef returns the object for ref as a pointer to t (or t's element type, when t is a pointer), or nil. a
+// reference may name its type explicitly, as in "User:alice"; otherwise the type is that of the Pointer, and a Pointer
+// to an interface type matches any registered type implementing it.
+func (r linkResolver) ResolveRef(t reflect.Type, ref string) (any, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if r.mu != nil {
+		r.mu.RLock()
+	}
+	found, id, err := r.lookup(t, ref)
+	if r.mu != nil {
+		r.mu.RUnlock()
+	}
+	if found != nil || err != nil || id == "" || r.external == nil {
+		return found, err
+	}
+
+	// the registry is not locked while the external resolver runs, which may be slow
+	found, err = r.external.ResolveRef(reflect.PointerTo(t), id)
+	if err != nil || found == nil {
+		return nil, err
+	}
+	target, err := resolvedPointer(found, t)
+	if err != nil {
+		return nil, err
+	}
+	if r.mu != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	if existing, found := r.registry[t.String()+":"+id]; found {
+		// resolved concurrently; keep the first, so that every reference shares it
+		return existing.Interface(), nil
+	}
+	registerIdentifiable(r.registry, target, id)
+	return target.Interface(), nil
+}
+
+// lookup resolves ref against the document and the registry. when it finds nothing, id is the Id to ask the external
+// resolver for, or empty when ref cannot refer to an object it provides.
+func (r linkResolver) lookup(t reflect.Type, ref string) (found any, id string, err error) {
+	if isPathRef(ref) && r.root.IsValid() {
+		target, err := resolvePathRef(r.root, ref, r.naming)
+		if err != nil {
+			return nil, "", err
+		}
+		if !refersTo(target, t) {
+			return nil, "", &PointerTypeError{Reference: ref, Expected: t, Found: target.Type().Elem()}
+		}
+		return target.Interface(), "", nil
+	}
+
+	typeName, typedId, typed := splitTypedRef(ref)
+	if !typed && r.requireTyped {
+		return nil, "", fmt.Errorf("reference does not name its type, as in \"%s:%s\"", typeShortName(t), ref)
+	}
+	if !r.requireTyped && t.Kind() != reflect.Interface {
+		// an Id may itself contain a colon; an exact match takes precedence over a typed reference
+		if target, found := r.registry[t.String()+":"+ref]; found {
+			return target.Interface(), "", nil
+		}
+	}
+	if typed {
+		if target, found := r.registry[ref]; found {
+			if !target.IsValid() {
+				return nil, "", fmt.Errorf("type name %s is ambiguous; qualify it with its package", typeName)
+			}
+			if !refersTo(target, t) {
+				return nil, "", &PointerTypeError{Reference: ref, Expected: t, Found: target.Type().Elem()}
+			}
+			return target.Interface(), "", nil
+		}
+		if typeName == typeShortName(t) || typeName == t.String() {
+			ref = typedId
+		} else if r.requireTyped || t.Kind() == reflect.Interface {
+			return nil, "", nil
+		}
+	}
+	if t.Kind() == reflect.Interface {
+		found, err := r.resolveInterface(t, ref)
+		return found, "", err
+	}
+
+	if target, found := r.registry[t.String()+":"+ref]; found {
+		return target.Interface(), "", nil
+	}
+	return nil, ref, nil
+}
+
+// resolveInterface returns the registered object with the Id ref whose type implements the interface t, or nil. a
+// reference matching objects of more than one type is an error, which a typed reference avoids.
+func (r linkResolver) resolveInterface(t reflect.Type, ref string) (any, error) {
+	var match reflect.Value
+	var matches []string
+	for key, target := range r.registry {
+		// skip the short-name keys, which duplicate the qualified ones
+		if !target.IsValid() || !strings.HasPrefix(key, target.Type().Elem().String()+":") {
+			continue
+		}
+		if key[len(target.Type().Elem().String())+1:] != ref || !target.Type().Implements(t) {
+			continue
+		}
+		match = target
+		matches = append(matches, target.Type().Elem().String())
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return match.Interface(), nil
+	}
+	sort.Strings(matches)
+	return nil, fmt.Errorf("reference is ambiguous for %s, matching %s; name the type, as in \"%s:%s\"", t, strings.Join(matches, ", "), typeShortName(match.Type().Elem()), ref)
+}
+
+// registerIdentifiable adds target, a pointer to an object with the Id id, to registry under its qualified type name
+// ("dd.User:alice") and its short type name ("User:alice"). a short name shared by types from different packages is
+// marked ambiguous with an invalid value.
+func registerIdentifiable(registry map[string]reflect.Value, target reflect.Value, id string) {
+	t := target.Type().Elem()
+	registry[t.String()+":"+id] = target
+	if t.Name() == "" {
+		return
+	}
+	key := typeShortName(t) + ":" + id
+	if existing, found := registry[key]; found && !(existing.IsValid() && existing.Type() == target.Type()) {
+		registry[key] = reflect.Value{}
+		return
+	}
+	registry[key] = target
+}
+
+// splitTypedRef splits a typed reference, as in "User:alice" or "dd.User:alice", into its type name and Id. ok is
+// false when ref does not begin with a type name.
+func splitTypedRef(ref string) (typeName, id string, ok bool) {
+	typeName, id, found := strings.Cut(ref, ":")
+	if !found || id == "" {
+		return "", "", false
+	}
+	pkg, name, qualified := strings.Cut(typeName, ".")
+	if !qualified {
+		pkg, name = "", pkg
+	} else if !isIdentifier(pkg) {
+		return "", "", false
+	}
+	if !isIdentifier(name) {
+		return "", "", false
+	}
+	return typeName, id, true
+}
+
+// isIdentifier reports whether s is a Go identifier.
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// typeShortName returns the name of t without its package, as used in typed references.
+func typeShortName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// refersTo reports whether target, a pointer, may be the object of a Pointer to t.
+func refersTo(target reflect.Value, t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return target.Type().Implements(t)
+	}
+	return target.Type().Elem() == t
+}
+
+// resolvedPointer converts an object returned by a Resolver, either a *T or a T, to a pointer to T.
+func resolvedPointer(found any, t reflect.Type) (reflect.Value, error) {
+	value := reflect.ValueOf(found)
+	switch {
+	case value.Type() == reflect.PointerTo(t) && !value.IsNil():
+		return value, nil
+	case value.Type() == t:
+		ptr := reflect.New(t)
+		ptr.Elem().Set(value)
+		return ptr, nil
+	}
+	return reflect.Value{}, fmt.Errorf("resolver returned %T, expected %s or *%s", found, t, t)
+}
+
+// attachResolver sets resolver on every unresolved Pointer within value, walking the same fields as resolvePointers.
+func attachResolver(value reflect.Value, resolver Resolver) {
+	switch value.Kind() {
+	case reflect.Struct:
+		if isPointerType(value.Type()) {
+			if value.CanAddr() {
+				value.Addr().Interface().(lazyPointer).setResolver(resolver)
+			}
+			return
+		}
+		for _, meta := range cachedFields(value.Type()) {
+			field := meta.field
+			if field.PkgPath != "" || meta.tag.Skip {
+				continue
+			}
+			attachResolver(value.Field(meta.index), resolver)
+		}
+
+	case reflect.Ptr:
+		if !value.IsNil() {
+			attachResolver(value.Elem(), resolver)
+		}
+
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			attachResolver(value.Index(i), resolver)
+		}
+
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			elem := value.MapIndex(key)
+			if elem.Kind() == reflect.Struct {
+				// map values are not addressable; attach to a copy and store it back
+				copied := reflect.New(elem.Type()).Elem()
+				copied.Set(elem)
+				attachResolver(copied, resolver)
+				value.SetMapIndex(key, copied)
+				continue
+			}
+			attachResolver(elem, resolver)
+		}
+	}
+}
+
 // validateAndCollect validates a target and collects its identifiable objects.
 func (l *Linker) validateAndCollect(target interface{}, index int, registry map[string]reflect.Value) error {
 	elem, err := validateTarget(target)
@@ -195,25 +660,32 @@ func Link(targets ...interface{}) error {
 func (l *Linker) collectIdentifiableObjects(value reflect.Value, registry map[string]reflect.Value) {
 	switch value.Kind() {
 	case reflect.Struct:
-		// check if this struct implements Identifiable
-		if value.Addr().Type().Implements(identifiableInterfaceType) {
-			identifiable := value.Addr().Interface().(Identifiable)
-			typePrefix := value.Type().String()
-			key := typePrefix + ":" + identifiable.GetId()
-			registry[key] = value.Addr()
+		if isPointerType(value.Type()) {
+			// the resolved object is registered where it is held, not through references to it
+			return
+		}
+		// check if this struct implements Identifiable, or has an +id field
+		if id, ok := objectId(value); ok && value.CanAddr() {
+			if l.report != nil {
+				key := value.Type().String() + ":" + id
+				if existing, found := registry[key]; found && existing.Pointer() != value.Addr().Pointer() {
+					l.report.add(DuplicateId, value.Addr(), "", id, fmt.Sprintf("id %q is already used by another %s", id, value.Type()))
+				}
+			}
+			registerIdentifiable(registry, value.Addr(), id)
 		}
 
 		// recursively process struct fields
-		for i := 0; i < value.NumField(); i++ {
-			field := value.Type().Field(i)
+		for _, meta := range cachedFields(value.Type()) {
+			field := meta.field
 			if field.PkgPath != "" { // skip unexported fields
 				continue
 			}
-			tag := parseDdTag(field)
+			tag := meta.tag
 			if tag.Skip {
 				continue
 			}
-			l.collectIdentifiableObjects(value.Field(i), registry)
+			l.collectIdentifiableObjects(value.Field(meta.index), registry)
 		}
 
 	case reflect.Ptr:
@@ -232,18 +704,32 @@ func (l *Linker) collectIdentifiableObjects(value reflect.Value, registry map[st
 func (l *Linker) resolvePointers(value reflect.Value, registry map[string]reflect.Value) error {
 	switch value.Kind() {
 	case reflect.Struct:
-		for i := 0; i < value.NumField(); i++ {
-			field := value.Type().Field(i)
+		if _, ok := objectId(value); ok && value.CanAddr() {
+			// references within an Identifiable object are reported against it
+			owner, ownerPath := l.owner, l.ownerPath
+			l.owner, l.ownerPath = value.Addr(), len(l.path)
+			defer func() { l.owner, l.ownerPath = owner, ownerPath }()
+		}
+		for _, meta := range cachedFields(value.Type()) {
+			field := meta.field
 			if field.PkgPath != "" { // skip unexported fields
 				continue
 			}
-			tag := parseDdTag(field)
+			tag := meta.tag
 			if tag.Skip {
 				continue
 			}
 
-			fieldValue := value.Field(i)
-			if err := l.resolvePointersInField(fieldValue, field.Type, registry); err != nil {
+			fieldValue := value.Field(meta.index)
+			path, weak := l.path, l.weak
+			if path != "" {
+				l.path += "."
+			}
+			l.path += field.Name
+			l.weak = tag.Weak
+			err := l.resolvePointersInField(fieldValue, field.Type, registry)
+			l.path, l.weak = path, weak
+			if err != nil {
 				return fmt.Errorf("resolving pointers in field %s: %w", field.Name, err)
 			}
 		}
@@ -254,7 +740,10 @@ func (l *Linker) resolvePointers(value reflect.Value, registry map[string]reflec
 		}
 
 	case reflect.Slice:
+		path := l.path
+		defer func() { l.path = path }()
 		for i := 0; i < value.Len(); i++ {
+			l.path = fmt.Sprintf("%s[%d]", path, i)
 			if err := l.resolvePointers(value.Index(i), registry); err != nil {
 				return fmt.Errorf("resolving pointers in slice[%d]: %w", i, err)
 			}
@@ -285,16 +774,47 @@ func (l *Linker) resolvePointersInField(fieldValue reflect.Value, fieldType refl
 		return l.resolvePointers(fieldValue, registry)
 
 	case reflect.Slice:
+		path := l.path
+		defer func() { l.path = path }()
 		for i := 0; i < fieldValue.Len(); i++ {
 			elemType := fieldType.Elem()
+			l.path = fmt.Sprintf("%s[%d]", path, i)
 			if err := l.resolvePointersInField(fieldValue.Index(i), elemType, registry); err != nil {
 				return fmt.Errorf("[%d]: %w", i, err)
 			}
 		}
+
+	case reflect.Map:
+		path := l.path
+		defer func() { l.path = path }()
+		for _, key := range sortedMapKeys(fieldValue) {
+			l.path = fmt.Sprintf("%s[%v]", path, key)
+			elem := fieldValue.MapIndex(key)
+			var err error
+			if elem.Kind() == reflect.Struct {
+				// map values are not addressable; resolve a copy and store it back
+				resolved := reflect.New(elem.Type()).Elem()
+				resolved.Set(elem)
+				err = l.resolvePointersInField(resolved, fieldType.Elem(), registry)
+				fieldValue.SetMapIndex(key, resolved)
+			} else {
+				err = l.resolvePointersInField(elem, fieldType.Elem(), registry)
+			}
+			if err != nil {
+				return fmt.Errorf("[%v]: %w", key, err)
+			}
+		}
 	}
 	return nil
 }
 
+// sortedMapKeys returns the keys of the map m in a stable order, so that maps are linked deterministically.
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+	return keys
+}
+
 // isPointerType checks if the given type is a Pointer[T] generic type.
 // performs more robust checking including package path and struct tags.
 func isPointerType(t reflect.Type) bool {
@@ -307,8 +827,9 @@ func isPointerType(t reflect.Type) bool {
 		return false
 	}
 
-	// check if it has the exact structure of Pointer[T]: a "Ref" field with df:"$ref" tag and a "Resolved" field
-	if t.NumField() != 2 {
+	// check if it has the exact structure of Pointer[T]: a "Ref" field with df:"$ref" tag, a "Resolved" field, and
+	// the unexported resolver
+	if t.NumField() != 3 || t.Field(2).Name != "resolver" {
 		return false
 	}
 
@@ -359,37 +880,225 @@ func (l *Linker) resolvePointerField(pointerValue reflect.Value, pointerType ref
 	typePrefix := targetType.String()
 	key := typePrefix + ":" + ref
 
-	// look up the target object in the registry
-	targetValue, exists := registry[key]
-	if !exists {
+	// look up the target object in the registry; Validate then asks the external resolver, while linking leaves it
+	// until the Linker is released
+	var found any
+	var id string
+	var err error
+	if l.report != nil {
+		found, err = l.linkResolver(registry, l.root, nil).ResolveRef(targetType, ref)
+	} else {
+		found, id, err = l.linkResolver(registry, l.root, nil).lookup(targetType, ref)
+	}
+	var typeErr *PointerTypeError
+	if errors.As(err, &typeErr) {
+		typeErr.Path = l.path
+		if l.report != nil {
+			l.report.add(RefTypeMismatch, l.owner, l.fieldPath(), ref, typeErr.Error())
+			return nil
+		}
+		return &PointerError{Path: l.path, Reference: ref, Cause: typeErr}
+	}
+	if err != nil {
+		if l.report != nil {
+			l.report.add(UnresolvedRef, l.owner, l.fieldPath(), ref, err.Error())
+			return nil
+		}
+		return &PointerError{Path: l.path, Reference: ref, Cause: fmt.Errorf("resolving reference %s: %w", ref, err)}
+	}
+	site := ReferenceSite{Source: l.owner.Interface(), Field: l.fieldPath(), Ref: ref}
+	if found == nil && l.report == nil && ((id != "" && l.options.Resolver != nil) || l.options.OnMissing != nil) {
+		l.deferred = append(l.deferred, deferredRef{
+			site: site, pointer: pointerValue, targetType: targetType, id: id, registry: registry, path: l.path, weak: l.weak,
+		})
+		return nil
+	}
+	if found == nil {
+		if l.weak {
+			// a missing target is expected for +weak fields
+			return nil
+		}
+		if l.report != nil {
+			l.report.add(UnresolvedRef, l.owner, l.fieldPath(), ref, fmt.Sprintf("unresolved reference: %s (looking for %s)", ref, key))
+			return nil
+		}
 		if l.options.AllowPartialResolution {
 			// skip this resolution but don't fail the entire process
 			return nil
 		}
-		return &PointerError{Reference: ref, Message: fmt.Sprintf("unresolved reference: %s (looking for %s)", ref, key)}
+		return &PointerError{Path: l.path, Reference: ref, Message: fmt.Sprintf("unresolved reference: %s (looking for %s)", ref, key)}
 	}
 
-	// set the resolved field to the target object
-	// if resolved field expects a pointer, use the registry value directly
-	// if resolved field expects a value, dereference it
-	if resolvedField.Type().Kind() == reflect.Ptr {
+	if l.report != nil {
+		return nil
+	}
+	l.setResolved(pointerValue, site, found)
+	if l.options.OnResolve != nil {
+		l.resolved = append(l.resolved, resolvedRef{site: site, target: found})
+	}
+	return nil
+}
+
+// setResolved sets the Pointer pointerValue to found, a pointer to its target, and records the reference.
+func (l *Linker) setResolved(pointerValue reflect.Value, site ReferenceSite, found any) {
+	// a Pointer to a value holds a copy of the target
+	resolvedField := pointerValue.FieldByName("Resolved")
+	targetValue := reflect.ValueOf(found)
+	if resolvedField.Type().Kind() == reflect.Ptr || resolvedField.Type().Kind() == reflect.Interface {
 		resolvedField.Set(targetValue)
 	} else {
 		resolvedField.Set(targetValue.Elem())
 	}
+	if l.sites != nil {
+		l.recordSite(found, linkSite{ReferenceSite: site, pointer: pointerValue})
+	}
+}
+
+// resolveLocked runs resolve with the Linker held, then, with it released, resolves the references resolve deferred
+// to the external Resolver and OnMissing, and calls OnResolve for each resolved reference, so that these may call
+// back into the Linker.
+func (l *Linker) resolveLocked(resolve func() error) error {
+	l.mu.Lock()
+	err := resolve()
+	deferred, resolved := l.deferred, l.resolved
+	l.deferred, l.resolved = nil, nil
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, ref := range deferred {
+		found, err := l.resolveDeferred(ref)
+		if err != nil {
+			return err
+		}
+		if found != nil && l.options.OnResolve != nil {
+			resolved = append(resolved, resolvedRef{site: ref.site, target: found})
+		}
+	}
+	for _, r := range resolved {
+		l.options.OnResolve(r.site, r.target)
+	}
 	return nil
 }
 
+// resolveDeferred resolves ref through the external Resolver, then OnMissing, taking the Linker only to update it, and
+// returns the object it resolved to, or nil when it is left unresolved.
+func (l *Linker) resolveDeferred(ref deferredRef) (any, error) {
+	t := ref.targetType
+	var found any
+	if ref.id != "" && l.options.Resolver != nil {
+		key := t.String() + ":" + ref.id
+		l.mu.RLock()
+		existing, registered := ref.registry[key]
+		l.mu.RUnlock()
+		if registered {
+			// resolved for an earlier reference
+			found = existing.Interface()
+		} else {
+			object, err := l.options.Resolver.ResolveRef(reflect.PointerTo(t), ref.id)
+			if err == nil && object != nil {
+				var target reflect.Value
+				if target, err = resolvedPointer(object, t); err == nil {
+					l.mu.Lock()
+					if existing, registered := ref.registry[key]; registered {
+						target = existing
+					} else {
+						registerIdentifiable(ref.registry, target, ref.id)
+					}
+					l.mu.Unlock()
+					found = target.Interface()
+				}
+			}
+			if err != nil {
+				return nil, &PointerError{Path: ref.path, Reference: ref.site.Ref, Cause: fmt.Errorf("resolving reference %s: %w", ref.site.Ref, err)}
+			}
+		}
+	}
+	if found == nil && l.options.OnMissing != nil {
+		object, err := l.options.OnMissing(ref.site, reflect.PointerTo(t))
+		if err != nil {
+			return nil, &HookError{Path: ref.path, Hook: "OnMissing", Cause: err}
+		}
+		if object != nil {
+			if found, err = missingTarget(object, t); err != nil {
+				return nil, &PointerError{Path: ref.path, Reference: ref.site.Ref, Cause: fmt.Errorf("OnMissing: %w", err)}
+			}
+		}
+	}
+	if found == nil {
+		if ref.weak || l.options.AllowPartialResolution {
+			return nil, nil
+		}
+		key := t.String() + ":" + ref.site.Ref
+		return nil, &PointerError{Path: ref.path, Reference: ref.site.Ref, Message: fmt.Sprintf("unresolved reference: %s (looking for %s)", ref.site.Ref, key)}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.setResolved(ref.pointer, ref.site, found)
+	return found, nil
+}
+
+// missingTarget converts an object returned by OnMissing to a pointer to t, as returned by ResolveRef.
+func missingTarget(found any, t reflect.Type) (any, error) {
+	if t.Kind() == reflect.Interface {
+		if !reflect.TypeOf(found).Implements(t) {
+			return nil, fmt.Errorf("returned %T, which does not implement %s", found, t)
+		}
+		return found, nil
+	}
+	target, err := resolvedPointer(found, t)
+	if err != nil {
+		return nil, err
+	}
+	return target.Interface(), nil
+}
+
+// recordSite records site as a reference to target, replacing any earlier record of the same Pointer, which is
+// re-resolved when a target is passed to ResolveReferences again.
+func (l *Linker) recordSite(target any, site linkSite) {
+	if site.pointer.CanAddr() {
+		pointer := site.pointer.Addr().Interface()
+		if previous, found := l.sited[pointer]; found {
+			l.forgetSites(previous, func(s linkSite) bool { return s.pointer.CanAddr() && s.pointer.Addr().Interface() == pointer })
+		}
+		l.sited[pointer] = target
+	}
+	l.sites[target] = append(l.sites[target], site)
+}
+
+// fieldPath returns the path of the field being resolved within owner.
+func (l *Linker) fieldPath() string {
+	return strings.TrimPrefix(l.path[l.ownerPath:], ".")
+}
+
+// forgetSites removes the sites of references to target for which drop returns true.
+func (l *Linker) forgetSites(target any, drop func(linkSite) bool) {
+	kept := l.sites[target][:0]
+	for _, site := range l.sites[target] {
+		if !drop(site) {
+			kept = append(kept, site)
+		} else if site.pointer.CanAddr() {
+			delete(l.sited, site.pointer.Addr().Interface())
+		}
+	}
+	if len(kept) == 0 {
+		delete(l.sites, target)
+	} else {
+		l.sites[target] = kept
+	}
+}
+
 // bindPointer binds data to a Pointer[T] field during the bind phase. only the $ref field is populated; resolution
 // happens during the Link phase.
-func bindPointer(pointerValue reflect.Value, data map[string]any, path string) error {
+func bindPointer(pointerValue reflect.Value, data map[string]any, path string, opt *Options) error {
 	// get the Ref field and set it from the $ref key in the data
 	refField := pointerValue.FieldByName("Ref")
 	if !refField.IsValid() || !refField.CanSet() || refField.Kind() != reflect.String {
 		return fmt.Errorf("%s: invalid Pointer type: missing or non-settable Ref field", path)
 	}
 
-	refVal, ok := data[RefKey]
+	refVal, ok := data[opt.refKey()]
 	if !ok {
 		// empty reference is valid
 		return nil
@@ -397,15 +1106,24 @@ func bindPointer(pointerValue reflect.Value, data map[string]any, path string) e
 
 	refStr, ok := refVal.(string)
 	if !ok {
-		return fmt.Errorf("%s: '%s' must be a string, got '%T'", path, RefKey, refVal)
+		return fmt.Errorf("%s: '%s' must be a string, got '%T'", path, opt.refKey(), refVal)
 	}
 
 	refField.SetString(refStr)
 	return nil
 }
 
-// pointerToMap converts a Pointer[T] struct to a map containing the $ref field.
-func pointerToMap(pointerValue reflect.Value) (interface{}, bool, error) {
+// bindPointerResolver attaches Options.PointerResolver, if any, to a bound Pointer[T], so that it resolves itself on
+// first access.
+func bindPointerResolver(pointerValue reflect.Value, opt *Options) {
+	if opt != nil && opt.PointerResolver != nil && pointerValue.CanAddr() {
+		pointerValue.Addr().Interface().(lazyPointer).setResolver(opt.PointerResolver)
+	}
+}
+
+// pointerToMap converts a Pointer[T] struct to a map containing the $ref field (or Options.RefKey), or to the plain
+// reference with Options.PlainRefs.
+func pointerToMap(pointerValue reflect.Value, opt *Options) (interface{}, bool, error) {
 	refField := pointerValue.FieldByName("Ref")
 	if !refField.IsValid() || refField.Kind() != reflect.String {
 		return nil, false, fmt.Errorf("invalid Pointer type: missing Ref field")
@@ -417,5 +1135,44 @@ func pointerToMap(pointerValue reflect.Value) (interface{}, bool, error) {
 		return nil, false, nil
 	}
 
-	return map[string]any{RefKey: ref}, true, nil
+	if opt != nil && opt.PlainRefs {
+		return ref, true, nil
+	}
+	return map[string]any{opt.refKey(): ref}, true, nil
+}
+
+// structData returns raw as the data for a struct of type t: raw itself when it is an object or, for a Pointer[T]
+// given a plain string, an object holding the string as its reference.
+func structData(t reflect.Type, raw any, opt *Options) (map[string]any, bool) {
+	if ref, ok := raw.(string); ok && isPointerType(t) {
+		return map[string]any{opt.refKey(): ref}, true
+	}
+	data, ok := raw.(map[string]any)
+	return data, ok
+}
+
+// refKey returns the key holding Pointer references, Options.RefKey or RefKey by default.
+func (o *Options) refKey() string {
+	if o == nil || o.RefKey == "" {
+		return RefKey
+	}
+	return o.RefKey
+}
+
+// withDefaultRefKey returns the data for a Pointer[T] with the reference under Options.RefKey moved to RefKey, the key
+// its Ref field is bound from.
+func withDefaultRefKey(data map[string]any, opt *Options) map[string]any {
+	key := opt.refKey()
+	ref, found := data[key]
+	if key == RefKey || !found {
+		return data
+	}
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		if k != key && k != RefKey {
+			out[k] = v
+		}
+	}
+	out[RefKey] = ref
+	return out
 }
@@ -3,7 +3,12 @@ package dd
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // test types that implement identifiable
@@ -1020,3 +1025,1116 @@ func TestVariadicRegister(t *testing.T) {
 		t.Errorf("document author should point to user from source1")
 	}
 }
+
+func TestLinkerLazy(t *testing.T) {
+	type container struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	data := map[string]any{
+		"users": []any{map[string]any{"id": "user1", "name": "Alice"}},
+		"documents": []any{
+			map[string]any{"id": "doc1", "author": map[string]any{"$ref": "user1"}, "editor": map[string]any{"$ref": "nobody"}},
+		},
+	}
+	c, err := New[container](data)
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+
+	// a lazy link succeeds despite the missing editor, and resolves nothing up front
+	if err := NewLinker(LinkerOptions{Lazy: true}).Link(c); err != nil {
+		t.Fatalf("lazy link failed: %v", err)
+	}
+	doc := c.Documents[0]
+	if doc.Author.IsResolved() {
+		t.Errorf("author should not be resolved before access")
+	}
+	if author := doc.Author.Resolve(); author != c.Users[0] {
+		t.Errorf("expected author to resolve to user1, got %v", author)
+	}
+	if !doc.Author.IsResolved() {
+		t.Errorf("author should be resolved after access")
+	}
+
+	editor, err := doc.Editor.Lookup()
+	if editor != nil {
+		t.Errorf("expected nil editor, got %v", editor)
+	}
+	var pointerErr *PointerError
+	if !errors.As(err, &pointerErr) || pointerErr.Reference != "nobody" {
+		t.Errorf("expected PointerError for nobody, got %v", err)
+	}
+}
+
+func TestPointerResolver(t *testing.T) {
+	users := map[string]*User{"user1": {Id: "user1", Name: "Alice"}}
+	lookups := 0
+	resolver := ResolverFunc(func(typ reflect.Type, ref string) (any, error) {
+		lookups++
+		if typ != reflect.TypeOf(&User{}) {
+			return nil, fmt.Errorf("unexpected type %v", typ)
+		}
+		if ref == "broken" {
+			return nil, errors.New("database unavailable")
+		}
+		if user, found := users[ref]; found {
+			return user, nil
+		}
+		return nil, nil
+	})
+
+	doc, err := New[Document](map[string]any{
+		"id":     "doc1",
+		"author": map[string]any{"$ref": "user1"},
+		"editor": map[string]any{"$ref": "broken"},
+	}, &Options{PointerResolver: resolver})
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	if lookups != 0 {
+		t.Errorf("expected no lookups during bind, got %d", lookups)
+	}
+	if doc.Author.Resolve() != users["user1"] || doc.Author.Resolve() != users["user1"] {
+		t.Errorf("author not resolved")
+	}
+	if lookups != 1 {
+		t.Errorf("expected the resolution to be kept, got %d lookups", lookups)
+	}
+	if _, err := doc.Editor.Lookup(); err == nil || !strings.Contains(err.Error(), "database unavailable") {
+		t.Errorf("expected resolver error, got %v", err)
+	}
+
+	// a resolver may return a pointer for a non-pointer T
+	type byValue struct {
+		Label Pointer[valueLabel] `dd:"label"`
+	}
+	labels := ResolverFunc(func(_ reflect.Type, ref string) (any, error) {
+		return &valueLabel{Id: ref}, nil
+	})
+	v, err := New[byValue](map[string]any{"label": map[string]any{"$ref": "blue"}}, &Options{PointerResolver: labels})
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	if label := v.Label.Resolve(); label.Id != "blue" {
+		t.Errorf("expected label blue, got %v", label)
+	}
+}
+
+type valueLabel struct {
+	Id string `dd:"id"`
+}
+
+func (l valueLabel) GetId() string { return l.Id }
+
+func TestLinkerExternalResolver(t *testing.T) {
+	type container struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	remote := map[string]User{"user2": {Id: "user2", Name: "Bob"}}
+	var lookups []string
+	resolver := ResolverFunc(func(typ reflect.Type, ref string) (any, error) {
+		lookups = append(lookups, typ.String()+":"+ref)
+		if ref == "broken" {
+			return nil, errors.New("remote unavailable")
+		}
+		if user, found := remote[ref]; found {
+			return user, nil // a value is accepted as well as a pointer
+		}
+		return nil, nil
+	})
+
+	c := &container{
+		Users: []*User{{Id: "user1", Name: "Alice"}},
+		Documents: []*Document{
+			{Id: "doc1", Author: &Pointer[*User]{Ref: "user1"}, Editor: &Pointer[*User]{Ref: "user2"}},
+			{Id: "doc2", Author: &Pointer[*User]{Ref: "user2"}},
+		},
+	}
+	if err := NewLinker(LinkerOptions{Resolver: resolver}).Link(c); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if c.Documents[0].Author.Resolve() != c.Users[0] {
+		t.Errorf("local reference should resolve to the linked object")
+	}
+	editor := c.Documents[0].Editor.Resolve()
+	if editor == nil || editor.Name != "Bob" {
+		t.Fatalf("expected remote editor Bob, got %v", editor)
+	}
+	// the remote object is looked up once, and shared between references
+	if c.Documents[1].Author.Resolve() != editor {
+		t.Errorf("expected the same remote object for both references")
+	}
+	if len(lookups) != 1 || lookups[0] != "*dd.User:user2" {
+		t.Errorf("expected one lookup of *dd.User:user2, got %v", lookups)
+	}
+
+	// resolver errors, and references it cannot find, fail the link
+	c.Documents[1].Author = &Pointer[*User]{Ref: "broken"}
+	err := NewLinker(LinkerOptions{Resolver: resolver}).Link(c)
+	if err == nil || !strings.Contains(err.Error(), "remote unavailable") {
+		t.Errorf("expected resolver error, got %v", err)
+	}
+	c.Documents[1].Author = &Pointer[*User]{Ref: "nobody"}
+	var pointerErr *PointerError
+	if err := NewLinker(LinkerOptions{Resolver: resolver}).Link(c); !errors.As(err, &pointerErr) {
+		t.Errorf("expected PointerError, got %v", err)
+	}
+
+	// lazily, the resolver is only consulted on access
+	lookups = nil
+	c.Documents[1].Author = &Pointer[*User]{Ref: "user2"}
+	c.Documents[0].Editor = &Pointer[*User]{Ref: "user2"}
+	if err := NewLinker(LinkerOptions{Lazy: true, Resolver: resolver}).Link(c); err != nil {
+		t.Fatalf("lazy link failed: %v", err)
+	}
+	if len(lookups) != 0 {
+		t.Errorf("expected no lookups before access, got %v", lookups)
+	}
+	if c.Documents[1].Author.Resolve().Name != "Bob" || c.Documents[0].Editor.Resolve().Name != "Bob" {
+		t.Errorf("expected lazy remote resolution")
+	}
+	if len(lookups) != 1 {
+		t.Errorf("expected one lookup, got %v", lookups)
+	}
+}
+
+type pathTeam struct {
+	Id   string `dd:"id"`
+	Name string `dd:"name"`
+}
+
+func (t *pathTeam) GetId() string { return t.Id }
+
+type pathDepartment struct {
+	Id    string      `dd:"id"`
+	Teams []*pathTeam `dd:"teams"`
+}
+
+func (d *pathDepartment) GetId() string { return d.Id }
+
+type pathOrg struct {
+	Departments []*pathDepartment    `dd:"departments"`
+	Sites       map[string]*pathTeam `dd:"sites"`
+	Oncall      *Pointer[*pathTeam]  `dd:"oncall"`
+	Backup      *Pointer[*pathTeam]  `dd:"backup"`
+	Lead        *Pointer[*pathTeam]  `dd:"lead"`
+	Dept        *Pointer[*pathDepartment]
+}
+
+func TestPathReferences(t *testing.T) {
+	data := map[string]any{
+		"departments": []any{
+			map[string]any{"id": "eng", "teams": []any{
+				map[string]any{"id": "backend", "name": "Backend"},
+				map[string]any{"id": "frontend", "name": "Frontend"},
+			}},
+		},
+		"sites":  map[string]any{"a/b": map[string]any{"id": "site", "name": "Site"}},
+		"oncall": map[string]any{"$ref": "/departments/eng/teams/frontend"},
+		"backup": map[string]any{"$ref": "/departments/0/teams/0"},
+		"lead":   map[string]any{"$ref": "/sites/a~1b"},
+		"dept":   map[string]any{"$ref": "/departments/eng"},
+	}
+	org, err := New[pathOrg](data)
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	if err := Link(org); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if org.Oncall.Resolve() != org.Departments[0].Teams[1] {
+		t.Errorf("oncall should resolve to the frontend team")
+	}
+	if org.Backup.Resolve() != org.Departments[0].Teams[0] {
+		t.Errorf("backup should resolve to the backend team")
+	}
+	if org.Lead.Resolve() != org.Sites["a/b"] {
+		t.Errorf("lead should resolve to the site team")
+	}
+	if org.Dept.Resolve() != org.Departments[0] {
+		t.Errorf("dept should resolve to the eng department")
+	}
+
+	// the reference is kept as written
+	out, err := Unbind(org)
+	if err != nil {
+		t.Fatalf("unbind failed: %v", err)
+	}
+	if ref := out["oncall"].(map[string]any)["$ref"]; ref != "/departments/eng/teams/frontend" {
+		t.Errorf("unexpected unbound ref %v", ref)
+	}
+
+	// lazily resolved as well
+	org, _ = New[pathOrg](data)
+	if err := NewLinker(LinkerOptions{Lazy: true}).Link(org); err != nil {
+		t.Fatalf("lazy link failed: %v", err)
+	}
+	if org.Oncall.Resolve() != org.Departments[0].Teams[1] {
+		t.Errorf("lazy oncall should resolve to the frontend team")
+	}
+
+	// missing paths and mismatched types are errors
+	for _, ref := range []string{"/departments/ops/teams/frontend", "/departments/eng/teams/9", "/departments/eng"} {
+		data["oncall"] = map[string]any{"$ref": ref}
+		org, _ = New[pathOrg](data)
+		if err := Link(org); err == nil {
+			t.Errorf("expected an error for %s", ref)
+		}
+	}
+}
+
+type namedPathOrg struct {
+	OnCallTeams []*pathTeam
+	Primary     *Pointer[*pathTeam]
+}
+
+func TestPathReferencesNamingStrategy(t *testing.T) {
+	data := map[string]any{
+		"onCallTeams": []any{map[string]any{"id": "frontend", "name": "Frontend"}},
+		"primary":     map[string]any{"$ref": "/onCallTeams/frontend"},
+	}
+	org, err := New[namedPathOrg](data, &Options{NamingStrategy: CamelCase})
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	if err := NewLinker(LinkerOptions{NamingStrategy: CamelCase}).Link(org); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if org.Primary.Resolve() != org.OnCallTeams[0] {
+		t.Errorf("primary should resolve to the frontend team")
+	}
+
+	// under the default strategy the field is named on_call_teams
+	org, _ = New[namedPathOrg](data, &Options{NamingStrategy: CamelCase})
+	if err := Link(org); err == nil {
+		t.Errorf("expected an error resolving a camelCase path with the default naming strategy")
+	}
+}
+
+// named is implemented by both typedAuthor and typedBot, for pointers to an interface type.
+type named interface {
+	Identifiable
+	GetName() string
+}
+
+type typedAuthor struct {
+	Id   string `dd:"id"`
+	Name string `dd:"name"`
+}
+
+func (a *typedAuthor) GetId() string   { return a.Id }
+func (a *typedAuthor) GetName() string { return a.Name }
+
+type typedBot struct {
+	Id   string `dd:"id"`
+	Name string `dd:"name"`
+}
+
+func (b *typedBot) GetId() string   { return b.Id }
+func (b *typedBot) GetName() string { return b.Name }
+
+type typedDoc struct {
+	Authors  []*typedAuthor         `dd:"authors"`
+	Bots     []*typedBot            `dd:"bots"`
+	Owner    *Pointer[named]        `dd:"owner,omitempty"`
+	Reviewer *Pointer[*typedAuthor] `dd:"reviewer,omitempty"`
+}
+
+func TestTypedReferences(t *testing.T) {
+	newDoc := func(owner, reviewer string) *typedDoc {
+		data := map[string]any{
+			"authors": []any{map[string]any{"id": "alice", "name": "Alice"}, map[string]any{"id": "bob", "name": "Bob"}},
+			"bots":    []any{map[string]any{"id": "alice", "name": "Alice Bot"}},
+		}
+		if owner != "" {
+			data["owner"] = map[string]any{"$ref": owner}
+		}
+		if reviewer != "" {
+			data["reviewer"] = map[string]any{"$ref": reviewer}
+		}
+		doc, err := New[typedDoc](data)
+		if err != nil {
+			t.Fatalf("bind failed: %v", err)
+		}
+		return doc
+	}
+
+	// the type prefix picks between objects sharing an id, including through an interface
+	doc := newDoc("typedBot:alice", "typedAuthor:alice")
+	if err := Link(doc); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if doc.Owner.Resolve() != named(doc.Bots[0]) {
+		t.Errorf("owner should resolve to the bot, got %v", doc.Owner.Resolve())
+	}
+	if doc.Reviewer.Resolve() != doc.Authors[0] {
+		t.Errorf("reviewer should resolve to the author")
+	}
+
+	// the type may be qualified with its package, and an untyped id still resolves by the pointer's type
+	doc = newDoc("dd.typedAuthor:alice", "bob")
+	if err := Link(doc); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if doc.Owner.Resolve() != named(doc.Authors[0]) || doc.Reviewer.Resolve() != doc.Authors[1] {
+		t.Errorf("unexpected resolution: owner %v, reviewer %v", doc.Owner.Resolve(), doc.Reviewer.Resolve())
+	}
+
+	// an untyped id through an interface resolves when only one type matches it
+	doc = newDoc("bob", "")
+	if err := Link(doc); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if doc.Owner.Resolve() != named(doc.Authors[1]) {
+		t.Errorf("owner should resolve to bob")
+	}
+
+	// the reference is kept as written
+	doc = newDoc("typedBot:alice", "")
+	if err := Link(doc); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	out, err := Unbind(doc)
+	if err != nil {
+		t.Fatalf("unbind failed: %v", err)
+	}
+	if ref := out["owner"].(map[string]any)["$ref"]; ref != "typedBot:alice" {
+		t.Errorf("unexpected unbound ref %v", ref)
+	}
+
+	// lazily resolved as well
+	doc = newDoc("typedBot:alice", "typedAuthor:bob")
+	if err := NewLinker(LinkerOptions{Lazy: true}).Link(doc); err != nil {
+		t.Fatalf("lazy link failed: %v", err)
+	}
+	if doc.Owner.Resolve() != named(doc.Bots[0]) || doc.Reviewer.Resolve() != doc.Authors[1] {
+		t.Errorf("unexpected lazy resolution: owner %v, reviewer %v", doc.Owner.Resolve(), doc.Reviewer.Resolve())
+	}
+
+	// ambiguous ids through an interface, and typed references to the wrong type, are errors
+	for _, refs := range [][2]string{{"alice", ""}, {"", "typedBot:alice"}, {"typedBot:bob", ""}} {
+		if err := Link(newDoc(refs[0], refs[1])); err == nil {
+			t.Errorf("expected an error for %v", refs)
+		}
+	}
+
+	// RequireTypedRefs rejects untyped references
+	linker := NewLinker(LinkerOptions{RequireTypedRefs: true})
+	var pointerErr *PointerError
+	if err := linker.Link(newDoc("", "bob")); !errors.As(err, &pointerErr) || !strings.Contains(err.Error(), "typedAuthor:bob") {
+		t.Errorf("expected an error suggesting typedAuthor:bob, got %v", err)
+	}
+	doc = newDoc("typedBot:alice", "typedAuthor:bob")
+	if err := linker.Link(doc); err != nil {
+		t.Fatalf("typed link failed: %v", err)
+	}
+	if doc.Reviewer.Resolve() != doc.Authors[1] {
+		t.Errorf("reviewer should resolve to bob")
+	}
+}
+
+func TestLinkerReferencesTo(t *testing.T) {
+	type container struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+		Nodes     []*Node     `dd:"nodes"`
+		Featured  *Pointer[*User]
+	}
+	alice, bob := &User{Id: "alice"}, &User{Id: "bob"}
+	root, leaf := &Node{Id: "root"}, &Node{Id: "leaf"}
+	root.Children = []*Pointer[*Node]{{Ref: "root"}, {Ref: "leaf"}}
+	leaf.Parent = &Pointer[*Node]{Ref: "root"}
+	c := &container{
+		Users: []*User{alice, bob},
+		Documents: []*Document{
+			{Id: "doc1", Author: &Pointer[*User]{Ref: "alice"}, Editor: &Pointer[*User]{Ref: "bob"}},
+			{Id: "doc2", Author: &Pointer[*User]{Ref: "alice"}},
+		},
+		Nodes:    []*Node{root, leaf},
+		Featured: &Pointer[*User]{Ref: "alice"},
+	}
+	linker := NewLinker()
+	if err := linker.Link(c); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+
+	sites := linker.ReferencesTo(alice)
+	expected := []ReferenceSite{
+		{Source: c.Documents[0], Field: "Author", Ref: "alice"},
+		{Source: c.Documents[1], Field: "Author", Ref: "alice"},
+		{Source: c, Field: "Featured", Ref: "alice"}, // not within an Identifiable object
+	}
+	if !reflect.DeepEqual(sites, expected) {
+		t.Errorf("unexpected references to alice: %+v", sites)
+	}
+	if sites := linker.ReferencesTo(bob); len(sites) != 1 || sites[0].Source != c.Documents[0] || sites[0].Field != "Editor" {
+		t.Errorf("unexpected references to bob: %+v", sites)
+	}
+	sites = linker.ReferencesTo(root)
+	if len(sites) != 2 || sites[0].Source != root || sites[0].Field != "Children[0]" || sites[1].Source != leaf || sites[1].Field != "Parent" {
+		t.Errorf("unexpected references to root: %+v", sites)
+	}
+	if sites := linker.ReferencesTo(c.Documents[0]); len(sites) != 0 {
+		t.Errorf("expected no references to doc1, got %+v", sites)
+	}
+	if sites := linker.ReferencesTo(nil); sites != nil {
+		t.Errorf("expected no references to nil, got %+v", sites)
+	}
+
+	// each Link starts over
+	if err := linker.Link(&container{Users: []*User{bob}}); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if sites := linker.ReferencesTo(alice); len(sites) != 0 {
+		t.Errorf("expected references to be cleared, got %+v", sites)
+	}
+}
+
+func TestWeakPointers(t *testing.T) {
+	type issue struct {
+		Id       string            `dd:"id"`
+		Author   *Pointer[*User]   `dd:"author"`
+		Watchers []*Pointer[*User] `dd:"watchers,+weak"`
+		Assignee *Pointer[*User]   `dd:"assignee,+weak"`
+	}
+	type tracker struct {
+		Users  []*User  `dd:"users"`
+		Issues []*issue `dd:"issues"`
+	}
+	newTracker := func(author string) *tracker {
+		tr, err := New[tracker](map[string]any{
+			"users": []any{map[string]any{"id": "alice"}},
+			"issues": []any{map[string]any{
+				"id":       "1",
+				"author":   map[string]any{"$ref": author},
+				"watchers": []any{map[string]any{"$ref": "alice"}, map[string]any{"$ref": "deleted"}},
+				"assignee": map[string]any{"$ref": "deleted"},
+			}},
+		})
+		if err != nil {
+			t.Fatalf("bind failed: %v", err)
+		}
+		return tr
+	}
+
+	// missing targets of weak pointers are left unresolved, while the rest resolve
+	tr := newTracker("alice")
+	if err := Link(tr); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	it := tr.Issues[0]
+	if it.Author.Resolve() != tr.Users[0] || it.Watchers[0].Resolve() != tr.Users[0] {
+		t.Errorf("expected existing targets to resolve")
+	}
+	if it.Watchers[1].IsResolved() || it.Assignee.IsResolved() {
+		t.Errorf("expected missing weak targets to be left unresolved")
+	}
+	if it.Assignee.Ref != "deleted" {
+		t.Errorf("expected the reference to be kept, got %q", it.Assignee.Ref)
+	}
+
+	// other pointers still fail on missing targets, and Validate reports only those
+	tr = newTracker("deleted")
+	var pointerErr *PointerError
+	if err := Link(tr); !errors.As(err, &pointerErr) || !strings.Contains(err.Error(), "Author") {
+		t.Errorf("expected a PointerError for the author, got %v", err)
+	}
+	report, err := NewLinker().Validate(tr)
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Field != "Issues[0].Author" {
+		t.Errorf("expected one issue for the author, got %v", report.Issues)
+	}
+}
+
+func TestPointersInMaps(t *testing.T) {
+	type routing struct {
+		Users  []*User                      `dd:"users"`
+		Routes map[string]*Pointer[*User]   `dd:"routes"`
+		Groups map[string][]*Pointer[*User] `dd:"groups"`
+		Values map[string]Pointer[*User]    `dd:"values,omitempty"`
+	}
+	data := map[string]any{
+		"users": []any{map[string]any{"id": "alice", "name": "", "age": 0}, map[string]any{"id": "bob", "name": "", "age": 0}},
+		"routes": map[string]any{
+			"/billing": map[string]any{"$ref": "alice"},
+			"/support": map[string]any{"$ref": "bob"},
+		},
+		"groups": map[string]any{
+			"oncall": []any{map[string]any{"$ref": "bob"}, map[string]any{"$ref": "alice"}},
+		},
+		"values": map[string]any{"/sales": map[string]any{"$ref": "bob"}},
+	}
+	r, err := New[routing](data)
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	linker := NewLinker()
+	if err := linker.Link(r); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	alice, bob := r.Users[0], r.Users[1]
+	if r.Routes["/billing"].Resolve() != alice || r.Routes["/support"].Resolve() != bob {
+		t.Errorf("expected routes to resolve")
+	}
+	if group := r.Groups["oncall"]; group[0].Resolve() != bob || group[1].Resolve() != alice {
+		t.Errorf("expected group members to resolve")
+	}
+	if value := r.Values["/sales"]; value.Resolve() != bob {
+		t.Errorf("expected map values to resolve")
+	}
+	sites := linker.ReferencesTo(alice)
+	if len(sites) != 2 || sites[0].Field != "Routes[/billing]" || sites[1].Field != "Groups[oncall][1]" {
+		t.Errorf("unexpected references to alice: %+v", sites)
+	}
+
+	// unresolved references within maps are reported with their keys
+	r.Routes["/legal"] = &Pointer[*User]{Ref: "carol"}
+	if err := Link(r); err == nil || !strings.Contains(err.Error(), "[/legal]") {
+		t.Errorf("expected an error naming the key, got %v", err)
+	}
+	delete(r.Routes, "/legal")
+
+	// lazily resolved as well
+	r, _ = New[routing](data)
+	if err := NewLinker(LinkerOptions{Lazy: true}).Link(r); err != nil {
+		t.Fatalf("lazy link failed: %v", err)
+	}
+	if r.Routes["/support"].Resolve() != r.Users[1] || r.Groups["oncall"][1].Resolve() != r.Users[0] {
+		t.Errorf("expected lazy resolution within maps")
+	}
+
+	// references round trip
+	out, err := Unbind(r)
+	if err != nil {
+		t.Fatalf("unbind failed: %v", err)
+	}
+	if !reflect.DeepEqual(out, data) {
+		t.Errorf("round trip mismatch:\n got %v\nwant %v", out, data)
+	}
+}
+
+func TestLinkerConcurrency(t *testing.T) {
+	type container struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	remote := ResolverFunc(func(typ reflect.Type, ref string) (any, error) {
+		return &User{Id: ref, Name: "remote"}, nil
+	})
+	linker := NewLinker(LinkerOptions{EnableCaching: true, Lazy: true, Resolver: remote})
+
+	const loaders = 8
+	var wg sync.WaitGroup
+	docs := make([]*container, loaders)
+	for i := 0; i < loaders; i++ {
+		docs[i] = &container{Documents: []*Document{{Id: "doc", Author: &Pointer[*User]{Ref: fmt.Sprintf("user%d", i)}}}}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := linker.Register(&container{Users: []*User{{Id: fmt.Sprintf("user%d", i)}}}); err != nil {
+				t.Errorf("register failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// lazily linked pointers, each resolved on its own goroutine, alongside further registration and queries
+	for i := 0; i < loaders; i++ {
+		if err := linker.Link(docs[i]); err != nil {
+			t.Fatalf("link failed: %v", err)
+		}
+	}
+	for i := 0; i < loaders; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			if author := docs[i].Documents[0].Author.Resolve(); author == nil || author.Id != fmt.Sprintf("user%d", i) {
+				t.Errorf("unexpected author %v", author)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			if err := linker.Register(&container{Users: []*User{{Id: fmt.Sprintf("late%d", i)}}}); err != nil {
+				t.Errorf("register failed: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			linker.ReferencesTo(docs[0])
+			if _, err := linker.Validate(&container{Users: []*User{{Id: "validated"}}}); err != nil {
+				t.Errorf("validate failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLinkerDeregister(t *testing.T) {
+	type site struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	alice, bob := &User{Id: "alice"}, &User{Id: "bob"}
+	s := &site{
+		Documents: []*Document{
+			{Id: "doc1", Author: &Pointer[*User]{Ref: "alice"}, Editor: &Pointer[*User]{Ref: "bob"}},
+			{Id: "doc2", Author: &Pointer[*User]{Ref: "alice"}},
+		},
+	}
+	linker := NewLinker()
+	if err := linker.Register(&site{Users: []*User{alice, bob}}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := linker.ResolveReferences(s); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	// removing alice leaves the pointers to her dangling
+	dangling, err := linker.Deregister(alice)
+	if err != nil {
+		t.Fatalf("deregister failed: %v", err)
+	}
+	if len(dangling) != 2 || dangling[0].Source != s.Documents[0] || dangling[1].Source != s.Documents[1] {
+		t.Errorf("unexpected dangling references: %+v", dangling)
+	}
+	if s.Documents[0].Author.IsResolved() || s.Documents[0].Author.Ref != "alice" {
+		t.Errorf("expected the author to be unresolved, keeping its ref")
+	}
+	if s.Documents[0].Editor.Resolve() != bob {
+		t.Errorf("expected other references to be kept")
+	}
+	if sites := linker.ReferencesTo(alice); len(sites) != 0 {
+		t.Errorf("expected no references to alice, got %+v", sites)
+	}
+	var pointerErr *PointerError
+	if err := linker.ResolveReferences(s); !errors.As(err, &pointerErr) {
+		t.Errorf("expected alice to be unresolvable, got %v", err)
+	}
+
+	// a replacement is linked in her place
+	replacement := &User{Id: "alice", Name: "Alice II"}
+	if err := linker.Register(&site{Users: []*User{replacement}}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := linker.ResolveReferences(s); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if s.Documents[1].Author.Resolve() != replacement {
+		t.Errorf("expected the replacement to be linked")
+	}
+
+	// objects are also removed by id, and nothing happens for unknown ids
+	dangling = linker.DeregisterID(reflect.TypeOf(User{}), "bob")
+	if len(dangling) != 1 || dangling[0].Field != "Editor" || s.Documents[0].Editor.IsResolved() {
+		t.Errorf("unexpected dangling references: %+v", dangling)
+	}
+	if dangling := linker.DeregisterID(reflect.TypeOf(&User{}), "bob"); dangling != nil {
+		t.Errorf("expected nothing to deregister, got %+v", dangling)
+	}
+	if _, err := linker.Deregister(User{}); err == nil {
+		t.Errorf("expected an error for a non-pointer target")
+	}
+}
+
+func TestPointerTypeErrors(t *testing.T) {
+	type container struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	c := &container{
+		Users: []*User{{Id: "alice"}},
+		Documents: []*Document{
+			{Id: "doc1", Author: &Pointer[*User]{Ref: "alice"}},
+			{Id: "doc2", Author: &Pointer[*User]{Ref: "Document:doc1"}},
+		},
+	}
+	err := Link(c)
+	var pointerErr *PointerError
+	var typeErr *PointerTypeError
+	if !errors.As(err, &pointerErr) || !errors.As(err, &typeErr) {
+		t.Fatalf("expected a PointerError caused by a PointerTypeError, got %v", err)
+	}
+	if pointerErr.Path != "Documents[1].Author" || typeErr.Path != "Documents[1].Author" {
+		t.Errorf("unexpected paths %q and %q", pointerErr.Path, typeErr.Path)
+	}
+	if typeErr.Reference != "Document:doc1" || typeErr.Expected != reflect.TypeOf(User{}) || typeErr.Found != reflect.TypeOf(Document{}) {
+		t.Errorf("unexpected type error %+v", typeErr)
+	}
+	if !strings.Contains(err.Error(), `Documents[1].Author: reference "Document:doc1" is to dd.Document, expected dd.User`) {
+		t.Errorf("unexpected message: %v", err)
+	}
+
+	// path references, and unresolved references, carry their paths too
+	c.Documents[1].Author = &Pointer[*User]{Ref: "/documents/doc1"}
+	if err := Link(c); !errors.As(err, &typeErr) || !strings.Contains(err.Error(), `path "/documents/doc1" refers to dd.Document`) {
+		t.Errorf("unexpected error for a path reference: %v", err)
+	}
+	c.Documents[1].Author = &Pointer[*User]{Ref: "bob"}
+	if err := Link(c); !errors.As(err, &pointerErr) || pointerErr.Path != "Documents[1].Author" || errors.As(err, &typeErr) {
+		t.Errorf("unexpected error for an unresolved reference: %v", err)
+	}
+
+	// lazily, without a path
+	c.Documents[1].Author = &Pointer[*User]{Ref: "Document:doc1"}
+	if err := NewLinker(LinkerOptions{Lazy: true}).Link(c); err != nil {
+		t.Fatalf("lazy link failed: %v", err)
+	}
+	if _, err := c.Documents[1].Author.Lookup(); !errors.As(err, &typeErr) || typeErr.Path != "" {
+		t.Errorf("expected a PointerTypeError from Lookup, got %v", err)
+	}
+}
+
+// LedgerAccount stands for a third-party type that cannot implement Identifiable.
+type LedgerAccount struct {
+	Number int    `dd:"number,+id"`
+	Owner  string `dd:"owner"`
+}
+
+type ledgerEntry struct {
+	LedgerAccount `dd:",+inline"`
+	Note          string `dd:"note"`
+}
+
+func TestIdTag(t *testing.T) {
+	type ledger struct {
+		Accounts []*LedgerAccount         `dd:"accounts"`
+		Entries  []ledgerEntry            `dd:"entries"`
+		Primary  *Pointer[*LedgerAccount] `dd:"primary"`
+		Backup   *Pointer[LedgerAccount]  `dd:"backup"`
+		Latest   *Pointer[*ledgerEntry]   `dd:"latest"`
+	}
+	data := map[string]any{
+		"accounts": []any{map[string]any{"number": 100, "owner": "alice"}, map[string]any{"number": 200, "owner": "bob"}},
+		"entries":  []any{map[string]any{"number": 7, "owner": "carol", "note": "embedded"}},
+		"primary":  map[string]any{"$ref": "200"},
+		"backup":   map[string]any{"$ref": "100"},
+		"latest":   map[string]any{"$ref": "7"},
+	}
+	l, err := New[ledger](data)
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	if err := Link(l); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if l.Primary.Resolve() != l.Accounts[1] {
+		t.Errorf("primary should resolve to account 200")
+	}
+	if l.Backup.Resolve().Owner != "alice" {
+		t.Errorf("backup should resolve to account 100, got %+v", l.Backup.Resolve())
+	}
+	if l.Latest.Resolve() != &l.Entries[0] {
+		t.Errorf("latest should resolve to the entry, through its embedded +id field")
+	}
+
+	// unknown ids fail as usual, and typed and path references work alike
+	l.Primary = &Pointer[*LedgerAccount]{Ref: "300"}
+	var pointerErr *PointerError
+	if err := Link(l); !errors.As(err, &pointerErr) {
+		t.Errorf("expected a PointerError, got %v", err)
+	}
+	l.Primary = &Pointer[*LedgerAccount]{Ref: "LedgerAccount:100"}
+	l.Latest = &Pointer[*ledgerEntry]{Ref: "/entries/7"}
+	if err := Link(l); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if l.Primary.Resolve() != l.Accounts[0] || l.Latest.Resolve() != &l.Entries[0] {
+		t.Errorf("expected typed and path references to resolve")
+	}
+}
+
+func TestRefKeyOption(t *testing.T) {
+	type container struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	opts := &Options{RefKey: "@id"}
+	input := []byte(`{"users": [{"id": "alice", "name": "Alice", "age": 30}], "documents": [{"id": "doc1", "title": "Intro", "author": {"@id": "alice"}}]}`)
+	c, err := NewJSON[container](input, opts)
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	if c.Documents[0].Author.Ref != "alice" {
+		t.Fatalf("expected ref alice, got %q", c.Documents[0].Author.Ref)
+	}
+	if err := Link(c); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if c.Documents[0].Author.Resolve() != c.Users[0] {
+		t.Errorf("expected the author to resolve")
+	}
+
+	out, err := Unbind(c, opts)
+	if err != nil {
+		t.Fatalf("unbind failed: %v", err)
+	}
+	author := out["documents"].([]any)[0].(map[string]any)["author"].(map[string]any)
+	if !reflect.DeepEqual(author, map[string]any{"@id": "alice"}) {
+		t.Errorf("expected the reference under @id, got %v", author)
+	}
+
+	// the default key is unchanged without the option
+	out, err = Unbind(c)
+	if err != nil {
+		t.Fatalf("unbind failed: %v", err)
+	}
+	if author := out["documents"].([]any)[0].(map[string]any)["author"].(map[string]any); author[RefKey] != "alice" {
+		t.Errorf("expected the reference under $ref, got %v", author)
+	}
+	if _, err := NewJSON[container]([]byte(`{"documents": [{"id": "doc1", "author": {"@id": 7}}]}`), opts); err == nil {
+		t.Errorf("expected an error for a non-string reference")
+	}
+}
+
+func TestLinkerHooks(t *testing.T) {
+	type issue struct {
+		Id       string          `dd:"id"`
+		Author   *Pointer[*User] `dd:"author"`
+		Assignee *Pointer[*User] `dd:"assignee"`
+	}
+	type tracker struct {
+		Users  []*User  `dd:"users"`
+		Issues []*issue `dd:"issues"`
+	}
+	tr, err := New[tracker](map[string]any{
+		"users": []any{map[string]any{"id": "alice"}},
+		"issues": []any{map[string]any{
+			"id":       "1",
+			"author":   map[string]any{"$ref": "alice"},
+			"assignee": map[string]any{"$ref": "deleted"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+
+	// OnResolve sees each resolved reference, including those OnMissing fabricates
+	var resolved []string
+	var missing []ReferenceSite
+	linker := NewLinker(LinkerOptions{
+		OnResolve: func(site ReferenceSite, target any) {
+			resolved = append(resolved, site.Field+"="+target.(*User).Name)
+		},
+		OnMissing: func(site ReferenceSite, t reflect.Type) (any, error) {
+			missing = append(missing, site)
+			return User{Id: site.Ref, Name: "(unknown)"}, nil
+		},
+	})
+	tr.Users[0].Name = "Alice"
+	if err := linker.Link(tr); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	if len(missing) != 1 || missing[0].Source != tr || missing[0].Field != "Issues[0].Assignee" || missing[0].Ref != "deleted" {
+		t.Errorf("expected OnMissing for the assignee, got %+v", missing)
+	}
+	if strings.Join(resolved, ",") != "Issues[0].Author=Alice,Issues[0].Assignee=(unknown)" {
+		t.Errorf("unexpected OnResolve calls: %v", resolved)
+	}
+	if placeholder := tr.Issues[0].Assignee.Resolve(); placeholder == nil || placeholder.Id != "deleted" {
+		t.Errorf("expected the placeholder to be resolved, got %+v", placeholder)
+	}
+	if linker.ReferencesTo(tr.Users[0]) == nil {
+		t.Errorf("expected references to be recorded alongside the hooks")
+	}
+
+	// returning nil leaves the reference missing; an error fails the link
+	tr.Issues[0].Assignee.Resolved = nil
+	var pointerErr *PointerError
+	err = NewLinker(LinkerOptions{OnMissing: func(ReferenceSite, reflect.Type) (any, error) { return nil, nil }}).Link(tr)
+	if !errors.As(err, &pointerErr) {
+		t.Errorf("expected a PointerError, got %v", err)
+	}
+	var hookErr *HookError
+	err = NewLinker(LinkerOptions{OnMissing: func(ReferenceSite, reflect.Type) (any, error) {
+		return nil, errors.New("no placeholders")
+	}}).Link(tr)
+	if !errors.As(err, &hookErr) || hookErr.Hook != "OnMissing" {
+		t.Errorf("expected a HookError, got %v", err)
+	}
+	err = NewLinker(LinkerOptions{OnMissing: func(ReferenceSite, reflect.Type) (any, error) { return "alice", nil }}).Link(tr)
+	if !errors.As(err, &pointerErr) {
+		t.Errorf("expected a PointerError for a placeholder of the wrong type, got %v", err)
+	}
+
+	// Validate reports the missing reference without calling the hooks
+	missing = nil
+	report, err := linker.Validate(tr)
+	if err != nil || report.OK() || missing != nil {
+		t.Errorf("expected Validate to report without calling OnMissing, got %v, %v, %v", report, err, missing)
+	}
+}
+
+func TestLinkerHooksCallBack(t *testing.T) {
+	type site struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	s := &site{
+		Users: []*User{{Id: "alice"}},
+		Documents: []*Document{
+			{Id: "doc1", Author: &Pointer[*User]{Ref: "alice"}, Editor: &Pointer[*User]{Ref: "bob"}},
+			{Id: "doc2", Author: &Pointer[*User]{Ref: "carol"}},
+		},
+	}
+
+	// the hooks and the external resolver may call back into the Linker
+	var linker *Linker
+	var seen []int
+	linker = NewLinker(LinkerOptions{
+		Resolver: ResolverFunc(func(typ reflect.Type, ref string) (any, error) {
+			linker.ReferencesTo(s.Users[0])
+			if ref == "bob" {
+				return &User{Id: "bob"}, nil
+			}
+			return nil, nil
+		}),
+		OnMissing: func(site ReferenceSite, typ reflect.Type) (any, error) {
+			placeholder := &User{Id: site.Ref}
+			return placeholder, linker.Register(placeholder)
+		},
+		OnResolve: func(site ReferenceSite, target any) {
+			seen = append(seen, len(linker.ReferencesTo(target)))
+		},
+	})
+	done := make(chan error, 1)
+	go func() { done <- linker.Link(s) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("link failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("link did not return; the hooks deadlocked")
+	}
+	if s.Documents[0].Editor.Resolve().Id != "bob" || s.Documents[1].Author.Resolve().Id != "carol" {
+		t.Errorf("expected the deferred references to resolve")
+	}
+	if !reflect.DeepEqual(seen, []int{1, 1, 1}) {
+		t.Errorf("expected OnResolve to see each recorded reference, got %v", seen)
+	}
+}
+
+func TestPlainRefs(t *testing.T) {
+	type team struct {
+		Id       string                     `dd:"id"`
+		Lead     *Pointer[*User]            `dd:"lead"`
+		Members  []*Pointer[*User]          `dd:"members,+plainref"`
+		Backups  []Pointer[*User]           `dd:"backups"`
+		Rotation map[string]*Pointer[*User] `dd:"rotation"`
+	}
+	type org struct {
+		Users []*User `dd:"users"`
+		Teams []*team `dd:"teams"`
+	}
+	input := []byte(`{
+		"users": [{"id": "alice"}, {"id": "bob"}],
+		"teams": [{"id": "core", "lead": "alice", "members": ["alice", {"$ref": "bob"}], "backups": ["bob"], "rotation": {"mon": "bob"}}]
+	}`)
+	o, err := NewJSON[org](input)
+	if err != nil {
+		t.Fatalf("bind failed: %v", err)
+	}
+	if err := Link(o); err != nil {
+		t.Fatalf("link failed: %v", err)
+	}
+	tm := o.Teams[0]
+	if tm.Lead.Resolve() != o.Users[0] || tm.Members[1].Resolve() != o.Users[1] || tm.Backups[0].Resolve() != o.Users[1] || tm.Rotation["mon"].Resolve() != o.Users[1] {
+		t.Errorf("expected plain string references to bind and resolve")
+	}
+
+	// +plainref unbinds the field's references as strings, leaving the others as objects
+	out, err := Unbind(o)
+	if err != nil {
+		t.Fatalf("unbind failed: %v", err)
+	}
+	teamOut := out["teams"].([]any)[0].(map[string]any)
+	if !reflect.DeepEqual(teamOut["members"], []any{"alice", "bob"}) {
+		t.Errorf("expected plain members, got %v", teamOut["members"])
+	}
+	if !reflect.DeepEqual(teamOut["lead"], map[string]any{RefKey: "alice"}) {
+		t.Errorf("expected an object lead, got %v", teamOut["lead"])
+	}
+
+	// PlainRefs unbinds every reference as a string, and the output binds back
+	opts := &Options{PlainRefs: true}
+	out, err = Unbind(o, opts)
+	if err != nil {
+		t.Fatalf("unbind failed: %v", err)
+	}
+	teamOut = out["teams"].([]any)[0].(map[string]any)
+	if teamOut["lead"] != "alice" || !reflect.DeepEqual(teamOut["backups"], []any{"bob"}) || !reflect.DeepEqual(teamOut["rotation"], map[string]any{"mon": "bob"}) {
+		t.Errorf("expected plain references, got %v", teamOut)
+	}
+	again, err := New[org](out, opts)
+	if err != nil {
+		t.Fatalf("rebind failed: %v", err)
+	}
+	if again.Teams[0].Lead.Ref != "alice" || again.Teams[0].Rotation["mon"].Ref != "bob" {
+		t.Errorf("expected the plain references to round-trip, got %+v", again.Teams[0])
+	}
+
+	if _, err := NewJSON[org]([]byte(`{"teams": [{"id": "core", "lead": 7}]}`)); err == nil {
+		t.Errorf("expected an error for a non-string reference")
+	}
+}
+
+func TestLinkerRelink(t *testing.T) {
+	type site struct {
+		Users     []*User     `dd:"users"`
+		Documents []*Document `dd:"documents"`
+	}
+	s := &site{
+		Users: []*User{{Id: "alice"}, {Id: "bob"}},
+		Documents: []*Document{
+			{Id: "doc1", Author: &Pointer[*User]{Ref: "alice"}},
+			{Id: "doc2", Author: &Pointer[*User]{Ref: "bob"}},
+		},
+	}
+	linker := NewLinker()
+	if err := linker.Relink(s); err == nil {
+		t.Errorf("expected an error without a registry")
+	}
+	if err := linker.Register(s); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := linker.ResolveReferences(s); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	// a changed reference, and one to a newly added object, resolve when their holder is relinked
+	carol := &User{Id: "carol"}
+	s.Users = append(s.Users, carol)
+	doc := s.Documents[0]
+	doc.Author.Ref, doc.Editor = "bob", &Pointer[*User]{Ref: "carol"}
+	if err := linker.Relink(carol, doc); err != nil {
+		t.Fatalf("relink failed: %v", err)
+	}
+	if doc.Author.Resolve() != s.Users[1] || doc.Editor.Resolve() != carol {
+		t.Errorf("expected the changed references to resolve")
+	}
+	if sites := linker.ReferencesTo(s.Users[0]); len(sites) != 0 {
+		t.Errorf("expected no references to alice, got %+v", sites)
+	}
+	if sites := linker.ReferencesTo(s.Users[1]); len(sites) != 2 {
+		t.Errorf("expected two references to bob, got %+v", sites)
+	}
+
+	// a changed Id is registered in place of the old one
+	s.Users[0].Id = "alicia"
+	if err := linker.Relink(s.Users[0]); err != nil {
+		t.Fatalf("relink failed: %v", err)
+	}
+	doc.Author.Ref = "alicia"
+	if err := linker.Relink(doc); err != nil || doc.Author.Resolve() != s.Users[0] {
+		t.Errorf("expected the new Id to resolve, got %v", err)
+	}
+	doc.Author.Ref = "alice"
+	var pointerErr *PointerError
+	if err := linker.Relink(doc); !errors.As(err, &pointerErr) {
+		t.Errorf("expected the old Id to be unresolvable, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package dd
+
+import (
+	"reflect"
+)
+
+// Provenance records the origin of each field value in a target, keyed by the dotted path of external field names
+// (e.g. "server.port"). the value is the Options.Source that supplied the field's final value.
+//
+// a Provenance is owned by the caller: set Options.Provenance to a non-nil map, together with a non-empty
+// Options.Source, and Bind (which clears the map first) and Merge fill it in. keep one Provenance per target. fields
+// that were never supplied by a tracked source (defaults set in code, for example) do not appear. slices, maps, and
+// other values bound whole (including types with a Converter) are tracked as a single leaf, since Merge replaces
+// them wholesale.
+type Provenance map[string]string
+
+// recordProvenance records opt.Source as the origin of every field in data that maps onto structType, in
+// opt.Provenance. when reset is true, the previously recorded provenance is discarded first.
+func recordProvenance(structType reflect.Type, data map[string]any, opt *Options, reset bool) {
+	if opt == nil || opt.Source == "" || opt.Provenance == nil {
+		return
+	}
+	if reset {
+		clear(opt.Provenance)
+	}
+	collectProvenance(structType, data, "", opt, opt.Provenance)
+}
+
+// collectProvenance walks data alongside structType, recording source for each leaf path present in data.
+func collectProvenance(structType reflect.Type, data map[string]any, prefix string, opt *Options, out Provenance) {
+	consumed := make(map[string]bool)
+	hasExtra := collectProvenanceFields(structType, data, prefix, opt, out, consumed)
+	if hasExtra {
+		for key := range data {
+			if !consumed[key] {
+				out[joinProvenancePath(prefix, key)] = opt.Source
+			}
+		}
+	}
+}
+
+func collectProvenanceFields(structType reflect.Type, data map[string]any, prefix string, opt *Options, out Provenance, consumed map[string]bool) bool {
+	hasExtra := false
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+
+		// embedded structs share the parent namespace
+		if isInlined(field, meta.tag) {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				if collectProvenanceFields(embeddedType, data, prefix, opt, out, consumed) {
+					hasExtra = true
+				}
+			}
+			continue
+		}
+
+		tag := meta.tag
+		if tag.Skip {
+			continue
+		}
+		if tag.Extra {
+			hasExtra = true
+			continue
+		}
+		name := meta.externalName(opt.naming())
+
+		raw, ok := data[name]
+		if !ok {
+			continue
+		}
+		consumed[name] = true
+		path := joinProvenancePath(prefix, name)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if subMap, ok := raw.(map[string]any); ok && !tag.Raw && isProvenanceStruct(fieldType) && !bindsAsValue(fieldType, opt) {
+			collectProvenance(fieldType, subMap, path, opt, out)
+			continue
+		}
+		out[path] = opt.Source
+	}
+	return hasExtra
+}
+
+// isProvenanceStruct reports whether values of t are bound field-by-field (and therefore tracked per field).
+func isProvenanceStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || isPointerType(t) {
+		return false
+	}
+	if isScalarStruct(t) || isOptionalType(t) {
+		return false
+	}
+	if t.Implements(dynamicInterfaceType) || reflect.PointerTo(t).Implements(unmarshalerInterfaceType) {
+		return false
+	}
+	return true
+}
+
+func joinProvenancePath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
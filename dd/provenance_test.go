@@ -0,0 +1,128 @@
+package dd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenanceLayeredMerge(t *testing.T) {
+	type Server struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		Name   string
+		Server Server
+		Tags   []string
+	}
+
+	cfg := &Config{}
+	provenance := Provenance{}
+
+	err := Bind(cfg, map[string]any{
+		"name":   "app",
+		"server": map[string]any{"host": "localhost", "port": 8080},
+		"tags":   []any{"a"},
+	}, &Options{Source: "defaults", Provenance: provenance})
+	assert.Nil(t, err)
+
+	err = Merge(cfg, map[string]any{
+		"server": map[string]any{"port": 9090},
+	}, &Options{Source: "override.yaml", Provenance: provenance})
+	assert.Nil(t, err)
+
+	assert.Equal(t, Provenance{
+		"name":        "defaults",
+		"server.host": "defaults",
+		"server.port": "override.yaml",
+		"tags":        "defaults",
+	}, provenance)
+
+	// the record belongs to the caller, not to the target, so it survives copying the target
+	copied := *cfg
+	assert.Equal(t, 9090, copied.Server.Port)
+	assert.Equal(t, "override.yaml", provenance["server.port"])
+}
+
+func TestProvenanceBindResets(t *testing.T) {
+	type Config struct {
+		Name  string
+		Level string
+	}
+
+	cfg := &Config{}
+	provenance := Provenance{}
+
+	assert.Nil(t, Bind(cfg, map[string]any{"name": "a", "level": "info"}, &Options{Source: "first", Provenance: provenance}))
+	assert.Nil(t, Bind(cfg, map[string]any{"name": "b"}, &Options{Source: "second", Provenance: provenance}))
+
+	assert.Equal(t, Provenance{"name": "second"}, provenance)
+}
+
+func TestProvenanceEmbeddedAndExtra(t *testing.T) {
+	type Base struct {
+		Id string
+	}
+	type Config struct {
+		Base
+		Name  string
+		Extra map[string]any `dd:",+extra"`
+	}
+
+	cfg := &Config{}
+	provenance := Provenance{}
+
+	err := Bind(cfg, map[string]any{"id": "x", "name": "n", "unknown": true}, &Options{Source: "file", Provenance: provenance})
+	assert.Nil(t, err)
+	assert.Equal(t, Provenance{"id": "file", "name": "file", "unknown": "file"}, provenance)
+}
+
+func TestProvenanceNotRecordedWithoutSource(t *testing.T) {
+	type Config struct {
+		Name string
+	}
+
+	cfg := &Config{}
+	provenance := Provenance{}
+	assert.Nil(t, Bind(cfg, map[string]any{"name": "a"}, &Options{Provenance: provenance}))
+	assert.Empty(t, provenance)
+
+	assert.Nil(t, Merge(cfg, map[string]any{"name": "b"}, &Options{Source: "env", Provenance: provenance}))
+	assert.Equal(t, Provenance{"name": "env"}, provenance)
+}
+
+type provenanceEndpoint struct {
+	Host string
+	Port int
+}
+
+// endpointConverter binds a provenanceEndpoint from a map with a single "address" key.
+type endpointConverter struct{}
+
+func (endpointConverter) FromRaw(raw interface{}) (interface{}, error) {
+	m := raw.(map[string]any)
+	return provenanceEndpoint{Host: m["address"].(string)}, nil
+}
+
+func (endpointConverter) ToRaw(value interface{}) (interface{}, error) {
+	return map[string]any{"address": value.(provenanceEndpoint).Host}, nil
+}
+
+func TestProvenanceConverterLeaf(t *testing.T) {
+	type Config struct {
+		Endpoint provenanceEndpoint
+	}
+
+	cfg := &Config{}
+	provenance := Provenance{}
+	err := Bind(cfg, map[string]any{"endpoint": map[string]any{"address": "example.com"}}, &Options{
+		Source:     "file",
+		Provenance: provenance,
+		Converters: map[reflect.Type]Converter{reflect.TypeOf(provenanceEndpoint{}): endpointConverter{}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "example.com", cfg.Endpoint.Host)
+	assert.Equal(t, Provenance{"endpoint": "file"}, provenance)
+}
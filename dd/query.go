@@ -0,0 +1,139 @@
+package dd
+
+import (
+	"cmp"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// BindQuery binds URL query parameters (or parsed form values) to target (a pointer to a struct), as a lightweight
+// alternative to a form-decoding library. keys are external field names; nested fields are addressed as
+// "server.port" or "server[port]", list elements by index ("servers[0].host" or "servers[0][host]"), and map entries
+// like fields ("labels.tier"). repeated parameters bind a slice field ("tag=a&tag=b"), as does "tag[]=a&tag[]=b";
+// a repeated parameter for any other field binds its first value. empty values for non-string fields are ignored, so
+// an empty form input leaves its field unset.
+//
+// values are coerced with the normal binding rules, and converters, `+required`, constraints, and Validate all apply.
+// unknown parameters are captured by a `+extra` field, if any, as strings (or lists of strings when repeated), and
+// likewise bind any-typed fields.
+func BindQuery(target interface{}, values url.Values, opts ...*Options) error {
+	elem, err := validateTarget(target)
+	if err != nil {
+		return err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	data, err := queryToMap(elem.Type(), values, opt.naming())
+	if err != nil {
+		return err
+	}
+	return Bind(target, data, opts...)
+}
+
+// NewQuery creates and binds a new instance of T from URL query parameters; see BindQuery.
+func NewQuery[T any](values url.Values, opts ...*Options) (*T, error) {
+	target := new(T)
+	if err := BindQuery(target, values, opts...); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// queryBracketKey matches a bracketed name in a query key, as in "server[port]"; indices and "[]" are not matched.
+var queryBracketKey = regexp.MustCompile(`\[([^\]\[]*[^\]\[0-9+][^\]\[]*)\]`)
+
+// queryToMap converts query parameters into the data map bound into structType.
+func queryToMap(structType reflect.Type, values url.Values, naming NamingStrategy) (map[string]any, error) {
+	type param struct {
+		key      string
+		segments []pathSegment
+	}
+	params := make([]param, 0, len(values))
+	for key := range values {
+		// "server[port]" → "server.port", and "tags[]" → "tags[+]"
+		path := queryBracketKey.ReplaceAllString(key, ".$1")
+		path = strings.ReplaceAll(path, "[]", "[+]")
+		segments, err := parseFieldPath(path)
+		if err != nil {
+			return nil, &ValidationError{Field: key, Message: err.Error()}
+		}
+		if segments[0].isIndex {
+			return nil, &ValidationError{Field: key, Message: "query key must begin with a field name"}
+		}
+		params = append(params, param{key: key, segments: segments})
+	}
+	// indices are assigned in numeric order, so that "items[10]" follows "items[9]"
+	slices.SortFunc(params, func(a, b param) int {
+		return slices.CompareFunc(a.segments, b.segments, func(x, y pathSegment) int {
+			if c := cmp.Compare(x.key, y.key); c != 0 {
+				return c
+			}
+			return cmp.Compare(x.index, y.index)
+		})
+	})
+
+	var data any = make(map[string]any)
+	for _, p := range params {
+		fieldType := pathFieldType(structType, p.segments, naming)
+		untyped := fieldType == nil || fieldType.Kind() == reflect.Interface
+		var err error
+		vals := values[p.key]
+		switch {
+		case p.segments[len(p.segments)-1].append:
+			for _, v := range vals {
+				if data, err = assignPath(data, p.segments, v); err != nil {
+					break
+				}
+			}
+		case untyped && len(vals) > 1,
+			!untyped && fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() != reflect.Uint8:
+			items := make([]any, len(vals))
+			for i, v := range vals {
+				items[i] = v
+			}
+			data, err = assignPath(data, p.segments, items)
+		case len(vals) == 0, vals[0] == "" && !untyped && fieldType.Kind() != reflect.String:
+			continue
+		default:
+			data, err = assignPath(data, p.segments, vals[0])
+		}
+		if err != nil {
+			return nil, &ValidationError{Field: p.key, Message: err.Error()}
+		}
+	}
+	return data.(map[string]any), nil
+}
+
+// pathFieldType returns the type found at segments within structType, with pointers dereferenced, or nil when the
+// path does not lead to a known field.
+func pathFieldType(structType reflect.Type, segments []pathSegment, naming NamingStrategy) reflect.Type {
+	t := structType
+	for _, seg := range segments {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch {
+		case seg.isIndex && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+			t = t.Elem()
+		case !seg.isIndex && t.Kind() == reflect.Map:
+			t = t.Elem()
+		case !seg.isIndex && t.Kind() == reflect.Struct:
+			fieldType, found := externalFieldType(t, seg.key, naming)
+			if !found {
+				return nil
+			}
+			t = fieldType
+		default:
+			return nil
+		}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,90 @@
+package dd
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type queryServer struct {
+	Host string
+	Port int `dd:",+max=65535"`
+}
+
+type querySearch struct {
+	Q        string `dd:",+required"`
+	Page     int
+	Tags     []string
+	Since    *time.Time
+	Timeout  time.Duration
+	Server   queryServer
+	Backends []queryServer
+	Labels   map[string]string
+	Debug    bool
+	Extra    map[string]any `dd:",+extra"`
+}
+
+func TestBindQuery(t *testing.T) {
+	values, err := url.ParseQuery("q=dd&page=2&tags=a&tags=b&since=2024-01-01T00:00:00Z&timeout=5s" +
+		"&server.host=localhost&server[port]=8080" +
+		"&backends[0].host=b0&backends[1][host]=b1&backends[1][port]=81" +
+		"&labels.tier=web&labels[zone]=us&debug=&utm_source=mail&utm_source=web")
+	assert.NoError(t, err)
+
+	s, err := NewQuery[querySearch](values)
+	assert.NoError(t, err)
+	assert.Equal(t, "dd", s.Q)
+	assert.Equal(t, 2, s.Page)
+	assert.Equal(t, []string{"a", "b"}, s.Tags)
+	assert.Equal(t, 2024, s.Since.Year())
+	assert.Equal(t, 5*time.Second, s.Timeout)
+	assert.Equal(t, queryServer{Host: "localhost", Port: 8080}, s.Server)
+	assert.Equal(t, []queryServer{{Host: "b0"}, {Host: "b1", Port: 81}}, s.Backends)
+	assert.Equal(t, map[string]string{"tier": "web", "zone": "us"}, s.Labels)
+	assert.False(t, s.Debug)
+	assert.Equal(t, map[string]any{"utm_source": []any{"mail", "web"}}, s.Extra)
+}
+
+func TestBindQueryLists(t *testing.T) {
+	// "[]" appends, a single value still binds a slice, and indices sort numerically
+	values := url.Values{"tags[]": {"x", "y"}, "page": {"1", "9"}}
+	for i := 0; i < 12; i++ {
+		values.Set("backends["+strconv.Itoa(i)+"].port", strconv.Itoa(i))
+	}
+	s, err := NewQuery[querySearch](url.Values{"q": {"x"}, "tags": {"only"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"only"}, s.Tags)
+
+	values.Set("q", "x")
+	s, err = NewQuery[querySearch](values)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, s.Tags)
+	assert.Equal(t, 1, s.Page) // the first of repeated scalar values
+	if assert.Len(t, s.Backends, 12) {
+		assert.Equal(t, 11, s.Backends[11].Port)
+	}
+}
+
+func TestBindQueryErrors(t *testing.T) {
+	_, err := NewQuery[querySearch](url.Values{"page": {"2"}})
+	var rfe *RequiredFieldError
+	assert.ErrorAs(t, err, &rfe)
+
+	_, err = NewQuery[querySearch](url.Values{"q": {"x"}, "server.port": {"70000"}})
+	var ce *ConstraintError
+	assert.ErrorAs(t, err, &ce)
+
+	_, err = NewQuery[querySearch](url.Values{"q": {"x"}, "page": {"two"}})
+	assert.Error(t, err)
+
+	_, err = NewQuery[querySearch](url.Values{"q": {"x"}, "backends[1].host": {"b1"}})
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+	assert.Equal(t, "backends[1].host", ve.Field)
+
+	_, err = NewQuery[querySearch](url.Values{"[0]": {"x"}})
+	assert.ErrorAs(t, err, &ve)
+}
@@ -0,0 +1,78 @@
+package dd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Raw captures a subtree of the input data verbatim. dd does not interpret the contents of a Raw field during
+// binding; the decoded value (a map[string]any, []any, or scalar) is stored in Value and emitted unchanged by Unbind.
+// useful for payload sections that an application relays without understanding.
+type Raw struct {
+	Value any
+}
+
+// Bind binds the captured subtree into target, which must be a pointer to a struct.
+func (r Raw) Bind(target interface{}, opts ...*Options) error {
+	data, ok := r.Value.(map[string]any)
+	if !ok {
+		return &TypeMismatchError{Expected: "object", Actual: fmt.Sprintf("%T", r.Value)}
+	}
+	return Bind(target, data, opts...)
+}
+
+var rawType = reflect.TypeOf(Raw{})
+var jsonRawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// bindRaw captures raw into a Raw or json.RawMessage destination. json.RawMessage fields receive the JSON encoding
+// of the subtree. returns false if dst is not a pass-through type.
+func bindRaw(dst reflect.Value, raw interface{}, path string) (bool, error) {
+	switch dst.Type() {
+	case rawType:
+		if r, ok := raw.(Raw); ok {
+			dst.Set(reflect.ValueOf(r))
+			return true, nil
+		}
+		dst.Set(reflect.ValueOf(Raw{Value: raw}))
+		return true, nil
+
+	case jsonRawMessageType:
+		if msg, ok := raw.(json.RawMessage); ok {
+			dst.SetBytes(append(json.RawMessage(nil), msg...))
+			return true, nil
+		}
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return true, &ConversionError{Path: path, Value: fmt.Sprintf("%v", raw), Type: "json.RawMessage", Cause: err}
+		}
+		dst.SetBytes(data)
+		return true, nil
+	}
+	return false, nil
+}
+
+// unbindRaw returns the captured subtree held by a Raw or json.RawMessage value. a json.RawMessage is returned as is,
+// so that UnbindJSON emits its bytes verbatim (keeping key order and number precision), and the other formats decode
+// it. returns present=false for an empty value, and handled=false if v is not a pass-through type.
+func unbindRaw(v reflect.Value) (out interface{}, present bool, handled bool, err error) {
+	switch v.Type() {
+	case rawType:
+		r := v.Interface().(Raw)
+		return r.Value, r.Value != nil, true, nil
+
+	case jsonRawMessageType:
+		msg := v.Interface().(json.RawMessage)
+		if len(msg) == 0 {
+			return nil, false, true, nil
+		}
+		if !json.Valid(msg) {
+			return nil, false, true, &ConversionError{Value: string(msg), Type: "json.RawMessage", Message: "invalid JSON"}
+		}
+		if string(msg) == "null" {
+			return nil, false, true, nil
+		}
+		return append(json.RawMessage(nil), msg...), true, true, nil
+	}
+	return nil, false, false, nil
+}
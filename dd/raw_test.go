@@ -0,0 +1,82 @@
+package dd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type relayConfig struct {
+	Name    string
+	Payload Raw
+	Body    json.RawMessage
+	Items   []Raw
+}
+
+func TestBindRaw(t *testing.T) {
+	payload := map[string]any{"kind": "webhook", "headers": map[string]any{"x-id": 1}}
+	data := map[string]any{
+		"name":    "relay",
+		"payload": payload,
+		"body":    map[string]any{"b": []any{1, "two"}, "a": true},
+		"items":   []any{"scalar", []any{1, 2}},
+	}
+
+	cfg, err := New[relayConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, cfg.Payload.Value)
+	assert.JSONEq(t, `{"a":true,"b":[1,"two"]}`, string(cfg.Body))
+	assert.Equal(t, []Raw{{Value: "scalar"}, {Value: []any{1, 2}}}, cfg.Items)
+}
+
+func TestUnbindRawRoundTrip(t *testing.T) {
+	cfg := &relayConfig{
+		Name:    "relay",
+		Payload: Raw{Value: map[string]any{"kind": "webhook"}},
+		Body:    json.RawMessage(`{"count": 3}`),
+	}
+
+	data, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"kind": "webhook"}, data["payload"])
+	assert.Equal(t, json.RawMessage(`{"count": 3}`), data["body"])
+
+	out, err := New[relayConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.Payload, out.Payload)
+	assert.JSONEq(t, string(cfg.Body), string(out.Body))
+}
+
+func TestUnbindRawMessageVerbatim(t *testing.T) {
+	cfg := &relayConfig{Name: "relay", Body: json.RawMessage(`{"z":1,"a":12345678901234567890123}`)}
+
+	out, err := UnbindJSON(cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "\"z\": 1,\n    \"a\": 12345678901234567890123\n")
+
+	out, err = UnbindYAML(cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `body: {"z": 1, "a": 12345678901234567890123}`)
+}
+
+func TestUnbindRawEmpty(t *testing.T) {
+	data, err := Unbind(&relayConfig{Name: "empty"})
+	assert.NoError(t, err)
+	_, found := data["payload"]
+	assert.False(t, found)
+	_, found = data["body"]
+	assert.False(t, found)
+}
+
+func TestRawBind(t *testing.T) {
+	type webhook struct {
+		Kind string
+	}
+	r := Raw{Value: map[string]any{"kind": "webhook"}}
+	w := &webhook{}
+	assert.NoError(t, r.Bind(w))
+	assert.Equal(t, "webhook", w.Kind)
+
+	assert.Error(t, Raw{Value: "scalar"}.Bind(w))
+}
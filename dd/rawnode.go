@@ -0,0 +1,447 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlNodeType = reflect.TypeOf(yaml.Node{})
+var yamlNodePtrType = reflect.TypeOf((*yaml.Node)(nil))
+
+// isRawNodeType reports whether t can hold a `+raw` field.
+func isRawNodeType(t reflect.Type) bool {
+	return t == yamlNodeType || t == yamlNodePtrType || t == jsonRawMessageType
+}
+
+// bindRawNode stores a subtree into a `+raw` field. source is the subtree's node in the document being bound (a
+// *yaml.Node or json.RawMessage), which is stored as is, keeping ordering, comments, and styles. without one, the node
+// is built from the decoded value raw, and only approximates the original, as a map carries no ordering or style.
+func bindRawNode(fieldVal reflect.Value, raw interface{}, source any, path string) error {
+	if !isRawNodeType(fieldVal.Type()) {
+		return &TypeMismatchError{Path: path, Expected: "yaml.Node, *yaml.Node, or json.RawMessage for +raw field", Actual: fieldVal.Type().String()}
+	}
+	if fieldVal.Type() == jsonRawMessageType {
+		if msg, ok := source.(json.RawMessage); ok {
+			fieldVal.SetBytes(append(json.RawMessage(nil), msg...))
+			return nil
+		}
+		_, err := bindRaw(fieldVal, raw, path)
+		return err
+	}
+
+	switch source := source.(type) {
+	case *yaml.Node:
+		setRawNode(fieldVal, expandYAMLAliases(source))
+		return nil
+	case json.RawMessage:
+		var doc yaml.Node
+		if err := yaml.Unmarshal(source, &doc); err == nil && len(doc.Content) > 0 {
+			setRawNode(fieldVal, doc.Content[0])
+			return nil
+		}
+	}
+	node, ok := raw.(*yaml.Node)
+	if !ok {
+		node = &yaml.Node{}
+		if err := node.Encode(raw); err != nil {
+			return &ConversionError{Path: path, Value: fmt.Sprintf("%v", raw), Type: "yaml.Node", Cause: err}
+		}
+	}
+	setRawNode(fieldVal, node)
+	return nil
+}
+
+func setRawNode(fieldVal reflect.Value, node *yaml.Node) {
+	if fieldVal.Type() == yamlNodePtrType {
+		fieldVal.Set(reflect.ValueOf(node))
+	} else {
+		fieldVal.Set(reflect.ValueOf(*node))
+	}
+}
+
+// unbindRawNode returns the value emitted for a `+raw` field: the *yaml.Node or json.RawMessage itself, so that the
+// YAML and JSON encoders re-emit it faithfully. UnbindYAML and UnbindJSON convert nodes of the other format.
+func unbindRawNode(fieldVal reflect.Value) (interface{}, bool) {
+	switch fieldVal.Type() {
+	case yamlNodePtrType:
+		if fieldVal.IsNil() {
+			return nil, false
+		}
+		return fieldVal.Interface(), true
+	case yamlNodeType:
+		node := fieldVal.Interface().(yaml.Node)
+		if node.Kind == 0 {
+			return nil, false
+		}
+		return &node, true
+	case jsonRawMessageType:
+		msg := fieldVal.Interface().(json.RawMessage)
+		if len(msg) == 0 {
+			return nil, false
+		}
+		return msg, true
+	}
+	return nil, false
+}
+
+// hasRawFields reports whether t (or any struct reachable from it) declares a `+raw` field, or holds a json.RawMessage,
+// either of which unbinds to a value that must be resolved for the output format.
+func hasRawFields(t reflect.Type) bool {
+	return hasRawFieldsVisited(t, map[reflect.Type]bool{})
+}
+
+func hasRawFieldsVisited(t reflect.Type, visited map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		if t == jsonRawMessageType {
+			return true
+		}
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] || isScalarStruct(t) || isPointerType(t) {
+		return false
+	}
+	visited[t] = true
+	for _, meta := range cachedFields(t) {
+		field := meta.field
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		if meta.tag.Raw {
+			return true
+		}
+		if hasRawFieldsVisited(field.Type, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSourceRawNodes applies resolveRawNodes to data unbound from source, when source declares `+raw` fields.
+func resolveSourceRawNodes(source interface{}, data map[string]any, format string) error {
+	if source == nil || !hasRawFields(reflect.TypeOf(source)) {
+		return nil
+	}
+	_, err := resolveRawNodes(data, format)
+	return err
+}
+
+// yamlMappingValue returns the value node for key in a mapping node, or nil when absent. keys merged in with "<<"
+// are found as well, with explicit keys taking precedence and earlier merged mappings over later ones.
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	var merged *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		if k.Kind == yaml.ScalarNode && k.ShortTag() == "!!merge" {
+			if merged == nil {
+				merged = yamlMergedValue(v, key)
+			}
+			continue
+		}
+		if k.Value == key {
+			return v
+		}
+	}
+	return merged
+}
+
+// yamlMergedValue looks up key in the value of a "<<" merge key: an aliased mapping or a sequence of them.
+func yamlMergedValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveYAMLAlias(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		return yamlMappingValue(node, key)
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item = resolveYAMLAlias(item); item.Kind == yaml.MappingNode {
+				if value := yamlMappingValue(item, key); value != nil {
+					return value
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveYAMLAlias returns the node an alias refers to, or node itself.
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// expandYAMLAliases returns node with every alias within it replaced by a copy of the subtree it refers to, so that a
+// captured `+raw` node never references an anchor outside of it. node is returned as is when it holds no aliases.
+func expandYAMLAliases(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		target := *expandYAMLAliases(node.Alias)
+		target.Anchor = ""
+		return &target
+	}
+	var content []*yaml.Node
+	for i, child := range node.Content {
+		if expanded := expandYAMLAliases(child); expanded != child {
+			if content == nil {
+				content = append([]*yaml.Node(nil), node.Content...)
+			}
+			content[i] = expanded
+		}
+	}
+	if content == nil {
+		return node
+	}
+	expanded := *node
+	expanded.Content = content
+	return &expanded
+}
+
+// rawNodeIndex maps the maps and slices of a document decoded by BindYAML or BindJSON (and their variants) to their
+// source, so that the bind walk can store the original node in each `+raw` field rather than one rebuilt from the
+// decoded value. containers are identified by address, so data rewritten before binding (by Options.ExpandEnv, for
+// example) is not found, and its `+raw` fields are rebuilt as they are by Bind.
+type rawNodeIndex struct {
+	yaml map[uintptr]*yaml.Node                 // mapping and sequence nodes, registered as the bind walk descends
+	json map[uintptr]map[string]json.RawMessage // the bytes of each member of every object, recorded while decoding
+}
+
+// containerAddress returns the address identifying a decoded map or non-empty slice.
+func containerAddress(v any) (uintptr, bool) {
+	switch c := v.(type) {
+	case map[string]any:
+		if c != nil {
+			return reflect.ValueOf(c).Pointer(), true
+		}
+	case []any:
+		if len(c) > 0 {
+			return reflect.ValueOf(c).Pointer(), true
+		}
+	}
+	return 0, false
+}
+
+// addYAML records node as the source of container.
+func (idx *rawNodeIndex) addYAML(container any, node *yaml.Node) {
+	if address, ok := containerAddress(container); ok {
+		idx.yaml[address] = resolveYAMLAlias(node)
+	}
+}
+
+// descend registers the nodes of the maps and slices directly within container, as the bind walk enters it.
+func (idx *rawNodeIndex) descend(container any) {
+	if idx == nil || idx.yaml == nil {
+		return
+	}
+	address, ok := containerAddress(container)
+	if !ok || idx.yaml[address] == nil {
+		return
+	}
+	node := idx.yaml[address]
+	switch c := container.(type) {
+	case map[string]any:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for key, value := range c {
+			if _, ok := containerAddress(value); ok {
+				if valueNode := yamlMappingValue(node, key); valueNode != nil {
+					idx.addYAML(value, valueNode)
+				}
+			}
+		}
+	case []any:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range c {
+			if i < len(node.Content) {
+				idx.addYAML(item, node.Content[i])
+			}
+		}
+	}
+}
+
+// member returns the source of the value under key in data, a *yaml.Node or json.RawMessage, or nil when data was
+// not indexed.
+func (idx *rawNodeIndex) member(data map[string]any, key string) any {
+	if idx == nil {
+		return nil
+	}
+	address, ok := containerAddress(data)
+	if !ok {
+		return nil
+	}
+	if node := idx.yaml[address]; node != nil && node.Kind == yaml.MappingNode {
+		if value := yamlMappingValue(node, key); value != nil {
+			return value
+		}
+		return nil
+	}
+	if value, ok := idx.json[address][key]; ok {
+		return value
+	}
+	return nil
+}
+
+// descendRaw registers the source nodes within container, when binding from an indexed document.
+func (o *Options) descendRaw(container any) {
+	if o != nil {
+		o.rawNodes.descend(container)
+	}
+}
+
+// rawSource returns the source node of the value under key in data, when binding from an indexed document.
+func (o *Options) rawSource(data map[string]any, key string) any {
+	if o == nil {
+		return nil
+	}
+	return o.rawNodes.member(data, key)
+}
+
+// decodeYAMLIndexed decodes a YAML document into a map, as yaml.Unmarshal does, indexing its nodes in idx.
+func decodeYAMLIndexed(data []byte, idx *rawNodeIndex) (map[string]any, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil
+	}
+	var m map[string]any
+	if err := doc.Decode(&m); err != nil {
+		return nil, err
+	}
+	idx.yaml = map[uintptr]*yaml.Node{}
+	idx.addYAML(m, doc.Content[0])
+	return m, nil
+}
+
+// decodeJSONIndexed decodes a JSON document into a map, as decodeJSON does, recording the bytes of every object's
+// members in idx.
+func decodeJSONIndexed(data []byte, precise bool, idx *rawNodeIndex) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if precise {
+		dec.UseNumber()
+	}
+	idx.json = map[uintptr]map[string]json.RawMessage{}
+	value, err := decodeJSONValue(dec, data, idx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+	switch m := value.(type) {
+	case map[string]any:
+		return m, nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("cannot unmarshal %T into a map", value)
+}
+
+// decodeJSONValue decodes the next value from dec, which reads data.
+func decodeJSONValue(dec *json.Decoder, data []byte, idx *rawNodeIndex) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		m := make(map[string]any)
+		members := make(map[string]json.RawMessage)
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			start := dec.InputOffset()
+			value, err := decodeJSONValue(dec, data, idx)
+			if err != nil {
+				return nil, err
+			}
+			m[key.(string)] = value
+			members[key.(string)] = bytes.TrimLeft(data[start:dec.InputOffset()], " \t\r\n:")
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		if address, ok := containerAddress(m); ok {
+			idx.json[address] = members
+		}
+		return m, nil
+	case json.Delim('['):
+		s := make([]any, 0)
+		for dec.More() {
+			value, err := decodeJSONValue(dec, data, idx)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return token, nil
+}
+
+// resolveRawNodes prepares unbound data containing `+raw` values for encoding as format ("json", "yaml", or a binary
+// format such as "msgpack"), converting nodes not native to the format into plain values.
+func resolveRawNodes(v interface{}, format string) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, item := range t {
+			resolved, err := resolveRawNodes(item, format)
+			if err != nil {
+				return nil, err
+			}
+			t[k] = resolved
+		}
+		return t, nil
+	case []any:
+		for i, item := range t {
+			resolved, err := resolveRawNodes(item, format)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = resolved
+		}
+		return t, nil
+	case *yaml.Node:
+		if format == "yaml" {
+			return t, nil
+		}
+		var out any
+		if err := t.Decode(&out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	case json.RawMessage:
+		if format == "json" {
+			return t, nil
+		}
+		if format != "yaml" {
+			var out any
+			if err := json.Unmarshal(t, &out); err != nil {
+				return nil, err
+			}
+			return out, nil
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(t, &doc); err != nil {
+			return nil, err
+		}
+		if len(doc.Content) == 0 {
+			return nil, nil
+		}
+		return doc.Content[0], nil
+	}
+	return v, nil
+}
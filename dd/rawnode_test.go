@@ -0,0 +1,148 @@
+package dd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type rawNodeConfig struct {
+	Name     string
+	Template *yaml.Node      `dd:",+raw"`
+	Body     json.RawMessage `dd:",+raw"`
+}
+
+func TestRawNodeYAMLRoundTrip(t *testing.T) {
+	input := `name: relay
+template:
+  # keep this comment
+  zulu: 'quoted'
+  alpha: [1, 2]
+`
+	cfg, err := NewYAML[rawNodeConfig]([]byte(input))
+	assert.NoError(t, err)
+	assert.Equal(t, "relay", cfg.Name)
+	assert.NotNil(t, cfg.Template)
+	assert.Equal(t, yaml.MappingNode, cfg.Template.Kind)
+	assert.Equal(t, "zulu", cfg.Template.Content[0].Value)
+	assert.Equal(t, "keep this comment", strings.TrimPrefix(cfg.Template.Content[0].HeadComment, "# "))
+
+	out, err := UnbindYAML(cfg)
+	assert.NoError(t, err)
+	text := string(out)
+	assert.Contains(t, text, "# keep this comment")
+	assert.Contains(t, text, "zulu: 'quoted'")
+	assert.Contains(t, text, "alpha: [1, 2]")
+	assert.Less(t, strings.Index(text, "zulu"), strings.Index(text, "alpha"))
+}
+
+func TestRawNodeJSONPreservesOrder(t *testing.T) {
+	input := `{"name": "relay", "body": {"zulu": 1, "alpha": {"y": 2, "x": 3}}}`
+	cfg, err := NewJSON[rawNodeConfig]([]byte(input))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zulu": 1, "alpha": {"y": 2, "x": 3}}`, string(cfg.Body))
+
+	out, err := UnbindJSON(cfg)
+	assert.NoError(t, err)
+	text := string(out)
+	assert.Less(t, strings.Index(text, "zulu"), strings.Index(text, "alpha"))
+	assert.Less(t, strings.Index(text, `"y"`), strings.Index(text, `"x"`))
+}
+
+func TestRawNodeCrossFormat(t *testing.T) {
+	cfg, err := NewYAML[rawNodeConfig]([]byte("template:\n  a: 1\nbody:\n  b: 2\n"))
+	assert.NoError(t, err)
+
+	out, err := UnbindJSON(cfg)
+	assert.NoError(t, err)
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, map[string]any{"a": float64(1)}, decoded["template"])
+	assert.Equal(t, map[string]any{"b": float64(2)}, decoded["body"])
+
+	out, err = UnbindYAML(cfg)
+	assert.NoError(t, err)
+	var back map[string]any
+	assert.NoError(t, yaml.Unmarshal(out, &back))
+	assert.Equal(t, map[string]any{"b": 2}, back["body"])
+}
+
+func TestRawNodeFromMap(t *testing.T) {
+	cfg, err := New[rawNodeConfig](map[string]any{"template": map[string]any{"a": 1}})
+	assert.NoError(t, err)
+	var decoded map[string]any
+	assert.NoError(t, cfg.Template.Decode(&decoded))
+	assert.Equal(t, map[string]any{"a": 1}, decoded)
+}
+
+func TestRawNodeInvalidType(t *testing.T) {
+	type invalid struct {
+		Data map[string]any `dd:",+raw"`
+	}
+	_, err := New[invalid](map[string]any{"data": map[string]any{}})
+	assert.Error(t, err)
+	var mismatch *TypeMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+}
+
+func TestRawNodeMapValues(t *testing.T) {
+	type route struct {
+		Template *yaml.Node      `dd:",+raw"`
+		Body     json.RawMessage `dd:",+raw"`
+	}
+	type config struct {
+		Routes map[string]route
+		Groups []struct {
+			Routes map[string]*route
+		}
+	}
+
+	cfg, err := NewYAML[config]([]byte(`routes:
+  api:
+    template:
+      # keep this comment
+      zulu: 1
+      alpha: 2
+groups:
+  - routes:
+      web:
+        template: {yankee: 1, bravo: 2}
+`))
+	assert.NoError(t, err)
+	if assert.NotNil(t, cfg.Routes["api"].Template) {
+		assert.Equal(t, "zulu", cfg.Routes["api"].Template.Content[0].Value)
+		assert.Equal(t, "keep this comment", strings.TrimPrefix(cfg.Routes["api"].Template.Content[0].HeadComment, "# "))
+	}
+	if assert.Len(t, cfg.Groups, 1) && assert.NotNil(t, cfg.Groups[0].Routes["web"]) {
+		assert.Equal(t, yaml.FlowStyle, cfg.Groups[0].Routes["web"].Template.Style)
+		assert.Equal(t, "yankee", cfg.Groups[0].Routes["web"].Template.Content[0].Value)
+	}
+
+	cfg, err = NewJSON[config]([]byte(`{"routes": {"api": {"body": {"zulu": 1, "alpha": 12345678901234567890}}},
+		"groups": [{"routes": {"web": {"template": {"yankee": 1, "bravo": 2}}}}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zulu": 1, "alpha": 12345678901234567890}`, string(cfg.Routes["api"].Body))
+	if assert.Len(t, cfg.Groups, 1) && assert.NotNil(t, cfg.Groups[0].Routes["web"]) {
+		assert.Equal(t, "yankee", cfg.Groups[0].Routes["web"].Template.Content[0].Value)
+	}
+}
+
+func TestRawNodeDocumentErrors(t *testing.T) {
+	_, err := NewJSON[rawNodeConfig]([]byte(`{"name": "relay", "body": {`))
+	var syntaxErr *SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+
+	_, err = NewJSON[rawNodeConfig]([]byte(`[1, 2]`))
+	assert.Error(t, err)
+
+	cfg, err := NewJSON[rawNodeConfig]([]byte(`null`))
+	assert.NoError(t, err)
+	assert.Equal(t, &rawNodeConfig{}, cfg)
+
+	cfg, err = NewYAML[rawNodeConfig](nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &rawNodeConfig{}, cfg)
+}
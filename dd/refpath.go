@@ -0,0 +1,168 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// isPathRef reports whether ref is a path reference into the document, as in "/departments/eng/teams/frontend",
+// rather than the Id of an Identifiable object.
+func isPathRef(ref string) bool {
+	return strings.HasPrefix(ref, "/")
+}
+
+// resolvePathRef follows a path reference from root, returning a pointer to the referenced value. segments follow
+// JSON Pointer (RFC 6901), with "~1" for "/" and "~0" for "~": a struct field is addressed by its external name, a
+// map entry by its key, and a list element by its index or, for Identifiable elements, by its Id.
+func resolvePathRef(root reflect.Value, ref string, naming NamingStrategy) (reflect.Value, error) {
+	current := root
+	for _, segment := range strings.Split(ref, "/")[1:] {
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+			if current.IsNil() {
+				return reflect.Value{}, fmt.Errorf("%q is nil", segment)
+			}
+			current = current.Elem()
+		}
+		next, found := pathRefChild(current, segment, naming)
+		if !found {
+			return reflect.Value{}, fmt.Errorf("no %q in %s", segment, current.Type())
+		}
+		current = next
+	}
+	for current.Kind() == reflect.Interface && !current.IsNil() {
+		current = current.Elem()
+	}
+	if current.Kind() == reflect.Ptr {
+		if current.IsNil() {
+			return reflect.Value{}, fmt.Errorf("target is nil")
+		}
+		return current, nil
+	}
+	if !current.CanAddr() {
+		// map values are not addressable; refer to a copy
+		ptr := reflect.New(current.Type())
+		ptr.Elem().Set(current)
+		return ptr, nil
+	}
+	return current.Addr(), nil
+}
+
+// pathRefChild returns the child of v named by segment.
+func pathRefChild(v reflect.Value, segment string, naming NamingStrategy) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Struct:
+		return externalFieldValue(v, segment, naming)
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		value := v.MapIndex(reflect.ValueOf(segment).Convert(v.Type().Key()))
+		return value, value.IsValid()
+
+	case reflect.Slice, reflect.Array:
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < v.Len() {
+			return v.Index(index), true
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem := v.Index(i)
+			if id, ok := objectId(elem); ok && id == segment {
+				return elem, true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// objectId returns the Id of v, when it (or a pointer to it) implements Identifiable, or when it is a struct with a
+// field tagged +id.
+func objectId(v reflect.Value) (string, bool) {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "", false
+	}
+	if identifiable, ok := v.Interface().(Identifiable); ok {
+		return identifiable.GetId(), true
+	}
+	if v.CanAddr() {
+		if identifiable, ok := v.Addr().Interface().(Identifiable); ok {
+			return identifiable.GetId(), true
+		}
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+	index := idFieldIndex(v.Type())
+	if index == nil {
+		return "", false
+	}
+	switch field := v.FieldByIndex(index); field.Kind() {
+	case reflect.String:
+		return field.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), true
+	}
+	return "", false
+}
+
+// idFieldIndex returns the index of the field of struct type t tagged +id, looking through embedded structs, or nil.
+func idFieldIndex(t reflect.Type) []int {
+	for _, f := range cachedFields(t) {
+		if f.field.PkgPath != "" && !f.field.Anonymous {
+			continue
+		}
+		if f.tag.Id {
+			return []int{f.index}
+		}
+		if f.field.Anonymous && f.field.Type.Kind() == reflect.Struct {
+			if index := idFieldIndex(f.field.Type); index != nil {
+				return append([]int{f.index}, index...)
+			}
+		}
+	}
+	return nil
+}
+
+// externalFieldValue returns the field of structValue bound from key, looking through +inline fields.
+func externalFieldValue(structValue reflect.Value, key string, naming NamingStrategy) (reflect.Value, bool) {
+	structType := structValue.Type()
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		fieldVal := structValue.Field(meta.index)
+		if isInlined(field, meta.tag) {
+			if fieldVal.Kind() == reflect.Ptr {
+				if fieldVal.IsNil() {
+					continue
+				}
+				fieldVal = fieldVal.Elem()
+			}
+			if fieldVal.Kind() == reflect.Struct {
+				if value, ok := externalFieldValue(fieldVal, key, naming); ok {
+					return value, true
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra {
+			continue
+		}
+		if meta.externalName(naming) == key {
+			return fieldVal, true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,56 @@
+package dd
+
+import (
+	"reflect"
+	"sync"
+)
+
+// dynamicRegistry holds the binders registered with RegisterDynamic, keyed by discriminator.
+var dynamicRegistry sync.Map // string → func(map[string]any) (Dynamic, error)
+
+// RegisterDynamic registers T as the Dynamic implementation for the discriminator name in a package-level registry.
+// Bind, New, and Merge consult the registry when neither Options.FieldDynamicBinders nor Options.DynamicBinders has an
+// entry for name, so the common binder that just calls New[T] need not be written out for every type:
+//
+//	func init() {
+//	    dd.RegisterDynamic[EmailAction]("email")
+//	    dd.RegisterDynamic[SlackAction]("slack")
+//	}
+//
+// T may be a struct type or a pointer to one; in either case the bound value is returned as a pointer when that
+// pointer implements Dynamic, as New[T] would. registering a name again replaces its binder. the registered binder
+// does not receive the Options of the enclosing call.
+func RegisterDynamic[T Dynamic](name string) {
+	dynamicRegistry.Store(name, dynamicBinderFor[T]())
+}
+
+// dynamicBinderFor returns a binder that binds the discriminated map into a new T.
+func dynamicBinderFor[T Dynamic]() func(map[string]any) (Dynamic, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return func(m map[string]any) (Dynamic, error) {
+		if t.Kind() == reflect.Ptr {
+			ptr := reflect.New(t.Elem())
+			if err := Bind(ptr.Interface(), m); err != nil {
+				return nil, err
+			}
+			return ptr.Interface().(Dynamic), nil
+		}
+		v, err := New[T](m)
+		if err != nil {
+			return nil, err
+		}
+		if d, ok := any(v).(Dynamic); ok {
+			return d, nil
+		}
+		return *v, nil
+	}
+}
+
+// registeredDynamic returns the binder registered for name with RegisterDynamic, or nil.
+func registeredDynamic(name string) func(map[string]any) (Dynamic, error) {
+	binder, ok := dynamicRegistry.Load(name)
+	if !ok {
+		return nil
+	}
+	return binder.(func(map[string]any) (Dynamic, error))
+}
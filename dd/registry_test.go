@@ -0,0 +1,70 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type registryEmail struct {
+	To string
+}
+
+func (a registryEmail) Type() string { return "registry_email" }
+
+func (a registryEmail) ToMap() (map[string]any, error) { return Unbind(a) }
+
+type registrySlack struct {
+	Channel string
+}
+
+func (a *registrySlack) Type() string { return "registry_slack" }
+
+func (a *registrySlack) ToMap() (map[string]any, error) { return Unbind(a) }
+
+type registryRule struct {
+	Actions []Dynamic
+	Primary Dynamic
+}
+
+func TestRegisterDynamic(t *testing.T) {
+	RegisterDynamic[registryEmail]("registry_email")
+	RegisterDynamic[*registrySlack]("registry_slack")
+	defer dynamicRegistry.Delete("registry_email")
+	defer dynamicRegistry.Delete("registry_slack")
+
+	data := map[string]any{
+		"actions": []any{
+			map[string]any{"type": "registry_email", "to": "ops@example.com"},
+			map[string]any{"type": "registry_slack", "channel": "#alerts"},
+		},
+		"primary": map[string]any{"type": "registry_slack", "channel": "#ops"},
+	}
+	rule, err := New[registryRule](data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Dynamic{&registryEmail{To: "ops@example.com"}, &registrySlack{Channel: "#alerts"}}, rule.Actions)
+	assert.Equal(t, &registrySlack{Channel: "#ops"}, rule.Primary)
+
+	out, err := Unbind(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, data, out)
+}
+
+func TestRegisterDynamicPrecedence(t *testing.T) {
+	RegisterDynamic[registryEmail]("registry_email")
+	defer dynamicRegistry.Delete("registry_email")
+
+	// Options.DynamicBinders wins over the registry
+	opts := &Options{DynamicBinders: map[string]func(map[string]any) (Dynamic, error){
+		"registry_email": func(m map[string]any) (Dynamic, error) {
+			return &registryEmail{To: "override"}, nil
+		},
+	}}
+	rule, err := New[registryRule](map[string]any{"primary": map[string]any{"type": "registry_email", "to": "x"}}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, &registryEmail{To: "override"}, rule.Primary)
+
+	_, err = New[registryRule](map[string]any{"primary": map[string]any{"type": "registry_unknown"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown Dynamic type "registry_unknown"`)
+}
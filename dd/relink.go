@@ -0,0 +1,89 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Relink re-links objects (pointers to structs within a linked graph) after they have changed, without a full Link
+// over the graph: the Identifiable objects within them are registered again, under their current Ids, and the
+// Pointers within them are resolved again against the registry, so that added objects, changed Ids, and new or
+// changed references take effect. references elsewhere in the graph are not revisited, so a Pointer to an object
+// whose Id changed still refers to it until its own holder is relinked.
+//
+// Relink needs the registry kept by Register, or by Link with EnableCaching. path references are resolved against
+// the target of the most recent Link or ResolveReferences, and references with no Identifiable object enclosing them
+// are reported by ReferencesTo with the relinked object as their Source.
+func (l *Linker) Relink(objects ...interface{}) error {
+	if len(objects) == 0 {
+		return &ValidationError{Message: "no targets provided"}
+	}
+	var elems []reflect.Value
+	for i, object := range objects {
+		elem, err := validateTarget(object)
+		if err != nil {
+			return fmt.Errorf("target at index %d: %w", i, err)
+		}
+		elems = append(elems, elem)
+	}
+	return l.resolveLocked(func() error { return l.relink(elems) })
+}
+
+// relink performs Relink with the Linker held.
+func (l *Linker) relink(elems []reflect.Value) error {
+	if l.cache == nil {
+		return fmt.Errorf("no registry available - call Register first")
+	}
+
+	// remove the objects from under their Ids before registering them again, in case the Ids changed
+	var within []reflect.Value
+	for _, elem := range elems {
+		identifiablesWithin(elem, &within)
+	}
+	l.unregisterChanged(within)
+	for _, elem := range elems {
+		l.collectIdentifiableObjects(elem, l.cache)
+	}
+
+	if l.sites == nil {
+		l.sites, l.sited = make(map[any][]linkSite), make(map[any]any)
+	}
+	root := l.root
+	defer func() { l.root = root }()
+	for _, elem := range elems {
+		if !root.IsValid() {
+			l.root = elem
+		}
+		l.owner, l.path, l.ownerPath = elem.Addr(), "", 0
+		if err := l.resolvePointers(elem, l.cache); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unregisterChanged removes objects, pointers to Identifiable objects, from the registry where they are registered
+// under an Id other than their current one.
+func (l *Linker) unregisterChanged(objects []reflect.Value) {
+	changed := make(map[any]bool, len(objects))
+	for _, object := range objects {
+		changed[object.Interface()] = true
+	}
+	stale := make(map[string]reflect.Value)
+	for key, value := range l.cache {
+		if !value.IsValid() || !changed[value.Interface()] {
+			continue
+		}
+		prefix := value.Type().Elem().String() + ":"
+		if !strings.HasPrefix(key, prefix) {
+			continue // the short name, removed along with the qualified one
+		}
+		if id, _ := objectId(value); key != prefix+id {
+			stale[strings.TrimPrefix(key, prefix)] = value
+		}
+	}
+	for id, value := range stale {
+		unregisterIdentifiable(l.cache, value, id)
+	}
+}
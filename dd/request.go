@@ -0,0 +1,121 @@
+package dd
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// requestMaxMemory is the portion of a multipart body held in memory while parsing; larger file parts are stored in
+// temporary files, as with http.Request.FormValue.
+const requestMaxMemory = 32 << 20
+
+// BindRequest binds an HTTP request to target (a pointer to a struct), so a handler can populate and validate its
+// input with one call. the body is decoded according to its Content-Type:
+//
+//   - application/json (or any "+json" type) binds the JSON object in the body
+//   - application/x-www-form-urlencoded and multipart/form-data bind the form values, as with BindQuery
+//
+// query parameters are bound as with BindQuery and merged beneath the body, so a body value wins over a query value
+// for the same field. a request without a body (or with an empty one) binds its query parameters alone; any other
+// content type is a ValidationError. multipart files are not bound, and remain available from r.MultipartForm.
+//
+// converters see r.Context(), as with BindCtx. the body is read in full; wrap it with http.MaxBytesReader to bound
+// its size.
+func BindRequest(target interface{}, r *http.Request, opts ...*Options) error {
+	elem, err := validateTarget(target)
+	if err != nil {
+		return err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return &ValidationError{Message: "nil request provided"}
+	}
+
+	query, err := queryToMap(elem.Type(), r.URL.Query(), opt.naming())
+	if err != nil {
+		return err
+	}
+	data := query
+	if r.Body != nil && r.Body != http.NoBody {
+		contentType := r.Header.Get("Content-Type")
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				return &ConversionError{Message: "failed to read request body", Cause: err}
+			}
+			if len(body) > 0 {
+				var m map[string]any
+				if err := decodeJSON(body, &m, opt); err != nil {
+					return parseError("JSON", body, err)
+				}
+				data = overlayMap(query, m)
+			}
+
+		case mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data":
+			if mediaType == "multipart/form-data" {
+				err = r.ParseMultipartForm(requestMaxMemory)
+			} else {
+				err = r.ParseForm()
+			}
+			if err != nil {
+				return &ConversionError{Type: mediaType, Message: "failed to parse", Cause: err}
+			}
+			// r.PostForm holds the body values alone (multipart values included), so query values are not mixed
+			// into repeated parameters
+			form, err := queryToMap(elem.Type(), r.PostForm, opt.naming())
+			if err != nil {
+				return err
+			}
+			data = overlayMap(query, form)
+
+		case contentType == "":
+			body, err := io.ReadAll(io.LimitReader(r.Body, 1))
+			if err != nil {
+				return &ConversionError{Message: "failed to read request body", Cause: err}
+			}
+			if len(body) > 0 {
+				return &ValidationError{Message: "request body has no content type"}
+			}
+
+		default:
+			return &ValidationError{Message: fmt.Sprintf("unsupported content type %q", contentType)}
+		}
+	}
+	return BindCtx(r.Context(), target, data, opt)
+}
+
+// NewRequest creates and binds a new instance of T from an HTTP request; see BindRequest.
+func NewRequest[T any](r *http.Request, opts ...*Options) (*T, error) {
+	target := new(T)
+	if err := BindRequest(target, r, opts...); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// overlayMap returns base with over laid on top of it: nested objects are merged key by key, and any other value in
+// over replaces the one in base. neither input is modified.
+func overlayMap(base, over map[string]any) map[string]any {
+	out := make(map[string]any, len(base)+len(over))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range over {
+		if overMap, ok := v.(map[string]any); ok {
+			if baseMap, ok := out[k].(map[string]any); ok {
+				out[k] = overlayMap(baseMap, overMap)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package dd
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindRequestJSON(t *testing.T) {
+	body := `{"q": "dd", "server": {"host": "api"}, "tags": ["a"]}`
+	r := httptest.NewRequest(http.MethodPost, "/search?page=3&q=ignored&server.port=8080", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	s, err := NewRequest[querySearch](r)
+	assert.NoError(t, err)
+	assert.Equal(t, "dd", s.Q)
+	assert.Equal(t, 3, s.Page)
+	assert.Equal(t, []string{"a"}, s.Tags)
+	// nested objects merge with query parameters
+	assert.Equal(t, queryServer{Host: "api", Port: 8080}, s.Server)
+}
+
+func TestBindRequestForm(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/search?page=3&tags=q", strings.NewReader("q=dd&tags=a&tags=b&server[port]=81"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s, err := NewRequest[querySearch](r)
+	assert.NoError(t, err)
+	assert.Equal(t, "dd", s.Q)
+	assert.Equal(t, 3, s.Page)
+	assert.Equal(t, []string{"a", "b"}, s.Tags)
+	assert.Equal(t, 81, s.Server.Port)
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	assert.NoError(t, w.WriteField("q", "upload"))
+	assert.NoError(t, w.WriteField("labels.tier", "web"))
+	file, err := w.CreateFormFile("attachment", "a.txt")
+	assert.NoError(t, err)
+	_, _ = file.Write([]byte("contents"))
+	assert.NoError(t, w.Close())
+	r = httptest.NewRequest(http.MethodPost, "/upload?page=1", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	s, err = NewRequest[querySearch](r)
+	assert.NoError(t, err)
+	assert.Equal(t, "upload", s.Q)
+	assert.Equal(t, 1, s.Page)
+	assert.Equal(t, map[string]string{"tier": "web"}, s.Labels)
+	assert.Empty(t, s.Extra)
+	assert.Len(t, r.MultipartForm.File["attachment"], 1)
+}
+
+func TestBindRequestQueryOnly(t *testing.T) {
+	s, err := NewRequest[querySearch](httptest.NewRequest(http.MethodGet, "/search?q=dd&page=2", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "dd", s.Q)
+	assert.Equal(t, 2, s.Page)
+
+	// an empty JSON body binds the query alone
+	r := httptest.NewRequest(http.MethodPost, "/search?q=dd", strings.NewReader(""))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = NewRequest[querySearch](r)
+	assert.NoError(t, err)
+}
+
+func TestBindRequestErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/search", strings.NewReader("<q>dd</q>"))
+	r.Header.Set("Content-Type", "application/xml")
+	_, err := NewRequest[querySearch](r)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), `unsupported content type "application/xml"`)
+
+	r = httptest.NewRequest(http.MethodPost, "/search", strings.NewReader("{"))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = NewRequest[querySearch](r)
+	var conversionErr *ConversionError
+	assert.ErrorAs(t, err, &conversionErr)
+
+	r = httptest.NewRequest(http.MethodPost, "/search", strings.NewReader("q=dd"))
+	_, err = NewRequest[querySearch](r)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no content type")
+
+	// validation applies across body and query
+	r = httptest.NewRequest(http.MethodPost, "/search?server.port=70000", strings.NewReader(`{"q": "dd"}`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = NewRequest[querySearch](r)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "+max=65535")
+
+	// the request context reaches binding
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r = httptest.NewRequest(http.MethodGet, "/search?q=dd", nil).WithContext(ctx)
+	_, err = NewRequest[querySearch](r)
+	assert.ErrorIs(t, err, context.Canceled)
+}
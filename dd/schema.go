@@ -0,0 +1,495 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaDialect is the JSON Schema dialect of the documents produced by Schema.
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaOptions configures Schema.
+type SchemaOptions struct {
+	// Defaults is a value of the schema's type (or a pointer to one) whose unbound field values are emitted as
+	// "default" annotations. secret fields, and empty lists and maps, never receive a default.
+	Defaults any
+
+	// DynamicTypes maps a discriminator string to a prototype of the concrete type bound for it, e.g.
+	// {"file": FileSink{}}; it is the schema counterpart of Options.DynamicBinders. Dynamic fields are described as a
+	// oneOf over these types, each requiring its discriminator under the "type" key.
+	DynamicTypes map[string]any
+
+	// FieldDynamicTypes specifies DynamicTypes per field path, keyed like Options.FieldDynamicBinders. when present for
+	// a field, it takes precedence over DynamicTypes.
+	FieldDynamicTypes map[string]map[string]any
+
+	// NamingStrategy derives property names, matching Options.NamingStrategy; defaults to SnakeCase.
+	NamingStrategy NamingStrategy
+}
+
+// Schema generates a JSON Schema (draft 2020-12) document describing the data accepted by Bind for T, suitable for
+// publishing to editors for autocompletion and validation of YAML or JSON configuration. the result is a document
+// map; marshal it with encoding/json.
+//
+// dd tags are honored: `+required` fields are listed as required, `+doc` becomes the description, `+secret` fields
+// are marked writeOnly, `+oneof` becomes an enum, `+match` a const, `+regex` a pattern, and `+min`/`+max` become
+// minimum/maximum (or length, item, and property counts for strings, slices, and maps). named struct types are
+// described once under "$defs" and referenced, so recursive types are supported. Dynamic fields become a oneOf over
+// SchemaOptions.DynamicTypes.
+//
+// opts are optional; pass nil or omit to use defaults.
+func Schema[T any](opts ...*SchemaOptions) (map[string]any, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, &TypeMismatchError{Expected: "struct", Actual: t.String()}
+	}
+	opt, err := getSchemaOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := schemaDefaults(t, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newSchemaBuilder(opt, "#/$defs/")
+	b.refs[t] = "#"
+	root := b.structSchema(t, t.Name(), defaults)
+	root["$schema"] = SchemaDialect
+	root["title"] = t.Name()
+	if len(b.defs) > 0 {
+		root["$defs"] = b.defs
+	}
+	return root, nil
+}
+
+func getSchemaOptions(opts ...*SchemaOptions) (*SchemaOptions, error) {
+	if len(opts) > 1 {
+		return nil, &ValidationError{Message: fmt.Sprintf("only one option allowed, got %d", len(opts))}
+	}
+	if len(opts) == 0 || opts[0] == nil {
+		return &SchemaOptions{}, nil
+	}
+	return opts[0], nil
+}
+
+// schemaDefaults unbinds SchemaOptions.Defaults, which must hold a value of type t.
+func schemaDefaults(t reflect.Type, opt *SchemaOptions) (map[string]any, error) {
+	if opt.Defaults == nil {
+		return nil, nil
+	}
+	dt := reflect.TypeOf(opt.Defaults)
+	if dt.Kind() == reflect.Ptr {
+		dt = dt.Elem()
+	}
+	if dt != t {
+		return nil, &TypeMismatchError{Path: "defaults", Expected: t.String(), Actual: fmt.Sprintf("%T", opt.Defaults)}
+	}
+	return Unbind(opt.Defaults, &Options{NamingStrategy: opt.NamingStrategy, SecretPolicy: OmitSecrets})
+}
+
+// OpenAPISchema generates OpenAPI 3.1 component schemas for the given struct types (values or pointers), returning
+// the map to be used as "components.schemas" in an API specification. each named struct type reachable from types is
+// described once, keyed by its type name, and referenced as "#/components/schemas/<name>". dd tags are honored as in
+// Schema.
+//
+// types implementing Dynamic (with a value or pointer receiver) are also registered as the concrete alternatives of
+// Dynamic fields, under the discriminator returned by Type() on their zero value; Dynamic fields become a oneOf over
+// them with an OpenAPI discriminator mapping, so polymorphic fields are specified as discriminated unions.
+func OpenAPISchema(types ...any) (map[string]any, error) {
+	var structTypes []reflect.Type
+	dynamicTypes := make(map[string]any)
+	for _, v := range types {
+		if v == nil {
+			return nil, &ValidationError{Message: "nil type provided"}
+		}
+		t := reflect.TypeOf(v)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return nil, &TypeMismatchError{Expected: "named struct", Actual: fmt.Sprintf("%T", v)}
+		}
+		structTypes = append(structTypes, t)
+		if reflect.PointerTo(t).Implements(dynamicInterfaceType) {
+			dynamicTypes[reflect.New(t).Interface().(Dynamic).Type()] = reflect.Zero(t).Interface()
+		}
+	}
+
+	b := newSchemaBuilder(&SchemaOptions{DynamicTypes: dynamicTypes}, "#/components/schemas/")
+	b.discriminators = true
+	for _, t := range structTypes {
+		b.ref(t, t.Name())
+	}
+	return b.defs, nil
+}
+
+// schemaBuilder accumulates the shared definitions of named struct types while a schema is generated.
+type schemaBuilder struct {
+	opt            *SchemaOptions
+	refPrefix      string
+	discriminators bool // emit OpenAPI discriminator objects for Dynamic fields
+	defs           map[string]any
+	refs           map[reflect.Type]string
+}
+
+func newSchemaBuilder(opt *SchemaOptions, refPrefix string) *schemaBuilder {
+	return &schemaBuilder{opt: opt, refPrefix: refPrefix, defs: make(map[string]any), refs: make(map[reflect.Type]string)}
+}
+
+// ref returns a reference to the definition of the named struct type t, generating the definition on first use.
+func (b *schemaBuilder) ref(t reflect.Type, path string) map[string]any {
+	ref, ok := b.refs[t]
+	if !ok {
+		name := b.defName(t)
+		ref = b.refPrefix + name
+		b.refs[t] = ref
+		b.defs[name] = nil // reserve the name while the definition is built
+		b.defs[name] = b.structSchema(t, path, nil)
+	}
+	return map[string]any{"$ref": ref}
+}
+
+// defName returns a definition name for t that is not yet in use.
+func (b *schemaBuilder) defName(t reflect.Type) string {
+	base := strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == '.' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, t.Name())
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := b.defs[name]; !taken {
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+// structSchema describes the struct type t, found at path. defaults holds unbound default values for its fields.
+func (b *schemaBuilder) structSchema(t reflect.Type, path string, defaults map[string]any) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	b.structFields(t, path, defaults, properties, &required)
+	out := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		out["required"] = required
+	}
+	return out
+}
+
+func (b *schemaBuilder) structFields(t reflect.Type, path string, defaults map[string]any, properties map[string]any, required *[]string) {
+	for _, meta := range cachedFields(t) {
+		field := meta.field
+		if field.PkgPath != "" {
+			continue
+		}
+		if isInlined(field, meta.tag) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.structFields(embedded, path, defaults, properties, required)
+			}
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra {
+			continue
+		}
+		name := meta.externalName(b.opt.NamingStrategy)
+
+		var s map[string]any
+		switch {
+		case tag.Raw:
+			s = map[string]any{}
+		case tag.Keyed:
+			s = b.keyedSchema(field.Type, path+"."+field.Name)
+		case tag.Format != "" && isTimeField(field.Type):
+			s = map[string]any{"type": "string"}
+			if tag.Format == TimeFormatUnix || tag.Format == TimeFormatUnixMilli {
+				s["type"] = "integer"
+			}
+		default:
+			s = b.typeSchema(field.Type, path+"."+field.Name)
+		}
+		b.annotate(s, field.Type, tag)
+		if v, ok := defaults[name]; ok && !tag.Secret && !isEmptyDefault(v) {
+			s["default"] = v
+		}
+		properties[name] = s
+		if tag.Required {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// isEmptyDefault reports whether an unbound default carries no information worth publishing.
+func isEmptyDefault(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// typeSchema describes values of type t, found at path.
+func (b *schemaBuilder) typeSchema(t reflect.Type, path string) map[string]any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return map[string]any{"type": "string"}
+	case t == reflect.TypeOf(time.Time{}):
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == bigIntType:
+		return map[string]any{"type": []any{"string", "integer"}}
+	case t == bigFloatType:
+		return map[string]any{"type": []any{"string", "number"}}
+	case t == bigRatType:
+		return map[string]any{"type": []any{"string", "number"}}
+	case isUUIDType(t):
+		return map[string]any{"type": "string", "format": "uuid"}
+	case t == netipAddrType, t == netipAddrPortType, t == netipPrefixType:
+		return map[string]any{"type": "string"}
+	case t == rawType, t == jsonRawMessageType:
+		return map[string]any{}
+	case t == dynamicInterfaceType:
+		return b.dynamicSchema(path)
+	case isPointerType(t):
+		return map[string]any{
+			"type":       "object",
+			"properties": map[string]any{RefKey: map[string]any{"type": "string"}},
+			"required":   []string{RefKey},
+		}
+	case isOptionalType(t):
+		return b.typeSchema(t.Field(0).Type, path)
+	case t.Implements(marshalerInterfaceType), reflect.PointerTo(t).Implements(marshalerInterfaceType),
+		reflect.PointerTo(t).Implements(unmarshalerInterfaceType):
+		return map[string]any{}
+	case isTextType(t):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.typeSchema(t.Elem(), path)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.typeSchema(t.Elem(), path)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t, path, nil)
+		}
+		return b.ref(t, path)
+	}
+	return map[string]any{}
+}
+
+// dynamicTypes returns the concrete types registered for Dynamic values at path.
+func (b *schemaBuilder) dynamicTypes(path string) map[string]any {
+	for _, perField := range matchFieldPath(b.opt.FieldDynamicTypes, path) {
+		if perField != nil {
+			return perField
+		}
+	}
+	return b.opt.DynamicTypes
+}
+
+// keyedSchema describes a +keyed field of type t at path: an object keyed by discriminator, whose values are the
+// corresponding concrete types. slices additionally accept a list of single-key objects.
+func (b *schemaBuilder) keyedSchema(t reflect.Type, path string) map[string]any {
+	entry := map[string]any{"type": "object"}
+	if types := b.dynamicTypes(path); len(types) > 0 {
+		properties := make(map[string]any, len(types))
+		for d, proto := range types {
+			body := map[string]any{"type": "object"}
+			if proto != nil {
+				pt := reflect.TypeOf(proto)
+				for pt.Kind() == reflect.Ptr {
+					pt = pt.Elem()
+				}
+				if pt.Kind() == reflect.Struct && pt.Name() != "" {
+					body = b.ref(pt, pt.Name())
+				}
+			}
+			properties[d] = body
+		}
+		entry["properties"] = properties
+		entry["additionalProperties"] = false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice {
+		return entry
+	}
+	single := map[string]any{"minProperties": 1, "maxProperties": 1}
+	for k, v := range entry {
+		single[k] = v
+	}
+	return map[string]any{"oneOf": []any{entry, map[string]any{"type": "array", "items": single}}}
+}
+
+// dynamicSchema describes a Dynamic field at path as a oneOf over its registered concrete types.
+func (b *schemaBuilder) dynamicSchema(path string) map[string]any {
+	types := b.dynamicTypes(path)
+	if len(types) == 0 {
+		return map[string]any{
+			"type":       "object",
+			"properties": map[string]any{TypeKey: map[string]any{"type": "string"}},
+			"required":   []string{TypeKey},
+		}
+	}
+
+	discriminators := make([]string, 0, len(types))
+	for d := range types {
+		discriminators = append(discriminators, d)
+	}
+	sort.Strings(discriminators)
+	variants := make([]any, 0, len(types))
+	mapping := make(map[string]any)
+	for _, d := range discriminators {
+		variant := map[string]any{
+			"properties": map[string]any{TypeKey: map[string]any{"const": d}},
+			"required":   []string{TypeKey},
+		}
+		if types[d] != nil {
+			t := reflect.TypeOf(types[d])
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t.Kind() == reflect.Struct && t.Name() != "" {
+				variant["$ref"] = b.ref(t, t.Name())["$ref"]
+				mapping[d] = variant["$ref"]
+			}
+		}
+		variants = append(variants, variant)
+	}
+	out := map[string]any{"oneOf": variants}
+	if b.discriminators {
+		discriminator := map[string]any{"propertyName": TypeKey}
+		if len(mapping) > 0 {
+			discriminator["mapping"] = mapping
+		}
+		out["discriminator"] = discriminator
+	}
+	return out
+}
+
+// annotate adds the documentation and constraints from a field's dd tag to its schema s.
+func (b *schemaBuilder) annotate(s map[string]any, t reflect.Type, tag DdTag) {
+	if tag.Doc != "" {
+		s["description"] = tag.Doc
+	}
+	if tag.Secret {
+		s["writeOnly"] = true
+	}
+
+	base := schemaBaseType(t)
+	if tag.HasMatch {
+		s["const"] = schemaLiteral(base, tag.MatchValue)
+	}
+
+	// +regex and +oneof apply to each element of a list
+	values, valueType := s, base
+	if items, ok := s["items"].(map[string]any); ok && (base.Kind() == reflect.Slice || base.Kind() == reflect.Array) {
+		values, valueType = items, schemaBaseType(base.Elem())
+	}
+	if tag.Regex != "" {
+		values["pattern"] = tag.Regex
+	}
+	if tag.OneOf != nil {
+		enum := make([]any, len(tag.OneOf))
+		for i, v := range tag.OneOf {
+			enum[i] = schemaLiteral(valueType, v)
+		}
+		values["enum"] = enum
+	}
+
+	if tag.Min != "" || tag.Max != "" {
+		minKey, maxKey, length := "minimum", "maximum", false
+		switch {
+		case base == reflect.TypeOf(time.Duration(0)):
+			return // durations are strings; their bounds cannot be expressed
+		case base.Kind() == reflect.String:
+			minKey, maxKey, length = "minLength", "maxLength", true
+		case base.Kind() == reflect.Slice || base.Kind() == reflect.Array:
+			minKey, maxKey, length = "minItems", "maxItems", true
+		case base.Kind() == reflect.Map:
+			minKey, maxKey, length = "minProperties", "maxProperties", true
+		}
+		for _, bound := range []struct{ key, value string }{{minKey, tag.Min}, {maxKey, tag.Max}} {
+			if bound.value == "" {
+				continue
+			}
+			if length {
+				if n, err := strconv.Atoi(bound.value); err == nil {
+					s[bound.key] = n
+				}
+			} else if n, err := strconv.ParseFloat(bound.value, 64); err == nil {
+				s[bound.key] = n
+			}
+		}
+	}
+}
+
+// schemaBaseType strips pointers and Optional wrappers from t.
+func schemaBaseType(t reflect.Type) reflect.Type {
+	for {
+		switch {
+		case t.Kind() == reflect.Ptr:
+			t = t.Elem()
+		case isOptionalType(t):
+			t = t.Field(0).Type
+		default:
+			return t
+		}
+	}
+}
+
+// schemaLiteral converts a tag value to a JSON value matching type t, falling back to the string itself.
+func schemaLiteral(t reflect.Type, s string) any {
+	switch t.Kind() {
+	case reflect.Bool:
+		if v, err := strconv.ParseBool(s); err == nil {
+			return v
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == reflect.TypeOf(time.Duration(0)) {
+			return s
+		}
+		if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err := strconv.ParseFloat(s, 64); err == nil {
+			return v
+		}
+	}
+	return s
+}
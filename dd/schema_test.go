@@ -0,0 +1,152 @@
+package dd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaDatabase struct {
+	Host     string `dd:",+required,+doc=\"database host\""`
+	Port     uint16 `dd:",+min=1,+max=65535"`
+	Password string `dd:",+secret"`
+}
+
+type schemaSink struct {
+	Path string
+}
+
+func (s *schemaSink) Type() string                   { return "file" }
+func (s *schemaSink) ToMap() (map[string]any, error) { return Unbind(s) }
+
+type schemaNode struct {
+	Name     string
+	Children []schemaNode
+}
+
+type schemaConfig struct {
+	Kind     string   `dd:",+match=\"config\""`
+	Level    string   `dd:",+oneof=debug|info|warn"`
+	Retries  int      `dd:",+oneof=1|3|5"`
+	Tags     []string `dd:",+regex=\"^[a-z]+$\",+max=4"`
+	Timeout  time.Duration
+	Database schemaDatabase
+	Replica  *schemaDatabase
+	Labels   map[string]string
+	Sink     Dynamic
+	Tree     schemaNode
+	Skipped  string `dd:"-"`
+}
+
+func TestSchema(t *testing.T) {
+	schema, err := Schema[schemaConfig](&SchemaOptions{
+		Defaults:     &schemaConfig{Level: "info", Timeout: 30 * time.Second, Database: schemaDatabase{Password: "secret"}},
+		DynamicTypes: map[string]any{"file": schemaSink{}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaDialect, schema["$schema"])
+	assert.Equal(t, "schemaConfig", schema["title"])
+	assert.Equal(t, "object", schema["type"])
+
+	props := schema["properties"].(map[string]any)
+	assert.NotContains(t, props, "skipped")
+	assert.Equal(t, map[string]any{"type": "string", "const": "config", "default": ""}, props["kind"])
+	assert.Equal(t, map[string]any{"type": "string", "enum": []any{"debug", "info", "warn"}, "default": "info"}, props["level"])
+	assert.Equal(t, []any{int64(1), int64(3), int64(5)}, props["retries"].(map[string]any)["enum"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string", "pattern": "^[a-z]+$"}, "maxItems": 4}, props["tags"])
+	assert.Equal(t, "30s", props["timeout"].(map[string]any)["default"])
+	assert.Equal(t, map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}, props["labels"])
+	assert.Equal(t, "#/$defs/schemaDatabase", props["database"].(map[string]any)["$ref"])
+	assert.Equal(t, map[string]any{"host": "", "port": uint16(0)}, props["database"].(map[string]any)["default"])
+	assert.Equal(t, "#/$defs/schemaDatabase", props["replica"].(map[string]any)["$ref"])
+	assert.Equal(t, "#/$defs/schemaNode", props["tree"].(map[string]any)["$ref"])
+
+	sink := props["sink"].(map[string]any)["oneOf"].([]any)
+	assert.Len(t, sink, 1)
+	assert.Equal(t, map[string]any{
+		"$ref":       "#/$defs/schemaSink",
+		"properties": map[string]any{"type": map[string]any{"const": "file"}},
+		"required":   []string{"type"},
+	}, sink[0])
+
+	defs := schema["$defs"].(map[string]any)
+	db := defs["schemaDatabase"].(map[string]any)
+	assert.Equal(t, []string{"host"}, db["required"])
+	dbProps := db["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "description": "database host"}, dbProps["host"])
+	assert.Equal(t, map[string]any{"type": "integer", "minimum": 1.0, "maximum": 65535.0}, dbProps["port"])
+	assert.Equal(t, map[string]any{"type": "string", "writeOnly": true}, dbProps["password"])
+
+	// recursive types refer to their own definition
+	node := defs["schemaNode"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/$defs/schemaNode"}, node["children"].(map[string]any)["items"])
+
+	_, err = json.Marshal(schema)
+	assert.NoError(t, err)
+}
+
+func TestSchemaNaming(t *testing.T) {
+	schema, err := Schema[schemaDatabase](&SchemaOptions{NamingStrategy: CamelCase})
+	assert.NoError(t, err)
+	props := schema["properties"].(map[string]any)
+	assert.Contains(t, props, "host")
+	assert.Contains(t, props, "password")
+	assert.NotContains(t, schema, "$defs")
+}
+
+func TestSchemaErrors(t *testing.T) {
+	_, err := Schema[string]()
+	assert.Error(t, err)
+
+	_, err = Schema[schemaConfig](&SchemaOptions{Defaults: &schemaDatabase{}})
+	assert.Error(t, err)
+}
+
+type openAPIConsole struct {
+	Color bool
+}
+
+func (c openAPIConsole) Type() string                   { return "console" }
+func (c openAPIConsole) ToMap() (map[string]any, error) { return Unbind(c) }
+
+type openAPIService struct {
+	Name  string `dd:",+required"`
+	Sinks []Dynamic
+	Owner schemaDatabase
+}
+
+func TestOpenAPISchema(t *testing.T) {
+	schemas, err := OpenAPISchema(openAPIService{}, &schemaSink{}, openAPIConsole{})
+	assert.NoError(t, err)
+	assert.Len(t, schemas, 4)
+	assert.Contains(t, schemas, "schemaDatabase")
+	assert.Contains(t, schemas, "schemaSink")
+	assert.Contains(t, schemas, "openAPIConsole")
+
+	service := schemas["openAPIService"].(map[string]any)
+	assert.Equal(t, []string{"name"}, service["required"])
+	props := service["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/schemaDatabase"}, props["owner"])
+
+	sinks := props["sinks"].(map[string]any)["items"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"propertyName": "type",
+		"mapping": map[string]any{
+			"console": "#/components/schemas/openAPIConsole",
+			"file":    "#/components/schemas/schemaSink",
+		},
+	}, sinks["discriminator"])
+	variants := sinks["oneOf"].([]any)
+	assert.Len(t, variants, 2)
+	assert.Equal(t, "#/components/schemas/openAPIConsole", variants[0].(map[string]any)["$ref"])
+}
+
+func TestOpenAPISchemaErrors(t *testing.T) {
+	_, err := OpenAPISchema(nil)
+	assert.Error(t, err)
+
+	_, err = OpenAPISchema("not a struct")
+	assert.Error(t, err)
+}
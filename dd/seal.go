@@ -0,0 +1,248 @@
+package dd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SealedPrefix marks a secret value encrypted by Seal.
+const SealedPrefix = "enc:v1:"
+
+// KeyProvider supplies the AES key used by Seal and Open. the key must be 16, 24, or 32 bytes long, selecting
+// AES-128, AES-192, or AES-256.
+type KeyProvider interface {
+	Key() ([]byte, error)
+}
+
+// StaticKey is a KeyProvider that returns a fixed key.
+type StaticKey []byte
+
+// Key implements KeyProvider.
+func (k StaticKey) Key() ([]byte, error) {
+	return k, nil
+}
+
+// KeyProviderFunc adapts a function to the KeyProvider interface, e.g. to read a key from the environment or a
+// secrets manager.
+type KeyProviderFunc func() ([]byte, error)
+
+// Key implements KeyProvider.
+func (f KeyProviderFunc) Key() ([]byte, error) {
+	return f()
+}
+
+// Seal unbinds source exactly like Unbind, then encrypts the value of every `+secret` field with AES-GCM, so the
+// resulting document can be saved and committed without exposing credentials. non-secret fields are left in the
+// clear. each sealed value is a string of the form "enc:v1:<base64>"; see Open.
+//
+// secret fields inside nested structs, and inside structs held in slices and maps, are sealed as well.
+func Seal(source interface{}, keys KeyProvider, opts ...*Options) (map[string]any, error) {
+	data, err := Unbind(source, opts...)
+	if err != nil {
+		return nil, err
+	}
+	opt, _ := getOptions(opts...)
+	return sealSecrets(reflect.TypeOf(source), data, keys, opt.naming())
+}
+
+// Open decrypts the sealed `+secret` values in data (as produced by Seal) and binds the result into target exactly like
+// Bind. secret fields holding plain (unsealed) values are bound as-is, so a document can be sealed incrementally.
+func Open(target interface{}, data map[string]any, keys KeyProvider, opts ...*Options) error {
+	if _, err := validateTarget(target); err != nil {
+		return err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	opened, err := openSecrets(reflect.TypeOf(target), data, keys, opt.naming())
+	if err != nil {
+		return err
+	}
+	return Bind(target, opened, opts...)
+}
+
+// sealSecrets returns a copy of data, as unbound from a value of type t, with every `+secret` value encrypted.
+func sealSecrets(t reflect.Type, data map[string]any, keys KeyProvider, naming NamingStrategy) (map[string]any, error) {
+	aead, err := sealCipher(keys)
+	if err != nil {
+		return nil, err
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return transformSecrets(t, data, "", naming, func(path string, v any) (any, error) {
+		if s, ok := v.(string); ok && strings.HasPrefix(s, SealedPrefix) {
+			return v, nil // already sealed
+		}
+		plain, err := json.Marshal(v)
+		if err != nil {
+			return nil, &ConversionError{Path: path, Type: "sealed secret", Message: "encoding secret", Cause: err}
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, &ConversionError{Path: path, Type: "sealed secret", Message: "generating nonce", Cause: err}
+		}
+		return SealedPrefix + base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plain, nil)), nil
+	})
+}
+
+// openSecrets returns a copy of data, to be bound into a value of type t, with every sealed `+secret` value decrypted.
+func openSecrets(t reflect.Type, data map[string]any, keys KeyProvider, naming NamingStrategy) (map[string]any, error) {
+	aead, err := sealCipher(keys)
+	if err != nil {
+		return nil, err
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return transformSecrets(t, data, "", naming, func(path string, v any) (any, error) {
+		s, ok := v.(string)
+		if !ok || !strings.HasPrefix(s, SealedPrefix) {
+			return v, nil
+		}
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, SealedPrefix))
+		if err != nil || len(sealed) < aead.NonceSize() {
+			return nil, &ConversionError{Path: path, Type: "sealed secret", Message: "malformed sealed value", Cause: err}
+		}
+		plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+		if err != nil {
+			return nil, &ConversionError{Path: path, Type: "sealed secret", Message: "decrypting secret", Cause: err}
+		}
+		var out any
+		if err := json.Unmarshal(plain, &out); err != nil {
+			return nil, &ConversionError{Path: path, Type: "sealed secret", Message: "decoding secret", Cause: err}
+		}
+		return out, nil
+	})
+}
+
+func sealCipher(keys KeyProvider) (cipher.AEAD, error) {
+	if keys == nil {
+		return nil, &ValidationError{Message: "nil key provider"}
+	}
+	key, err := keys.Key()
+	if err != nil {
+		return nil, &ValidationError{Message: fmt.Sprintf("obtaining key: %v", err)}
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, &ValidationError{Message: fmt.Sprintf("invalid key: %v", err)}
+	}
+	return cipher.NewGCM(block)
+}
+
+// transformSecrets returns a copy of data, as unbound from a struct of type t, in which the value of every `+secret`
+// field has been replaced by fn. nested structs, and structs inside slices and maps, are visited as well.
+func transformSecrets(t reflect.Type, data map[string]any, prefix string, naming NamingStrategy, fn func(path string, v any) (any, error)) (map[string]any, error) {
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		out[k] = v
+	}
+	if err := transformSecretFields(t, out, prefix, naming, fn); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// transformSecretFields rewrites the fields of struct type t in place within out; embedded structs share the map.
+func transformSecretFields(t reflect.Type, out map[string]any, prefix string, naming NamingStrategy, fn func(path string, v any) (any, error)) error {
+	for _, meta := range cachedFields(t) {
+		field := meta.field
+		if field.PkgPath != "" {
+			continue
+		}
+		if isInlined(field, meta.tag) {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := transformSecretFields(ft, out, prefix, naming, fn); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra {
+			continue
+		}
+		name := meta.externalName(naming)
+		v, ok := out[name]
+		if !ok || v == nil {
+			continue
+		}
+		path := joinProvenancePath(prefix, name)
+		var err error
+		if tag.Secret {
+			out[name], err = fn(path, v)
+		} else {
+			out[name], err = transformSecretValue(field.Type, v, path, naming, fn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transformSecretValue descends into v according to t, looking for structs that hold secret fields.
+func transformSecretValue(t reflect.Type, v any, path string, naming NamingStrategy, fn func(path string, v any) (any, error)) (any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case isProvenanceStruct(t):
+		if m, ok := v.(map[string]any); ok {
+			return transformSecrets(t, m, path, naming, fn)
+		}
+
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice || !containsStructs(t.Elem()) {
+			return v, nil
+		}
+		items := make([]any, rv.Len())
+		for i := range items {
+			item, err := transformSecretValue(t.Elem(), rv.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i), naming, fn)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+
+	case t.Kind() == reflect.Map:
+		if m, ok := v.(map[string]any); ok {
+			out := make(map[string]any, len(m))
+			for k, item := range m {
+				transformed, err := transformSecretValue(t.Elem(), item, joinProvenancePath(path, k), naming, fn)
+				if err != nil {
+					return nil, err
+				}
+				out[k] = transformed
+			}
+			return out, nil
+		}
+	}
+	return v, nil
+}
+
+// containsStructs reports whether values of type t can hold bindable structs.
+func containsStructs(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return containsStructs(t.Elem())
+	}
+	return isProvenanceStruct(t)
+}
@@ -0,0 +1,127 @@
+package dd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sealDatabase struct {
+	Host     string
+	Password string `dd:",+secret"`
+}
+
+type sealConfig struct {
+	Name      string
+	Token     string `dd:",+secret"`
+	Pin       int    `dd:",+secret"`
+	Database  *sealDatabase
+	Replicas  []sealDatabase
+	Upstreams map[string]*sealDatabase
+}
+
+var sealKey = StaticKey([]byte("0123456789abcdef0123456789abcdef"))
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	cfg := &sealConfig{
+		Name:      "app",
+		Token:     "t0ken",
+		Pin:       1234,
+		Database:  &sealDatabase{Host: "db", Password: "hunter2"},
+		Replicas:  []sealDatabase{{Host: "r1", Password: "r1pass"}},
+		Upstreams: map[string]*sealDatabase{"auth": {Host: "auth", Password: "authpass"}},
+	}
+
+	sealed, err := Seal(cfg, sealKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "app", sealed["name"])
+	assert.True(t, strings.HasPrefix(sealed["token"].(string), SealedPrefix))
+	assert.True(t, strings.HasPrefix(sealed["pin"].(string), SealedPrefix))
+	db := sealed["database"].(map[string]any)
+	assert.Equal(t, "db", db["host"])
+	assert.True(t, strings.HasPrefix(db["password"].(string), SealedPrefix))
+	replica := sealed["replicas"].([]any)[0].(map[string]any)
+	assert.True(t, strings.HasPrefix(replica["password"].(string), SealedPrefix))
+	upstream := sealed["upstreams"].(map[string]any)["auth"].(map[string]any)
+	assert.True(t, strings.HasPrefix(upstream["password"].(string), SealedPrefix))
+
+	// the source is untouched
+	assert.Equal(t, "t0ken", cfg.Token)
+
+	opened := &sealConfig{}
+	assert.NoError(t, Open(opened, sealed, sealKey))
+	assert.Equal(t, cfg, opened)
+}
+
+func TestOpenPlainSecrets(t *testing.T) {
+	cfg := &sealConfig{}
+	assert.NoError(t, Open(cfg, map[string]any{"token": "plain"}, sealKey))
+	assert.Equal(t, "plain", cfg.Token)
+}
+
+func TestOpenWrongKey(t *testing.T) {
+	sealed, err := Seal(&sealConfig{Token: "t0ken"}, sealKey)
+	assert.NoError(t, err)
+
+	err = Open(&sealConfig{}, sealed, StaticKey([]byte("fedcba9876543210fedcba9876543210")))
+	var ce *ConversionError
+	if assert.True(t, errors.As(err, &ce)) {
+		assert.Equal(t, "token", ce.Path)
+	}
+}
+
+func TestSealInvalidKey(t *testing.T) {
+	_, err := Seal(&sealConfig{}, StaticKey([]byte("short")))
+	assert.Error(t, err)
+
+	_, err = Seal(&sealConfig{}, KeyProviderFunc(func() ([]byte, error) { return nil, errors.New("vault unavailable") }))
+	assert.Contains(t, err.Error(), "vault unavailable")
+
+	_, err = Seal(&sealConfig{}, nil)
+	assert.Error(t, err)
+}
+
+func TestSecretKeysOption(t *testing.T) {
+	cfg := &sealConfig{
+		Name:      "app",
+		Token:     "t0ken",
+		Pin:       1234,
+		Database:  &sealDatabase{Host: "db", Password: "hunter2"},
+		Replicas:  []sealDatabase{},
+		Upstreams: map[string]*sealDatabase{},
+	}
+	opts := &Options{SecretKeys: sealKey}
+
+	data, err := UnbindYAML(cfg, opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "t0ken")
+	assert.NotContains(t, string(data), "hunter2")
+	assert.Contains(t, string(data), SealedPrefix)
+
+	loaded, err := NewYAML[sealConfig](data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+
+	// merging a sealed overlay decrypts it too
+	overlay, err := Seal(&sealConfig{Token: "rotated"}, sealKey)
+	assert.NoError(t, err)
+	assert.NoError(t, Merge(loaded, map[string]any{"token": overlay["token"]}, opts))
+	assert.Equal(t, "rotated", loaded.Token)
+
+	// masking takes precedence over sealing
+	masked, err := Unbind(cfg, &Options{SecretKeys: sealKey, SecretPolicy: MaskSecrets})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultSecretPlaceholder, masked["token"])
+
+	// fingerprints and diffs stay stable
+	fa, err := Fingerprint(cfg, &FingerprintOptions{Options: opts})
+	assert.NoError(t, err)
+	fb, err := Fingerprint(cfg, &FingerprintOptions{Options: opts})
+	assert.NoError(t, err)
+	assert.Equal(t, fa, fb)
+	diff, err := Diff(cfg, cfg, opts)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+}
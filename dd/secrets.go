@@ -0,0 +1,23 @@
+package dd
+
+// SecretPolicy controls how Unbind emits `+secret` fields.
+type SecretPolicy int
+
+const (
+	// PlainSecrets emits secret values in the clear (the default), so the result can be bound again.
+	PlainSecrets SecretPolicy = iota
+	// MaskSecrets replaces each secret value with Options.SecretPlaceholder.
+	MaskSecrets
+	// OmitSecrets leaves secret fields out entirely.
+	OmitSecrets
+)
+
+// DefaultSecretPlaceholder is the value emitted for secret fields under MaskSecrets when no placeholder is configured.
+const DefaultSecretPlaceholder = "<redacted>"
+
+func (o *Options) secretPlaceholder() string {
+	if o.SecretPlaceholder != "" {
+		return o.SecretPlaceholder
+	}
+	return DefaultSecretPlaceholder
+}
@@ -0,0 +1,70 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type secretsDatabase struct {
+	Host     string
+	Password string `dd:",+secret"`
+}
+
+type secretsConfig struct {
+	Name     string
+	Token    string  `dd:",+secret"`
+	Optional *string `dd:",+secret"`
+	Database secretsDatabase
+}
+
+func TestUnbindSecretPolicy(t *testing.T) {
+	cfg := &secretsConfig{Name: "app", Token: "t0ken", Database: secretsDatabase{Host: "db", Password: "hunter2"}}
+
+	plain, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "t0ken", plain["token"])
+
+	masked, err := Unbind(cfg, &Options{SecretPolicy: MaskSecrets})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":     "app",
+		"token":    "<redacted>",
+		"database": map[string]any{"host": "db", "password": "<redacted>"},
+	}, masked)
+
+	masked, err = Unbind(cfg, &Options{SecretPolicy: MaskSecrets, SecretPlaceholder: "***"})
+	assert.NoError(t, err)
+	assert.Equal(t, "***", masked["token"])
+
+	omitted, err := Unbind(cfg, &Options{SecretPolicy: OmitSecrets})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":     "app",
+		"database": map[string]any{"host": "db"},
+	}, omitted)
+}
+
+func TestUnbindSecretMasker(t *testing.T) {
+	cfg := &secretsConfig{Name: "app", Token: "abcd1234", Database: secretsDatabase{Host: "db", Password: "hunter2"}}
+	masker := func(value any) any {
+		s, _ := value.(string)
+		if len(s) <= 4 {
+			return "****"
+		}
+		return "****" + s[len(s)-4:]
+	}
+
+	masked, err := Unbind(cfg, &Options{SecretPolicy: MaskSecrets, SecretMasker: masker})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":     "app",
+		"token":    "****1234",
+		"database": map[string]any{"host": "db", "password": "****ter2"},
+	}, masked)
+
+	// the masker is only consulted under MaskSecrets
+	plain, err := Unbind(cfg, &Options{SecretMasker: masker})
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd1234", plain["token"])
+}
@@ -0,0 +1,391 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SkeletonFormat selects the output format for Skeleton.
+type SkeletonFormat string
+
+const (
+	SkeletonYAML SkeletonFormat = "yaml"
+	SkeletonJSON SkeletonFormat = "json"
+)
+
+// Skeleton generates a sample configuration document for T containing every bindable field. values are taken from
+// defaults when provided (the first element is used), otherwise from the zero value of T. nil nested struct pointers
+// are expanded so that the full structure is visible, and an empty list of structs holds a single example element.
+//
+// YAML output annotates each key with a comment describing the field's type, its `+doc` description, and whether it
+// is required. JSON has no comment syntax, so JSON output contains only the keys and default values, in declaration
+// order.
+//
+// fields marked `+secret` are emitted as DefaultSecretPlaceholder rather than their default value.
+//
+// useful for "init-config" style commands that write a starter configuration for users to edit.
+func Skeleton[T any](format SkeletonFormat, defaults ...*T) ([]byte, error) {
+	var proto reflect.Value
+	if len(defaults) > 0 && defaults[0] != nil {
+		proto = reflect.ValueOf(defaults[0]).Elem()
+	} else {
+		proto = reflect.New(reflect.TypeOf((*T)(nil)).Elem()).Elem()
+	}
+	if proto.Kind() != reflect.Struct {
+		return nil, &TypeMismatchError{Expected: "struct", Actual: proto.Type().String()}
+	}
+
+	root, err := skeletonStruct(proto, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case SkeletonYAML:
+		node, err := root.yamlNode()
+		if err != nil {
+			return nil, &ConversionError{Type: "YAML", Message: "failed to marshal", Cause: err}
+		}
+		doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		if err := enc.Encode(doc); err != nil {
+			return nil, &ConversionError{Type: "YAML", Message: "failed to marshal", Cause: err}
+		}
+		if err := enc.Close(); err != nil {
+			return nil, &ConversionError{Type: "YAML", Message: "failed to marshal", Cause: err}
+		}
+		return buf.Bytes(), nil
+
+	case SkeletonJSON:
+		var buf bytes.Buffer
+		if err := root.writeJSON(&buf, 0); err != nil {
+			return nil, &ConversionError{Type: "JSON", Message: "failed to marshal", Cause: err}
+		}
+		buf.WriteString("\n")
+		return buf.Bytes(), nil
+
+	default:
+		return nil, &UnsupportedError{Operation: fmt.Sprintf("skeleton format %q", format)}
+	}
+}
+
+// skeletonNode is an ordered document tree used to render skeletons; either a mapping (fields != nil), a list of
+// structs (items != nil), or a leaf value.
+type skeletonNode struct {
+	comment string
+	fields  []skeletonField
+	items   []*skeletonNode
+	value   any
+}
+
+type skeletonField struct {
+	key  string
+	node *skeletonNode
+}
+
+const skeletonMaxDepth = 10
+
+func skeletonStruct(structVal reflect.Value, depth int) (*skeletonNode, error) {
+	node := &skeletonNode{fields: []skeletonField{}}
+	if depth > skeletonMaxDepth {
+		return node, nil
+	}
+	if err := skeletonFields(structVal, depth, node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func skeletonFields(structVal reflect.Value, depth int, node *skeletonNode) error {
+	structType := structVal.Type()
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		fieldVal := structVal.Field(meta.index)
+
+		// embedded structs share the parent namespace
+		if isInlined(field, meta.tag) {
+			embeddedVal := fieldVal
+			if field.Type.Kind() == reflect.Ptr {
+				if fieldVal.IsNil() {
+					embeddedVal = reflect.New(field.Type.Elem()).Elem()
+				} else {
+					embeddedVal = fieldVal.Elem()
+				}
+			}
+			if embeddedVal.Kind() == reflect.Struct {
+				if err := skeletonFields(embeddedVal, depth, node); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		tag := meta.tag
+		if tag.Skip || tag.Extra {
+			continue
+		}
+		name := meta.externalName(nil)
+
+		child, err := skeletonValue(fieldVal, tag, depth)
+		if err != nil {
+			return &UnbindingError{Path: structType.Name(), Field: field.Name, Key: name, Cause: err}
+		}
+		child.comment = skeletonComment(field.Type, tag)
+		node.fields = append(node.fields, skeletonField{key: name, node: child})
+	}
+	return nil
+}
+
+func skeletonValue(v reflect.Value, tag DdTag, depth int) (*skeletonNode, error) {
+	if tag.Secret {
+		return &skeletonNode{value: DefaultSecretPlaceholder}, nil
+	}
+	if tag.Raw {
+		return &skeletonNode{value: nil}, nil
+	}
+
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.New(t.Elem()).Elem()
+		} else {
+			v = v.Elem()
+		}
+		t = v.Type()
+	}
+	if isOptionalType(t) {
+		return skeletonValue(v.Field(0), tag, depth)
+	}
+
+	if isSkeletonStruct(t) {
+		return skeletonStruct(v, depth+1)
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		elem := t.Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if isSkeletonStruct(elem) {
+			return skeletonList(v, depth)
+		}
+	}
+	if isPointerType(t) {
+		return &skeletonNode{fields: []skeletonField{{key: RefKey, node: &skeletonNode{value: v.FieldByName("Ref").String()}}}}, nil
+	}
+	if t.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return &skeletonNode{value: nil}, nil
+		}
+	}
+
+	out, present, err := valueToInterface(v, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !present {
+		out = nil
+	}
+	if out == nil {
+		switch t.Kind() {
+		case reflect.Slice:
+			out = []any{}
+		case reflect.Map:
+			out = map[string]any{}
+		}
+	}
+	return &skeletonNode{value: out}, nil
+}
+
+// isSkeletonStruct reports whether values of type t are rendered field by field.
+func isSkeletonStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isPointerType(t) && !isScalarStruct(t) &&
+		!t.Implements(dynamicInterfaceType) && !t.Implements(marshalerInterfaceType) && !reflect.PointerTo(t).Implements(marshalerInterfaceType)
+}
+
+// skeletonList renders a list of structs element by element, or as a single example element when it is empty.
+func skeletonList(v reflect.Value, depth int) (*skeletonNode, error) {
+	node := &skeletonNode{items: []*skeletonNode{}}
+	if v.Len() == 0 {
+		example, err := skeletonValue(reflect.New(v.Type().Elem()).Elem(), DdTag{}, depth)
+		if err != nil {
+			return nil, err
+		}
+		node.items = append(node.items, example)
+		return node, nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		item, err := skeletonValue(v.Index(i), DdTag{}, depth)
+		if err != nil {
+			return nil, &IndexError{Index: i, Cause: err}
+		}
+		node.items = append(node.items, item)
+	}
+	return node, nil
+}
+
+// skeletonComment describes a field for the YAML skeleton.
+func skeletonComment(t reflect.Type, tag DdTag) string {
+	var parts []string
+	parts = append(parts, describeType(t))
+	if tag.Required {
+		parts = append(parts, "required")
+	}
+	if tag.Secret {
+		parts = append(parts, "secret")
+	}
+	comment := "(" + strings.Join(parts, ", ") + ")"
+	if tag.Doc != "" {
+		comment = tag.Doc + " " + comment
+	}
+	return comment
+}
+
+// describeType returns a short, user-facing description of a field type.
+func describeType(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return describeType(t.Elem())
+	}
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return "duration"
+	case t == reflect.TypeOf(time.Time{}):
+		return "time (RFC3339)"
+	case t == bigIntType:
+		return "integer (arbitrary precision)"
+	case t == bigFloatType:
+		return "number (arbitrary precision)"
+	case t == bigRatType:
+		return "rational (arbitrary precision)"
+	case t == rawType || t == jsonRawMessageType:
+		return "any (passed through verbatim)"
+	case isUUIDType(t):
+		return "uuid"
+	case t == netipAddrType:
+		return "ip address"
+	case t == netipAddrPortType:
+		return "ip address and port"
+	case t == netipPrefixType:
+		return "ip prefix (CIDR)"
+	case t == dynamicInterfaceType:
+		return "dynamic object with '" + TypeKey + "' discriminator"
+	case isPointerType(t):
+		return "reference"
+	case isOptionalType(t):
+		return describeType(t.Field(0).Type)
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		return "list of " + describeType(t.Elem())
+	case reflect.Map:
+		return "map of " + describeType(t.Key()) + " to " + describeType(t.Elem())
+	case reflect.Struct:
+		return "object"
+	case reflect.Interface:
+		return "any"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return t.Kind().String()
+}
+
+func (n *skeletonNode) yamlNode() (*yaml.Node, error) {
+	if n.items != nil {
+		out := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range n.items {
+			value, err := item.yamlNode()
+			if err != nil {
+				return nil, err
+			}
+			out.Content = append(out.Content, value)
+		}
+		return out, nil
+	}
+	if n.fields == nil {
+		out := &yaml.Node{}
+		if err := out.Encode(n.value); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, f := range n.fields {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.key, HeadComment: f.node.comment}
+		value, err := f.node.yamlNode()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.key, err)
+		}
+		out.Content = append(out.Content, key, value)
+	}
+	return out, nil
+}
+
+func (n *skeletonNode) writeJSON(buf *bytes.Buffer, depth int) error {
+	if n.items != nil {
+		buf.WriteString("[\n")
+		for i, item := range n.items {
+			buf.WriteString(strings.Repeat("  ", depth+1))
+			if err := item.writeJSON(buf, depth+1); err != nil {
+				return err
+			}
+			if i < len(n.items)-1 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(strings.Repeat("  ", depth))
+		buf.WriteString("]")
+		return nil
+	}
+	if n.fields == nil {
+		return writeSkeletonJSONValue(buf, n.value, depth)
+	}
+	if len(n.fields) == 0 {
+		buf.WriteString("{}")
+		return nil
+	}
+	buf.WriteString("{\n")
+	for i, f := range n.fields {
+		buf.WriteString(strings.Repeat("  ", depth+1))
+		if err := writeSkeletonJSONValue(buf, f.key, depth+1); err != nil {
+			return err
+		}
+		buf.WriteString(": ")
+		if err := f.node.writeJSON(buf, depth+1); err != nil {
+			return err
+		}
+		if i < len(n.fields)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString(strings.Repeat("  ", depth))
+	buf.WriteString("}")
+	return nil
+}
+
+// writeSkeletonJSONValue writes v as indented JSON at depth, without escaping HTML characters such as those of the
+// secret placeholder.
+func writeSkeletonJSONValue(buf *bytes.Buffer, v any, depth int) error {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(strings.Repeat("  ", depth), "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimSuffix(out.Bytes(), []byte("\n")))
+	return nil
+}
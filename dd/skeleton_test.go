@@ -0,0 +1,171 @@
+package dd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type skeletonDatabase struct {
+	Host     string `dd:"host,+doc=\"database hostname\""`
+	Port     int    `dd:"port,+required"`
+	Password string `dd:"password,+secret"`
+}
+
+type skeletonConfig struct {
+	Name     string            `dd:"name,+doc=\"application name, shown in logs\""`
+	Timeout  time.Duration     `dd:"timeout"`
+	Database *skeletonDatabase `dd:"database"`
+	Features []string          `dd:"features"`
+	Internal string            `dd:"-"`
+}
+
+func TestSkeletonYAML(t *testing.T) {
+	out, err := Skeleton[skeletonConfig](SkeletonYAML, &skeletonConfig{
+		Name:    "myapp",
+		Timeout: 30 * time.Second,
+	})
+	assert.Nil(t, err)
+
+	expected := `# application name, shown in logs (string)
+name: myapp
+# (duration)
+timeout: 30s
+# (object)
+database:
+    # database hostname (string)
+    host: ""
+    # (integer, required)
+    port: 0
+    # (string, secret)
+    password: <redacted>
+# (list of string)
+features: []
+`
+	assert.Equal(t, expected, string(out))
+}
+
+func TestSkeletonJSON(t *testing.T) {
+	out, err := Skeleton[skeletonConfig](SkeletonJSON)
+	assert.Nil(t, err)
+
+	// declaration order is preserved
+	assert.True(t, strings.Index(string(out), `"name"`) < strings.Index(string(out), `"timeout"`))
+	assert.True(t, strings.Index(string(out), `"timeout"`) < strings.Index(string(out), `"database"`))
+
+	var m map[string]any
+	assert.Nil(t, json.Unmarshal(out, &m))
+	assert.Equal(t, map[string]any{
+		"name":    "",
+		"timeout": "0s",
+		"database": map[string]any{
+			"host":     "",
+			"port":     float64(0),
+			"password": DefaultSecretPlaceholder,
+		},
+		"features": []any{},
+	}, m)
+}
+
+func TestSkeletonJSONDoesNotEscapeHTML(t *testing.T) {
+	out, err := Skeleton[skeletonConfig](SkeletonJSON)
+	assert.Nil(t, err)
+	assert.Contains(t, string(out), `"password": "<redacted>"`)
+}
+
+type skeletonContainer struct {
+	Image string `dd:"image,+doc=\"container image\""`
+	Ports []int  `dd:"ports"`
+}
+
+type skeletonPod struct {
+	Containers []skeletonContainer  `dd:"containers"`
+	Sidecars   []*skeletonContainer `dd:"sidecars"`
+}
+
+func TestSkeletonStructList(t *testing.T) {
+	out, err := Skeleton[skeletonPod](SkeletonYAML)
+	assert.Nil(t, err)
+	expected := `# (list of object)
+containers:
+    - # container image (string)
+      image: ""
+      # (list of integer)
+      ports: []
+# (list of object)
+sidecars:
+    - # container image (string)
+      image: ""
+      # (list of integer)
+      ports: []
+`
+	assert.Equal(t, expected, string(out))
+
+	out, err = Skeleton[skeletonPod](SkeletonJSON, &skeletonPod{Containers: []skeletonContainer{{Image: "web"}, {Image: "db"}}})
+	assert.Nil(t, err)
+	var m map[string]any
+	assert.Nil(t, json.Unmarshal(out, &m))
+	assert.Equal(t, []any{
+		map[string]any{"image": "web", "ports": []any{}},
+		map[string]any{"image": "db", "ports": []any{}},
+	}, m["containers"])
+	assert.Equal(t, []any{map[string]any{"image": "", "ports": []any{}}}, m["sidecars"])
+}
+
+func TestSkeletonRoundTrip(t *testing.T) {
+	out, err := Skeleton[skeletonConfig](SkeletonYAML, &skeletonConfig{Name: "roundtrip"})
+	assert.Nil(t, err)
+
+	cfg, err := NewYAML[skeletonConfig](out)
+	assert.Nil(t, err)
+	assert.Equal(t, "roundtrip", cfg.Name)
+	assert.NotNil(t, cfg.Database)
+	assert.Equal(t, DefaultSecretPlaceholder, cfg.Database.Password)
+}
+
+func TestSkeletonUnsupportedFormat(t *testing.T) {
+	_, err := Skeleton[skeletonConfig]("toml")
+	assert.NotNil(t, err)
+	var unsupported *UnsupportedError
+	assert.ErrorAs(t, err, &unsupported)
+}
+
+// unencodable fails to marshal to YAML.
+type unencodable struct{}
+
+func (unencodable) MarshalYAML() (interface{}, error) {
+	return nil, errors.New("not encodable")
+}
+
+func TestSkeletonYAMLEncodeError(t *testing.T) {
+	type config struct {
+		Payload Raw
+	}
+	_, err := Skeleton[config](SkeletonYAML, &config{Payload: Raw{Value: unencodable{}}})
+	var conversion *ConversionError
+	if assert.ErrorAs(t, err, &conversion) {
+		assert.Contains(t, err.Error(), "not encodable")
+	}
+}
+
+func TestParseDdTagDoc(t *testing.T) {
+	type TestStruct struct {
+		Quoted   string `dd:"quoted,+doc=\"with, commas\",+required"`
+		Unquoted string `dd:",+doc=simple"`
+	}
+
+	field, _ := getStructField[TestStruct]("Quoted")
+	tag := parseDdTag(field)
+	assert.Equal(t, "quoted", tag.Name)
+	assert.Equal(t, "with, commas", tag.Doc)
+	assert.True(t, tag.Required)
+
+	field, _ = getStructField[TestStruct]("Unquoted")
+	tag = parseDdTag(field)
+	assert.Equal(t, "", tag.Name)
+	assert.Equal(t, "simple", tag.Doc)
+}
@@ -0,0 +1,283 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PatchDirectiveKey is the key carrying strategic merge patch directives, as in Kubernetes.
+const PatchDirectiveKey = "$patch"
+
+// patch directive values recognized under PatchDirectiveKey.
+const (
+	PatchDelete  = "delete"
+	PatchReplace = "replace"
+)
+
+// StrategicMerge applies patch to target (a pointer to a struct) using Kubernetes-style strategic merge patch
+// semantics:
+//   - nested structs and maps are merged recursively; keys absent from the patch are left unchanged.
+//   - a null value deletes a field (resets it to its zero value) or removes a map entry.
+//   - an object containing `$patch: delete` deletes the field or map entry; `$patch: replace` replaces the object
+//     wholesale instead of merging into it.
+//   - lists of structs whose field is tagged `+mergekey=name` are merged element-by-element, matching elements on the
+//     named key: matching elements are merged, new elements are appended, and elements containing `$patch: delete`
+//     are removed. a list item of `{$patch: replace}` replaces the whole list with the remaining items.
+//   - all other lists, and scalar values, are replaced.
+//
+// opts are optional; pass nil or omit to use defaults.
+func StrategicMerge(target interface{}, patch map[string]any, opts ...*Options) error {
+	elem, err := validateTarget(target)
+	if err != nil {
+		return err
+	}
+	opt, err := getOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if err := strategicMergeStruct(elem, patch, elem.Type().Name(), opt); err != nil {
+		return err
+	}
+	recordProvenance(elem.Type(), patch, opt, false)
+	return nil
+}
+
+func strategicMergeStruct(structVal reflect.Value, patch map[string]any, path string, opt *Options) error {
+	switch patchDirective(patch) {
+	case PatchReplace:
+		structVal.Set(reflect.Zero(structVal.Type()))
+		return bindStruct(structVal, withoutDirective(patch), path, opt, false, nil)
+	case PatchDelete:
+		structVal.Set(reflect.Zero(structVal.Type()))
+		return nil
+	}
+
+	structType := structVal.Type()
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		fieldVal := structVal.Field(meta.index)
+
+		if isInlined(field, meta.tag) {
+			if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+				if fieldVal.IsNil() {
+					fieldVal.Set(reflect.New(field.Type.Elem()))
+				}
+				fieldVal = fieldVal.Elem()
+			}
+			if fieldVal.Kind() == reflect.Struct {
+				if err := strategicMergeStruct(fieldVal, withoutDirective(patch), path, opt); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip || tag.Extra {
+			continue
+		}
+		name := meta.externalName(opt.naming())
+		raw, ok := patch[name]
+		if !ok {
+			continue
+		}
+		if err := strategicMergeField(fieldVal, raw, tag, path+"."+field.Name, opt); err != nil {
+			return &BindingError{Path: path, Field: field.Name, Key: name, Cause: err}
+		}
+	}
+	return nil
+}
+
+func strategicMergeField(fieldVal reflect.Value, raw any, tag DdTag, path string, opt *Options) error {
+	if raw == nil {
+		fieldVal.Set(reflect.Zero(fieldVal.Type()))
+		return nil
+	}
+	subPatch, isObject := raw.(map[string]any)
+	if isObject && patchDirective(subPatch) == PatchDelete {
+		fieldVal.Set(reflect.Zero(fieldVal.Type()))
+		return nil
+	}
+
+	t := fieldVal.Type()
+	switch {
+	case isObject && isMergeableStruct(t):
+		return strategicMergeStruct(fieldVal, subPatch, path, opt)
+
+	case isObject && t.Kind() == reflect.Ptr && isMergeableStruct(t.Elem()):
+		if fieldVal.IsNil() {
+			fieldVal.Set(reflect.New(t.Elem()))
+		}
+		return strategicMergeStruct(fieldVal.Elem(), subPatch, path, opt)
+
+	case isObject && t.Kind() == reflect.Map && patchDirective(subPatch) != PatchReplace:
+		return strategicMergeMap(fieldVal, subPatch, path, opt)
+
+	case t.Kind() == reflect.Slice && tag.MergeKey != "":
+		items, ok := raw.([]any)
+		if !ok {
+			return &TypeMismatchError{Path: path, Expected: "array for slice", Actual: fmt.Sprintf("%T", raw)}
+		}
+		return strategicMergeList(fieldVal, items, tag.MergeKey, path, opt)
+	}
+
+	if isObject {
+		raw = withoutDirective(subPatch)
+	}
+	return setField(fieldVal, raw, path, opt, false)
+}
+
+// strategicMergeMap merges patch into a map field entry by entry; null entries are removed. a nil map is only
+// allocated once an entry is written into it.
+func strategicMergeMap(fieldVal reflect.Value, patch map[string]any, path string, opt *Options) error {
+	t := fieldVal.Type()
+	for keyStr, raw := range patch {
+		itemPath := fmt.Sprintf("%s[%q]", path, keyStr)
+		key, err := stringToKey(keyStr, t.Key(), itemPath, opt)
+		if err != nil {
+			return keyError(path, err)
+		}
+		if sub, ok := raw.(map[string]any); raw == nil || (ok && patchDirective(sub) == PatchDelete) {
+			if !fieldVal.IsNil() {
+				fieldVal.SetMapIndex(key, reflect.Value{})
+			}
+			continue
+		}
+		elem := reflect.New(t.Elem()).Elem()
+		if existing := fieldVal.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := strategicMergeField(elem, raw, DdTag{}, itemPath, opt); err != nil {
+			return err
+		}
+		if fieldVal.IsNil() {
+			fieldVal.Set(reflect.MakeMap(t))
+		}
+		fieldVal.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+// strategicMergeList merges patch items into a slice of structs, matching elements on mergeKey.
+func strategicMergeList(fieldVal reflect.Value, items []any, mergeKey string, path string, opt *Options) error {
+	elemType := fieldVal.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if !isMergeableStruct(structType) {
+		return &TypeMismatchError{Path: path, Expected: "slice of structs for +mergekey", Actual: fieldVal.Type().String()}
+	}
+
+	out := reflect.MakeSlice(fieldVal.Type(), 0, fieldVal.Len()+len(items))
+	replace := false
+	var patches []map[string]any
+	for idx, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return &TypeMismatchError{Path: fmt.Sprintf("%s[%d]", path, idx), Expected: "object for merge list element", Actual: fmt.Sprintf("%T", item)}
+		}
+		if len(m) == 1 && patchDirective(m) == PatchReplace {
+			replace = true
+			continue
+		}
+		patches = append(patches, m)
+	}
+	if !replace {
+		out = reflect.AppendSlice(out, fieldVal)
+	}
+
+	naming := opt.naming()
+	for idx, m := range patches {
+		itemPath := fmt.Sprintf("%s[%d]", path, idx)
+		key, ok := m[mergeKey]
+		if !ok {
+			return &RequiredFieldError{Path: itemPath, Field: mergeKey}
+		}
+		match := -1
+		for j := 0; j < out.Len(); j++ {
+			if existing, found := mergeKeyValue(out.Index(j), mergeKey, naming); found && fmt.Sprint(existing) == fmt.Sprint(key) {
+				match = j
+				break
+			}
+		}
+
+		if patchDirective(m) == PatchDelete {
+			if match >= 0 {
+				out = reflect.AppendSlice(out.Slice(0, match), out.Slice(match+1, out.Len()))
+			}
+			continue
+		}
+		if match >= 0 {
+			elem := out.Index(match)
+			if elem.Kind() == reflect.Ptr {
+				if elem.IsNil() {
+					elem.Set(reflect.New(structType))
+				}
+				elem = elem.Elem()
+			}
+			if err := strategicMergeStruct(elem, m, itemPath, opt); err != nil {
+				return err
+			}
+			continue
+		}
+		elem := reflect.New(structType)
+		if err := bindStruct(elem.Elem(), withoutDirective(m), itemPath, opt, false, nil); err != nil {
+			return err
+		}
+		if elemType.Kind() == reflect.Ptr {
+			out = reflect.Append(out, elem)
+		} else {
+			out = reflect.Append(out, elem.Elem())
+		}
+	}
+	fieldVal.Set(out)
+	return nil
+}
+
+// mergeKeyValue returns the value of the field of elem whose external name, under naming, is key.
+func mergeKeyValue(elem reflect.Value, key string, naming NamingStrategy) (any, bool) {
+	if elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return nil, false
+		}
+		elem = elem.Elem()
+	}
+	fieldVal, ok := externalFieldValue(elem, key, naming)
+	if !ok {
+		return nil, false
+	}
+	for fieldVal.Kind() == reflect.Ptr || fieldVal.Kind() == reflect.Interface {
+		if fieldVal.IsNil() {
+			return nil, false
+		}
+		fieldVal = fieldVal.Elem()
+	}
+	return fieldVal.Interface(), true
+}
+
+// isMergeableStruct reports whether t is a struct merged field by field.
+func isMergeableStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && isProvenanceStruct(t)
+}
+
+func patchDirective(m map[string]any) string {
+	directive, _ := m[PatchDirectiveKey].(string)
+	return directive
+}
+
+// withoutDirective returns m without its patch directive key.
+func withoutDirective(m map[string]any) map[string]any {
+	if _, ok := m[PatchDirectiveKey]; !ok {
+		return m
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if k != PatchDirectiveKey {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type smContainer struct {
+	Name  string
+	Image string
+	Ports []int
+}
+
+type smSpec struct {
+	Replicas   int
+	Containers []smContainer  `dd:",+mergekey=name"`
+	Volumes    []*smContainer `dd:",+mergekey=name"`
+	Labels     map[string]string
+	Selector   *smSelector
+	Args       []string
+}
+
+type smSelector struct {
+	App  string
+	Tier string
+}
+
+func newSMSpec() *smSpec {
+	return &smSpec{
+		Replicas: 1,
+		Containers: []smContainer{
+			{Name: "app", Image: "app:1", Ports: []int{80}},
+			{Name: "sidecar", Image: "proxy:1"},
+		},
+		Volumes:  []*smContainer{{Name: "data"}},
+		Labels:   map[string]string{"app": "web", "tier": "frontend"},
+		Selector: &smSelector{App: "web", Tier: "frontend"},
+		Args:     []string{"--a", "--b"},
+	}
+}
+
+func TestStrategicMergeLists(t *testing.T) {
+	spec := newSMSpec()
+	err := StrategicMerge(spec, map[string]any{
+		"containers": []any{
+			map[string]any{"name": "app", "image": "app:2"},
+			map[string]any{"name": "sidecar", "$patch": "delete"},
+			map[string]any{"name": "metrics", "image": "metrics:1"},
+		},
+		"volumes": []any{map[string]any{"name": "cache"}},
+		"args":    []any{"--c"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []smContainer{
+		{Name: "app", Image: "app:2", Ports: []int{80}},
+		{Name: "metrics", Image: "metrics:1"},
+	}, spec.Containers)
+	assert.Equal(t, []*smContainer{{Name: "data"}, {Name: "cache"}}, spec.Volumes)
+	assert.Equal(t, []string{"--c"}, spec.Args)
+	assert.Equal(t, 1, spec.Replicas)
+}
+
+func TestStrategicMergeListReplace(t *testing.T) {
+	spec := newSMSpec()
+	err := StrategicMerge(spec, map[string]any{
+		"containers": []any{
+			map[string]any{"$patch": "replace"},
+			map[string]any{"name": "only", "image": "only:1"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []smContainer{{Name: "only", Image: "only:1"}}, spec.Containers)
+}
+
+func TestStrategicMergeMapsAndDeletes(t *testing.T) {
+	spec := newSMSpec()
+	err := StrategicMerge(spec, map[string]any{
+		"replicas": 3,
+		"labels":   map[string]any{"tier": nil, "env": "prod"},
+		"selector": map[string]any{"$patch": "replace", "app": "api"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, spec.Replicas)
+	assert.Equal(t, map[string]string{"app": "web", "env": "prod"}, spec.Labels)
+	assert.Equal(t, &smSelector{App: "api"}, spec.Selector)
+
+	err = StrategicMerge(spec, map[string]any{
+		"selector": map[string]any{"$patch": "delete"},
+		"labels":   nil,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, spec.Selector)
+	assert.Nil(t, spec.Labels)
+}
+
+func TestStrategicMergeLeavesNilMaps(t *testing.T) {
+	spec := &smSpec{Containers: []smContainer{{Name: "app"}}}
+	err := StrategicMerge(spec, map[string]any{
+		"replicas":   2,
+		"containers": []any{map[string]any{"name": "app", "image": "app:2"}},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, spec.Labels)
+
+	// removing entries from a nil map writes nothing into it
+	err = StrategicMerge(spec, map[string]any{"labels": map[string]any{"tier": nil, "app": map[string]any{"$patch": "delete"}}})
+	assert.NoError(t, err)
+	assert.Nil(t, spec.Labels)
+	err = StrategicMerge(spec, map[string]any{"labels": map[string]any{}})
+	assert.NoError(t, err)
+	assert.Nil(t, spec.Labels)
+
+	err = StrategicMerge(spec, map[string]any{"labels": map[string]any{"env": "prod"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, spec.Labels)
+}
+
+func TestStrategicMergeMissingKey(t *testing.T) {
+	spec := newSMSpec()
+	err := StrategicMerge(spec, map[string]any{
+		"containers": []any{map[string]any{"image": "x"}},
+	})
+	assert.Error(t, err)
+	var required *RequiredFieldError
+	assert.ErrorAs(t, err, &required)
+}
+
+func TestStrategicMergeListNaming(t *testing.T) {
+	type service struct {
+		ServiceName string
+		Port        int
+	}
+	type config struct {
+		Services []service `dd:",+mergekey=serviceName"`
+	}
+	cfg := &config{Services: []service{{ServiceName: "a", Port: 1}}}
+	err := StrategicMerge(cfg, map[string]any{
+		"services": []any{map[string]any{"serviceName": "a", "port": 2}},
+	}, &Options{NamingStrategy: CamelCase})
+	assert.NoError(t, err)
+	assert.Equal(t, []service{{ServiceName: "a", Port: 2}}, cfg.Services)
+}
@@ -0,0 +1,74 @@
+package dd
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"unicode"
+)
+
+// BindStream decodes a sequence of JSON objects from r and binds each into a new instance of T, passing it to fn before
+// decoding the next, so arbitrarily large inputs are processed with memory bounded by the largest single element. r
+// may hold either a JSON array of objects or newline-delimited JSON (NDJSON, or any whitespace-separated sequence of
+// objects); the form is detected from the first non-whitespace byte.
+//
+// decoding stops at the first error: a parse or binding error is returned wrapped in an IndexError carrying the
+// element's position, and an error returned by fn is returned unchanged.
+func BindStream[T any](r io.Reader, fn func(*T) error, opts ...*Options) error {
+	if fn == nil {
+		return &ValidationError{Message: "nil callback provided"}
+	}
+	br := bufio.NewReader(r)
+	array, err := streamIsArray(br)
+	if err != nil {
+		return &ConversionError{Type: "JSON", Message: "failed to read from reader", Cause: err}
+	}
+
+	dec := json.NewDecoder(br)
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return &ConversionError{Type: "JSON", Message: "failed to parse", Cause: err}
+		}
+	}
+	for i := 0; ; i++ {
+		if array && !dec.More() {
+			break
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF && !array {
+				break
+			}
+			return &IndexError{Index: i, Cause: &ConversionError{Type: "JSON", Message: "failed to parse", Cause: err}}
+		}
+		target, err := NewJSON[T](raw, opts...)
+		if err != nil {
+			return &IndexError{Index: i, Cause: err}
+		}
+		if err := fn(target); err != nil {
+			return err
+		}
+	}
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return &ConversionError{Type: "JSON", Message: "failed to parse", Cause: err}
+		}
+	}
+	return nil
+}
+
+// streamIsArray peeks past leading whitespace in r and reports whether the input is a JSON array.
+func streamIsArray(r *bufio.Reader) (bool, error) {
+	for {
+		c, _, err := r.ReadRune()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !unicode.IsSpace(c) {
+			return c == '[', r.UnreadRune()
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package dd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type streamEvent struct {
+	ID   int `dd:",+required"`
+	Kind string
+}
+
+func collectStream(input string) ([]streamEvent, error) {
+	var events []streamEvent
+	err := BindStream(strings.NewReader(input), func(e *streamEvent) error {
+		events = append(events, *e)
+		return nil
+	})
+	return events, err
+}
+
+func TestBindStreamNDJSON(t *testing.T) {
+	events, err := collectStream("{\"id\": 1, \"kind\": \"a\"}\n{\"id\": 2}\n\n{\"id\": 3, \"kind\": \"c\"}\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []streamEvent{{ID: 1, Kind: "a"}, {ID: 2}, {ID: 3, Kind: "c"}}, events)
+}
+
+func TestBindStreamArray(t *testing.T) {
+	events, err := collectStream("  [\n {\"id\": 1}, {\"id\": 2, \"kind\": \"b\"}\n]\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []streamEvent{{ID: 1}, {ID: 2, Kind: "b"}}, events)
+
+	events, err = collectStream("[]")
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = collectStream("")
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestBindStreamErrors(t *testing.T) {
+	events, err := collectStream("{\"id\": 1}\n{\"kind\": \"missing id\"}\n{\"id\": 3}\n")
+	var indexErr *IndexError
+	assert.ErrorAs(t, err, &indexErr)
+	assert.Equal(t, 1, indexErr.Index)
+	assert.Len(t, events, 1)
+
+	_, err = collectStream("[{\"id\": 1}, {\"id\": ")
+	assert.ErrorAs(t, err, &indexErr)
+
+	stop := errors.New("stop")
+	count := 0
+	err = BindStream(strings.NewReader("{\"id\": 1}\n{\"id\": 2}\n"), func(e *streamEvent) error {
+		count++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, count)
+}
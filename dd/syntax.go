@@ -0,0 +1,93 @@
+package dd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// offsetError is a parse error at a byte offset, as reported by stripJSONC.
+type offsetError struct {
+	msg    string
+	offset int64
+}
+
+func (e *offsetError) Error() string {
+	return e.msg
+}
+
+// yamlLineError matches the line reported in yaml.v3 errors, as in "yaml: line 3: did not find expected key".
+var yamlLineError = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// parseError wraps an error from decoding data in format ("JSON", "JSONC", or "YAML") as a ConversionError whose
+// cause is a SyntaxError locating it within data.
+func parseError(format string, data []byte, err error) error {
+	se := &SyntaxError{Format: format, Message: err.Error(), Cause: err}
+	offset := int64(-1)
+	var jsonSyntax *json.SyntaxError
+	var jsonType *json.UnmarshalTypeError
+	var jsonc *offsetError
+	var yamlType *yaml.TypeError
+	switch {
+	case errors.As(err, &jsonSyntax):
+		// the offset follows the offending character, or is the end of truncated input
+		offset = jsonSyntax.Offset
+		if jsonSyntax.Error() != "unexpected end of JSON input" {
+			offset--
+		}
+	case errors.As(err, &jsonType):
+		offset = jsonType.Offset - 1
+	case errors.As(err, &jsonc):
+		offset = jsonc.offset
+	case errors.As(err, &yamlType) && len(yamlType.Errors) > 0:
+		se.Message = yamlType.Errors[0]
+		se.Line, se.Message = yamlLine(se.Message)
+	default:
+		if format == "YAML" {
+			se.Line, se.Message = yamlLine(se.Message)
+		}
+	}
+	if offset >= 0 {
+		if offset >= int64(len(data)) {
+			offset = int64(len(data))
+		}
+		se.Line = bytes.Count(data[:offset], []byte("\n")) + 1
+		se.Column = int(offset) - (bytes.LastIndexByte(data[:offset], '\n') + 1) + 1
+	}
+	if se.Line > 0 {
+		se.Snippet = sourceLine(data, se.Line)
+	}
+	return &ConversionError{Type: format, Message: "failed to parse", Cause: se}
+}
+
+// yamlLine splits a yaml.v3 message into its line number and the remaining message; the line is zero when absent.
+func yamlLine(msg string) (int, string) {
+	if m := yamlLineError.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line, m[2]
+	}
+	return 0, strings.TrimPrefix(msg, "yaml: ")
+}
+
+// sourceLine returns line n (1-based) of data, without its line ending.
+func sourceLine(data []byte, n int) string {
+	lines := bytes.SplitN(data, []byte("\n"), n+1)
+	if n > len(lines) {
+		return ""
+	}
+	return strings.TrimRight(string(lines[n-1]), "\r")
+}
+
+// withFile records path as the File of a SyntaxError within err, as returned by the file variants.
+func withFile(err error, path string) error {
+	var se *SyntaxError
+	if errors.As(err, &se) && se.File == "" {
+		se.File = path
+	}
+	return err
+}
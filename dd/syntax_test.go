@@ -0,0 +1,108 @@
+package dd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type syntaxConfig struct {
+	Name string
+	Port int
+}
+
+func TestSyntaxErrorJSON(t *testing.T) {
+	_, err := NewJSON[syntaxConfig]([]byte("{\n  \"name\": \"relay\",\n  \"port\": 80,}\n"))
+	var se *SyntaxError
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, "JSON", se.Format)
+		assert.Equal(t, 3, se.Line)
+		assert.Equal(t, 14, se.Column)
+		assert.Equal(t, `  "port": 80,}`, se.Snippet)
+		assert.Equal(t, "JSON line 3, column 14: invalid character '}' looking for beginning of object key string", err.Error())
+	}
+	var conversionErr *ConversionError
+	assert.ErrorAs(t, err, &conversionErr)
+
+	// truncated input is located at its end
+	_, err = NewJSON[syntaxConfig]([]byte("{\n  \"name\": "))
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, 2, se.Line)
+		assert.Equal(t, 11, se.Column)
+	}
+
+	// a document that is not an object
+	_, err = NewJSON[syntaxConfig]([]byte("\n[1, 2]"))
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, 2, se.Line)
+	}
+}
+
+func TestSyntaxErrorYAML(t *testing.T) {
+	_, err := NewYAML[syntaxConfig]([]byte("name: relay\nport: [80\n"))
+	var se *SyntaxError
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, "YAML", se.Format)
+		assert.Greater(t, se.Line, 0)
+		assert.NotEmpty(t, se.Snippet)
+		assert.NotContains(t, se.Message, "yaml: line")
+	}
+
+	_, err = NewYAML[syntaxConfig]([]byte("name: relay\n\t- bad\n"))
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, 2, se.Line)
+		assert.Equal(t, "\t- bad", se.Snippet)
+	}
+
+	_, err = NewYAML[syntaxConfig]([]byte("# list\n- a\n- b\n"))
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, 2, se.Line)
+	}
+}
+
+func TestSyntaxErrorJSONC(t *testing.T) {
+	input := "{\n  // comment\n  \"port\": 80 80,\n}\n"
+	_, err := NewJSONC[syntaxConfig]([]byte(input))
+	var se *SyntaxError
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, "JSONC", se.Format)
+		assert.Equal(t, 3, se.Line)
+		assert.Equal(t, 14, se.Column)
+		assert.Equal(t, `  "port": 80 80,`, se.Snippet)
+	}
+
+	_, err = NewJSONC[syntaxConfig]([]byte("{\n  \"port\": 80 /* open\n}"))
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, 2, se.Line)
+		assert.Equal(t, 14, se.Column)
+		assert.Equal(t, "unterminated comment", se.Message)
+	}
+}
+
+func TestSyntaxErrorFile(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "config.json")
+	yamlPath := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte("{\n  \"port\": 80,\n  \"name\" \"x\"\n}"), 0644))
+	assert.NoError(t, os.WriteFile(yamlPath, []byte("name: x\n  port: 80\n"), 0644))
+
+	_, err := NewJSONFile[syntaxConfig](jsonPath)
+	assert.Error(t, err)
+	assert.Equal(t, jsonPath+":3:10: invalid character '\"' after object key", err.Error())
+
+	var cfg syntaxConfig
+	err = BindYAMLFile(&cfg, yamlPath)
+	var se *SyntaxError
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, yamlPath, se.File)
+		assert.Equal(t, 2, se.Line)
+	}
+
+	_, _, err = LoadLayered[syntaxConfig]([]Layer{FileLayer(jsonPath)})
+	if assert.ErrorAs(t, err, &se) {
+		assert.Equal(t, jsonPath, se.File)
+		assert.Equal(t, 3, se.Line)
+	}
+}
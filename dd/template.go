@@ -0,0 +1,188 @@
+package dd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// RenderTemplates renders the fields of target (a pointer to a struct) tagged `+template` as Go text/template
+// templates, executed against data with funcs available, replacing each field with its rendered output. it is an
+// opt-in pass intended to run after Bind, for message templates and path patterns stored in configuration.
+//
+// template fields may be string, *string, []string, or a map with string values (and named types based on them);
+// every element is rendered. nested structs, pointers, slices, and maps are traversed to find template fields.
+// referencing a missing key in a data map is an error.
+func RenderTemplates(target interface{}, data any, funcs template.FuncMap) error {
+	if target == nil {
+		return &ValidationError{Message: "target must be a non-nil pointer to a struct"}
+	}
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return &ValidationError{Message: "target must be a non-nil pointer to a struct"}
+	}
+	if value.Elem().Kind() != reflect.Struct {
+		return &TypeMismatchError{Expected: "pointer to struct", Actual: value.Type().String()}
+	}
+	r := &templateRenderer{data: data, funcs: funcs, visited: make(map[uintptr]bool)}
+	return r.renderValue(value, value.Elem().Type().Name())
+}
+
+type templateRenderer struct {
+	data    any
+	funcs   template.FuncMap
+	visited map[uintptr]bool
+}
+
+// renderValue traverses v looking for structs containing template fields.
+func (r *templateRenderer) renderValue(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || r.visited[v.Pointer()] {
+			return nil
+		}
+		r.visited[v.Pointer()] = true
+		return r.renderValue(v.Elem(), path)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// interface contents are not addressable; only pointers can be rendered in place
+		if v.Elem().Kind() == reflect.Ptr {
+			return r.renderValue(v.Elem(), path)
+		}
+		return nil
+
+	case reflect.Struct:
+		if isScalarStruct(v.Type()) || isPointerType(v.Type()) {
+			return nil
+		}
+		return r.renderStruct(v, path)
+
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := r.renderValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		elemType := v.Type().Elem()
+		if elemType.Kind() != reflect.Struct {
+			for _, key := range v.MapKeys() {
+				if err := r.renderValue(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key.Interface())); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		// struct map values are not addressable; render a copy and store it back
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(elemType).Elem()
+			elem.Set(v.MapIndex(key))
+			if err := r.renderValue(elem, fmt.Sprintf("%s[%v]", path, key.Interface())); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, elem)
+		}
+	}
+	return nil
+}
+
+func (r *templateRenderer) renderStruct(structVal reflect.Value, path string) error {
+	structType := structVal.Type()
+	for _, meta := range cachedFields(structType) {
+		field := meta.field
+		fieldVal := structVal.Field(meta.index)
+		if isInlined(field, meta.tag) {
+			if err := r.renderValue(fieldVal, path); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := meta.tag
+		if tag.Skip {
+			continue
+		}
+		fieldPath := path + "." + field.Name
+		if !tag.Template {
+			if err := r.renderValue(fieldVal, fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.renderField(fieldVal, fieldPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderField renders a `+template` field in place.
+func (r *templateRenderer) renderField(fieldVal reflect.Value, path string) error {
+	switch fieldVal.Kind() {
+	case reflect.String:
+		out, err := r.render(fieldVal.String(), path)
+		if err != nil {
+			return err
+		}
+		fieldVal.SetString(out)
+		return nil
+
+	case reflect.Ptr:
+		if fieldVal.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		if fieldVal.IsNil() {
+			return nil
+		}
+		return r.renderField(fieldVal.Elem(), path)
+
+	case reflect.Slice:
+		if fieldVal.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		for i := 0; i < fieldVal.Len(); i++ {
+			if err := r.renderField(fieldVal.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if fieldVal.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		for _, key := range fieldVal.MapKeys() {
+			elem := reflect.New(fieldVal.Type().Elem()).Elem()
+			elem.Set(fieldVal.MapIndex(key))
+			if err := r.renderField(elem, fmt.Sprintf("%s[%v]", path, key.Interface())); err != nil {
+				return err
+			}
+			fieldVal.SetMapIndex(key, elem)
+		}
+		return nil
+	}
+	return &TypeMismatchError{Path: path, Expected: "string, *string, []string, or map of strings for +template field", Actual: fieldVal.Type().String()}
+}
+
+func (r *templateRenderer) render(text string, path string) (string, error) {
+	// plain strings are left untouched, avoiding a parse per field
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(path).Funcs(r.funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", &ConversionError{Path: path, Value: text, Type: "template", Cause: err}
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, r.data); err != nil {
+		return "", &ConversionError{Path: path, Value: text, Type: "template", Cause: err}
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,66 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type templateConfig struct {
+	Greeting string `dd:",+template"`
+	Plain    string
+	Paths    []string          `dd:",+template"`
+	Headers  map[string]string `dd:",+template"`
+	Footer   *string           `dd:",+template"`
+	Channels []templateChannel
+	Named    map[string]templateChannel
+}
+
+type templateChannel struct {
+	Subject string `dd:",+template"`
+}
+
+func TestRenderTemplates(t *testing.T) {
+	footer := "-- {{ .App }}"
+	cfg := &templateConfig{
+		Greeting: "hello {{ .User }}",
+		Plain:    "{{ .User }}",
+		Paths:    []string{"/var/{{ .App }}", "/static"},
+		Headers:  map[string]string{"x-app": "{{ upper .App }}"},
+		Footer:   &footer,
+		Channels: []templateChannel{{Subject: "[{{ .App }}] alert"}},
+		Named:    map[string]templateChannel{"ops": {Subject: "{{ .User }}: ops"}},
+	}
+
+	data := map[string]any{"User": "alice", "App": "df"}
+	err := RenderTemplates(cfg, data, template.FuncMap{"upper": strings.ToUpper})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello alice", cfg.Greeting)
+	assert.Equal(t, "{{ .User }}", cfg.Plain)
+	assert.Equal(t, []string{"/var/df", "/static"}, cfg.Paths)
+	assert.Equal(t, "DF", cfg.Headers["x-app"])
+	assert.Equal(t, "-- df", *cfg.Footer)
+	assert.Equal(t, "[df] alert", cfg.Channels[0].Subject)
+	assert.Equal(t, "alice: ops", cfg.Named["ops"].Subject)
+}
+
+func TestRenderTemplatesErrors(t *testing.T) {
+	cfg := &templateConfig{Greeting: "hello {{ .Missing }}"}
+	err := RenderTemplates(cfg, map[string]any{}, nil)
+	assert.Error(t, err)
+	var convErr *ConversionError
+	assert.ErrorAs(t, err, &convErr)
+	assert.Equal(t, "templateConfig.Greeting", convErr.Path)
+
+	cfg = &templateConfig{Greeting: "hello {{ .User"}
+	assert.Error(t, RenderTemplates(cfg, nil, nil))
+
+	type invalid struct {
+		Count int `dd:",+template"`
+	}
+	err = RenderTemplates(&invalid{}, nil, nil)
+	var mismatch *TypeMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+}
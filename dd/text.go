@@ -0,0 +1,67 @@
+package dd
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// isTextType reports whether t round-trips through its text form, implementing both encoding.TextMarshaler and
+// encoding.TextUnmarshaler (the latter typically on the pointer receiver). such fields are bound from and unbound to
+// strings without requiring a registered converter.
+func isTextType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return false
+	}
+	if !t.Implements(textMarshalerType) && !reflect.PointerTo(t).Implements(textMarshalerType) {
+		return false
+	}
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// bindText sets a text type destination by calling UnmarshalText on a raw string. struct types only accept a string
+// (or a value of their own type); other kinds, such as net.IP, fall through to regular conversion when raw is not a
+// string. returns false if dst is not a text type.
+func bindText(dst reflect.Value, raw interface{}, path string) (bool, error) {
+	if !isTextType(dst.Type()) || !dst.CanAddr() {
+		return false, nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		if rv := reflect.ValueOf(raw); rv.IsValid() && rv.Type() == dst.Type() {
+			dst.Set(rv)
+			return true, nil
+		}
+		if dst.Kind() != reflect.Struct {
+			return false, nil
+		}
+		return true, &TypeMismatchError{Path: path, Expected: dst.Type().String() + " string", Actual: fmt.Sprintf("%T", raw)}
+	}
+	if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+		return true, &ConversionError{Path: path, Value: s, Type: dst.Type().String(), Cause: err}
+	}
+	return true, nil
+}
+
+// unbindText formats a text type using MarshalText. returns false if v is not a text type.
+func unbindText(v reflect.Value) (interface{}, bool, error) {
+	if !isTextType(v.Type()) {
+		return nil, false, nil
+	}
+	var m encoding.TextMarshaler
+	if v.Type().Implements(textMarshalerType) {
+		m = v.Interface().(encoding.TextMarshaler)
+	} else {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		m = ptr.Interface().(encoding.TextMarshaler)
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return nil, true, err
+	}
+	return string(text), true, nil
+}
@@ -0,0 +1,137 @@
+package dd
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type textVersion struct {
+	Major, Minor, Patch int
+}
+
+func (v textVersion) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)), nil
+}
+
+func (v *textVersion) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "v%d.%d.%d", &v.Major, &v.Minor, &v.Patch)
+	return err
+}
+
+type textLevel int
+
+func (l textLevel) MarshalText() ([]byte, error) {
+	switch l {
+	case 0:
+		return []byte("info"), nil
+	case 1:
+		return []byte("debug"), nil
+	}
+	return nil, fmt.Errorf("unknown level %d", int(l))
+}
+
+func (l *textLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "info":
+		*l = 0
+	case "debug":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+type textConfig struct {
+	Version  textVersion
+	Previous *textVersion
+	Level    textLevel
+	Address  net.IP
+	History  []textVersion
+	Levels   map[string]textLevel
+}
+
+func TestTextMarshalerRoundTrip(t *testing.T) {
+	data := map[string]any{
+		"version":  "v1.2.3",
+		"previous": "v1.1.0",
+		"level":    "DEBUG",
+		"address":  "10.0.0.1",
+		"history":  []any{"v0.9.0", "v1.0.0"},
+		"levels":   map[string]any{"http": "info"},
+	}
+	cfg, err := New[textConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, textVersion{1, 2, 3}, cfg.Version)
+	assert.Equal(t, &textVersion{1, 1, 0}, cfg.Previous)
+	assert.Equal(t, textLevel(1), cfg.Level)
+	assert.True(t, net.ParseIP("10.0.0.1").Equal(cfg.Address))
+	assert.Equal(t, []textVersion{{0, 9, 0}, {1, 0, 0}}, cfg.History)
+	assert.Equal(t, map[string]textLevel{"http": 0}, cfg.Levels)
+
+	out, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.3", out["version"])
+	assert.Equal(t, "v1.1.0", out["previous"])
+	assert.Equal(t, "debug", out["level"])
+	assert.Equal(t, "10.0.0.1", out["address"])
+	assert.Equal(t, []any{"v0.9.0", "v1.0.0"}, out["history"])
+	assert.Equal(t, map[string]any{"http": "info"}, out["levels"])
+}
+
+func TestTextMarshalerNonString(t *testing.T) {
+	// non-struct text types still accept their underlying kind
+	cfg, err := New[textConfig](map[string]any{"level": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, textLevel(1), cfg.Level)
+
+	_, err = New[textConfig](map[string]any{"version": map[string]any{"major": 1}})
+	var mismatch *TypeMismatchError
+	assert.ErrorAs(t, err, &mismatch)
+}
+
+func TestTextMarshalerErrors(t *testing.T) {
+	_, err := New[textConfig](map[string]any{"level": "verbose"})
+	var convErr *ConversionError
+	assert.ErrorAs(t, err, &convErr)
+	assert.Contains(t, err.Error(), "unknown level")
+
+	_, err = Unbind(&textConfig{Level: 7})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown level 7")
+}
+
+type textVersionConverter struct{}
+
+func (textVersionConverter) FromRaw(raw interface{}) (interface{}, error) {
+	return textVersion{Major: raw.(int)}, nil
+}
+
+func (textVersionConverter) ToRaw(value interface{}) (interface{}, error) {
+	return value.(textVersion).Major, nil
+}
+
+func TestTextMarshalerConverterPrecedence(t *testing.T) {
+	opts := &Options{Converters: map[reflect.Type]Converter{reflect.TypeOf(textVersion{}): textVersionConverter{}}}
+
+	cfg, err := New[textConfig](map[string]any{"version": 4}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, textVersion{Major: 4}, cfg.Version)
+
+	out, err := Unbind(cfg, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, out["version"])
+}
+
+func TestTextMarshalerSchema(t *testing.T) {
+	schema, err := Schema[textConfig]()
+	assert.NoError(t, err)
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string"}, properties["version"])
+	assert.Equal(t, map[string]any{"type": "string"}, properties["level"])
+}
@@ -0,0 +1,84 @@
+package dd
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// time formats recognized by the `+format` tag in addition to time.Parse layouts.
+const (
+	TimeFormatUnix      = "unix"      // integer seconds since the Unix epoch
+	TimeFormatUnixMilli = "unixmilli" // integer milliseconds since the Unix epoch
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isTimeField reports whether t is time.Time or *time.Time, the field types that honor `+format`.
+func isTimeField(t reflect.Type) bool {
+	return t == timeType || (t.Kind() == reflect.Ptr && t.Elem() == timeType)
+}
+
+// parseTimeFormat converts raw to a time.Time according to format: a time.Parse layout, TimeFormatUnix, or
+// TimeFormatUnixMilli. Unix timestamps are accepted as numbers or numeric strings and yield UTC times.
+func parseTimeFormat(raw any, format string) (time.Time, error) {
+	if t, ok := raw.(time.Time); ok {
+		return t, nil
+	}
+	switch format {
+	case TimeFormatUnix, TimeFormatUnixMilli:
+		n, err := unixNumber(raw)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if format == TimeFormatUnixMilli {
+			whole, frac := math.Modf(n / 1000)
+			return time.Unix(int64(whole), int64(frac*1e9)).UTC(), nil
+		}
+		whole, frac := math.Modf(n)
+		return time.Unix(int64(whole), int64(frac*1e9)).UTC(), nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("expected time string in format %q, got %T", format, raw)
+	}
+	t, err := time.Parse(format, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse time: %w", err)
+	}
+	return t, nil
+}
+
+// unixNumber returns the numeric value of a Unix timestamp supplied as a number or numeric string.
+func unixNumber(raw any) (float64, error) {
+	if s, ok := raw.(string); ok {
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse Unix timestamp %q", s)
+		}
+		return n, nil
+	}
+	v := reflect.ValueOf(raw)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	}
+	return 0, fmt.Errorf("expected Unix timestamp, got %T", raw)
+}
+
+// formatTime renders t according to format, as an int64 for the Unix formats and a string otherwise.
+func formatTime(t time.Time, format string) any {
+	switch format {
+	case TimeFormatUnix:
+		return t.Unix()
+	case TimeFormatUnixMilli:
+		return t.UnixMilli()
+	}
+	return t.Format(format)
+}
@@ -0,0 +1,67 @@
+package dd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type timeFormatEvent struct {
+	Day       time.Time  `dd:",+format=2006-01-02"`
+	CreatedAt time.Time  `dd:",+format=unix"`
+	UpdatedAt *time.Time `dd:",+format=unixmilli"`
+	Seen      time.Time
+}
+
+func TestTimeFormatTag(t *testing.T) {
+	event, err := New[timeFormatEvent](map[string]any{
+		"day":        "2024-03-15",
+		"created_at": 1710460800,
+		"updated_at": "1710460800500",
+		"seen":       "2024-03-15T10:00:00Z",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), event.Day)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), event.CreatedAt)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, int(500*time.Millisecond), time.UTC), *event.UpdatedAt)
+	assert.Equal(t, time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC), event.Seen)
+
+	data, err := Unbind(event)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"day":        "2024-03-15",
+		"created_at": int64(1710460800),
+		"updated_at": int64(1710460800500),
+		"seen":       "2024-03-15T10:00:00Z",
+	}, data)
+
+	// JSON numbers decode as float64
+	encoded, err := UnbindJSON(event)
+	assert.NoError(t, err)
+	again, err := NewJSON[timeFormatEvent](encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, event, again)
+}
+
+func TestTimeFormatTagErrors(t *testing.T) {
+	_, err := New[timeFormatEvent](map[string]any{"day": "15/03/2024"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot parse time")
+
+	_, err = New[timeFormatEvent](map[string]any{"created_at": "yesterday"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unix timestamp")
+
+	_, err = New[timeFormatEvent](map[string]any{"created_at": true})
+	assert.Error(t, err)
+}
+
+func TestTimeFormatSchema(t *testing.T) {
+	schema, err := Schema[timeFormatEvent]()
+	assert.NoError(t, err)
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string"}, properties["day"])
+	assert.Equal(t, map[string]any{"type": "integer"}, properties["created_at"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["seen"])
+}
@@ -11,9 +11,10 @@ import (
 // - `dd:"name"` overrides the key name
 // - `dd:"-"` skips the field
 // - `dd:",+omitempty"` omits the field if it has a zero value
-// - when no tag is provided, the key defaults to snake_case of the field name
+// - when no tag is provided, the key defaults to snake_case of the field name (or Options.NamingStrategy, when set)
 //
-// pointers to values: if nil, the key is omitted; otherwise the pointed value is emitted.
+// pointers to values: if nil, the key is omitted (or emitted as null, when the field is recorded in
+// Options.ExplicitNulls as cleared by an explicit null); otherwise the pointed value is emitted.
 // slices, structs, maps, and nested pointers are handled recursively. time.Duration values
 // are emitted as strings using Duration.String() (e.g., "30s"). time.Time values are emitted
 // as RFC3339 strings (e.g., "2024-03-15T14:30:45Z"). map keys are converted to strings for
@@ -40,23 +41,63 @@ func Unbind(source interface{}, opts ...*Options) (map[string]any, error) {
 	if err != nil {
 		return nil, err
 	}
-	return structToMap(val, opt)
+	out, err := structToMap(val, opt)
+	if err != nil {
+		return nil, err
+	}
+	if opt != nil && opt.ExplicitNulls != nil {
+		restoreNulls(opt.ExplicitNulls, out)
+	}
+	if opt != nil && opt.SecretKeys != nil && opt.SecretPolicy == PlainSecrets {
+		return sealSecrets(val.Type(), out, opt.SecretKeys, opt.naming())
+	}
+	return out, nil
 }
 
+// structToMap unbinds a struct to its own object, calling its BeforeUnbind and AfterUnbind hooks around its fields.
 func structToMap(structVal reflect.Value, opt *Options) (map[string]any, error) {
-	out := make(map[string]any)
+	if err := checkContext(opt); err != nil {
+		return nil, err
+	}
+	path := structVal.Type().Name()
+	if err := beforeUnbind(structVal, path); err != nil {
+		return nil, err
+	}
+	out, err := structFieldsToMap(structVal, opt)
+	if err != nil {
+		return nil, err
+	}
+	if err := afterUnbind(structVal, out, path); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func structFieldsToMap(structVal reflect.Value, opt *Options) (map[string]any, error) {
 	structType := structVal.Type()
-	for i := 0; i < structVal.NumField(); i++ {
-		field := structType.Field(i)
+	if opt == nil {
+		if codec, ok := lookupGenerated(structType); ok {
+			if !structVal.CanAddr() {
+				addressable := reflect.New(structType).Elem()
+				addressable.Set(structVal)
+				structVal = addressable
+			}
+			return codec.unbind(structVal.Addr().Interface())
+		}
+	}
+	out := make(map[string]any)
+	fields := cachedFields(structType)
+	for _, meta := range fields {
+		field := meta.field
 		// skip unexported fields
 		if field.PkgPath != "" { // unexported
 			continue
 		}
 
-		fieldVal := structVal.Field(i)
+		fieldVal := structVal.Field(meta.index)
 
 		// handle embedded structs by flattening their fields into the parent map
-		if field.Anonymous {
+		if isInlined(field, meta.tag) {
 			var embeddedVal reflect.Value
 			if field.Type.Kind() == reflect.Ptr {
 				if fieldVal.IsNil() {
@@ -68,7 +109,14 @@ func structToMap(structVal reflect.Value, opt *Options) (map[string]any, error)
 			}
 
 			if embeddedVal.Kind() == reflect.Struct {
-				embeddedMap, err := structToMap(embeddedVal, opt)
+				// embedded structs share their parent's hooks through method promotion; +inline fields share only
+				// its object, so they prepare themselves here
+				if !field.Anonymous {
+					if err := beforeUnbind(embeddedVal, structType.Name()+"."+field.Name); err != nil {
+						return nil, err
+					}
+				}
+				embeddedMap, err := structFieldsToMap(embeddedVal, opt)
 				if err != nil {
 					return nil, err
 				}
@@ -80,14 +128,14 @@ func structToMap(structVal reflect.Value, opt *Options) (map[string]any, error)
 			continue
 		}
 
-		tag := parseDdTag(field)
+		tag := meta.tag
 		if tag.Skip || tag.Extra {
 			continue
 		}
-		name := tag.Name
-		if name == "" {
-			name = toSnakeCase(field.Name)
+		if tag.Secret && opt != nil && opt.SecretPolicy == OmitSecrets {
+			continue
 		}
+		name := meta.externalName(opt.naming())
 
 		// omit nil pointer fields entirely
 		if fieldVal.Kind() == reflect.Ptr && fieldVal.IsNil() {
@@ -99,7 +147,48 @@ func structToMap(structVal reflect.Value, opt *Options) (map[string]any, error)
 			continue
 		}
 
-		v, ok, err := valueToInterface(fieldVal, opt)
+		if tag.Secret && opt != nil && opt.SecretPolicy == MaskSecrets {
+			if opt.SecretMasker == nil {
+				out[name] = opt.secretPlaceholder()
+				continue
+			}
+			v, ok, err := valueToInterface(fieldVal, opt)
+			if err != nil {
+				return nil, &UnbindingError{Path: structType.Name(), Field: field.Name, Key: name, Cause: err}
+			}
+			if ok {
+				out[name] = opt.SecretMasker(v)
+			}
+			continue
+		}
+
+		// +raw fields are emitted as their original node
+		if tag.Raw {
+			if v, ok := unbindRawNode(fieldVal); ok {
+				out[name] = v
+			}
+			continue
+		}
+
+		if tag.Format != "" && isTimeField(field.Type) {
+			t := fieldVal
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			out[name] = formatTime(t.Interface().(time.Time), tag.Format)
+			continue
+		}
+
+		fieldOpt := opt
+		if tag.PlainRef && (opt == nil || !opt.PlainRefs) {
+			plain := Options{}
+			if opt != nil {
+				plain = *opt
+			}
+			plain.PlainRefs = true
+			fieldOpt = &plain
+		}
+		v, ok, err := valueToInterface(fieldVal, fieldOpt)
 		if err != nil {
 			return nil, &UnbindingError{Path: structType.Name(), Field: field.Name, Key: name, Cause: err}
 		}
@@ -107,6 +196,9 @@ func structToMap(structVal reflect.Value, opt *Options) (map[string]any, error)
 			// nothing to emit (e.g., nil pointer)
 			continue
 		}
+		if tag.Keyed {
+			v = discriminatedToKeyed(v)
+		}
 		// omit struct fields that unbind to empty maps when +omitempty is set
 		if tag.OmitEmpty {
 			if m, ok := v.(map[string]any); ok && len(m) == 0 {
@@ -117,17 +209,13 @@ func structToMap(structVal reflect.Value, opt *Options) (map[string]any, error)
 	}
 
 	// merge extra field contents into output
-	for i := 0; i < structVal.NumField(); i++ {
-		field := structType.Field(i)
-		if field.PkgPath != "" {
-			continue
-		}
-		tag := parseDdTag(field)
-		if !tag.Extra {
+	for _, meta := range fields {
+		field := meta.field
+		if field.PkgPath != "" || !meta.tag.Extra {
 			continue
 		}
 
-		fieldVal := structVal.Field(i)
+		fieldVal := structVal.Field(meta.index)
 		if fieldVal.IsNil() {
 			continue
 		}
@@ -197,11 +285,46 @@ func valueToInterface(v reflect.Value, opt *Options) (interface{}, bool, error)
 		return t.Format(time.RFC3339), true, nil
 	}
 
+	// special-case math/big values, emitted as strings to preserve precision
+	if out, ok := unbindBig(v); ok {
+		return out, true, nil
+	}
+
+	// UUID-shaped arrays are emitted in canonical string form
+	if out, ok := unbindUUID(v); ok {
+		return out, true, nil
+	}
+
+	// netip values are emitted in their string form
+	if out, ok := unbindNetip(v); ok {
+		return out, true, nil
+	}
+
+	// encoding.TextMarshaler types are emitted in their text form
+	if out, handled, err := unbindText(v); handled {
+		return out, err == nil, err
+	}
+
+	// pass-through subtrees are emitted unchanged
+	if out, present, handled, err := unbindRaw(v); handled {
+		return out, present, err
+	}
+
+	// optionals are emitted only when set
+	if out, present, handled, err := unbindOptional(v, opt); handled {
+		return out, present, err
+	}
+
+	// json.Marshaler types are emitted in their decoded JSON form, when enabled
+	if out, handled, err := unbindJSON(v, opt); handled {
+		return out, err == nil, err
+	}
+
 	switch v.Kind() {
 	case reflect.Struct:
 		// check if this is a Pointer[T] type
 		if isPointerType(v.Type()) {
-			return pointerToMap(v)
+			return pointerToMap(v, opt)
 		}
 
 		// if the concrete struct implements Dynamic (directly or via pointer receiver),
@@ -324,6 +447,14 @@ func valueToInterface(v reflect.Value, opt *Options) (interface{}, bool, error)
 			}
 			return m, true, nil
 		}
+		// registered interface implementations carry their discriminator
+		if name, ok := interfaceTypeName(v.Type(), v.Elem(), opt); ok {
+			out, present, err := valueToInterface(v.Elem(), opt)
+			if m, isMap := out.(map[string]any); isMap && err == nil {
+				m[TypeKey] = name
+			}
+			return out, present, err
+		}
 		// for interface{} or any types, unwrap and process the actual value
 		return valueToInterface(v.Elem(), opt)
 
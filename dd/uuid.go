@@ -0,0 +1,92 @@
+package dd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// isUUIDType reports whether t is shaped like a UUID ([16]byte, including named types such as uuid.UUID). such
+// fields are bound from and unbound to the canonical string form without requiring a registered converter.
+func isUUIDType(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
+// bindUUID sets a UUID-shaped destination from a raw string (or a value of a UUID-shaped type). returns false if
+// dst is not UUID-shaped.
+func bindUUID(dst reflect.Value, raw interface{}, path string) (bool, error) {
+	if !isUUIDType(dst.Type()) {
+		return false, nil
+	}
+	switch v := raw.(type) {
+	case string:
+		u, err := parseUUID(v)
+		if err != nil {
+			return true, &ConversionError{Path: path, Value: v, Type: "uuid", Cause: err}
+		}
+		reflect.Copy(dst, reflect.ValueOf(u[:]))
+		return true, nil
+	default:
+		rv := reflect.ValueOf(raw)
+		if rv.IsValid() && isUUIDType(rv.Type()) {
+			dst.Set(rv.Convert(dst.Type()))
+			return true, nil
+		}
+		return true, &TypeMismatchError{Path: path, Expected: "uuid string", Actual: fmt.Sprintf("%T", raw)}
+	}
+}
+
+// unbindUUID formats a UUID-shaped value in canonical form. returns false if v is not UUID-shaped.
+func unbindUUID(v reflect.Value) (interface{}, bool) {
+	if !isUUIDType(v.Type()) {
+		return nil, false
+	}
+	var u [16]byte
+	reflect.Copy(reflect.ValueOf(u[:]), v)
+	return formatUUID(u), true
+}
+
+// parseUUID parses the canonical 8-4-4-4-12 form, optionally wrapped in braces or prefixed with "urn:uuid:", as well
+// as the 32-digit form without hyphens. hex digits are case-insensitive.
+func parseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+	in := strings.TrimSpace(s)
+	switch {
+	case len(in) == 38 && in[0] == '{' && in[37] == '}':
+		in = in[1:37]
+	case len(in) == 45 && strings.EqualFold(in[:9], "urn:uuid:"):
+		in = in[9:]
+	}
+
+	switch len(in) {
+	case 36:
+		if in[8] != '-' || in[13] != '-' || in[18] != '-' || in[23] != '-' {
+			return u, fmt.Errorf("invalid uuid format %q", s)
+		}
+		in = in[0:8] + in[9:13] + in[14:18] + in[19:23] + in[24:]
+	case 32:
+	default:
+		return u, fmt.Errorf("invalid uuid length %d", len(s))
+	}
+
+	if _, err := hex.Decode(u[:], []byte(in)); err != nil {
+		return u, fmt.Errorf("invalid uuid %q: %w", s, err)
+	}
+	return u, nil
+}
+
+// formatUUID renders u in canonical lowercase 8-4-4-4-12 form.
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
@@ -0,0 +1,60 @@
+package dd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testUUID mirrors the shape of github.com/google/uuid.UUID.
+type testUUID [16]byte
+
+type uuidConfig struct {
+	ID      testUUID
+	Parent  *testUUID
+	Members []testUUID
+	Owners  map[testUUID]string
+}
+
+const sampleUUID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+func TestBindUUID(t *testing.T) {
+	data := map[string]any{
+		"id":      sampleUUID,
+		"parent":  "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}",
+		"members": []any{"urn:uuid:" + sampleUUID, "6ba7b8109dad11d180b400c04fd430c8"},
+		"owners":  map[string]any{sampleUUID: "alice"},
+	}
+
+	cfg, err := New[uuidConfig](data)
+	assert.NoError(t, err)
+	expected := testUUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	assert.Equal(t, expected, cfg.ID)
+	assert.Equal(t, expected, *cfg.Parent)
+	assert.Equal(t, []testUUID{expected, expected}, cfg.Members)
+	assert.Equal(t, "alice", cfg.Owners[expected])
+}
+
+func TestBindUUIDInvalid(t *testing.T) {
+	for _, bad := range []any{"not-a-uuid", "6ba7b810-9dad-11d1-80b4-00c04fd430cz", "6ba7b810x9dad-11d1-80b4-00c04fd430c8", 42} {
+		_, err := New[uuidConfig](map[string]any{"id": bad})
+		assert.Error(t, err, "%v", bad)
+	}
+}
+
+func TestUnbindUUIDRoundTrip(t *testing.T) {
+	id, _ := parseUUID(sampleUUID)
+	parent := testUUID(id)
+	cfg := &uuidConfig{ID: id, Parent: &parent, Members: []testUUID{id}, Owners: map[testUUID]string{id: "bob"}}
+
+	data, err := Unbind(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, sampleUUID, data["id"])
+	assert.Equal(t, sampleUUID, data["parent"])
+	assert.Equal(t, []any{sampleUUID}, data["members"])
+	assert.Equal(t, map[string]any{sampleUUID: "bob"}, data["owners"])
+
+	out, err := New[uuidConfig](data)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, out)
+}
@@ -0,0 +1,83 @@
+package dd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errPortRange = errors.New("min_port must not exceed max_port")
+
+type validatorRange struct {
+	MinPort int
+	MaxPort int
+}
+
+func (r *validatorRange) Validate() error {
+	if r.MinPort > r.MaxPort {
+		return errPortRange
+	}
+	return nil
+}
+
+type validatorConfig struct {
+	Mode   string
+	Ranges []validatorRange
+	Cert   string
+}
+
+func (c validatorConfig) Validate() error {
+	if c.Mode == "tls" && c.Cert == "" {
+		return errors.New("tls mode requires cert")
+	}
+	return nil
+}
+
+type ValidatorPorts struct {
+	MinPort int
+	MaxPort int
+}
+
+func (p ValidatorPorts) Validate() error {
+	if p.MinPort > p.MaxPort {
+		return errPortRange
+	}
+	return nil
+}
+
+type validatorEmbedding struct {
+	ValidatorPorts
+	Name string
+}
+
+func TestValidatorInvokedAfterBind(t *testing.T) {
+	cfg, err := New[validatorConfig](map[string]any{"mode": "tls", "cert": "cert.pem", "ranges": []any{map[string]any{"min_port": 1, "max_port": 2}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "cert.pem", cfg.Cert)
+
+	_, err = New[validatorConfig](map[string]any{"mode": "tls"})
+	var ve *ValidationError
+	if assert.True(t, errors.As(err, &ve)) {
+		assert.Equal(t, "validatorConfig", ve.Field)
+		assert.Contains(t, err.Error(), "tls mode requires cert")
+	}
+}
+
+func TestValidatorNested(t *testing.T) {
+	_, err := New[validatorConfig](map[string]any{"ranges": []any{map[string]any{"min_port": 5, "max_port": 2}}})
+	assert.True(t, errors.Is(err, errPortRange))
+	assert.Contains(t, err.Error(), "validatorConfig.Ranges[0]")
+}
+
+func TestValidatorOnMerge(t *testing.T) {
+	cfg := &validatorConfig{Mode: "plain"}
+	assert.NoError(t, Merge(cfg, map[string]any{"cert": "cert.pem"}))
+	cfg.Cert = ""
+	assert.Error(t, Merge(cfg, map[string]any{"mode": "tls"}))
+}
+
+func TestValidatorEmbedded(t *testing.T) {
+	_, err := New[validatorEmbedding](map[string]any{"min_port": 3, "max_port": 1})
+	assert.True(t, errors.Is(err, errPortRange))
+}
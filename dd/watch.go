@@ -0,0 +1,156 @@
+package dd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher holds a configuration loaded from a file, re-binding it whenever the file changes; see WatchJSONFile.
+type Watcher[T any] struct {
+	path     string
+	decode   func([]byte) (*T, error)
+	onChange func(old, new *T) error
+	fs       *fsnotify.Watcher
+	current  atomic.Pointer[T]
+	data     []byte
+	err      atomic.Pointer[error]
+	done     chan struct{}
+	close    sync.Once
+}
+
+// WatchJSONFile loads the JSON file at path into a new T, then watches it for changes until Close is called. on each
+// change, the file is bound into a fresh T (with the usual validation), and onChange, if not nil, is called with the
+// current and new values. the new value replaces the current one, as returned by Current, only when binding succeeds
+// and onChange returns nil; otherwise the current value is kept, and the error is reported by Err.
+//
+// the file's directory is watched rather than the file itself, so files replaced by an editor or by an atomic rename
+// (as with Kubernetes ConfigMap volumes) are followed; changes are detected by content, so a save that leaves the
+// file unchanged does not call onChange. onChange runs on the watcher's goroutine, and calls are never concurrent.
+//
+// an error is returned if the file cannot be loaded initially, or cannot be watched.
+func WatchJSONFile[T any](path string, onChange func(old, new *T) error, opts ...*Options) (*Watcher[T], error) {
+	return watchFile(path, func(data []byte) (*T, error) {
+		return NewJSON[T](data, opts...)
+	}, onChange)
+}
+
+// WatchYAMLFile loads the YAML file at path into a new T, then watches it for changes until Close is called; see
+// WatchJSONFile.
+func WatchYAMLFile[T any](path string, onChange func(old, new *T) error, opts ...*Options) (*Watcher[T], error) {
+	return watchFile(path, func(data []byte) (*T, error) {
+		return NewYAML[T](data, opts...)
+	}, onChange)
+}
+
+func watchFile[T any](path string, decode func([]byte) (*T, error), onChange func(old, new *T) error) (*Watcher[T], error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, &FileError{Path: path, Operation: "watch", Cause: err}
+	}
+	w := &Watcher[T]{path: path, decode: decode, onChange: onChange, done: make(chan struct{})}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &FileError{Path: path, Operation: "read", Cause: err}
+	}
+	initial, err := decode(data)
+	if err != nil {
+		return nil, withFile(err, path)
+	}
+	w.current.Store(initial)
+	w.data = data
+
+	w.fs, err = fsnotify.NewWatcher()
+	if err != nil {
+		return nil, &FileError{Path: path, Operation: "watch", Cause: err}
+	}
+	if err := w.fs.Add(filepath.Dir(path)); err != nil {
+		_ = w.fs.Close()
+		return nil, &FileError{Path: path, Operation: "watch", Cause: err}
+	}
+	go w.run()
+	return w, nil
+}
+
+// Current returns the most recently loaded value. it is safe to call from any goroutine; the value returned must be
+// treated as read-only, as it may be shared with other callers.
+func (w *Watcher[T]) Current() *T {
+	return w.current.Load()
+}
+
+// Err returns the error from the most recent reload, or nil when it succeeded (or none has happened yet).
+func (w *Watcher[T]) Err() error {
+	if err := w.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// Close stops watching the file. Current continues to return the last value loaded.
+func (w *Watcher[T]) Close() error {
+	var err error
+	w.close.Do(func() {
+		err = w.fs.Close()
+		<-w.done
+	})
+	return err
+}
+
+func (w *Watcher[T]) run() {
+	defer close(w.done)
+	for {
+		select {
+		case _, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			w.reload()
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			w.setErr(&FileError{Path: w.path, Operation: "watch", Cause: err})
+		}
+	}
+}
+
+// reload re-binds the file when its content has changed since the last successful load.
+func (w *Watcher[T]) reload() {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		// the file is briefly missing while it is replaced; the next event reloads it
+		if !os.IsNotExist(err) {
+			w.setErr(&FileError{Path: w.path, Operation: "read", Cause: err})
+		}
+		return
+	}
+	if bytes.Equal(data, w.data) {
+		return
+	}
+	next, err := w.decode(data)
+	if err != nil {
+		w.setErr(withFile(err, w.path))
+		return
+	}
+	if w.onChange != nil {
+		if err := w.onChange(w.current.Load(), next); err != nil {
+			w.setErr(&HookError{Path: w.path, Hook: "onChange", Cause: err})
+			return
+		}
+	}
+	w.current.Store(next)
+	w.data = data
+	w.setErr(nil)
+}
+
+func (w *Watcher[T]) setErr(err error) {
+	if err == nil {
+		w.err.Store(nil)
+		return
+	}
+	w.err.Store(&err)
+}
@@ -0,0 +1,90 @@
+package dd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type watchConfig struct {
+	Name string `dd:",+required"`
+	Port int
+}
+
+func TestWatchYAMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("name: relay\nport: 80\n"), 0644))
+
+	changes := make(chan [2]*watchConfig, 4)
+	w, err := WatchYAMLFile(path, func(old, new *watchConfig) error {
+		if new.Port == 0 {
+			return errors.New("port required")
+		}
+		changes <- [2]*watchConfig{old, new}
+		return nil
+	})
+	assert.NoError(t, err)
+	defer w.Close()
+	assert.Equal(t, &watchConfig{Name: "relay", Port: 80}, w.Current())
+
+	assert.NoError(t, os.WriteFile(path, []byte("name: relay\nport: 8080\n"), 0644))
+	select {
+	case change := <-changes:
+		assert.Equal(t, 80, change[0].Port)
+		assert.Equal(t, 8080, change[1].Port)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change observed")
+	}
+	assert.Equal(t, 8080, w.Current().Port)
+	assert.NoError(t, w.Err())
+
+	// an invalid file is reported, and the current value kept
+	assert.NoError(t, os.WriteFile(path, []byte("port: 9090\n"), 0644))
+	assert.Eventually(t, func() bool { return w.Err() != nil }, 5*time.Second, 10*time.Millisecond)
+	var required *RequiredFieldError
+	assert.ErrorAs(t, w.Err(), &required)
+	assert.Equal(t, 8080, w.Current().Port)
+
+	// as is a change rejected by onChange
+	assert.NoError(t, os.WriteFile(path, []byte("name: relay\n"), 0644))
+	assert.Eventually(t, func() bool {
+		var hookErr *HookError
+		return errors.As(w.Err(), &hookErr)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 8080, w.Current().Port)
+
+	// files replaced by rename are followed
+	tmp := filepath.Join(filepath.Dir(path), "config.yaml.tmp")
+	assert.NoError(t, os.WriteFile(tmp, []byte("name: edge\nport: 443\n"), 0644))
+	assert.NoError(t, os.Rename(tmp, path))
+	select {
+	case change := <-changes:
+		assert.Equal(t, "edge", change[1].Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change observed")
+	}
+	assert.Equal(t, &watchConfig{Name: "edge", Port: 443}, w.Current())
+	assert.NoError(t, w.Err())
+
+	assert.NoError(t, w.Close())
+	assert.NoError(t, w.Close())
+}
+
+func TestWatchJSONFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := WatchJSONFile[watchConfig](filepath.Join(dir, "missing.json"), nil)
+	var fileErr *FileError
+	assert.ErrorAs(t, err, &fileErr)
+
+	path := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"name": }`), 0644))
+	_, err = WatchJSONFile[watchConfig](path, nil)
+	var syntaxErr *SyntaxError
+	if assert.ErrorAs(t, err, &syntaxErr) {
+		assert.Equal(t, path, syntaxErr.File)
+	}
+}
@@ -0,0 +1,116 @@
+package dd
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// anchorSharedSubtrees rewrites node so that every mapping or sequence repeated within it is emitted once with an
+// anchor and referenced by alias thereafter. anchors are named after the key under which the subtree first appears.
+// subtrees only repeated as part of a larger shared subtree are covered by the larger alias, and receive no anchor of
+// their own.
+func anchorSharedSubtrees(node *yaml.Node) {
+	a := &yamlAnchorer{
+		fingerprints: make(map[*yaml.Node]string),
+		counts:       make(map[string]int),
+		anchors:      make(map[string]*yaml.Node),
+		names:        make(map[string]bool),
+	}
+	a.count(node)
+	a.rewrite(node, "")
+}
+
+type yamlAnchorer struct {
+	fingerprints map[*yaml.Node]string
+	counts       map[string]int
+	anchors      map[string]*yaml.Node
+	names        map[string]bool
+}
+
+// shareable reports whether node may be anchored: a non-empty mapping or sequence that is not already anchored.
+func shareable(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && len(node.Content) > 0 && node.Anchor == ""
+}
+
+// count tallies the occurrences of each shareable subtree, without descending into repeats.
+func (a *yamlAnchorer) count(node *yaml.Node) {
+	if shareable(node) {
+		fp := a.fingerprint(node)
+		a.counts[fp]++
+		if a.counts[fp] > 1 {
+			return
+		}
+	}
+	if node.Kind != yaml.AliasNode {
+		for _, child := range node.Content {
+			a.count(child)
+		}
+	}
+}
+
+// rewrite anchors the first occurrence of each repeated subtree and replaces later occurrences with aliases. key is
+// the mapping key under which node appears, if any.
+func (a *yamlAnchorer) rewrite(node *yaml.Node, key string) {
+	if shareable(node) {
+		fp := a.fingerprint(node)
+		if a.counts[fp] > 1 {
+			if anchor, found := a.anchors[fp]; found {
+				*node = yaml.Node{Kind: yaml.AliasNode, Alias: anchor, Value: anchor.Anchor}
+				return
+			}
+			node.Anchor = a.anchorName(key)
+			a.anchors[fp] = node
+		}
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			a.rewrite(node.Content[i+1], node.Content[i].Value)
+		}
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			a.rewrite(child, key)
+		}
+	}
+}
+
+// anchorName returns a unique anchor name derived from key.
+func (a *yamlAnchorer) anchorName(key string) string {
+	base := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '_'
+	}, key)
+	if base == "" {
+		base = "anchor"
+	}
+	name := base
+	for i := 2; a.names[name]; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	a.names[name] = true
+	return name
+}
+
+// fingerprint returns a structural encoding of node, equal for subtrees that decode to the same value.
+func (a *yamlAnchorer) fingerprint(node *yaml.Node) string {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return a.fingerprint(node.Alias)
+	}
+	if fp, found := a.fingerprints[node]; found {
+		return fp
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d%s%q(", node.Kind, node.ShortTag(), node.Value)
+	for _, child := range node.Content {
+		b.WriteString(a.fingerprint(child))
+		b.WriteByte(',')
+	}
+	b.WriteByte(')')
+	fp := b.String()
+	a.fingerprints[node] = fp
+	return fp
+}
@@ -0,0 +1,122 @@
+package dd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type anchorPool struct {
+	Host    string
+	Timeout int
+	Tags    []string
+}
+
+type anchorConfig struct {
+	Primary   anchorPool
+	Secondary anchorPool
+	Replicas  []anchorPool
+	Template  *yaml.Node `dd:",+raw"`
+}
+
+func TestYAMLMergeKeys(t *testing.T) {
+	input := `defaults: &defaults
+  timeout: 30
+  tags: [a, b]
+tmpl: &tmpl
+  kind: web
+primary:
+  <<: *defaults
+  host: db1
+secondary:
+  <<: [*defaults]
+  timeout: 5
+  host: db2
+template:
+  <<: *tmpl
+`
+	type config struct {
+		Primary   anchorPool
+		Secondary anchorPool
+		Template  *yaml.Node     `dd:",+raw"`
+		Extra     map[string]any `dd:",+extra"`
+	}
+	cfg, err := NewYAML[config]([]byte(input))
+	assert.NoError(t, err)
+	assert.Equal(t, anchorPool{Host: "db1", Timeout: 30, Tags: []string{"a", "b"}}, cfg.Primary)
+	assert.Equal(t, anchorPool{Host: "db2", Timeout: 5, Tags: []string{"a", "b"}}, cfg.Secondary)
+	// a raw node keeps its merge key, with the alias expanded so that it can be emitted on its own
+	assert.Equal(t, yaml.MappingNode, cfg.Template.Kind)
+	assert.Equal(t, "<<", cfg.Template.Content[0].Value)
+	out, err := yaml.Marshal(cfg.Template)
+	assert.NoError(t, err)
+	var decoded map[string]any
+	assert.NoError(t, yaml.Unmarshal(out, &decoded))
+	assert.Equal(t, map[string]any{"kind": "web"}, decoded)
+}
+
+func TestYAMLMergedRawNode(t *testing.T) {
+	input := `base: &base
+  template: {zulu: 1, alpha: 2}
+<<: *base
+`
+	type config struct {
+		Template *yaml.Node     `dd:",+raw"`
+		Extra    map[string]any `dd:",+extra"`
+	}
+	cfg, err := NewYAML[config]([]byte(input))
+	assert.NoError(t, err)
+	// the original node is captured through the merge key, keeping its key order
+	if assert.NotNil(t, cfg.Template) {
+		assert.Equal(t, "zulu", cfg.Template.Content[0].Value)
+	}
+}
+
+func TestYAMLAnchors(t *testing.T) {
+	pool := anchorPool{Host: "db", Timeout: 30, Tags: []string{"a", "b"}}
+	cfg := &anchorConfig{Primary: pool, Secondary: pool, Replicas: []anchorPool{pool, {Host: "r1", Tags: []string{"a", "b"}}}}
+
+	out, err := UnbindYAML(cfg, &Options{YAMLAnchors: true})
+	assert.NoError(t, err)
+	text := string(out)
+	assert.Contains(t, text, "primary: &primary\n")
+	assert.Contains(t, text, "secondary: *primary\n")
+	assert.Contains(t, text, "- *primary\n")
+	// the tags list is shared with the other replica, but only anchored once outside the shared pool
+	assert.Equal(t, 1, strings.Count(text, "&tags"))
+	assert.Equal(t, 1, strings.Count(text, "*tags"))
+
+	again, err := NewYAML[anchorConfig](out)
+	assert.NoError(t, err)
+	assert.Equal(t, cfg, again)
+
+	// without the option, the output is unchanged
+	out, err = UnbindYAML(cfg)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(out), "&")
+}
+
+func TestYAMLAnchorNames(t *testing.T) {
+	data := map[string]any{
+		"a.b": map[string]any{"x": 1},
+		"c":   map[string]any{"x": 1},
+		"d":   []any{map[string]any{"y": 2}, map[string]any{"y": 2}},
+		"e":   map[string]any{"z": map[string]any{"y": 2}},
+	}
+	node := &yaml.Node{}
+	assert.NoError(t, node.Encode(data))
+	anchorSharedSubtrees(node)
+	out, err := yaml.Marshal(node)
+	assert.NoError(t, err)
+	text := string(out)
+	assert.Contains(t, text, "a.b: &a_b\n")
+	assert.Contains(t, text, "c: *a_b\n")
+	assert.Contains(t, text, "- &d\n")
+	assert.Contains(t, text, "z: *d\n")
+
+	var decoded map[string]any
+	assert.NoError(t, yaml.Unmarshal(out, &decoded))
+	assert.Equal(t, data, decoded)
+}
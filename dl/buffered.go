@@ -0,0 +1,151 @@
+package dl
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// BufferOptions configures a BufferedWriter
+type BufferOptions struct {
+	Size          int           // buffer size in bytes, defaults to 64KiB
+	FlushInterval time.Duration // periodic flush interval, defaults to 1s
+	FlushLevel    slog.Leveler  // records at or above this level flush immediately, defaults to slog.LevelError
+	CloseOutput   bool          // Close also closes the underlying writer, if it is an io.Closer other than os.Stdout or os.Stderr
+}
+
+// BufferedWriter buffers log output to reduce syscall overhead for high-volume channels. the buffer is flushed when
+// full, every FlushInterval, and immediately after any record at or above FlushLevel, bounding the amount of output
+// lost on a crash. call Close (or Flush) at shutdown to write out any remaining buffered output. the underlying writer
+// remains the caller's to close, unless BufferOptions.CloseOutput hands it over.
+type BufferedWriter struct {
+	mu          sync.Mutex
+	buf         *bufio.Writer
+	dst         io.Writer
+	flushLevel  slog.Leveler
+	closeOutput bool
+	closed      bool
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// NewBufferedWriter wraps w with a buffer configured by opts (nil uses defaults)
+func NewBufferedWriter(w io.Writer, opts *BufferOptions) *BufferedWriter {
+	if opts == nil {
+		opts = &BufferOptions{}
+	}
+	size := opts.Size
+	if size <= 0 {
+		size = 64 * 1024
+	}
+	interval := opts.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	flushLevel := opts.FlushLevel
+	if flushLevel == nil {
+		flushLevel = slog.LevelError
+	}
+
+	bw := &BufferedWriter{
+		buf:         bufio.NewWriterSize(w, size),
+		dst:         w,
+		flushLevel:  flushLevel,
+		closeOutput: opts.CloseOutput && w != os.Stdout && w != os.Stderr,
+		done:        make(chan struct{}),
+	}
+	go bw.run(interval)
+	return bw
+}
+
+// Write implements io.Writer. once the writer is closed, output is written straight through to the underlying writer,
+// so that nothing is left in a buffer that will never be flushed.
+func (bw *BufferedWriter) Write(p []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if bw.closed {
+		return bw.dst.Write(p)
+	}
+	return bw.buf.Write(p)
+}
+
+// Flush writes any buffered output to the underlying writer
+func (bw *BufferedWriter) Flush() error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.buf.Flush()
+}
+
+// Close stops periodic flushing and flushes remaining output. it closes the underlying writer only when
+// BufferOptions.CloseOutput is set and the writer is an io.Closer.
+func (bw *BufferedWriter) Close() error {
+	var err error
+	bw.closeOnce.Do(func() {
+		close(bw.done)
+		bw.mu.Lock()
+		err = bw.buf.Flush()
+		bw.closed = true
+		bw.mu.Unlock()
+		if !bw.closeOutput {
+			return
+		}
+		if c, ok := bw.dst.(io.Closer); ok {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+	})
+	return err
+}
+
+func (bw *BufferedWriter) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = bw.Flush()
+		case <-bw.done:
+			return
+		}
+	}
+}
+
+// flushHandler flushes a BufferedWriter after handling records at or above its flush level
+type flushHandler struct {
+	slog.Handler
+	bw *BufferedWriter
+}
+
+// withBufferFlush wraps handler so that high-severity records are flushed immediately when output is buffered
+func withBufferFlush(handler slog.Handler, output io.Writer) slog.Handler {
+	if bw, ok := output.(*BufferedWriter); ok {
+		return &flushHandler{Handler: handler, bw: bw}
+	}
+	return handler
+}
+
+// Handle implements slog.Handler.Handle
+func (h *flushHandler) Handle(ctx context.Context, r slog.Record) error {
+	err := h.Handler.Handle(ctx, r)
+	if r.Level >= h.bw.flushLevel.Level() {
+		if ferr := h.bw.Flush(); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
+
+// WithAttrs implements slog.Handler.WithAttrs
+func (h *flushHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &flushHandler{Handler: h.Handler.WithAttrs(attrs), bw: h.bw}
+}
+
+// WithGroup implements slog.Handler.WithGroup
+func (h *flushHandler) WithGroup(name string) slog.Handler {
+	return &flushHandler{Handler: h.Handler.WithGroup(name), bw: h.bw}
+}
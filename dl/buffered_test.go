@@ -0,0 +1,129 @@
+package dl
+
+import (
+	"bytes"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use by the flush goroutine and the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestBufferedWriterHoldsUntilFlush(t *testing.T) {
+	dst := &syncBuffer{}
+	bw := NewBufferedWriter(dst, &BufferOptions{FlushInterval: time.Hour})
+	defer bw.Close()
+
+	_, err := bw.Write([]byte("hello\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", dst.String())
+
+	assert.NoError(t, bw.Flush())
+	assert.Equal(t, "hello\n", dst.String())
+}
+
+func TestBufferedWriterPeriodicFlush(t *testing.T) {
+	dst := &syncBuffer{}
+	bw := NewBufferedWriter(dst, &BufferOptions{FlushInterval: 10 * time.Millisecond})
+	defer bw.Close()
+
+	_, _ = bw.Write([]byte("tick\n"))
+	assert.Eventually(t, func() bool { return dst.String() == "tick\n" }, time.Second, 5*time.Millisecond)
+}
+
+func TestBufferedWriterSizeFlush(t *testing.T) {
+	dst := &syncBuffer{}
+	bw := NewBufferedWriter(dst, &BufferOptions{Size: 16, FlushInterval: time.Hour})
+	defer bw.Close()
+
+	_, _ = bw.Write([]byte("0123456789abcdefXYZ"))
+	assert.NotEqual(t, "", dst.String())
+}
+
+func TestBufferedOutputFlushesOnError(t *testing.T) {
+	dst := &syncBuffer{}
+	opts := DefaultOptions().SetOutput(dst).JSON().Buffered(&BufferOptions{FlushInterval: time.Hour})
+	defer opts.Output.(*BufferedWriter).Close()
+
+	logger := slog.New(NewDfHandler(opts)).With("channel", "test")
+	logger.Info("buffered")
+	assert.Equal(t, "", dst.String())
+
+	logger.Error("urgent")
+	out := dst.String()
+	assert.Contains(t, out, "buffered")
+	assert.Contains(t, out, "urgent")
+}
+
+func TestBufferedWriterCustomFlushLevel(t *testing.T) {
+	dst := &syncBuffer{}
+	bw := NewBufferedWriter(dst, &BufferOptions{FlushInterval: time.Hour, FlushLevel: slog.LevelWarn})
+	defer bw.Close()
+
+	cm := NewChannelManager(DefaultOptions())
+	cm.ConfigureChannel("audit", DefaultOptions().SetOutput(bw).Pretty().NoColor())
+	cm.GetChannelLogger("audit").Warn("warned")
+	assert.Contains(t, dst.String(), "warned")
+}
+
+// closeRecorder is a syncBuffer that records whether it was closed
+type closeRecorder struct {
+	syncBuffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestBufferedWriterCloseLeavesOutputOpen(t *testing.T) {
+	dst := &closeRecorder{}
+	bw := NewBufferedWriter(dst, &BufferOptions{FlushInterval: time.Hour})
+	_, err := bw.Write([]byte("before\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, bw.Close())
+	assert.Equal(t, "before\n", dst.String())
+	assert.False(t, dst.closed)
+
+	owned := &closeRecorder{}
+	bw = NewBufferedWriter(owned, &BufferOptions{FlushInterval: time.Hour, CloseOutput: true})
+	assert.NoError(t, bw.Close())
+	assert.True(t, owned.closed)
+}
+
+func TestBufferedWriterWritesThroughAfterClose(t *testing.T) {
+	dst := &syncBuffer{}
+	bw := NewBufferedWriter(dst, &BufferOptions{FlushInterval: time.Hour})
+	assert.NoError(t, bw.Close())
+
+	_, err := bw.Write([]byte("late\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "late\n", dst.String())
+}
+
+func TestBufferedWriterNeverClosesStdout(t *testing.T) {
+	opts := DefaultOptions().Buffered(&BufferOptions{FlushInterval: time.Hour, CloseOutput: true})
+	bw := opts.Output.(*BufferedWriter)
+	assert.False(t, bw.closeOutput)
+	assert.NoError(t, bw.Close())
+}
@@ -183,13 +183,13 @@ func (cm *ChannelManager) createHandlerForChannel(channelName string, opts *Opti
 	}
 
 	if opts.UseJSON {
-		return slog.NewJSONHandler(output, &slog.HandlerOptions{
+		return withBufferFlush(slog.NewJSONHandler(output, &slog.HandlerOptions{
 			Level:     opts.Level,
 			AddSource: true,
-		})
+		}), output)
 	}
 
-	return NewPrettyHandlerWithChannel(opts.Level, opts, channelName)
+	return withBufferFlush(NewPrettyHandlerWithChannel(opts.Level, opts, channelName), output)
 }
 
 // copyOptions creates a deep copy of Options
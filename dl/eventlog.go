@@ -0,0 +1,180 @@
+package dl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// EventLogOptions configures the Windows Event Log handler
+type EventLogOptions struct {
+	Source         string            // event source for records without a mapped channel, defaults to "dl"
+	ChannelSources map[string]string // maps channel names to event sources, so each subsystem appears under its own source
+	Level          slog.Leveler      // minimum level, defaults to slog.LevelInfo
+	EventID        uint32            // event id reported for every record, defaults to 1
+}
+
+// eventLogSeverity is the Event Log entry type used for a record
+type eventLogSeverity int
+
+const (
+	eventLogInfo eventLogSeverity = iota
+	eventLogWarning
+	eventLogError
+)
+
+// eventLogWriter writes entries for a single event source
+type eventLogWriter interface {
+	write(severity eventLogSeverity, eventID uint32, msg string) error
+	close() error
+}
+
+// EventLogHandler is a slog.Handler that writes records to the Windows Event Log. on other platforms,
+// NewEventLogHandler returns an error.
+type EventLogHandler struct {
+	opts    *EventLogOptions
+	sources *eventLogSources
+	attrs   []slog.Attr
+	groups  []string
+}
+
+// Enabled implements slog.Handler.Enabled
+func (h *EventLogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle implements slog.Handler.Handle
+func (h *EventLogHandler) Handle(_ context.Context, r slog.Record) error {
+	channel, msg := formatEventLogRecord(r, h.attrs, h.groups)
+	source := h.opts.Source
+	if mapped, ok := h.opts.ChannelSources[channel]; ok && channel != "" {
+		source = mapped
+	}
+	w, err := h.sources.get(source)
+	if err != nil {
+		return err
+	}
+	return w.write(eventLogSeverityFor(r.Level), h.opts.EventID, msg)
+}
+
+// WithAttrs implements slog.Handler.WithAttrs
+func (h *EventLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefixed := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	prefixed = append(prefixed, h.attrs...)
+	for _, a := range attrs {
+		if len(h.groups) > 0 && a.Key != ChannelKey {
+			a.Key = strings.Join(h.groups, ".") + "." + a.Key
+		}
+		prefixed = append(prefixed, a)
+	}
+	return &EventLogHandler{opts: h.opts, sources: h.sources, attrs: prefixed, groups: h.groups}
+}
+
+// WithGroup implements slog.Handler.WithGroup
+func (h *EventLogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := append(append([]string{}, h.groups...), name)
+	return &EventLogHandler{opts: h.opts, sources: h.sources, attrs: h.attrs, groups: groups}
+}
+
+// Close releases all open event sources
+func (h *EventLogHandler) Close() error {
+	return h.sources.closeAll()
+}
+
+func applyEventLogDefaults(opts *EventLogOptions) *EventLogOptions {
+	out := EventLogOptions{}
+	if opts != nil {
+		out = *opts
+	}
+	if out.Source == "" {
+		out.Source = "dl"
+	}
+	if out.Level == nil {
+		out.Level = slog.LevelInfo
+	}
+	if out.EventID == 0 {
+		out.EventID = 1
+	}
+	return &out
+}
+
+func eventLogSeverityFor(level slog.Level) eventLogSeverity {
+	switch {
+	case level >= slog.LevelError:
+		return eventLogError
+	case level >= slog.LevelWarn:
+		return eventLogWarning
+	default:
+		return eventLogInfo
+	}
+}
+
+// formatEventLogRecord renders a record as "message key=value ...", returning the record's channel (if any)
+// separately so that it can select the event source.
+func formatEventLogRecord(r slog.Record, handlerAttrs []slog.Attr, groups []string) (string, string) {
+	var channel string
+	var fields []string
+	add := func(a slog.Attr, prefix string) {
+		if a.Key == ChannelKey {
+			channel = a.Value.String()
+			return
+		}
+		fields = append(fields, fmt.Sprintf("%s%s=%v", prefix, a.Key, a.Value.Any()))
+	}
+	for _, a := range handlerAttrs {
+		add(a, "")
+	}
+	prefix := ""
+	if len(groups) > 0 {
+		prefix = strings.Join(groups, ".") + "."
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		add(a, prefix)
+		return true
+	})
+
+	msg := r.Message
+	if len(fields) > 0 {
+		msg += " " + strings.Join(fields, " ")
+	}
+	return channel, msg
+}
+
+// eventLogSources lazily opens and caches one writer per event source
+type eventLogSources struct {
+	mu      sync.Mutex
+	open    func(source string) (eventLogWriter, error)
+	writers map[string]eventLogWriter
+}
+
+func (s *eventLogSources) get(source string) (eventLogWriter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w, ok := s.writers[source]; ok {
+		return w, nil
+	}
+	w, err := s.open(source)
+	if err != nil {
+		return nil, fmt.Errorf("opening event source '%s': %w", source, err)
+	}
+	s.writers[source] = w
+	return w, nil
+}
+
+func (s *eventLogSources) closeAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstErr error
+	for source, w := range s.writers {
+		if err := w.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(s.writers, source)
+	}
+	return firstErr
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package dl
+
+import "errors"
+
+var errEventLogUnsupported = errors.New("windows event log is only available on windows")
+
+// NewEventLogHandler creates a handler writing to the Windows Event Log; on this platform it always returns an error.
+func NewEventLogHandler(opts *EventLogOptions) (*EventLogHandler, error) {
+	return nil, errEventLogUnsupported
+}
+
+// InstallEventLogSource registers source with the Windows Event Log; on this platform it always returns an error.
+func InstallEventLogSource(source string) error {
+	return errEventLogUnsupported
+}
+
+// RemoveEventLogSource removes a registered event source; on this platform it always returns an error.
+func RemoveEventLogSource(source string) error {
+	return errEventLogUnsupported
+}
@@ -0,0 +1,70 @@
+package dl
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type eventLogEntry struct {
+	source   string
+	severity eventLogSeverity
+	eventID  uint32
+	msg      string
+}
+
+type fakeEventLog struct {
+	source  string
+	entries *[]eventLogEntry
+	closed  bool
+}
+
+func (f *fakeEventLog) write(severity eventLogSeverity, eventID uint32, msg string) error {
+	*f.entries = append(*f.entries, eventLogEntry{source: f.source, severity: severity, eventID: eventID, msg: msg})
+	return nil
+}
+
+func (f *fakeEventLog) close() error {
+	f.closed = true
+	return nil
+}
+
+func newFakeEventLogHandler(opts *EventLogOptions) (*EventLogHandler, *[]eventLogEntry) {
+	entries := &[]eventLogEntry{}
+	sources := &eventLogSources{
+		open: func(source string) (eventLogWriter, error) {
+			return &fakeEventLog{source: source, entries: entries}, nil
+		},
+		writers: make(map[string]eventLogWriter),
+	}
+	return &EventLogHandler{opts: applyEventLogDefaults(opts), sources: sources}, entries
+}
+
+func TestEventLogHandlerChannelSources(t *testing.T) {
+	h, entries := newFakeEventLogHandler(&EventLogOptions{
+		Source:         "myapp",
+		ChannelSources: map[string]string{"database": "myapp-db"},
+	})
+
+	logger := slog.New(h)
+	logger.Info("started", "port", 8080)
+	logger.With(ChannelKey, "database").Error("connection lost", "host", "db1")
+	logger.With(ChannelKey, "http").Warn("slow request")
+	logger.Debug("ignored")
+
+	assert.Len(t, *entries, 3)
+	assert.Equal(t, eventLogEntry{source: "myapp", severity: eventLogInfo, eventID: 1, msg: "started port=8080"}, (*entries)[0])
+	assert.Equal(t, eventLogEntry{source: "myapp-db", severity: eventLogError, eventID: 1, msg: "connection lost host=db1"}, (*entries)[1])
+	assert.Equal(t, "myapp", (*entries)[2].source)
+	assert.Equal(t, eventLogWarning, (*entries)[2].severity)
+
+	assert.NoError(t, h.Close())
+}
+
+func TestEventLogHandlerGroups(t *testing.T) {
+	h, entries := newFakeEventLogHandler(nil)
+	slog.New(h).WithGroup("req").With("id", 7).Info("handled", "status", 200)
+	assert.Equal(t, "handled req.id=7 req.status=200", (*entries)[0].msg)
+	assert.Equal(t, "dl", (*entries)[0].source)
+}
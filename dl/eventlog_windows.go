@@ -0,0 +1,56 @@
+//go:build windows
+
+package dl
+
+import (
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// NewEventLogHandler creates a handler writing to the Windows Event Log. event sources should be registered first
+// (see InstallEventLogSource), typically by the service installer, since registration requires administrator rights.
+func NewEventLogHandler(opts *EventLogOptions) (*EventLogHandler, error) {
+	opts = applyEventLogDefaults(opts)
+	sources := &eventLogSources{open: openEventLogSource, writers: make(map[string]eventLogWriter)}
+	if _, err := sources.get(opts.Source); err != nil {
+		return nil, err
+	}
+	return &EventLogHandler{opts: opts, sources: sources}, nil
+}
+
+// InstallEventLogSource registers source with the Windows Event Log, accepting informational, warning, and error
+// entries. requires administrator rights.
+func InstallEventLogSource(source string) error {
+	return eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info)
+}
+
+// RemoveEventLogSource removes a source registered with InstallEventLogSource.
+func RemoveEventLogSource(source string) error {
+	return eventlog.Remove(source)
+}
+
+type windowsEventLog struct {
+	log *eventlog.Log
+}
+
+func openEventLogSource(source string) (eventLogWriter, error) {
+	l, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &windowsEventLog{log: l}, nil
+}
+
+func (w *windowsEventLog) write(severity eventLogSeverity, eventID uint32, msg string) error {
+	switch severity {
+	case eventLogError:
+		return w.log.Error(eventID, msg)
+	case eventLogWarning:
+		return w.log.Warning(eventID, msg)
+	default:
+		return w.log.Info(eventID, msg)
+	}
+}
+
+func (w *windowsEventLog) close() error {
+	return w.log.Close()
+}
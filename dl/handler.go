@@ -27,13 +27,13 @@ func NewDfHandler(opts *Options) slog.Handler {
 	}
 
 	if opts.UseJSON {
-		return slog.NewJSONHandler(output, &slog.HandlerOptions{
+		return withBufferFlush(slog.NewJSONHandler(output, &slog.HandlerOptions{
 			Level:     opts.Level,
 			AddSource: true,
-		})
+		}), output)
 	}
 
-	return NewPrettyHandler(opts.Level, opts)
+	return withBufferFlush(NewPrettyHandler(opts.Level, opts), output)
 }
 
 // PrettyHandler is a direct port of pfxlog's PrettyHandler for df
@@ -76,7 +76,7 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 		seconds := time.Since(h.options.StartTimestamp).Seconds()
 		timeLabel = fmt.Sprintf("[%8.3f]", seconds)
 	}
-	out.WriteString(h.options.TimestampColor + timeLabel + h.options.getDefaultFgColor())
+	out.WriteString(h.options.colorize(h.options.TimestampColor, timeLabel))
 
 	var level string
 	switch r.Level {
@@ -89,7 +89,7 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 	case slog.LevelDebug:
 		level = h.options.DebugLabel
 	}
-	out.WriteString(" " + level)
+	out.WriteString(" " + h.options.colorize(h.options.levelColor(r.Level), level))
 
 	fs := runtime.CallersFrames([]uintptr{r.PC})
 	f, _ := fs.Next()
@@ -97,7 +97,7 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 	if h.options.TrimPrefix != "" {
 		functionStr = strings.TrimPrefix(functionStr, h.options.TrimPrefix)
 	}
-	out.WriteString(" " + h.options.FunctionColor + functionStr + h.options.getDefaultFgColor())
+	out.WriteString(" " + h.options.colorize(h.options.FunctionColor, functionStr))
 
 	// collect handler attributes
 	allAttrs := make([]slog.Attr, 0, len(h.attrs)+r.NumAttrs())
@@ -112,7 +112,7 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 
 	// add channel name if specified
 	if h.channelName != "" {
-		out.WriteString(h.options.ChannelColor + " |" + h.channelName + "|" + h.options.getDefaultFgColor())
+		out.WriteString(h.options.colorize(h.options.ChannelColor, " |"+h.channelName+"|"))
 	}
 
 	// process all attributes
@@ -120,7 +120,7 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 		if a.Key != ChannelKey {
 			fieldsMap[a.Key] = a.Value.Any()
 		} else {
-			out.WriteString(h.options.ChannelColor + " |" + a.Value.String() + "|" + h.options.getDefaultFgColor())
+			out.WriteString(h.options.colorize(h.options.ChannelColor, " |"+a.Value.String()+"|"))
 		}
 	}
 
@@ -129,7 +129,7 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 		return err
 	}
 	if len(fieldsBytes) > 2 {
-		out.WriteString(" " + h.options.FieldsColor + string(fieldsBytes) + h.options.getDefaultFgColor())
+		out.WriteString(" " + h.options.colorize(h.options.FieldsColor, string(fieldsBytes)))
 	}
 
 	out.WriteString(" " + r.Message)
@@ -0,0 +1,273 @@
+package dl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Backpressure selects what a NetworkHandler does when its queue is full because the collector is slow or unreachable.
+type Backpressure int
+
+const (
+	// DropNewest discards the record being logged (the default); logging never blocks.
+	DropNewest Backpressure = iota
+	// DropOldest discards the oldest queued record to make room; logging never blocks.
+	DropOldest
+	// Block waits for room in the queue, slowing the caller down to the collector's pace.
+	Block
+)
+
+// NetworkOptions configures a NetworkHandler.
+type NetworkOptions struct {
+	// Network is "tcp" or "udp"; defaults to "tcp".
+	Network string
+	// Address is the collector address, e.g. "logs.internal:5170".
+	Address string
+	// Level is the minimum level forwarded; defaults to slog.LevelInfo.
+	Level slog.Leveler
+	// QueueSize is the number of records buffered while the collector is slow or unreachable; defaults to 1024.
+	QueueSize int
+	// Backpressure selects the policy applied when the queue is full; defaults to DropNewest.
+	Backpressure Backpressure
+	// DialTimeout bounds each connection attempt; defaults to 5 seconds.
+	DialTimeout time.Duration
+	// WriteTimeout bounds each record write; defaults to 5 seconds.
+	WriteTimeout time.Duration
+	// ReconnectBackoff is the initial delay between reconnection attempts, doubling up to MaxReconnectBackoff;
+	// defaults to 100ms and 10 seconds.
+	ReconnectBackoff    time.Duration
+	MaxReconnectBackoff time.Duration
+	// DrainTimeout bounds how long Close waits to forward queued records; defaults to 5 seconds.
+	DrainTimeout time.Duration
+	// OnError receives connection and write failures; failures are dropped when nil.
+	OnError func(error)
+}
+
+// NetworkHandler is a slog.Handler that forwards newline-delimited JSON records to a remote collector over TCP or
+// UDP, for environments without a local log agent. use it as Options.CustomHandler for the default logger or for any
+// channel.
+//
+// records are queued and written by a background goroutine, which reconnects with exponential backoff when the
+// connection fails; a record whose write fails is retried on the next connection. over UDP each record is sent as a
+// single datagram. Close should be called during shutdown to forward any queued records.
+type NetworkHandler struct {
+	slog.Handler
+	forwarder *networkForwarder
+}
+
+// NewNetworkHandler creates a new NetworkHandler and starts its background forwarding loop.
+func NewNetworkHandler(opts *NetworkOptions) *NetworkHandler {
+	if opts == nil {
+		opts = &NetworkOptions{}
+	}
+	o := *opts
+	if o.Network == "" {
+		o.Network = "tcp"
+	}
+	if o.Level == nil {
+		o.Level = slog.LevelInfo
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = 1024
+	}
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = 5 * time.Second
+	}
+	if o.WriteTimeout <= 0 {
+		o.WriteTimeout = 5 * time.Second
+	}
+	if o.ReconnectBackoff <= 0 {
+		o.ReconnectBackoff = 100 * time.Millisecond
+	}
+	if o.MaxReconnectBackoff <= 0 {
+		o.MaxReconnectBackoff = 10 * time.Second
+	}
+	if o.DrainTimeout <= 0 {
+		o.DrainTimeout = 5 * time.Second
+	}
+
+	f := &networkForwarder{
+		options: o,
+		queue:   make(chan []byte, o.QueueSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go f.run()
+	return &NetworkHandler{
+		Handler:   slog.NewJSONHandler(f, &slog.HandlerOptions{Level: o.Level, AddSource: true}),
+		forwarder: f,
+	}
+}
+
+// WithAttrs implements slog.Handler.WithAttrs
+func (h *NetworkHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &NetworkHandler{Handler: h.Handler.WithAttrs(attrs), forwarder: h.forwarder}
+}
+
+// WithGroup implements slog.Handler.WithGroup
+func (h *NetworkHandler) WithGroup(name string) slog.Handler {
+	return &NetworkHandler{Handler: h.Handler.WithGroup(name), forwarder: h.forwarder}
+}
+
+// Dropped returns the number of records discarded by the backpressure policy or after Close.
+func (h *NetworkHandler) Dropped() uint64 {
+	return h.forwarder.dropped.Load()
+}
+
+// Close stops accepting records, forwards queued records for up to DrainTimeout, and closes the connection.
+func (h *NetworkHandler) Close() error {
+	h.forwarder.closeOnce.Do(func() {
+		close(h.forwarder.done)
+	})
+	<-h.forwarder.stopped
+	return nil
+}
+
+type networkForwarder struct {
+	options   NetworkOptions
+	queue     chan []byte
+	dropped   atomic.Uint64
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+	enqueueMu sync.Mutex // serializes DropOldest eviction
+	conn      net.Conn
+}
+
+// Write implements io.Writer; the JSON handler issues exactly one Write per record.
+func (f *networkForwarder) Write(p []byte) (int, error) {
+	select {
+	case <-f.done:
+		f.dropped.Add(1)
+		return len(p), nil
+	default:
+	}
+	rec := append([]byte(nil), p...)
+
+	switch f.options.Backpressure {
+	case Block:
+		select {
+		case f.queue <- rec:
+		case <-f.done:
+			f.dropped.Add(1)
+		}
+	case DropOldest:
+		f.enqueueMu.Lock()
+		defer f.enqueueMu.Unlock()
+		for {
+			select {
+			case f.queue <- rec:
+				return len(p), nil
+			default:
+			}
+			select {
+			case <-f.queue:
+				f.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case f.queue <- rec:
+		default:
+			f.dropped.Add(1)
+		}
+	}
+	return len(p), nil
+}
+
+func (f *networkForwarder) run() {
+	defer close(f.stopped)
+	defer f.disconnect()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-f.done:
+			// give queued records until the drain deadline before abandoning them
+			timer := time.NewTimer(f.options.DrainTimeout)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				cancel()
+			case <-ctx.Done():
+			}
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		var rec []byte
+		select {
+		case rec = <-f.queue:
+		case <-f.done:
+			select {
+			case rec = <-f.queue:
+			default:
+				return
+			}
+		}
+		if !f.send(ctx, rec) {
+			// abandoned at the drain deadline
+			f.dropped.Add(uint64(1 + len(f.queue)))
+			return
+		}
+	}
+}
+
+// send writes rec, reconnecting with backoff until it succeeds. returns false if ctx is cancelled first.
+func (f *networkForwarder) send(ctx context.Context, rec []byte) bool {
+	backoff := f.options.ReconnectBackoff
+	for {
+		if f.conn == nil {
+			dialer := net.Dialer{Timeout: f.options.DialTimeout}
+			conn, err := dialer.DialContext(ctx, f.options.Network, f.options.Address)
+			if err != nil {
+				f.report(fmt.Errorf("network log: connecting to %s %s: %w", f.options.Network, f.options.Address, err))
+			} else {
+				f.conn = conn
+				backoff = f.options.ReconnectBackoff
+			}
+		}
+		if f.conn != nil {
+			_ = f.conn.SetWriteDeadline(time.Now().Add(f.options.WriteTimeout))
+			_, err := f.conn.Write(rec)
+			if err == nil {
+				return true
+			}
+			f.report(fmt.Errorf("network log: writing to %s: %w", f.options.Address, err))
+			f.disconnect()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		}
+		backoff *= 2
+		if backoff > f.options.MaxReconnectBackoff {
+			backoff = f.options.MaxReconnectBackoff
+		}
+	}
+}
+
+func (f *networkForwarder) disconnect() {
+	if f.conn != nil {
+		_ = f.conn.Close()
+		f.conn = nil
+	}
+}
+
+func (f *networkForwarder) report(err error) {
+	if f.options.OnError != nil {
+		f.options.OnError(err)
+	}
+}
@@ -0,0 +1,156 @@
+package dl
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// acceptLines collects newline-delimited records from every connection accepted by l
+func acceptLines(l net.Listener) <-chan map[string]any {
+	out := make(chan map[string]any, 16)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func(c net.Conn) {
+				defer c.Close()
+				scanner := bufio.NewScanner(c)
+				for scanner.Scan() {
+					var rec map[string]any
+					if json.Unmarshal(scanner.Bytes(), &rec) == nil {
+						out <- rec
+					}
+				}
+			}(conn)
+		}
+	}()
+	return out
+}
+
+func receive(t *testing.T, ch <-chan map[string]any) map[string]any {
+	t.Helper()
+	select {
+	case rec := <-ch:
+		return rec
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for record")
+		return nil
+	}
+}
+
+func TestNetworkHandlerTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	records := acceptLines(l)
+
+	h := NewNetworkHandler(&NetworkOptions{Address: l.Addr().String()})
+	logger := slog.New(h).With(ChannelKey, "api")
+	logger.Info("hello", "user", "alice")
+	logger.Debug("filtered")
+	logger.Warn("second")
+	assert.NoError(t, h.Close())
+
+	rec := receive(t, records)
+	assert.Equal(t, "hello", rec["msg"])
+	assert.Equal(t, "alice", rec["user"])
+	assert.Equal(t, "api", rec[ChannelKey])
+	assert.Equal(t, "second", receive(t, records)["msg"])
+	assert.Equal(t, uint64(0), h.Dropped())
+}
+
+func TestNetworkHandlerReconnects(t *testing.T) {
+	// reserve an address, then release it so the first connection attempts fail
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := l.Addr().String()
+	assert.NoError(t, l.Close())
+
+	errs := make(chan error, 64)
+	h := NewNetworkHandler(&NetworkOptions{
+		Address:          addr,
+		ReconnectBackoff: 5 * time.Millisecond,
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	defer h.Close()
+	slog.New(h).Info("queued while down")
+
+	select {
+	case err := <-errs:
+		assert.Contains(t, err.Error(), "connecting")
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected a connection error")
+	}
+
+	l, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("could not rebind %s: %v", addr, err)
+	}
+	defer l.Close()
+	records := acceptLines(l)
+	assert.Equal(t, "queued while down", receive(t, records)["msg"])
+}
+
+func TestNetworkHandlerUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer pc.Close()
+
+	h := NewNetworkHandler(&NetworkOptions{Network: "udp", Address: pc.LocalAddr().String()})
+	slog.New(h).Error("datagram", "code", 7)
+	assert.NoError(t, h.Close())
+
+	_ = pc.SetReadDeadline(time.Now().Add(3 * time.Second))
+	buf := make([]byte, 64*1024)
+	n, _, err := pc.ReadFrom(buf)
+	assert.NoError(t, err)
+	var rec map[string]any
+	assert.NoError(t, json.Unmarshal(buf[:n], &rec))
+	assert.Equal(t, "datagram", rec["msg"])
+	assert.Equal(t, float64(7), rec["code"])
+}
+
+func TestNetworkForwarderBackpressure(t *testing.T) {
+	newest := &networkForwarder{options: NetworkOptions{Backpressure: DropNewest}, queue: make(chan []byte, 2), done: make(chan struct{})}
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = newest.Write([]byte(s))
+	}
+	assert.Equal(t, uint64(1), newest.dropped.Load())
+	assert.Equal(t, "a", string(<-newest.queue))
+
+	oldest := &networkForwarder{options: NetworkOptions{Backpressure: DropOldest}, queue: make(chan []byte, 2), done: make(chan struct{})}
+	for _, s := range []string{"a", "b", "c"} {
+		_, _ = oldest.Write([]byte(s))
+	}
+	assert.Equal(t, uint64(1), oldest.dropped.Load())
+	assert.Equal(t, "b", string(<-oldest.queue))
+	assert.Equal(t, "c", string(<-oldest.queue))
+}
+
+func TestNetworkHandlerCloseDropsUndeliverable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := l.Addr().String()
+	assert.NoError(t, l.Close())
+
+	h := NewNetworkHandler(&NetworkOptions{Address: addr, ReconnectBackoff: 5 * time.Millisecond, DrainTimeout: 50 * time.Millisecond})
+	slog.New(h).Info("never delivered")
+	assert.NoError(t, h.Close())
+	assert.Equal(t, uint64(1), h.Dropped())
+
+	slog.New(h).Info("after close")
+	assert.Equal(t, uint64(2), h.Dropped())
+}
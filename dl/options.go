@@ -26,7 +26,7 @@ type Options struct {
 	InfoLabel    string
 	DebugLabel   string
 
-	// colors
+	// colors, applied only when UseColor is set; see Theme and SetTheme
 	TimestampColor string
 	FunctionColor  string
 	ChannelColor   string
@@ -51,21 +51,10 @@ func DefaultOptions() *Options {
 		WarningLabel:    "WARNING",
 		InfoLabel:       "   INFO",
 		DebugLabel:      "  DEBUG",
-		TimestampColor:  "\033[90m", // dark gray
-		FunctionColor:   "\033[36m", // cyan
-		ChannelColor:    "\033[35m", // magenta
-		FieldsColor:     "\033[33m", // yellow
-		DefaultFgColor:  "\033[0m",  // reset
-		ErrorColor:      "\033[31m", // red
-		WarningColor:    "\033[33m", // yellow
-		InfoColor:       "\033[37m", // white
-		DebugColor:      "\033[34m", // blue
 	}
-	if out.UseColor && !out.UseJSON {
-		out.ErrorLabel = out.ErrorColor + out.ErrorLabel + out.DefaultFgColor
-		out.WarningLabel = out.WarningColor + out.WarningLabel + out.DefaultFgColor
-		out.InfoLabel = out.InfoColor + out.InfoLabel + out.DefaultFgColor
-		out.DebugLabel = out.DebugColor + out.DebugLabel + out.DefaultFgColor
+	out.SetTheme(DarkTheme)
+	if theme, ok := ThemeByName(os.Getenv("DL_THEME")); ok {
+		out.SetTheme(theme)
 	}
 	return out
 }
@@ -112,6 +101,17 @@ func (o *Options) SetOutput(w io.Writer) *Options {
 	return o
 }
 
+// Buffered wraps the current output destination in a BufferedWriter configured by opts (nil uses defaults).
+// intended for file outputs; close the returned writer (available as Output) at shutdown.
+func (o *Options) Buffered(opts *BufferOptions) *Options {
+	output := o.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	o.Output = NewBufferedWriter(output, opts)
+	return o
+}
+
 // isTerminal checks if stdout is a terminal
 func isTerminal() bool {
 	if env := os.Getenv("DL_USE_JSON"); env != "" {
@@ -126,20 +126,16 @@ func isTerminal() bool {
 	return (fileInfo.Mode() & os.ModeCharDevice) != 0
 }
 
-// shouldUseColor checks environment variables to determine if color should be used
+// shouldUseColor checks environment variables to determine if color should be used. DL_USE_COLOR takes
+// precedence; otherwise a non-empty NO_COLOR (see https://no-color.org) disables color.
 func shouldUseColor() bool {
 	if env := os.Getenv("DL_USE_COLOR"); env != "" {
 		if val, err := strconv.ParseBool(env); err == nil {
 			return val
 		}
 	}
-	return true
-}
-
-// getDefaultFgColor returns the default foreground color (reset sequence)
-func (o *Options) getDefaultFgColor() string {
-	if o.UseColor {
-		return o.DefaultFgColor
+	if os.Getenv("NO_COLOR") != "" {
+		return false
 	}
-	return ""
+	return true
 }
@@ -0,0 +1,522 @@
+package dl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OTLPProtocol selects the transport an OTLPHandler exports with.
+type OTLPProtocol int
+
+const (
+	// OTLPHTTP exports over OTLP/HTTP with JSON encoding (the default).
+	OTLPHTTP OTLPProtocol = iota
+	// OTLPGRPC exports over OTLP/gRPC: protobuf encoding over HTTP/2.
+	OTLPGRPC
+)
+
+// OTLPOptions configures an OTLPHandler.
+type OTLPOptions struct {
+	// Protocol selects OTLP/HTTP (the default) or OTLP/gRPC.
+	Protocol OTLPProtocol
+	// Endpoint is, for OTLP/HTTP, the full URL of the collector's logs endpoint, e.g. "http://localhost:4318/v1/logs".
+	// for OTLP/gRPC it is the collector's address, e.g. "localhost:4317" (plaintext) or "https://collector:4317".
+	Endpoint string
+	// Headers are added to every export request (authentication tokens, tenant ids, etc.).
+	Headers map[string]string
+	// ServiceName populates the "service.name" resource attribute.
+	ServiceName string
+	// Resource holds additional resource attributes describing the process emitting the logs.
+	Resource map[string]string
+	// Level is the minimum level exported; defaults to slog.LevelInfo.
+	Level slog.Leveler
+	// BatchSize is the number of records buffered before an export is triggered; defaults to 512.
+	BatchSize int
+	// FlushInterval is the maximum time a record is buffered before export; defaults to 5 seconds.
+	FlushInterval time.Duration
+	// MaxQueueSize bounds the number of records buffered while an export is in progress or being retried; when the
+	// queue is full the oldest record is dropped. defaults to 4 times BatchSize, and is never less than BatchSize.
+	MaxQueueSize int
+	// Client is the HTTP client used for exports; defaults to a client with a 10 second timeout (speaking HTTP/2 only,
+	// for OTLP/gRPC). a client given for OTLP/gRPC must support HTTP/2, including over plaintext for "http" endpoints.
+	Client *http.Client
+	// MaxRetries bounds how many times an export that failed with a retryable error (a connection failure, or a
+	// throttled or unavailable collector) is retried; defaults to 5. set it negative to disable retries.
+	MaxRetries int
+	// RetryBackoff is the initial delay between retries, doubling after each; defaults to 500ms. a longer delay
+	// requested by the collector (HTTP Retry-After) is honored.
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the delay between retries; defaults to 30 seconds.
+	MaxRetryBackoff time.Duration
+	// OnError receives export failures, after any retries; failures are dropped when nil.
+	OnError func(error)
+}
+
+// OTLPHandler is a slog.Handler that batches records and exports them to an OpenTelemetry collector using OTLP/HTTP
+// with JSON encoding, or OTLP/gRPC. use it as Options.CustomHandler for the default logger or for any channel.
+//
+// records are exported when a batch fills, when the flush interval elapses, or when Flush or Close are called. Close
+// should be called during shutdown to export any buffered records. exports that fail with a retryable error are
+// retried with exponential backoff; a batch that still fails is dropped and reported to OnError. while the collector
+// is unreachable, at most MaxQueueSize records are buffered, dropping the oldest.
+type OTLPHandler struct {
+	exporter *otlpExporter
+	attrs    []slog.Attr
+	groups   []string
+}
+
+// NewOTLPHandler creates a new OTLPHandler and starts its background flush loop.
+func NewOTLPHandler(opts *OTLPOptions) *OTLPHandler {
+	if opts == nil {
+		opts = &OTLPOptions{}
+	}
+	o := *opts
+	if o.Level == nil {
+		o.Level = slog.LevelInfo
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 512
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = 5 * time.Second
+	}
+	if o.MaxQueueSize <= 0 {
+		o.MaxQueueSize = 4 * o.BatchSize
+	}
+	if o.MaxQueueSize < o.BatchSize {
+		o.MaxQueueSize = o.BatchSize
+	}
+	if o.Client == nil {
+		o.Client = &http.Client{Timeout: 10 * time.Second}
+		if o.Protocol == OTLPGRPC {
+			o.Client.Transport = grpcTransport()
+		}
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = 5
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = 500 * time.Millisecond
+	}
+	if o.MaxRetryBackoff <= 0 {
+		o.MaxRetryBackoff = 30 * time.Second
+	}
+
+	e := &otlpExporter{
+		options:  o,
+		resource: otlpResource(&o),
+		flushCh:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go e.run()
+	return &OTLPHandler{exporter: e}
+}
+
+// Enabled implements slog.Handler.Enabled
+func (h *OTLPHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.exporter.options.Level.Level()
+}
+
+// Handle implements slog.Handler.Handle
+func (h *OTLPHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := make([]otlpKeyValue, 0, len(h.attrs)+r.NumAttrs()+1)
+	for _, a := range h.attrs {
+		attrs = appendOTLPAttr(attrs, "", a)
+	}
+	prefix := groupPrefix(h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendOTLPAttr(attrs, prefix, a)
+		return true
+	})
+	if r.PC != 0 {
+		fs := runtime.CallersFrames([]uintptr{r.PC})
+		f, _ := fs.Next()
+		if f.Function != "" {
+			attrs = append(attrs, otlpKeyValue{Key: "code.function", Value: otlpAnyValue{StringValue: &f.Function}})
+		}
+	}
+
+	ts := r.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	body := r.Message
+	rec := otlpLogRecord{
+		TimeUnixNano:         strconv.FormatInt(ts.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber:       otlpSeverity(r.Level),
+		SeverityText:         r.Level.String(),
+		Body:                 otlpAnyValue{StringValue: &body},
+		Attributes:           attrs,
+	}
+	h.exporter.enqueue(rec)
+	return nil
+}
+
+// WithAttrs implements slog.Handler.WithAttrs
+func (h *OTLPHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefix := groupPrefix(h.groups)
+	out := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	out = append(out, h.attrs...)
+	for _, a := range attrs {
+		if prefix != "" {
+			a.Key = prefix + a.Key
+		}
+		out = append(out, a)
+	}
+	return &OTLPHandler{exporter: h.exporter, attrs: out, groups: h.groups}
+}
+
+// WithGroup implements slog.Handler.WithGroup
+func (h *OTLPHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := append(append([]string{}, h.groups...), name)
+	return &OTLPHandler{exporter: h.exporter, attrs: h.attrs, groups: groups}
+}
+
+// Flush synchronously exports any buffered records, retrying as needed.
+func (h *OTLPHandler) Flush() error {
+	return h.exporter.flush(nil)
+}
+
+// Dropped returns the number of records discarded because the queue was full, because their export failed, or
+// because they arrived after Close.
+func (h *OTLPHandler) Dropped() uint64 {
+	return h.exporter.dropped.Load()
+}
+
+// Close stops the background flush loop and exports any buffered records. the handler drops records after Close.
+func (h *OTLPHandler) Close() error {
+	h.exporter.closeOnce.Do(func() {
+		close(h.exporter.done)
+		<-h.exporter.stopped
+	})
+	return h.exporter.flush(nil)
+}
+
+type otlpExporter struct {
+	options   OTLPOptions
+	resource  otlpResourceSpec
+	mu        sync.Mutex
+	pending   []otlpLogRecord
+	dropped   atomic.Uint64
+	sendMu    sync.Mutex
+	flushCh   chan struct{}
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+func (e *otlpExporter) enqueue(rec otlpLogRecord) {
+	select {
+	case <-e.done:
+		e.dropped.Add(1)
+		return
+	default:
+	}
+	e.mu.Lock()
+	e.pending = append(e.pending, rec)
+	e.trimPending()
+	full := len(e.pending) >= e.options.BatchSize
+	e.mu.Unlock()
+	if full {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// trimPending drops the oldest pending records beyond MaxQueueSize. e.mu must be held.
+func (e *otlpExporter) trimPending() {
+	if excess := len(e.pending) - e.options.MaxQueueSize; excess > 0 {
+		e.pending = e.pending[excess:]
+		e.dropped.Add(uint64(excess))
+	}
+}
+
+func (e *otlpExporter) run() {
+	defer close(e.stopped)
+	ticker := time.NewTicker(e.options.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.report(e.flush(e.done))
+		case <-e.flushCh:
+			e.report(e.flush(e.done))
+		case <-e.done:
+			return
+		}
+	}
+}
+
+func (e *otlpExporter) report(err error) {
+	if err != nil && e.options.OnError != nil {
+		e.options.OnError(err)
+	}
+}
+
+// flush exports the pending records, retrying with backoff. if stop is closed while waiting to retry, the records are
+// returned to the front of the queue for the final flush by Close.
+func (e *otlpExporter) flush(stop <-chan struct{}) error {
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+
+	e.mu.Lock()
+	batch := e.pending
+	e.pending = nil
+	e.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	if e.options.Endpoint == "" {
+		e.dropped.Add(uint64(len(batch)))
+		return fmt.Errorf("otlp: no endpoint configured; dropped %d records", len(batch))
+	}
+
+	payload := otlpExportRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource: e.resource,
+		ScopeLogs: []otlpScopeLogs{{
+			Scope:      otlpScope{Name: "github.com/michaelquigley/df/dl"},
+			LogRecords: batch,
+		}},
+	}}}
+	send := e.sendHTTP
+	if e.options.Protocol == OTLPGRPC {
+		send = e.sendGRPC
+	}
+
+	backoff := e.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := send(payload)
+		if err == nil {
+			return nil
+		}
+		var retryable *otlpRetryableError
+		if !errors.As(err, &retryable) || attempt >= e.options.MaxRetries {
+			e.dropped.Add(uint64(len(batch)))
+			if attempt > 0 {
+				return fmt.Errorf("otlp: exporting %d records (%d attempts): %w", len(batch), attempt+1, err)
+			}
+			return fmt.Errorf("otlp: exporting %d records: %w", len(batch), err)
+		}
+		delay := backoff
+		if retryable.delay > delay {
+			delay = retryable.delay
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-stop:
+			timer.Stop()
+			e.mu.Lock()
+			e.pending = append(batch, e.pending...)
+			e.trimPending()
+			e.mu.Unlock()
+			return nil
+		}
+		backoff *= 2
+		if backoff > e.options.MaxRetryBackoff {
+			backoff = e.options.MaxRetryBackoff
+		}
+	}
+}
+
+// sendHTTP exports payload over OTLP/HTTP with JSON encoding.
+func (e *otlpExporter) sendHTTP(payload otlpExportRequest) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, e.options.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.options.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.options.Client.Do(req)
+	if err != nil {
+		return &otlpRetryableError{err: err}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := fmt.Errorf("collector returned %s", resp.Status)
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return &otlpRetryableError{err: err, delay: retryAfter(resp.Header.Get("Retry-After"))}
+		}
+		return err
+	}
+	return nil
+}
+
+// otlpRetryableError marks an export failure that may succeed when retried, no sooner than delay.
+type otlpRetryableError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *otlpRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *otlpRetryableError) Unwrap() error {
+	return e.err
+}
+
+// retryAfter parses the delay of an HTTP Retry-After header given in seconds; zero if absent or a date.
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// otlpSeverity maps slog levels onto the OpenTelemetry severity number range (DEBUG=5, INFO=9, WARN=13, ERROR=17).
+func otlpSeverity(level slog.Level) int {
+	sev := int(level) + 9
+	if sev < 1 {
+		return 1
+	}
+	if sev > 24 {
+		return 24
+	}
+	return sev
+}
+
+func otlpResource(o *OTLPOptions) otlpResourceSpec {
+	var attrs []otlpKeyValue
+	if o.ServiceName != "" {
+		name := o.ServiceName
+		attrs = append(attrs, otlpKeyValue{Key: "service.name", Value: otlpAnyValue{StringValue: &name}})
+	}
+	for k, v := range o.Resource {
+		v := v
+		attrs = append(attrs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: &v}})
+	}
+	return otlpResourceSpec{Attributes: attrs}
+}
+
+func groupPrefix(groups []string) string {
+	prefix := ""
+	for _, g := range groups {
+		prefix += g + "."
+	}
+	return prefix
+}
+
+// appendOTLPAttr flattens an slog attribute (including groups) into OTLP key/value pairs.
+func appendOTLPAttr(out []otlpKeyValue, prefix string, a slog.Attr) []otlpKeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return out
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix = prefix + a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			out = appendOTLPAttr(out, groupPrefix, ga)
+		}
+		return out
+	}
+	return append(out, otlpKeyValue{Key: prefix + a.Key, Value: otlpValue(a.Value)})
+}
+
+func otlpValue(v slog.Value) otlpAnyValue {
+	switch v.Kind() {
+	case slog.KindString:
+		s := v.String()
+		return otlpAnyValue{StringValue: &s}
+	case slog.KindBool:
+		b := v.Bool()
+		return otlpAnyValue{BoolValue: &b}
+	case slog.KindInt64:
+		i := strconv.FormatInt(v.Int64(), 10)
+		return otlpAnyValue{IntValue: &i}
+	case slog.KindUint64:
+		i := strconv.FormatUint(v.Uint64(), 10)
+		return otlpAnyValue{IntValue: &i}
+	case slog.KindFloat64:
+		f := v.Float64()
+		return otlpAnyValue{DoubleValue: &f}
+	case slog.KindDuration:
+		i := strconv.FormatInt(int64(v.Duration()), 10)
+		return otlpAnyValue{IntValue: &i}
+	case slog.KindTime:
+		s := v.Time().Format(time.RFC3339Nano)
+		return otlpAnyValue{StringValue: &s}
+	default:
+		if err, ok := v.Any().(error); ok {
+			s := err.Error()
+			return otlpAnyValue{StringValue: &s}
+		}
+		s := fmt.Sprint(v.Any())
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+// OTLP/HTTP JSON wire types (see opentelemetry-proto logs/v1), also encoded as protobuf for OTLP/gRPC.
+
+type otlpExportRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResourceSpec `json:"resource"`
+	ScopeLogs []otlpScopeLogs  `json:"scopeLogs"`
+}
+
+type otlpResourceSpec struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
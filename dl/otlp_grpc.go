@@ -0,0 +1,243 @@
+package dl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// grpcLogsExportPath is the gRPC method exporting logs to an OpenTelemetry collector.
+const grpcLogsExportPath = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+
+// grpcTransport returns an HTTP transport speaking HTTP/2 only: over TLS for "https" endpoints, and with prior
+// knowledge over plaintext (h2c) for "http" endpoints, as gRPC requires.
+func grpcTransport() *http.Transport {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Protocols = protocols
+	return transport
+}
+
+// grpcURL returns the URL of the logs export method on the collector at endpoint, which defaults to plaintext when it
+// names no scheme.
+func grpcURL(endpoint string) string {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/") + grpcLogsExportPath
+}
+
+// sendGRPC exports payload over OTLP/gRPC: a single length-prefixed protobuf message, answered with a gRPC status in
+// the response trailers.
+func (e *otlpExporter) sendGRPC(payload otlpExportRequest) error {
+	message, err := payload.protobuf()
+	if err != nil {
+		return fmt.Errorf("encoding: %w", err)
+	}
+	frame := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	frame = append(frame, message...)
+
+	req, err := http.NewRequest(http.MethodPost, grpcURL(e.options.Endpoint), bytes.NewReader(frame))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	for k, v := range e.options.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	resp, err := e.options.Client.Do(req)
+	if err != nil {
+		return &otlpRetryableError{err: err}
+	}
+	defer resp.Body.Close()
+	// trailers are only available once the body has been read
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return &otlpRetryableError{err: err}
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("collector returned %s", resp.Status)
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return &otlpRetryableError{err: err}
+		}
+		return err
+	}
+
+	status := resp.Trailer.Get("Grpc-Status")
+	grpcMessage := resp.Trailer.Get("Grpc-Message")
+	if status == "" { // a trailers-only response carries the status in its headers
+		status, grpcMessage = resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")
+	}
+	if status == "" {
+		return errors.New("collector returned no gRPC status")
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return fmt.Errorf("collector returned invalid gRPC status %q", status)
+	}
+	if code == 0 {
+		return nil
+	}
+	if unescaped, err := url.PathUnescape(grpcMessage); err == nil {
+		grpcMessage = unescaped
+	}
+	err = fmt.Errorf("collector returned gRPC status %d: %s", code, grpcMessage)
+	// CANCELLED, DEADLINE_EXCEEDED, ABORTED, OUT_OF_RANGE, UNAVAILABLE, and DATA_LOSS are retryable under OTLP
+	switch code {
+	case 1, 4, 10, 11, 14, 15:
+		return &otlpRetryableError{err: err}
+	}
+	return err
+}
+
+// protobuf encodes the request as an opentelemetry.proto.collector.logs.v1.ExportLogsServiceRequest.
+func (r otlpExportRequest) protobuf() ([]byte, error) {
+	var b protoBuffer
+	for _, rl := range r.ResourceLogs {
+		var resource protoBuffer
+		for _, kv := range rl.Resource.Attributes {
+			resource.message(1, kv.protobuf())
+		}
+		var resourceLogs protoBuffer
+		resourceLogs.message(1, resource)
+		for _, sl := range rl.ScopeLogs {
+			var scope protoBuffer
+			scope.string(1, sl.Scope.Name)
+			var scopeLogs protoBuffer
+			scopeLogs.message(1, scope)
+			for _, rec := range sl.LogRecords {
+				record, err := rec.protobuf()
+				if err != nil {
+					return nil, err
+				}
+				scopeLogs.message(2, record)
+			}
+			resourceLogs.message(2, scopeLogs)
+		}
+		b.message(1, resourceLogs)
+	}
+	return b, nil
+}
+
+// protobuf encodes the record as an opentelemetry.proto.logs.v1.LogRecord.
+func (rec otlpLogRecord) protobuf() (protoBuffer, error) {
+	timestamp, err := strconv.ParseUint(rec.TimeUnixNano, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("time %q: %w", rec.TimeUnixNano, err)
+	}
+	observed, err := strconv.ParseUint(rec.ObservedTimeUnixNano, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("observed time %q: %w", rec.ObservedTimeUnixNano, err)
+	}
+	var b protoBuffer
+	b.fixed64(1, timestamp)
+	b.varint(2, uint64(rec.SeverityNumber))
+	b.string(3, rec.SeverityText)
+	b.message(5, rec.Body.protobuf())
+	for _, kv := range rec.Attributes {
+		b.message(6, kv.protobuf())
+	}
+	b.fixed64(11, observed)
+	return b, nil
+}
+
+// protobuf encodes the pair as an opentelemetry.proto.common.v1.KeyValue.
+func (kv otlpKeyValue) protobuf() protoBuffer {
+	var b protoBuffer
+	b.string(1, kv.Key)
+	b.message(2, kv.Value.protobuf())
+	return b
+}
+
+// protobuf encodes the value as an opentelemetry.proto.common.v1.AnyValue. the set member of the oneof is written
+// even when it holds its zero value, which would otherwise leave the value empty.
+func (v otlpAnyValue) protobuf() protoBuffer {
+	var b protoBuffer
+	switch {
+	case v.StringValue != nil:
+		b.tag(1, protoBytes)
+		b.bytes([]byte(*v.StringValue))
+	case v.BoolValue != nil:
+		b.tag(2, protoVarint)
+		if *v.BoolValue {
+			b.uvarint(1)
+		} else {
+			b.uvarint(0)
+		}
+	case v.IntValue != nil:
+		i, _ := strconv.ParseInt(*v.IntValue, 10, 64)
+		b.tag(3, protoVarint)
+		b.uvarint(uint64(i))
+	case v.DoubleValue != nil:
+		b.tag(4, protoFixed64)
+		b.uint64(math.Float64bits(*v.DoubleValue))
+	}
+	return b
+}
+
+// protobuf wire types.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+)
+
+// protoBuffer accumulates a protobuf message in wire format. fields holding their zero value are omitted, as proto3
+// does.
+type protoBuffer []byte
+
+func (b *protoBuffer) tag(field, wireType int) {
+	b.uvarint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (b *protoBuffer) uvarint(v uint64) {
+	*b = binary.AppendUvarint(*b, v)
+}
+
+func (b *protoBuffer) uint64(v uint64) {
+	*b = binary.LittleEndian.AppendUint64(*b, v)
+}
+
+func (b *protoBuffer) bytes(data []byte) {
+	b.uvarint(uint64(len(data)))
+	*b = append(*b, data...)
+}
+
+func (b *protoBuffer) varint(field int, v uint64) {
+	if v != 0 {
+		b.tag(field, protoVarint)
+		b.uvarint(v)
+	}
+}
+
+func (b *protoBuffer) fixed64(field int, v uint64) {
+	if v != 0 {
+		b.tag(field, protoFixed64)
+		b.uint64(v)
+	}
+}
+
+func (b *protoBuffer) string(field int, s string) {
+	if s != "" {
+		b.tag(field, protoBytes)
+		b.bytes([]byte(s))
+	}
+}
+
+// message writes an embedded message; unlike scalar fields, an empty message is written, since its presence is
+// significant.
+func (b *protoBuffer) message(field int, m protoBuffer) {
+	b.tag(field, protoBytes)
+	b.bytes(m)
+}
@@ -0,0 +1,317 @@
+package dl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type otlpCollector struct {
+	mu       sync.Mutex
+	requests []otlpExportRequest
+	headers  []http.Header
+}
+
+func (c *otlpCollector) handler(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	var req otlpExportRequest
+	_ = json.Unmarshal(data, &req)
+	c.mu.Lock()
+	c.requests = append(c.requests, req)
+	c.headers = append(c.headers, r.Header.Clone())
+	c.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *otlpCollector) records() []otlpLogRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []otlpLogRecord
+	for _, req := range c.requests {
+		for _, rl := range req.ResourceLogs {
+			for _, sl := range rl.ScopeLogs {
+				out = append(out, sl.LogRecords...)
+			}
+		}
+	}
+	return out
+}
+
+func TestOTLPHandlerExport(t *testing.T) {
+	collector := &otlpCollector{}
+	server := httptest.NewServer(http.HandlerFunc(collector.handler))
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{
+		Endpoint:    server.URL + "/v1/logs",
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		ServiceName: "test-service",
+		Level:       slog.LevelDebug,
+	})
+
+	logger := slog.New(h).With("channel", "database")
+	logger.Debug("connecting", "host", "db.local", "port", 5432)
+	logger.WithGroup("req").Error("failed", "ok", false)
+	assert.Nil(t, h.Close())
+
+	records := collector.records()
+	assert.Len(t, records, 2)
+
+	assert.Equal(t, 5, records[0].SeverityNumber)
+	assert.Equal(t, "connecting", *records[0].Body.StringValue)
+	attrs := map[string]otlpAnyValue{}
+	for _, kv := range records[0].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, "database", *attrs["channel"].StringValue)
+	assert.Equal(t, "db.local", *attrs["host"].StringValue)
+	assert.Equal(t, "5432", *attrs["port"].IntValue)
+
+	assert.Equal(t, 17, records[1].SeverityNumber)
+	attrs = map[string]otlpAnyValue{}
+	for _, kv := range records[1].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	assert.Equal(t, false, *attrs["req.ok"].BoolValue)
+
+	assert.Equal(t, "Bearer token", collector.headers[0].Get("Authorization"))
+	resource := collector.requests[0].ResourceLogs[0].Resource
+	assert.Equal(t, "service.name", resource.Attributes[0].Key)
+	assert.Equal(t, "test-service", *resource.Attributes[0].Value.StringValue)
+}
+
+func TestOTLPHandlerBatchSize(t *testing.T) {
+	collector := &otlpCollector{}
+	server := httptest.NewServer(http.HandlerFunc(collector.handler))
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{
+		Endpoint:      server.URL,
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	defer h.Close()
+
+	logger := slog.New(h)
+	logger.Info("one")
+	logger.Info("two")
+
+	assert.Eventually(t, func() bool { return len(collector.records()) == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestOTLPHandlerLevelFiltering(t *testing.T) {
+	h := NewOTLPHandler(&OTLPOptions{Level: slog.LevelWarn})
+	defer h.Close()
+
+	assert.False(t, h.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, h.Enabled(context.Background(), slog.LevelWarn))
+}
+
+func TestOTLPHandlerReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{Endpoint: server.URL, MaxRetries: -1})
+	slog.New(h).Info("message")
+	err := h.Flush()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "503")
+	_ = h.Close()
+}
+
+func TestOTLPHandlerQueueBounded(t *testing.T) {
+	collector := &otlpCollector{}
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+		<-release
+		collector.handler(w, r)
+	}))
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{
+		Endpoint:      server.URL,
+		BatchSize:     2,
+		MaxQueueSize:  4,
+		FlushInterval: time.Hour,
+	})
+	logger := slog.New(h)
+	logger.Info("record 0")
+	logger.Info("record 1")
+	<-received
+
+	// the first batch is stuck in export; only the newest 4 of the next 10 records are kept
+	for i := 2; i < 12; i++ {
+		logger.Info(fmt.Sprintf("record %d", i))
+	}
+	assert.Equal(t, uint64(6), h.Dropped())
+
+	close(release)
+	assert.Nil(t, h.Close())
+	var bodies []string
+	for _, rec := range collector.records() {
+		bodies = append(bodies, *rec.Body.StringValue)
+	}
+	assert.Equal(t, []string{"record 0", "record 1", "record 8", "record 9", "record 10", "record 11"}, bodies)
+
+	logger.Info("after close")
+	assert.Equal(t, uint64(7), h.Dropped())
+}
+
+func TestOTLPHandlerRetries(t *testing.T) {
+	collector := &otlpCollector{}
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+		if n <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		collector.handler(w, r)
+	}))
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{Endpoint: server.URL, RetryBackoff: time.Millisecond})
+	slog.New(h).Info("message")
+	assert.Nil(t, h.Flush())
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, collector.records(), 1)
+	_ = h.Close()
+}
+
+func TestOTLPHandlerRetriesExhausted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{Endpoint: server.URL, MaxRetries: 2, RetryBackoff: time.Millisecond})
+	slog.New(h).Info("message")
+	err := h.Flush()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "3 attempts")
+	assert.Equal(t, 3, attempts)
+	_ = h.Close()
+}
+
+func TestOTLPHandlerDoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{Endpoint: server.URL, RetryBackoff: time.Millisecond})
+	slog.New(h).Info("message")
+	assert.NotNil(t, h.Flush())
+	assert.Equal(t, 1, attempts)
+	_ = h.Close()
+}
+
+// newGRPCCollector starts a plaintext HTTP/2 server answering OTLP/gRPC exports with status, passing each exported
+// message to received.
+func newGRPCCollector(t *testing.T, status string, received func(r *http.Request, message []byte)) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if assert.GreaterOrEqual(t, len(data), 5) {
+			assert.Equal(t, byte(0), data[0])
+			assert.Equal(t, int(binary.BigEndian.Uint32(data[1:5])), len(data)-5)
+			received(r, data[5:])
+		}
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set(http.TrailerPrefix+"Grpc-Status", status)
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", "bad%20request")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte{0, 0, 0, 0, 0})
+	}))
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	return server
+}
+
+func TestOTLPHandlerGRPC(t *testing.T) {
+	var mu sync.Mutex
+	var messages [][]byte
+	server := newGRPCCollector(t, "0", func(r *http.Request, message []byte) {
+		assert.Equal(t, 2, r.ProtoMajor)
+		assert.Equal(t, grpcLogsExportPath, r.URL.Path)
+		assert.Equal(t, "application/grpc", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		mu.Lock()
+		messages = append(messages, message)
+		mu.Unlock()
+	})
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{
+		Protocol:    OTLPGRPC,
+		Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		ServiceName: "grpc-service",
+	})
+	slog.New(h).Info("over grpc", "count", 3)
+	assert.Nil(t, h.Flush())
+	_ = h.Close()
+
+	if assert.Len(t, messages, 1) {
+		assert.True(t, bytes.Contains(messages[0], []byte("grpc-service")))
+		assert.True(t, bytes.Contains(messages[0], []byte("over grpc")))
+		assert.True(t, bytes.Contains(messages[0], []byte("count")))
+	}
+}
+
+func TestOTLPHandlerGRPCStatus(t *testing.T) {
+	attempts := 0
+	server := newGRPCCollector(t, "3", func(*http.Request, []byte) { attempts++ })
+	defer server.Close()
+
+	h := NewOTLPHandler(&OTLPOptions{Protocol: OTLPGRPC, Endpoint: server.URL, RetryBackoff: time.Millisecond})
+	slog.New(h).Info("message")
+	err := h.Flush()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "gRPC status 3: bad request")
+	assert.Equal(t, 1, attempts)
+	_ = h.Close()
+}
+
+func TestOTLPAnyValueProtobuf(t *testing.T) {
+	f, s := false, ""
+	assert.Equal(t, []byte{0x10, 0x00}, []byte(otlpAnyValue{BoolValue: &f}.protobuf()))
+	assert.Equal(t, []byte{0x0a, 0x00}, []byte(otlpAnyValue{StringValue: &s}.protobuf()))
+	i := "-1"
+	assert.Equal(t, []byte{0x18, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, []byte(otlpAnyValue{IntValue: &i}.protobuf()))
+}
+
+func TestOTLPSeverity(t *testing.T) {
+	assert.Equal(t, 5, otlpSeverity(slog.LevelDebug))
+	assert.Equal(t, 9, otlpSeverity(slog.LevelInfo))
+	assert.Equal(t, 13, otlpSeverity(slog.LevelWarn))
+	assert.Equal(t, 17, otlpSeverity(slog.LevelError))
+	assert.Equal(t, 1, otlpSeverity(slog.Level(-100)))
+}
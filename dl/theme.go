@@ -0,0 +1,111 @@
+package dl
+
+import "log/slog"
+
+// Theme is the color palette used by the pretty handler. each entry is an ANSI escape sequence written before the
+// corresponding element; Reset is written after it. an empty entry leaves that element uncolored.
+type Theme struct {
+	Timestamp string
+	Function  string
+	Channel   string
+	Fields    string
+	Reset     string
+	Error     string
+	Warning   string
+	Info      string
+	Debug     string
+}
+
+// DarkTheme is the default palette, suited to terminals with a dark background
+var DarkTheme = Theme{
+	Timestamp: "\033[90m", // dark gray
+	Function:  "\033[36m", // cyan
+	Channel:   "\033[35m", // magenta
+	Fields:    "\033[33m", // yellow
+	Reset:     "\033[0m",
+	Error:     "\033[31m", // red
+	Warning:   "\033[33m", // yellow
+	Info:      "\033[37m", // white
+	Debug:     "\033[34m", // blue
+}
+
+// LightTheme is a palette suited to terminals with a light background, avoiding white and pale colors
+var LightTheme = Theme{
+	Timestamp: "\033[90m", // dark gray
+	Function:  "\033[34m", // blue
+	Channel:   "\033[35m", // magenta
+	Fields:    "\033[32m", // green
+	Reset:     "\033[0m",
+	Error:     "\033[1;31m", // bold red
+	Warning:   "\033[33m",   // yellow
+	Info:      "\033[30m",   // black
+	Debug:     "\033[36m",   // cyan
+}
+
+// NoColorTheme emits no escape sequences at all
+var NoColorTheme = Theme{}
+
+// Theme returns the palette currently configured on the options
+func (o *Options) Theme() Theme {
+	return Theme{
+		Timestamp: o.TimestampColor,
+		Function:  o.FunctionColor,
+		Channel:   o.ChannelColor,
+		Fields:    o.FieldsColor,
+		Reset:     o.DefaultFgColor,
+		Error:     o.ErrorColor,
+		Warning:   o.WarningColor,
+		Info:      o.InfoColor,
+		Debug:     o.DebugColor,
+	}
+}
+
+// SetTheme replaces the color palette used for pretty output. colors are only emitted when UseColor is set, so
+// the theme does not override NO_COLOR or DL_USE_COLOR=false.
+func (o *Options) SetTheme(theme Theme) *Options {
+	o.TimestampColor = theme.Timestamp
+	o.FunctionColor = theme.Function
+	o.ChannelColor = theme.Channel
+	o.FieldsColor = theme.Fields
+	o.DefaultFgColor = theme.Reset
+	o.ErrorColor = theme.Error
+	o.WarningColor = theme.Warning
+	o.InfoColor = theme.Info
+	o.DebugColor = theme.Debug
+	return o
+}
+
+// ThemeByName returns the preset named "dark", "light", or "none"
+func ThemeByName(name string) (Theme, bool) {
+	switch name {
+	case "dark", "default":
+		return DarkTheme, true
+	case "light":
+		return LightTheme, true
+	case "none", "nocolor":
+		return NoColorTheme, true
+	}
+	return Theme{}, false
+}
+
+// colorize wraps s in color when colored output is enabled
+func (o *Options) colorize(color, s string) string {
+	if !o.UseColor || color == "" {
+		return s
+	}
+	return color + s + o.DefaultFgColor
+}
+
+// levelColor returns the configured color for a level
+func (o *Options) levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return o.ErrorColor
+	case level >= slog.LevelWarn:
+		return o.WarningColor
+	case level >= slog.LevelInfo:
+		return o.InfoColor
+	default:
+		return o.DebugColor
+	}
+}
@@ -0,0 +1,63 @@
+package dl
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetThemeRoundTrip(t *testing.T) {
+	opts := DefaultOptions().SetTheme(LightTheme)
+	assert.Equal(t, LightTheme, opts.Theme())
+
+	custom := Theme{Error: "\033[41m", Reset: "\033[0m"}
+	assert.Equal(t, custom, opts.SetTheme(custom).Theme())
+}
+
+func TestPrettyHandlerUsesTheme(t *testing.T) {
+	var buf bytes.Buffer
+	opts := DefaultOptions().SetOutput(&buf).Pretty().Color().SetTheme(Theme{Error: "<e>", Channel: "<c>", Reset: "</>"})
+	logger := slog.New(NewPrettyHandlerWithChannel(slog.LevelInfo, opts, "db"))
+	logger.Error("boom")
+
+	line := buf.String()
+	assert.Contains(t, line, "<e>  ERROR</>")
+	assert.Contains(t, line, "<c> |db|</>")
+	assert.NotContains(t, line, "\033[")
+}
+
+func TestPrettyHandlerNoColorEmitsNoEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	opts := DefaultOptions().SetOutput(&buf).Pretty().NoColor()
+	logger := slog.New(NewPrettyHandlerWithChannel(slog.LevelInfo, opts, "db"))
+	logger.Warn("careful", "key", "value")
+
+	line := buf.String()
+	assert.False(t, strings.Contains(line, "\033["), "unexpected escape sequence in %q", line)
+	assert.Contains(t, line, "WARNING")
+}
+
+func TestThemeByName(t *testing.T) {
+	theme, ok := ThemeByName("light")
+	assert.True(t, ok)
+	assert.Equal(t, LightTheme, theme)
+
+	theme, ok = ThemeByName("none")
+	assert.True(t, ok)
+	assert.Equal(t, NoColorTheme, theme)
+
+	_, ok = ThemeByName("solarized")
+	assert.False(t, ok)
+}
+
+func TestNoColorEnvironment(t *testing.T) {
+	t.Setenv("DL_USE_COLOR", "")
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, shouldUseColor())
+
+	t.Setenv("DL_USE_COLOR", "true")
+	assert.True(t, shouldUseColor())
+}